@@ -26,6 +26,8 @@ import (
 
 	"github.com/crunchydata/postgres-operator/internal/bridge"
 	"github.com/crunchydata/postgres-operator/internal/bridge/crunchybridgecluster"
+	"github.com/crunchydata/postgres-operator/internal/controller/pgclone"
+	"github.com/crunchydata/postgres-operator/internal/controller/pgswitchover"
 	"github.com/crunchydata/postgres-operator/internal/controller/pgupgrade"
 	"github.com/crunchydata/postgres-operator/internal/controller/postgrescluster"
 	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
@@ -258,6 +260,8 @@ func main() {
 	// add all PostgreSQL Operator controllers to the runtime manager
 	addControllersToManager(manager, log, registrar)
 	must(pgupgrade.ManagedReconciler(manager, registrar))
+	must(pgswitchover.ManagedReconciler(manager))
+	must(pgclone.ManagedReconciler(manager))
 	must(standalone_pgadmin.ManagedReconciler(manager))
 	must(crunchybridgecluster.ManagedReconciler(manager, func() bridge.ClientInterface {
 		return bridgeClient()
@@ -246,6 +246,41 @@ func EnablePostgresLogging(
 			Exporters: exporters,
 		}
 
+		// Send pgAudit records only to the audit exporters, when there are any.
+		// The transform above identifies them by their instrumentation scope.
+		if audit := inCluster.Spec.Audit; audit != nil && len(audit.Exporters) > 0 {
+			// https://github.com/open-telemetry/opentelemetry-collector-contrib/blob/-/processor/filterprocessor#readme
+			outConfig.Processors["filter/pgaudit_exclude"] = map[string]any{
+				"error_mode": "ignore",
+				"logs": map[string]any{
+					"log_record": []string{`instrumentation_scope.name == "pgaudit"`},
+				},
+			}
+			outConfig.Processors["filter/pgaudit_only"] = map[string]any{
+				"error_mode": "ignore",
+				"logs": map[string]any{
+					"log_record": []string{`instrumentation_scope.name != "pgaudit"`},
+				},
+			}
+
+			postgresLogs := outConfig.Pipelines["logs/postgres"]
+			auditLogs := Pipeline{
+				Extensions: slices.Clone(postgresLogs.Extensions),
+				Receivers:  slices.Clone(postgresLogs.Receivers),
+				Exporters:  slices.Clone(audit.Exporters),
+			}
+
+			// Filter immediately after the transform.
+			i := slices.Index(postgresLogs.Processors, "transform/postgres_logs") + 1
+			auditLogs.Processors = slices.Insert(slices.Clone(postgresLogs.Processors), i,
+				"filter/pgaudit_only")
+			postgresLogs.Processors = slices.Insert(postgresLogs.Processors, i,
+				"filter/pgaudit_exclude")
+
+			outConfig.Pipelines["logs/postgres"] = postgresLogs
+			outConfig.Pipelines["logs/pgaudit"] = auditLogs
+		}
+
 		// pgBackRest pipeline
 		outConfig.Extensions["file_storage/pgbackrest_logs"] = map[string]any{
 			"directory":        naming.PGBackRestPGDataLogPath + "/receiver",
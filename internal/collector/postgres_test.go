@@ -12,6 +12,7 @@ import (
 
 	"github.com/crunchydata/postgres-operator/internal/feature"
 	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -550,6 +551,88 @@ service:
       - filelog/postgres_jsonlog
 `)
 	})
+
+	t.Run("AuditExporters", func(t *testing.T) {
+		gate := feature.NewGate()
+		assert.NilError(t, gate.SetFromMap(map[string]bool{
+			feature.OpenTelemetryLogs: true,
+		}))
+		ctx := feature.NewContext(context.Background(), gate)
+
+		cluster := new(v1beta1.PostgresCluster)
+		cluster.Spec.PostgresVersion = 99
+		cluster.Spec.Instrumentation = testInstrumentationSpec()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			audit: { exporters: [siem] },
+		}`)
+
+		config := NewConfig(cluster.Spec.Instrumentation)
+		params := postgres.NewParameters()
+
+		PostgreSQLParameters(ctx, cluster, &params)
+		EnablePostgresLogging(ctx, cluster, params.Mandatory, config)
+
+		assert.Assert(t, cmp.MarshalMatches(config.Processors["filter/pgaudit_exclude"], `
+error_mode: ignore
+logs:
+  log_record:
+  - instrumentation_scope.name == "pgaudit"
+		`))
+		assert.Assert(t, cmp.MarshalMatches(config.Processors["filter/pgaudit_only"], `
+error_mode: ignore
+logs:
+  log_record:
+  - instrumentation_scope.name != "pgaudit"
+		`))
+
+		// Audit records are removed from the other PostgreSQL logs.
+		assert.Assert(t, cmp.MarshalMatches(config.Pipelines["logs/postgres"], `
+Exporters:
+- googlecloud
+Extensions:
+- file_storage/postgres_logs
+Processors:
+- resource/postgres
+- transform/postgres_logs
+- filter/pgaudit_exclude
+- resourcedetection
+- batch/logs
+- groupbyattrs/compact
+Receivers:
+- filelog/postgres_csvlog
+- filelog/postgres_jsonlog
+		`))
+
+		// Only audit records are sent to the audit exporters.
+		assert.Assert(t, cmp.MarshalMatches(config.Pipelines["logs/pgaudit"], `
+Exporters:
+- siem
+Extensions:
+- file_storage/postgres_logs
+Processors:
+- resource/postgres
+- transform/postgres_logs
+- filter/pgaudit_only
+- resourcedetection
+- batch/logs
+- groupbyattrs/compact
+Receivers:
+- filelog/postgres_csvlog
+- filelog/postgres_jsonlog
+		`))
+
+		t.Run("NoExporters", func(t *testing.T) {
+			cluster := cluster.DeepCopy()
+			cluster.Spec.Audit.Exporters = nil
+
+			config := NewConfig(cluster.Spec.Instrumentation)
+			EnablePostgresLogging(ctx, cluster, params.Mandatory, config)
+
+			assert.Assert(t, config.Processors["filter/pgaudit_exclude"] == nil)
+			_, ok := config.Pipelines["logs/pgaudit"]
+			assert.Assert(t, !ok)
+		})
+	})
 }
 
 func TestEnablePostgresMetrics(t *testing.T) {
@@ -7,7 +7,10 @@ package config
 import (
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
+	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
@@ -135,6 +138,92 @@ func PGONamespace() string {
 	return os.Getenv("PGO_NAMESPACE")
 }
 
+// RootCertificatePolicy returns the settings of the operator for generated
+// certificates. These environment variables are read:
+//
+//   - PGO_CERTIFICATE_ALGORITHM: one of ECDSA-P256, ECDSA-P384, RSA-2048, or RSA-4096
+//   - PGO_CERTIFICATE_LIFETIME: how long leaf certificates are valid, e.g. "90d"
+//   - PGO_CERTIFICATE_RENEW_BEFORE: how long before expiry to replace leaf certificates
+//   - PGO_ROOT_CERTIFICATE_LIFETIME: how long root certificates are valid
+//   - PGO_ROOT_CERTIFICATE_ROTATION_PHASE: the shortest time between steps of a root rotation
+//
+// Variables that are not set use the defaults of [pki.Policy]. The returned
+// policy ignores any variable that is not valid and reports it in the error.
+func RootCertificatePolicy() (pki.Policy, error) {
+	var policy pki.Policy
+	var errs []string
+
+	if value := os.Getenv("PGO_CERTIFICATE_ALGORITHM"); value != "" {
+		if validCertificateAlgorithm(value) {
+			policy.Algorithm = value
+		} else {
+			errs = append(errs, fmt.Sprintf("PGO_CERTIFICATE_ALGORITHM: unknown algorithm %q", value))
+		}
+	}
+
+	for key, duration := range map[string]*time.Duration{
+		"PGO_CERTIFICATE_LIFETIME":            &policy.LeafLifetime,
+		"PGO_CERTIFICATE_RENEW_BEFORE":        &policy.LeafRenewBefore,
+		"PGO_ROOT_CERTIFICATE_LIFETIME":       &policy.RootLifetime,
+		"PGO_ROOT_CERTIFICATE_ROTATION_PHASE": &policy.RootRotationPhase,
+	} {
+		if value := os.Getenv(key); value != "" {
+			if d, err := v1beta1.NewDuration(value); err != nil || d.AsDuration().Duration <= 0 {
+				errs = append(errs, fmt.Sprintf("%s: invalid duration %q", key, value))
+			} else {
+				*duration = d.AsDuration().Duration
+			}
+		}
+	}
+
+	if policy.LeafLifetime > 0 && policy.LeafRenewBefore >= policy.LeafLifetime {
+		errs = append(errs, "PGO_CERTIFICATE_RENEW_BEFORE: must be less than the certificate lifetime")
+		policy.LeafRenewBefore = 0
+	}
+
+	if len(errs) > 0 {
+		slices.Sort(errs)
+		return policy, fmt.Errorf("invalid certificate settings: %s", errs)
+	}
+	return policy, nil
+}
+
+// CertificatePolicy returns the settings for certificates generated for
+// cluster. Fields of spec.certificates take precedence over the settings of
+// the operator in [RootCertificatePolicy].
+func CertificatePolicy(cluster *v1beta1.PostgresCluster) (pki.Policy, error) {
+	policy, err := RootCertificatePolicy()
+
+	if spec := cluster.Spec.Certificates; spec != nil {
+		if spec.Algorithm != "" {
+			policy.Algorithm = spec.Algorithm
+		}
+		if spec.Lifetime != nil {
+			policy.LeafLifetime = spec.Lifetime.AsDuration().Duration
+		}
+		if spec.RenewBefore != nil {
+			policy.LeafRenewBefore = spec.RenewBefore.AsDuration().Duration
+		}
+
+		// Settings of the operator and the cluster may not fit together.
+		// Renew at the default time rather than continuously.
+		if policy.LeafLifetime > 0 && policy.LeafRenewBefore >= policy.LeafLifetime {
+			policy.LeafRenewBefore = 0
+		}
+	}
+
+	return policy, err
+}
+
+// validCertificateAlgorithm reports whether algorithm is one that [pki.Policy]
+// understands.
+func validCertificateAlgorithm(algorithm string) bool {
+	return slices.Contains([]string{
+		pki.AlgorithmECDSAP256, pki.AlgorithmECDSAP384,
+		pki.AlgorithmRSA2048, pki.AlgorithmRSA4096,
+	}, algorithm)
+}
+
 // VerifyImageValues checks that all container images required by the
 // spec are defined. If any are undefined, a list is returned in an error.
 func VerifyImageValues(cluster *v1beta1.PostgresCluster) error {
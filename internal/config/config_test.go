@@ -7,9 +7,11 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 
+	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -126,6 +128,105 @@ func TestFetchKeyCommand(t *testing.T) {
 	})
 }
 
+func TestRootCertificatePolicy(t *testing.T) {
+	for _, key := range []string{
+		"PGO_CERTIFICATE_ALGORITHM",
+		"PGO_CERTIFICATE_LIFETIME",
+		"PGO_CERTIFICATE_RENEW_BEFORE",
+		"PGO_ROOT_CERTIFICATE_LIFETIME",
+		"PGO_ROOT_CERTIFICATE_ROTATION_PHASE",
+	} {
+		t.Setenv(key, "")
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		policy, err := RootCertificatePolicy()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, policy, pki.Policy{})
+	})
+
+	t.Run("Settings", func(t *testing.T) {
+		t.Setenv("PGO_CERTIFICATE_ALGORITHM", "RSA-4096")
+		t.Setenv("PGO_CERTIFICATE_LIFETIME", "90d")
+		t.Setenv("PGO_CERTIFICATE_RENEW_BEFORE", "2w")
+		t.Setenv("PGO_ROOT_CERTIFICATE_LIFETIME", "1825d")
+		t.Setenv("PGO_ROOT_CERTIFICATE_ROTATION_PHASE", "24h")
+
+		policy, err := RootCertificatePolicy()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, policy, pki.Policy{
+			Algorithm:         pki.AlgorithmRSA4096,
+			LeafLifetime:      90 * 24 * time.Hour,
+			LeafRenewBefore:   14 * 24 * time.Hour,
+			RootLifetime:      1825 * 24 * time.Hour,
+			RootRotationPhase: 24 * time.Hour,
+		})
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Setenv("PGO_CERTIFICATE_ALGORITHM", "DSA")
+		t.Setenv("PGO_CERTIFICATE_LIFETIME", "30d")
+		t.Setenv("PGO_CERTIFICATE_RENEW_BEFORE", "60d")
+		t.Setenv("PGO_ROOT_CERTIFICATE_LIFETIME", "forever")
+
+		// Invalid settings are reported and ignored.
+		policy, err := RootCertificatePolicy()
+		assert.ErrorContains(t, err, `PGO_CERTIFICATE_ALGORITHM: unknown algorithm "DSA"`)
+		assert.ErrorContains(t, err, `PGO_ROOT_CERTIFICATE_LIFETIME: invalid duration "forever"`)
+		assert.ErrorContains(t, err, `PGO_CERTIFICATE_RENEW_BEFORE: must be less than`)
+		assert.DeepEqual(t, policy, pki.Policy{LeafLifetime: 30 * 24 * time.Hour})
+	})
+}
+
+func TestCertificatePolicy(t *testing.T) {
+	t.Setenv("PGO_CERTIFICATE_ALGORITHM", "ECDSA-P384")
+	t.Setenv("PGO_CERTIFICATE_LIFETIME", "")
+	t.Setenv("PGO_CERTIFICATE_RENEW_BEFORE", "30d")
+	t.Setenv("PGO_ROOT_CERTIFICATE_LIFETIME", "")
+	t.Setenv("PGO_ROOT_CERTIFICATE_ROTATION_PHASE", "")
+
+	t.Run("Operator", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+
+		policy, err := CertificatePolicy(cluster)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, policy, pki.Policy{
+			Algorithm:       pki.AlgorithmECDSAP384,
+			LeafRenewBefore: 30 * 24 * time.Hour,
+		})
+	})
+
+	t.Run("Cluster", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			certificates: { algorithm: RSA-2048, lifetime: 90d, renewBefore: 10d },
+		}`)
+
+		policy, err := CertificatePolicy(cluster)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, policy, pki.Policy{
+			Algorithm:       pki.AlgorithmRSA2048,
+			LeafLifetime:    90 * 24 * time.Hour,
+			LeafRenewBefore: 10 * 24 * time.Hour,
+		})
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			certificates: { lifetime: 7d },
+		}`)
+
+		// The renewal time of the operator does not fit this lifetime.
+		policy, err := CertificatePolicy(cluster)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, policy, pki.Policy{
+			Algorithm:    pki.AlgorithmECDSAP384,
+			LeafLifetime: 7 * 24 * time.Hour,
+		})
+	})
+}
+
 func TestPGAdminContainerImage(t *testing.T) {
 	cluster := &v1beta1.PostgresCluster{}
 
@@ -0,0 +1,147 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgclone
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// cloneClusterName returns the name of the PostgresCluster created by clone.
+func cloneClusterName(clone *v1beta1.PGClone) string {
+	if clone.Spec.ClusterName != "" {
+		return clone.Spec.ClusterName
+	}
+	return clone.Name
+}
+
+// restoreOptions returns the pgBackRest restore options that recover to target.
+// The PostgresCluster controller adds "--target-action=promote" as necessary.
+// - https://pgbackrest.org/command.html#command-restore
+func restoreOptions(target *v1beta1.PGCloneTarget) []string {
+	switch {
+	case target == nil:
+		return nil
+	case target.Time != nil:
+		// The restore command is interpreted by Bash, so quote the space.
+		return []string{"--type=time",
+			`--target="` + target.Time.UTC().Format("2006-01-02 15:04:05-07") + `"`}
+	case target.LSN != "":
+		return []string{"--type=lsn", "--target=" + target.LSN}
+	case target.BackupLabel != "":
+		return []string{"--type=immediate", "--set=" + target.BackupLabel}
+	}
+	return nil
+}
+
+// generateCluster returns a PostgresCluster that is a copy of source restored
+// according to clone. Only repositories that belong to a single cluster are
+// copied; the clone must never write to the repositories of source.
+func generateCluster(clone *v1beta1.PGClone, source *v1beta1.PostgresCluster) (*v1beta1.PostgresCluster, error) {
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace = clone.Namespace
+	cluster.Name = cloneClusterName(clone)
+	cluster.Labels = map[string]string{naming.LabelPGClone: clone.Name}
+
+	spec := source.Spec.DeepCopy()
+	spec.DataSource = &v1beta1.DataSource{
+		PostgresCluster: &v1beta1.PostgresClusterDataSource{
+			ClusterName: source.Name,
+			RepoName:    clone.Spec.RepoName,
+			Options:     restoreOptions(clone.Spec.Target),
+		},
+	}
+	spec.Shutdown = nil
+	spec.Standby = nil
+
+	pgbackrest := &spec.Backups.PGBackRest
+	pgbackrest.Manual = nil
+	pgbackrest.Restore = nil
+
+	var repos []v1beta1.PGBackRestRepo
+	for _, repo := range pgbackrest.Repos {
+		if repo.Volume != nil {
+			repos = append(repos, repo)
+		}
+	}
+	pgbackrest.Repos = repos
+
+	// Without a repository of its own, the clone has no backups.
+	if len(repos) == 0 {
+		spec.Backups = v1beta1.Backups{}
+	}
+
+	if len(clone.Spec.Overrides) > 0 {
+		object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(spec)
+		if err == nil {
+			object = mergePatch(object, clone.Spec.Overrides.DeepCopy())
+			spec = new(v1beta1.PostgresClusterSpec)
+			err = runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(object, spec, true)
+		}
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid overrides")
+		}
+	}
+
+	cluster.Spec = *spec
+	return cluster, nil
+}
+
+// isolateCluster returns a copy of cluster that clients cannot reach. It has
+// no users, no proxy, and no user interface, and its pg_hba.conf rejects every
+// network connection not required by the operator. The result is unstructured
+// so that its empty list of users is sent to the API.
+func isolateCluster(cluster *v1beta1.PostgresCluster) (*unstructured.Unstructured, error) {
+	isolated := cluster.DeepCopy()
+	isolated.Spec.Proxy = nil
+	isolated.Spec.UserInterface = nil
+
+	// Rules in the spec follow those required by the operator and precede any
+	// defaults, so this one rejects everything else.
+	if isolated.Spec.Authentication == nil {
+		isolated.Spec.Authentication = new(v1beta1.PostgresAuthenticationSpec)
+	}
+	isolated.Spec.Authentication.Rules = append([]v1beta1.PostgresHBARuleSpec{{
+		PostgresHBARule: v1beta1.PostgresHBARule{Connection: "host", Method: "reject"},
+	}}, isolated.Spec.Authentication.Rules...)
+
+	object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(isolated)
+	if err == nil {
+		err = unstructured.SetNestedSlice(object, []any{}, "spec", "users")
+	}
+	return &unstructured.Unstructured{Object: object}, err
+}
+
+// releaseCluster copies the fields that [isolateCluster] withholds from
+// generated into cluster.
+func releaseCluster(cluster, generated *v1beta1.PostgresCluster) {
+	cluster.Spec.Authentication = generated.Spec.Authentication
+	cluster.Spec.Proxy = generated.Spec.Proxy
+	cluster.Spec.UserInterface = generated.Spec.UserInterface
+	cluster.Spec.Users = generated.Spec.Users
+}
+
+// mergePatch applies patch to target according to RFC 7386 and returns the result.
+// - https://www.rfc-editor.org/rfc/rfc7386#section-2
+func mergePatch(target map[string]any, patch map[string]any) map[string]any {
+	if target == nil {
+		target = map[string]any{}
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+		} else if object, ok := value.(map[string]any); ok {
+			existing, _ := target[key].(map[string]any)
+			target[key] = mergePatch(existing, object)
+		} else {
+			target[key] = value
+		}
+	}
+	return target
+}
@@ -0,0 +1,212 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgclone
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestRestoreOptions(t *testing.T) {
+	assert.Assert(t, restoreOptions(nil) == nil)
+
+	assert.DeepEqual(t, restoreOptions(&v1beta1.PGCloneTarget{
+		Time: &metav1.Time{Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.FixedZone("", -5*60*60))},
+	}), []string{"--type=time", `--target="2025-01-02 08:04:05+00"`})
+
+	assert.DeepEqual(t, restoreOptions(&v1beta1.PGCloneTarget{LSN: "0/3000060"}),
+		[]string{"--type=lsn", "--target=0/3000060"})
+
+	assert.DeepEqual(t, restoreOptions(&v1beta1.PGCloneTarget{BackupLabel: "20250102-030405F"}),
+		[]string{"--type=immediate", "--set=20250102-030405F"})
+}
+
+func TestGenerateCluster(t *testing.T) {
+	source := v1beta1.NewPostgresCluster()
+	source.Namespace, source.Name = "ns1", "prod"
+	require.UnmarshalInto(t, &source.Spec, `{
+		postgresVersion: 16,
+		shutdown: true,
+		standby: { enabled: true, repoName: repo2 },
+		dataSource: { postgresCluster: { clusterName: older, repoName: repo1 } },
+		instances: [{
+			name: big, replicas: 3,
+			dataVolumeClaimSpec: { accessModes: [ReadWriteOnce], resources: { requests: { storage: 1Ti } } },
+		}],
+		backups: { pgbackrest: {
+			manual: { repoName: repo1 },
+			restore: { enabled: true, repoName: repo1 },
+			repos: [
+				{ name: repo1, volume: { volumeClaimSpec: { accessModes: [ReadWriteOnce] } } },
+				{ name: repo2, s3: { bucket: shared, endpoint: s3.example.com, region: us-east-1 } },
+			],
+		} },
+	}`)
+
+	clone := &v1beta1.PGClone{}
+	clone.Namespace, clone.Name = "ns1", "masked"
+	clone.Spec.SourceClusterName = "prod"
+	clone.Spec.RepoName = "repo2"
+	clone.Spec.Target = &v1beta1.PGCloneTarget{LSN: "0/3000060"}
+
+	t.Run("Copy", func(t *testing.T) {
+		cluster, err := generateCluster(clone, source)
+		assert.NilError(t, err)
+
+		assert.Equal(t, cluster.Namespace, "ns1")
+		assert.Equal(t, cluster.Name, "masked")
+		assert.DeepEqual(t, cluster.Labels, map[string]string{
+			"postgres-operator.crunchydata.com/pgclone": "masked",
+		})
+		assert.Assert(t, cmp.MarshalMatches(cluster.Spec.DataSource, `
+postgresCluster:
+  clusterName: prod
+  options:
+  - --type=lsn
+  - --target=0/3000060
+  repoName: repo2
+		`))
+		assert.Assert(t, cluster.Spec.Shutdown == nil)
+		assert.Assert(t, cluster.Spec.Standby == nil)
+		assert.Assert(t, cluster.Spec.Backups.PGBackRest.Manual == nil)
+		assert.Assert(t, cluster.Spec.Backups.PGBackRest.Restore == nil)
+		assert.Equal(t, len(cluster.Spec.Backups.PGBackRest.Repos), 1,
+			"expected only repositories that belong to one cluster")
+		assert.Equal(t, cluster.Spec.Backups.PGBackRest.Repos[0].Name, "repo1")
+		assert.Equal(t, *cluster.Spec.InstanceSets[0].Replicas, int32(3))
+
+		// The source is unchanged.
+		assert.Assert(t, source.Spec.Standby != nil)
+		assert.Equal(t, len(source.Spec.Backups.PGBackRest.Repos), 2)
+	})
+
+	t.Run("NoRepos", func(t *testing.T) {
+		source := source.DeepCopy()
+		source.Spec.Backups.PGBackRest.Repos = source.Spec.Backups.PGBackRest.Repos[1:]
+
+		cluster, err := generateCluster(clone, source)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, cluster.Spec.Backups, v1beta1.Backups{})
+	})
+
+	t.Run("Overrides", func(t *testing.T) {
+		clone := clone.DeepCopy()
+		clone.Spec.ClusterName = "dev"
+		require.UnmarshalInto(t, &clone.Spec.Overrides, `{
+			instances: [{
+				name: small, replicas: 1,
+				dataVolumeClaimSpec: { accessModes: [ReadWriteOnce], resources: { requests: { storage: 10Gi } } },
+			}],
+			metadata: { labels: { env: dev } },
+			userInterface: null,
+		}`)
+
+		cluster, err := generateCluster(clone, source)
+		assert.NilError(t, err)
+		assert.Equal(t, cluster.Name, "dev")
+		assert.Equal(t, cluster.Spec.PostgresVersion, 16)
+		assert.Assert(t, cmp.MarshalMatches(cluster.Spec.InstanceSets, `
+- dataVolumeClaimSpec:
+    accessModes:
+    - ReadWriteOnce
+    resources:
+      requests:
+        storage: 10Gi
+  name: small
+  replicas: 1
+		`))
+		assert.DeepEqual(t, cluster.Spec.Metadata.Labels, map[string]string{"env": "dev"})
+		assert.Equal(t, cluster.Spec.Backups.PGBackRest.Repos[0].Name, "repo1")
+	})
+
+	t.Run("InvalidOverrides", func(t *testing.T) {
+		clone := clone.DeepCopy()
+		clone.Spec.Overrides = v1beta1.SchemalessObject{"postgresVersion": "sixteen"}
+
+		_, err := generateCluster(clone, source)
+		assert.ErrorContains(t, err, "invalid overrides")
+
+		clone.Spec.Overrides = v1beta1.SchemalessObject{"unknownField": true}
+		_, err = generateCluster(clone, source)
+		assert.ErrorContains(t, err, "unknownField")
+	})
+}
+
+func TestIsolateCluster(t *testing.T) {
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace, cluster.Name = "ns1", "masked"
+	require.UnmarshalInto(t, &cluster.Spec, `{
+		postgresVersion: 16,
+		authentication: { rules: [{ connection: hostssl, method: md5 }] },
+		proxy: { pgBouncer: {} },
+		userInterface: { pgAdmin: {} },
+		users: [{ name: app }],
+	}`)
+
+	isolated, err := isolateCluster(cluster)
+	assert.NilError(t, err)
+	assert.Equal(t, isolated.GetName(), "masked")
+	assert.Equal(t, isolated.GetKind(), "PostgresCluster")
+	assert.Assert(t, cmp.MarshalMatches(isolated.Object["spec"], `
+authentication:
+  rules:
+  - connection: host
+    method: reject
+  - connection: hostssl
+    method: md5
+instances: null
+postgresVersion: 16
+users: []
+	`))
+
+	// The cluster is unchanged.
+	assert.Equal(t, len(cluster.Spec.Authentication.Rules), 1)
+	assert.Assert(t, cluster.Spec.Proxy != nil)
+	assert.Equal(t, len(cluster.Spec.Users), 1)
+
+	t.Run("Release", func(t *testing.T) {
+		released := v1beta1.NewPostgresCluster()
+		assert.NilError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(
+			isolated.Object, released))
+
+		releaseCluster(released, cluster)
+		assert.DeepEqual(t, released.Spec, cluster.Spec)
+	})
+}
+
+func TestMergePatch(t *testing.T) {
+	// Examples from https://www.rfc-editor.org/rfc/rfc7386#appendix-A
+	for _, tt := range []struct{ target, patch, expected map[string]any }{
+		{map[string]any{"a": "b"}, map[string]any{"a": "c"}, map[string]any{"a": "c"}},
+		{map[string]any{"a": "b"}, map[string]any{"b": "c"}, map[string]any{"a": "b", "b": "c"}},
+		{map[string]any{"a": "b"}, map[string]any{"a": nil}, map[string]any{}},
+		{
+			map[string]any{"a": map[string]any{"b": "c"}},
+			map[string]any{"a": map[string]any{"b": "d", "c": nil}},
+			map[string]any{"a": map[string]any{"b": "d"}},
+		},
+		{
+			map[string]any{"a": []any{map[string]any{"b": "c"}}},
+			map[string]any{"a": []any{int64(1)}},
+			map[string]any{"a": []any{int64(1)}},
+		},
+		{
+			map[string]any{"a": "foo"},
+			map[string]any{"a": map[string]any{"bb": map[string]any{"ccc": nil}}},
+			map[string]any{"a": map[string]any{"bb": map[string]any{}}},
+		},
+		{nil, map[string]any{"a": "b"}, map[string]any{"a": "b"}},
+	} {
+		assert.DeepEqual(t, mergePatch(tt.target, tt.patch), tt.expected)
+	}
+}
@@ -0,0 +1,467 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgclone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/postgrescluster"
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/patroni"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/internal/tracing"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+const (
+	// ConditionProgressing is the type used in a condition to indicate that
+	// a clone is being created, restored, or sanitized.
+	ConditionProgressing = "Progressing"
+
+	// ConditionReady is the type used in a condition to indicate that the
+	// PostgresCluster of a clone is restored and sanitized.
+	ConditionReady = "Ready"
+)
+
+// PGCloneReconciler reconciles a PGClone object
+type PGCloneReconciler struct {
+	PodExec func(
+		ctx context.Context, namespace, pod, container string,
+		stdin io.Reader, stdout, stderr io.Writer, command ...string,
+	) error
+
+	Reader interface {
+		Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error
+		List(context.Context, client.ObjectList, ...client.ListOption) error
+	}
+	Writer interface {
+		Create(context.Context, client.Object, ...client.CreateOption) error
+		Update(context.Context, client.Object, ...client.UpdateOption) error
+	}
+	StatusWriter interface {
+		Patch(context.Context, client.Object, client.Patch, ...client.SubResourcePatchOption) error
+	}
+
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgclones",verbs={list,watch}
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={list,watch}
+//+kubebuilder:rbac:groups="",resources="pods",verbs={list,watch}
+
+// ManagedReconciler creates a [PGCloneReconciler] and adds it to m.
+func ManagedReconciler(m ctrl.Manager) error {
+	exec, err := runtime.NewPodExecutor(m.GetConfig())
+	kubernetes := client.WithFieldOwner(m.GetClient(), naming.ControllerPGClone)
+	recorder := m.GetEventRecorderFor(naming.ControllerPGClone)
+
+	reconciler := &PGCloneReconciler{
+		PodExec:      exec,
+		Reader:       kubernetes,
+		Recorder:     recorder,
+		StatusWriter: kubernetes.Status(),
+		Writer:       kubernetes,
+	}
+
+	return errors.Join(err, ctrl.NewControllerManagedBy(m).
+		For(&v1beta1.PGClone{}).
+		Watches(
+			v1beta1.NewPostgresCluster(),
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, cluster client.Object) []ctrl.Request {
+				return reconciler.findClonesForPostgresCluster(ctx, cluster)
+			}),
+		).
+		Complete(reconciler))
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgclones",verbs={list}
+
+// findClonesForPostgresCluster returns requests for the PGClone that created
+// cluster and for any PGClones that are waiting to copy it.
+func (r *PGCloneReconciler) findClonesForPostgresCluster(
+	ctx context.Context, cluster client.Object,
+) []ctrl.Request {
+	var matching []ctrl.Request
+	var clones v1beta1.PGCloneList
+
+	if r.Reader.List(ctx, &clones, &client.ListOptions{
+		Namespace: cluster.GetNamespace(),
+	}) == nil {
+		for i := range clones.Items {
+			if clones.Items[i].Spec.SourceClusterName == cluster.GetName() ||
+				clones.Items[i].Name == cluster.GetLabels()[naming.LabelPGClone] {
+				matching = append(matching, ctrl.Request{
+					NamespacedName: client.ObjectKeyFromObject(&clones.Items[i]),
+				})
+			}
+		}
+	}
+	return matching
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgclones",verbs={get}
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgclones/status",verbs={patch}
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={get,create,update}
+//+kubebuilder:rbac:groups="",resources="configmaps",verbs={get}
+//+kubebuilder:rbac:groups="batch",resources="jobs",verbs={list}
+//+kubebuilder:rbac:groups="",resources="pods",verbs={list}
+//+kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// Reconcile moves a [v1beta1.PGClone] identified by req through the steps of
+// creating a copy of another PostgresCluster:
+//
+//  1. A PostgresCluster is created from the spec of the source cluster.
+//  2. It restores a backup of the source cluster to the target of the clone.
+//  3. Sanitization statements run in its primary.
+//  4. After sanitization is recorded, clients are allowed to connect.
+func (r *PGCloneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "reconcile-pgclone")
+	log := logging.FromContext(ctx)
+	defer span.End()
+	defer func(s tracing.Span) { _ = tracing.Escape(s, err) }(span)
+
+	clone := &v1beta1.PGClone{}
+	err = r.Reader.Get(ctx, req.NamespacedName, clone)
+
+	if err == nil {
+		// Write any changes to the clone status on the way out.
+		before := clone.DeepCopy()
+		defer func() {
+			if !equality.Semantic.DeepEqual(before.Status, clone.Status) {
+				status := r.StatusWriter.Patch(ctx, clone, client.MergeFrom(before))
+
+				if err == nil && status != nil {
+					err = status
+				} else if status != nil {
+					log.Error(status, "Patching PGClone status")
+				}
+			}
+		}()
+	} else {
+		// NotFound cannot be fixed by requeuing so ignore it.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Exit when the clone is ready. A PGClone creates only one PostgresCluster
+	// and leaves it alone afterward.
+	if meta.IsStatusConditionTrue(clone.Status.Conditions, ConditionReady) {
+		return ctrl.Result{}, nil
+	}
+
+	clone.Status.ObservedGeneration = clone.Generation
+	setReady(clone, metav1.ConditionFalse, "NotReady", "PostgresCluster %s is not ready",
+		cloneClusterName(clone))
+
+	cluster, err := r.getCluster(ctx, clone.Namespace, cloneClusterName(clone))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if cluster == nil {
+		return r.createCluster(ctx, clone)
+	}
+
+	// Never take over a PostgresCluster that this clone did not create.
+	if cluster.GetLabels()[naming.LabelPGClone] != clone.Name {
+		setProgressing(clone, metav1.ConditionFalse, "ClusterExists",
+			"PostgresCluster %s already exists and was not created by this PGClone", cluster.Name)
+		return ctrl.Result{}, nil
+	}
+
+	clone.Status.ClusterName = cluster.Name
+
+	if job, err := r.findRestoreJob(ctx, cluster); err != nil {
+		return ctrl.Result{}, err
+	} else if job != nil {
+		clone.Status.RestoreStartTime = job.Status.StartTime
+		clone.Status.RestoreCompletionTime = job.Status.CompletionTime
+	}
+
+	// The PostgresCluster controller sets this condition when its restore
+	// Job completes or fails.
+	initialized := meta.FindStatusCondition(cluster.Status.Conditions,
+		postgrescluster.ConditionPostgresDataInitialized)
+
+	switch {
+	case initialized == nil:
+		setProgressing(clone, metav1.ConditionTrue, "Restoring",
+			"Restoring PostgresCluster %s from %s of %s",
+			cluster.Name, clone.Spec.RepoName, clone.Spec.SourceClusterName)
+		return ctrl.Result{}, nil
+
+	case initialized.Status != metav1.ConditionTrue:
+		if progressing := meta.FindStatusCondition(clone.Status.Conditions,
+			ConditionProgressing); progressing == nil || progressing.Reason != "RestoreFailed" {
+			r.Recorder.Eventf(clone, corev1.EventTypeWarning, "RestoreFailed",
+				"Unable to restore PostgresCluster %s: %s", cluster.Name, initialized.Message)
+		}
+		setProgressing(clone, metav1.ConditionFalse, "RestoreFailed",
+			"Unable to restore PostgresCluster %s: %s", cluster.Name, initialized.Message)
+		return ctrl.Result{}, nil
+	}
+
+	// Wait for PostgreSQL to be running as primary.
+	pod, err := r.findPrimary(ctx, cluster)
+	if err != nil || pod == nil {
+		setProgressing(clone, metav1.ConditionTrue, "WaitingForPrimary",
+			"Waiting for PostgresCluster %s to have a primary", cluster.Name)
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, err
+	}
+
+	if spec := clone.Spec.Sanitization; spec != nil && clone.Status.SanitizationCompletionTime == nil {
+		setProgressing(clone, metav1.ConditionTrue, "Sanitizing",
+			"Sanitizing PostgresCluster %s", cluster.Name)
+
+		var sql string
+		if spec.SQL != nil {
+			configmap := &corev1.ConfigMap{}
+			err = r.Reader.Get(ctx, client.ObjectKey{
+				Namespace: clone.Namespace, Name: spec.SQL.Name,
+			}, configmap)
+
+			if value, ok := configmap.Data[spec.SQL.Key]; err == nil && ok {
+				sql = value
+			} else if err == nil || apierrors.IsNotFound(err) {
+				setProgressing(clone, metav1.ConditionFalse, "SanitizationInvalid",
+					"ConfigMap %s must exist and contain %q", spec.SQL.Name, spec.SQL.Key)
+				return ctrl.Result{}, nil
+			} else {
+				return ctrl.Result{}, err
+			}
+		}
+
+		if err := sanitize(ctx, r.postgresExec(pod), spec, sql); err != nil {
+			setProgressing(clone, metav1.ConditionTrue, "SanitizationFailed",
+				"Unable to sanitize PostgresCluster %s: %v", cluster.Name, err)
+			r.Recorder.Eventf(clone, corev1.EventTypeWarning, "SanitizationFailed",
+				"Unable to sanitize PostgresCluster %s: %v", cluster.Name, err)
+			return runtime.RequeueWithBackoff(), nil
+		}
+
+		clone.Status.SanitizationCompletionTime = &metav1.Time{Time: time.Now()}
+		setProgressing(clone, metav1.ConditionTrue, "Sanitized",
+			"Sanitized PostgresCluster %s", cluster.Name)
+		r.Recorder.Eventf(clone, corev1.EventTypeNormal, "Sanitized",
+			"Sanitized PostgresCluster %s", cluster.Name)
+
+		// Clients cannot connect until the completion time is in the status
+		// of the clone. Writing the status causes another reconcile.
+		return ctrl.Result{}, nil
+	}
+
+	if clone.Spec.Sanitization != nil {
+		if result, err := r.releaseCluster(ctx, clone, cluster); err != nil || result != nil {
+			return initialize.FromPointer(result), err
+		}
+	}
+
+	setProgressing(clone, metav1.ConditionFalse, "Completed",
+		"PostgresCluster %s is a clone of %s", cluster.Name, clone.Spec.SourceClusterName)
+	setReady(clone, metav1.ConditionTrue, "CloneReady",
+		"PostgresCluster %s is ready", cluster.Name)
+	r.Recorder.Eventf(clone, corev1.EventTypeNormal, "CloneReady",
+		"PostgresCluster %s is ready", cluster.Name)
+
+	return ctrl.Result{}, nil
+}
+
+// createCluster creates the PostgresCluster of clone from its source cluster.
+func (r *PGCloneReconciler) createCluster(
+	ctx context.Context, clone *v1beta1.PGClone,
+) (ctrl.Result, error) {
+	source, err := r.getCluster(ctx, clone.Namespace, clone.Spec.SourceClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// NotFound cannot be fixed by requeuing. We will reconcile again when
+	// a matching PostgresCluster is created.
+	if source == nil {
+		setProgressing(clone, metav1.ConditionFalse, "SourceNotFound",
+			"PostgresCluster %s does not exist", clone.Spec.SourceClusterName)
+		return ctrl.Result{}, nil
+	}
+
+	var found bool
+	for _, repo := range source.Spec.Backups.PGBackRest.Repos {
+		found = found || repo.Name == clone.Spec.RepoName
+	}
+	if !found {
+		setProgressing(clone, metav1.ConditionFalse, "RepoNotFound",
+			"PostgresCluster %s has no repository named %s",
+			source.Name, clone.Spec.RepoName)
+		return ctrl.Result{}, nil
+	}
+
+	cluster, err := generateCluster(clone, source)
+	if err != nil {
+		setProgressing(clone, metav1.ConditionFalse, "InvalidOverrides", "%v", err)
+		return ctrl.Result{}, nil
+	}
+
+	// A clone that has sanitization statements is unreachable until they run.
+	var object client.Object = cluster
+	if clone.Spec.Sanitization != nil {
+		if object, err = isolateCluster(cluster); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.Writer.Create(ctx, object); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	clone.Status.ClusterName = cluster.Name
+	setProgressing(clone, metav1.ConditionTrue, "Restoring",
+		"Restoring PostgresCluster %s from %s of %s",
+		cluster.Name, clone.Spec.RepoName, clone.Spec.SourceClusterName)
+	r.Recorder.Eventf(clone, corev1.EventTypeNormal, "ClusterCreated",
+		"Created PostgresCluster %s from %s of %s",
+		cluster.Name, clone.Spec.RepoName, clone.Spec.SourceClusterName)
+
+	return ctrl.Result{}, nil
+}
+
+// releaseCluster allows clients to connect to the sanitized cluster of clone by
+// restoring the users, proxy, user interface, and authentication of its source.
+// It returns a result when the clone should wait.
+func (r *PGCloneReconciler) releaseCluster(
+	ctx context.Context, clone *v1beta1.PGClone, cluster *v1beta1.PostgresCluster,
+) (*ctrl.Result, error) {
+	source, err := r.getCluster(ctx, clone.Namespace, clone.Spec.SourceClusterName)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		setProgressing(clone, metav1.ConditionFalse, "SourceNotFound",
+			"PostgresCluster %s does not exist", clone.Spec.SourceClusterName)
+		return &ctrl.Result{}, nil
+	}
+
+	generated, err := generateCluster(clone, source)
+	if err != nil {
+		setProgressing(clone, metav1.ConditionFalse, "InvalidOverrides", "%v", err)
+		return &ctrl.Result{}, nil
+	}
+
+	releaseCluster(cluster, generated)
+	return nil, r.Writer.Update(ctx, cluster)
+}
+
+// The client used by the controller sets up a cache and an informer for any GVK
+// that it GETs. That informer needs the "watch" permission.
+// - https://github.com/kubernetes-sigs/controller-runtime/issues/1249
+// - https://github.com/kubernetes-sigs/controller-runtime/issues/1454
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={get,watch}
+//+kubebuilder:rbac:groups="",resources="configmaps",verbs={list,watch}
+//+kubebuilder:rbac:groups="batch",resources="jobs",verbs={watch}
+
+// getCluster returns the PostgresCluster named name, or nil when it does not exist.
+func (r *PGCloneReconciler) getCluster(
+	ctx context.Context, namespace, name string,
+) (*v1beta1.PostgresCluster, error) {
+	cluster := v1beta1.NewPostgresCluster()
+	err := r.Reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, cluster)
+
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return cluster, err
+}
+
+// findRestoreJob returns the pgBackRest restore Job of cluster, if any.
+func (r *PGCloneReconciler) findRestoreJob(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) (*batchv1.Job, error) {
+	jobs := &batchv1.JobList{}
+	err := r.Reader.List(ctx, jobs,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabelsSelector{
+			Selector: naming.PGBackRestRestoreJobSelector(cluster.Name),
+		},
+	)
+
+	if err != nil || len(jobs.Items) == 0 {
+		return nil, err
+	}
+	return &jobs.Items[0], nil
+}
+
+// findPrimary returns the Pod of cluster that Patroni reports is primary, if any.
+func (r *PGCloneReconciler) findPrimary(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) (*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	selector, err := naming.AsSelector(naming.ClusterInstances(cluster.Name))
+	if err == nil {
+		err = r.Reader.List(ctx, pods,
+			client.InNamespace(cluster.Namespace),
+			client.MatchingLabelsSelector{Selector: selector},
+		)
+	}
+
+	for i := range pods.Items {
+		if err == nil && pods.Items[i].DeletionTimestamp == nil && patroni.PodIsPrimary(&pods.Items[i]) {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, err
+}
+
+// postgresExec returns a [postgres.Executor] for the database container of pod.
+func (r *PGCloneReconciler) postgresExec(pod *corev1.Pod) postgres.Executor {
+	return func(
+		ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+	) error {
+		return r.PodExec(ctx, pod.Namespace, pod.Name, naming.ContainerDatabase,
+			stdin, stdout, stderr, command...)
+	}
+}
+
+// setProgressing sets the Progressing condition of clone.
+func setProgressing(
+	clone *v1beta1.PGClone, status metav1.ConditionStatus,
+	reason, format string, args ...any,
+) {
+	meta.SetStatusCondition(&clone.Status.Conditions, metav1.Condition{
+		ObservedGeneration: clone.Generation,
+		Type:               ConditionProgressing,
+		Status:             status,
+		Reason:             reason,
+		Message:            fmt.Sprintf(format, args...),
+	})
+}
+
+// setReady sets the Ready condition of clone.
+func setReady(
+	clone *v1beta1.PGClone, status metav1.ConditionStatus,
+	reason, format string, args ...any,
+) {
+	meta.SetStatusCondition(&clone.Status.Conditions, metav1.Condition{
+		ObservedGeneration: clone.Generation,
+		Type:               ConditionReady,
+		Status:             status,
+		Reason:             reason,
+		Message:            fmt.Sprintf(format, args...),
+	})
+}
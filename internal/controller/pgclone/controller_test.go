@@ -0,0 +1,246 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgclone
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestFindClonesForPostgresCluster(t *testing.T) {
+	ctx := context.Background()
+
+	clone := func(name, source string) *v1beta1.PGClone {
+		c := &v1beta1.PGClone{}
+		c.Namespace, c.Name = "ns1", name
+		c.Spec.SourceClusterName = source
+		return c
+	}
+
+	reconciler := &PGCloneReconciler{
+		Reader: fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(
+			clone("one", "prod"), clone("two", "prod"), clone("three", "other"),
+		).Build(),
+	}
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace, cluster.Name = "ns1", "prod"
+
+	var names []string
+	for _, request := range reconciler.findClonesForPostgresCluster(ctx, cluster) {
+		names = append(names, request.Name)
+	}
+	assert.DeepEqual(t, names, []string{"one", "two"})
+
+	// A cluster created by a clone is labeled with its name.
+	cluster.Name = "three"
+	cluster.Labels = map[string]string{naming.LabelPGClone: "three"}
+
+	names = nil
+	for _, request := range reconciler.findClonesForPostgresCluster(ctx, cluster) {
+		names = append(names, request.Name)
+	}
+	assert.DeepEqual(t, names, []string{"three"})
+}
+
+// createRecorder is a client that remembers the objects it creates.
+type createRecorder struct {
+	client.Client
+	created []client.Object
+}
+
+func (r *createRecorder) Create(ctx context.Context, object client.Object, options ...client.CreateOption) error {
+	r.created = append(r.created, object.DeepCopyObject().(client.Object))
+	return r.Client.Create(ctx, object, options...)
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	source := v1beta1.NewPostgresCluster()
+	source.Namespace, source.Name = "ns1", "prod"
+	source.Spec.PostgresVersion = 16
+	source.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+		Name: "repo1", Volume: &v1beta1.RepoPVC{},
+	}}
+	source.Spec.Proxy = &v1beta1.PostgresProxySpec{PGBouncer: &v1beta1.PGBouncerPodSpec{}}
+	source.Spec.Users = []v1beta1.PostgresUserSpec{{Name: "app"}}
+
+	clone := &v1beta1.PGClone{}
+	clone.Namespace, clone.Name = "ns1", "masked"
+	clone.Spec.SourceClusterName = "prod"
+	clone.Spec.RepoName = "repo1"
+	clone.Spec.Sanitization = &v1beta1.PGCloneSanitization{
+		Cypher: []v1beta1.PGCloneCypherQuery{{Graph: "g", Query: "MATCH (n) SET n.email = null"}},
+	}
+
+	cc := fake.NewClientBuilder().WithScheme(runtime.Scheme).
+		WithObjects(source, clone).
+		WithStatusSubresource(clone, source).
+		Build()
+
+	var execs int
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	writer := &createRecorder{Client: cc}
+	reconciler := &PGCloneReconciler{
+		PodExec: func(
+			_ context.Context, namespace, pod, container string,
+			_ io.Reader, _, _ io.Writer, _ ...string,
+		) error {
+			execs++
+			assert.Equal(t, namespace, "ns1")
+			assert.Equal(t, pod, "masked-00-abcd-0")
+			assert.Equal(t, container, "database")
+			return nil
+		},
+		Reader:       cc,
+		Recorder:     recorder,
+		StatusWriter: cc.Status(),
+		Writer:       writer,
+	}
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(clone)}
+	condition := func(conditionType string) *metav1.Condition {
+		assert.NilError(t, cc.Get(ctx, request.NamespacedName, clone))
+		return meta.FindStatusCondition(clone.Status.Conditions, conditionType)
+	}
+
+	// The first reconcile creates the PostgresCluster.
+	_, err := reconciler.Reconcile(ctx, request)
+	assert.NilError(t, err)
+	assert.Equal(t, condition(ConditionProgressing).Reason, "Restoring")
+	assert.Equal(t, condition(ConditionReady).Status, metav1.ConditionFalse)
+	assert.Equal(t, clone.Status.ClusterName, "masked")
+
+	cluster := v1beta1.NewPostgresCluster()
+	assert.NilError(t, cc.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "masked"}, cluster))
+	assert.Equal(t, cluster.Labels[naming.LabelPGClone], "masked")
+	assert.Equal(t, cluster.Spec.DataSource.PostgresCluster.ClusterName, "prod")
+
+	// Clients cannot reach the clone before it is sanitized.
+	isolated := func(t *testing.T) {
+		t.Helper()
+		assert.NilError(t, cc.Get(ctx, client.ObjectKeyFromObject(cluster), cluster))
+		assert.Assert(t, cluster.Spec.Proxy == nil)
+		assert.Assert(t, cmp.MarshalMatches(cluster.Spec.Authentication, `
+rules:
+- connection: host
+  method: reject
+		`))
+	}
+	isolated(t)
+
+	// The fake client drops an empty list, so look at what was sent.
+	assert.Equal(t, len(writer.created), 1)
+	users, found, err := unstructured.NestedSlice(
+		writer.created[0].(*unstructured.Unstructured).Object, "spec", "users")
+	assert.NilError(t, err)
+	assert.Assert(t, found)
+	assert.Equal(t, len(users), 0)
+
+	// Nothing changes until the restore completes.
+	_, err = reconciler.Reconcile(ctx, request)
+	assert.NilError(t, err)
+	assert.Equal(t, condition(ConditionProgressing).Reason, "Restoring")
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type: "PostgresDataInitialized", Status: metav1.ConditionTrue, Reason: "PGBackRestRestoreComplete",
+	})
+	assert.NilError(t, cc.Status().Update(ctx, cluster))
+
+	result, err := reconciler.Reconcile(ctx, request)
+	assert.NilError(t, err)
+	assert.Assert(t, result.RequeueAfter > 0)
+	assert.Equal(t, condition(ConditionProgressing).Reason, "WaitingForPrimary")
+	assert.Equal(t, execs, 0)
+
+	pod := &corev1.Pod{}
+	pod.Namespace, pod.Name = "ns1", "masked-00-abcd-0"
+	pod.Labels = map[string]string{
+		naming.LabelCluster: "masked", naming.LabelInstance: "masked-00-abcd",
+	}
+	pod.Annotations = map[string]string{"status": `{"role":"primary"}`}
+	assert.NilError(t, cc.Create(ctx, pod))
+
+	_, err = reconciler.Reconcile(ctx, request)
+	assert.NilError(t, err)
+	assert.Equal(t, execs, 1)
+	assert.Equal(t, condition(ConditionProgressing).Reason, "Sanitized")
+	assert.Equal(t, condition(ConditionReady).Status, metav1.ConditionFalse)
+	assert.Assert(t, clone.Status.SanitizationCompletionTime != nil)
+	isolated(t)
+
+	// Clients can connect only after sanitization is recorded in the status.
+	_, err = reconciler.Reconcile(ctx, request)
+	assert.NilError(t, err)
+	assert.Equal(t, execs, 1)
+	assert.Equal(t, condition(ConditionProgressing).Reason, "Completed")
+	assert.Equal(t, condition(ConditionReady).Status, metav1.ConditionTrue)
+	assert.Assert(t, clone.Status.SanitizationCompletionTime != nil)
+
+	assert.NilError(t, cc.Get(ctx, client.ObjectKeyFromObject(cluster), cluster))
+	assert.DeepEqual(t, cluster.Spec.Users, source.Spec.Users)
+	assert.DeepEqual(t, cluster.Spec.Proxy, source.Spec.Proxy)
+	assert.Assert(t, cluster.Spec.Authentication == nil)
+
+	var reasons []string
+	for _, event := range recorder.Events {
+		reasons = append(reasons, event.Reason)
+	}
+	assert.DeepEqual(t, reasons, []string{"ClusterCreated", "Sanitized", "CloneReady"})
+
+	// A ready clone is left alone.
+	_, err = reconciler.Reconcile(ctx, request)
+	assert.NilError(t, err)
+	assert.Equal(t, execs, 1)
+}
+
+func TestReconcileClusterExists(t *testing.T) {
+	ctx := context.Background()
+
+	existing := v1beta1.NewPostgresCluster()
+	existing.Namespace, existing.Name = "ns1", "masked"
+
+	clone := &v1beta1.PGClone{}
+	clone.Namespace, clone.Name = "ns1", "masked"
+	clone.Spec.SourceClusterName = "prod"
+	clone.Spec.RepoName = "repo1"
+
+	cc := fake.NewClientBuilder().WithScheme(runtime.Scheme).
+		WithObjects(existing, clone).
+		WithStatusSubresource(clone).
+		Build()
+
+	reconciler := &PGCloneReconciler{
+		Reader:       cc,
+		Recorder:     events.NewRecorder(t, runtime.Scheme),
+		StatusWriter: cc.Status(),
+		Writer:       cc,
+	}
+
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(clone)})
+	assert.NilError(t, err)
+
+	assert.NilError(t, cc.Get(ctx, client.ObjectKeyFromObject(clone), clone))
+	progressing := meta.FindStatusCondition(clone.Status.Conditions, ConditionProgressing)
+	assert.Equal(t, progressing.Status, metav1.ConditionFalse)
+	assert.Equal(t, progressing.Reason, "ClusterExists")
+	assert.Equal(t, clone.Status.ClusterName, "")
+}
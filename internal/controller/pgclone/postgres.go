@@ -0,0 +1,73 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgclone
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// sanitizationScript returns psql input that runs the statements of spec in
+// a single transaction. The sql argument is the content of spec.SQL, if any.
+func sanitizationScript(spec *v1beta1.PGCloneSanitization, sql string) string {
+	var script strings.Builder
+	script.WriteString("BEGIN;\n")
+
+	if strings.TrimSpace(sql) != "" {
+		// End the statements in case the last one lacks a semicolon.
+		script.WriteString(sql)
+		script.WriteString("\n;\n")
+	}
+
+	// Apache AGE interprets Cypher passed to its "cypher" function. The query
+	// must be a dollar-quoted constant; the API forbids the quote tag used here.
+	// - https://age.apache.org/age-manual/master/intro/cypher.html
+	if len(spec.Cypher) > 0 {
+		script.WriteString("LOAD 'age';\n")
+		script.WriteString(`SET LOCAL search_path = ag_catalog, "$user", public;` + "\n")
+	}
+	for _, query := range spec.Cypher {
+		script.WriteString("SELECT * FROM ag_catalog.cypher(" +
+			postgres.QuoteLiteral(query.Graph) + ", $cypher$" + query.Query +
+			"$cypher$) AS (result ag_catalog.agtype);\n")
+	}
+
+	script.WriteString("COMMIT;\n")
+	return script.String()
+}
+
+// sanitize runs the statements of spec in the database it names.
+func sanitize(
+	ctx context.Context, exec postgres.Executor, spec *v1beta1.PGCloneSanitization, sql string,
+) error {
+	database := spec.Database
+	if database == "" {
+		database = "postgres"
+	}
+
+	stdout, stderr, err := exec.ExecInDatabasesFromQuery(ctx,
+		`SELECT :'database'`,
+		sanitizationScript(spec, sql),
+		map[string]string{
+			"database": database,
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	logging.FromContext(ctx).V(1).Info("sanitized clone", "stdout", stdout, "stderr", stderr)
+
+	// Include the reason a statement failed so it can be reported in status.
+	if err != nil && strings.TrimSpace(stderr) != "" {
+		err = errors.WithMessage(err, strings.TrimSpace(stderr))
+	}
+	return errors.WithStack(err)
+}
@@ -0,0 +1,98 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgclone
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestSanitizationScript(t *testing.T) {
+	t.Run("SQL", func(t *testing.T) {
+		assert.Equal(t, sanitizationScript(&v1beta1.PGCloneSanitization{},
+			"UPDATE people SET email = NULL"), `BEGIN;
+UPDATE people SET email = NULL
+;
+COMMIT;
+`)
+	})
+
+	t.Run("Cypher", func(t *testing.T) {
+		spec := &v1beta1.PGCloneSanitization{
+			Cypher: []v1beta1.PGCloneCypherQuery{
+				{Graph: "social", Query: "MATCH (p:Person) SET p.email = null"},
+				{Graph: "it's", Query: "MATCH (n) DETACH DELETE n"},
+			},
+		}
+
+		assert.Equal(t, sanitizationScript(spec, "  \n"), `BEGIN;
+LOAD 'age';
+SET LOCAL search_path = ag_catalog, "$user", public;
+SELECT * FROM ag_catalog.cypher( E'social', $cypher$MATCH (p:Person) SET p.email = null$cypher$) AS (result ag_catalog.agtype);
+SELECT * FROM ag_catalog.cypher( E'it''s', $cypher$MATCH (n) DETACH DELETE n$cypher$) AS (result ag_catalog.agtype);
+COMMIT;
+`)
+	})
+}
+
+func TestSanitize(t *testing.T) {
+	ctx := context.Background()
+	spec := &v1beta1.PGCloneSanitization{}
+
+	t.Run("Arguments", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Equal(t, string(b), "BEGIN;\nTRUNCATE secrets\n;\nCOMMIT;\n")
+
+			assert.Equal(t, command[0], "bash")
+			assert.DeepEqual(t, command[len(command)-4:], []string{
+				"SELECT :'database'",
+				"--set=ON_ERROR_STOP=on",
+				"--set=QUIET=on",
+				"--set=database=postgres",
+			})
+			return nil
+		}
+
+		assert.NilError(t, sanitize(ctx, exec, spec, "TRUNCATE secrets"))
+		assert.Equal(t, calls, 1)
+
+		spec := spec.DeepCopy()
+		spec.Database = "app"
+		assert.NilError(t, sanitize(ctx, func(
+			_ context.Context, _ io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			assert.Equal(t, command[len(command)-1], "--set=database=app")
+			return nil
+		}, spec, "TRUNCATE secrets"))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		expected := errors.New("exit status 3")
+		exec := func(
+			_ context.Context, _ io.Reader, _, stderr io.Writer, _ ...string,
+		) error {
+			_, _ = stderr.Write([]byte("ERROR:  relation \"secrets\" does not exist\n"))
+			return expected
+		}
+
+		err := sanitize(ctx, exec, spec, "TRUNCATE secrets")
+		assert.Assert(t, errors.Is(err, expected))
+		assert.Assert(t, strings.Contains(err.Error(), `relation "secrets" does not exist`), "%v", err)
+	})
+}
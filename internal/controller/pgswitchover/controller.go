@@ -0,0 +1,447 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgswitchover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/patroni"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/internal/tracing"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+const (
+	// AnnotationAllowSwitchover must be on both PostgresClusters of a switchover
+	// and contain the name of the PGSwitchover.
+	AnnotationAllowSwitchover = "postgres-operator.crunchydata.com/allow-switchover"
+
+	// ConditionProgressing is the type used in a condition to indicate that
+	// a switchover is in progress.
+	ConditionProgressing = "Progressing"
+
+	// ConditionSucceeded is the type used in a condition to indicate the
+	// status of a switchover.
+	ConditionSucceeded = "Succeeded"
+)
+
+// PGSwitchoverReconciler reconciles a PGSwitchover object
+type PGSwitchoverReconciler struct {
+	PodExec func(
+		ctx context.Context, namespace, pod, container string,
+		stdin io.Reader, stdout, stderr io.Writer, command ...string,
+	) error
+
+	Reader interface {
+		Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error
+		List(context.Context, client.ObjectList, ...client.ListOption) error
+	}
+	Writer interface {
+		Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error
+	}
+	StatusWriter interface {
+		Patch(context.Context, client.Object, client.Patch, ...client.SubResourcePatchOption) error
+	}
+
+	Recorder record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgswitchovers",verbs={list,watch}
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={list,watch}
+//+kubebuilder:rbac:groups="",resources="pods",verbs={list,watch}
+
+// ManagedReconciler creates a [PGSwitchoverReconciler] and adds it to m.
+func ManagedReconciler(m ctrl.Manager) error {
+	exec, err := runtime.NewPodExecutor(m.GetConfig())
+	kubernetes := client.WithFieldOwner(m.GetClient(), naming.ControllerPGSwitchover)
+	recorder := m.GetEventRecorderFor(naming.ControllerPGSwitchover)
+
+	reconciler := &PGSwitchoverReconciler{
+		PodExec:      exec,
+		Reader:       kubernetes,
+		Recorder:     recorder,
+		StatusWriter: kubernetes.Status(),
+		Writer:       kubernetes,
+	}
+
+	return errors.Join(err, ctrl.NewControllerManagedBy(m).
+		For(&v1beta1.PGSwitchover{}).
+		Watches(
+			v1beta1.NewPostgresCluster(),
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, cluster client.Object) []ctrl.Request {
+				return runtime.Requests(reconciler.findSwitchoversForPostgresCluster(ctx, client.ObjectKeyFromObject(cluster))...)
+			}),
+		).
+		Complete(reconciler))
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgswitchovers",verbs={list}
+
+// findSwitchoversForPostgresCluster returns PGSwitchovers that target cluster.
+func (r *PGSwitchoverReconciler) findSwitchoversForPostgresCluster(
+	ctx context.Context, cluster client.ObjectKey,
+) []*v1beta1.PGSwitchover {
+	var matching []*v1beta1.PGSwitchover
+	var switchovers v1beta1.PGSwitchoverList
+
+	if r.Reader.List(ctx, &switchovers, &client.ListOptions{
+		Namespace: cluster.Namespace,
+	}) == nil {
+		for i := range switchovers.Items {
+			if switchovers.Items[i].Spec.PrimaryClusterName == cluster.Name ||
+				switchovers.Items[i].Spec.StandbyClusterName == cluster.Name {
+				matching = append(matching, &switchovers.Items[i])
+			}
+		}
+	}
+	return matching
+}
+
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgswitchovers",verbs={get}
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="pgswitchovers/status",verbs={patch}
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={get,patch}
+//+kubebuilder:rbac:groups="",resources="pods",verbs={list}
+//+kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// Reconcile moves the PostgresClusters of a [v1beta1.PGSwitchover] identified
+// by req through the steps of exchanging their roles:
+//
+//  1. The primary cluster becomes a standby of the standby cluster.
+//  2. The standby cluster replays all the WAL the primary cluster wrote.
+//  3. The standby cluster is promoted on a new timeline.
+func (r *PGSwitchoverReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "reconcile-pgswitchover")
+	log := logging.FromContext(ctx)
+	defer span.End()
+	defer func(s tracing.Span) { _ = tracing.Escape(s, err) }(span)
+
+	switchover := &v1beta1.PGSwitchover{}
+	err = r.Reader.Get(ctx, req.NamespacedName, switchover)
+
+	if err == nil {
+		// Write any changes to the switchover status on the way out.
+		before := switchover.DeepCopy()
+		defer func() {
+			if !equality.Semantic.DeepEqual(before.Status, switchover.Status) {
+				status := r.StatusWriter.Patch(ctx, switchover, client.MergeFrom(before))
+
+				if err == nil && status != nil {
+					err = status
+				} else if status != nil {
+					log.Error(status, "Patching PGSwitchover status")
+				}
+			}
+		}()
+	} else {
+		// NotFound cannot be fixed by requeuing so ignore it.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Exit when the switchover has already succeeded or failed. A PGSwitchover
+	// is used only once; create another to switch the clusters back or to try
+	// again.
+	if meta.FindStatusCondition(switchover.Status.Conditions, ConditionSucceeded) != nil {
+		return ctrl.Result{}, nil
+	}
+
+	primary, standby, err := r.observeClusters(ctx, switchover)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// NotFound cannot be fixed by requeuing. We will reconcile again when
+	// a matching PostgresCluster is created.
+	if primary == nil || standby == nil {
+		setProgressing(switchover, metav1.ConditionFalse, "ClusterNotFound",
+			"PostgresClusters %q and %q must both exist",
+			switchover.Spec.PrimaryClusterName, switchover.Spec.StandbyClusterName)
+		return ctrl.Result{}, nil
+	}
+
+	// Each switchover changes the spec of two clusters. Check that each is
+	// annotated with the name of *this* switchover. This provides some
+	// assurance that the user who created the switchover also has authority
+	// to edit the clusters.
+	for _, cluster := range []*v1beta1.PostgresCluster{primary, standby} {
+		if cluster.GetAnnotations()[AnnotationAllowSwitchover] != switchover.Name {
+			setProgressing(switchover, metav1.ConditionFalse, "ClusterMissingRequiredAnnotation",
+				"PostgresCluster %s lacks annotation for switchover %s", cluster.Name, switchover.Name)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// Patroni reports progress through Pod annotations. Check again about as
+	// often as Patroni does.
+	requeue := ctrl.Result{RequeueAfter: time.Duration(
+		*initialize.FromPointer(standby.Spec.Patroni).SyncPeriodSeconds) * time.Second}
+
+	switch {
+	case switchover.Status.ObservedGeneration == 0:
+		// The switchover has not started. Verify the roles of the clusters
+		// then demote the primary cluster.
+		if isStandby(primary) || !isStandby(standby) {
+			setProgressing(switchover, metav1.ConditionFalse, "InvalidClusterRoles",
+				"PostgresCluster %s must be primary and %s must be a standby",
+				primary.Name, standby.Name)
+			return ctrl.Result{}, nil
+		}
+
+		patch := client.MergeFrom(primary.DeepCopy())
+		primary.Spec.Standby = standbyOf(standby)
+		err = r.Writer.Patch(ctx, primary, patch)
+
+		if err == nil {
+			now := metav1.Now()
+			switchover.Status.DemotedTime = &now
+			switchover.Status.ObservedGeneration = switchover.Generation
+			setProgressing(switchover, metav1.ConditionTrue, "Demoting",
+				"Demoting PostgresCluster %s", primary.Name)
+			r.Recorder.Eventf(switchover, corev1.EventTypeNormal, "Demoting",
+				"Demoting PostgresCluster %s to a standby of %s", primary.Name, standby.Name)
+		}
+		return requeue, err
+
+	case isStandby(standby) && timedOut(switchover, time.Now()):
+		// The standby cluster did not replay the WAL of the demoted cluster in
+		// time. It was never promoted, so promote the demoted cluster again.
+		if isStandby(primary) {
+			patch := client.MergeFrom(primary.DeepCopy())
+			primary.Spec.Standby.Enabled = false
+			err = r.Writer.Patch(ctx, primary, patch)
+		}
+
+		if err == nil {
+			setProgressing(switchover, metav1.ConditionFalse, "Failed",
+				"Promoted PostgresCluster %s again", primary.Name)
+			meta.SetStatusCondition(&switchover.Status.Conditions, metav1.Condition{
+				ObservedGeneration: switchover.Generation,
+				Type:               ConditionSucceeded,
+				Status:             metav1.ConditionFalse,
+				Reason:             "Timeout",
+				Message: fmt.Sprintf("PostgresCluster %s did not replay the WAL of %s in time",
+					standby.Name, primary.Name),
+			})
+			r.Recorder.Eventf(switchover, corev1.EventTypeWarning, "SwitchoverFailed",
+				"PostgresCluster %s did not replay the WAL of %s in time; promoting %s again",
+				standby.Name, primary.Name, primary.Name)
+		}
+		return ctrl.Result{}, err
+
+	case switchover.Status.DemotedLSN == "":
+		// Wait for the primary cluster to restart as a standby then find where
+		// it stopped writing WAL.
+		pod, err := r.findLeader(ctx, primary, patroni.PodIsStandbyLeader)
+		var timeline int64
+		var lsn uint64
+		if err == nil && pod != nil {
+			timeline, lsn, err = demotedPosition(ctx, r.postgresExec(pod))
+		}
+		if err == nil && pod != nil {
+			switchover.Status.DemotedTimeline = timeline
+			switchover.Status.DemotedLSN = formatLSN(lsn)
+			setProgressing(switchover, metav1.ConditionTrue, "WaitingForWAL",
+				"Waiting for PostgresCluster %s to replay WAL through %s on timeline %d",
+				standby.Name, switchover.Status.DemotedLSN, timeline)
+		}
+		return requeue, err
+
+	case isStandby(standby):
+		// Wait for the standby cluster to replay all the WAL of the demoted
+		// cluster on the same timeline then promote it.
+		required, err := parseLSN(switchover.Status.DemotedLSN)
+
+		pod, err2 := r.findLeader(ctx, standby, patroni.PodIsStandbyLeader)
+		err = errors.Join(err, err2)
+
+		if err != nil || pod == nil {
+			return requeue, err
+		}
+
+		if timeline := patroni.PodTimeline(pod); timeline != switchover.Status.DemotedTimeline {
+			setProgressing(switchover, metav1.ConditionTrue, "WaitingForWAL",
+				"PostgresCluster %s is on timeline %d but expected %d",
+				standby.Name, timeline, switchover.Status.DemotedTimeline)
+			return requeue, nil
+		}
+
+		replayed, err := replayedPosition(ctx, r.postgresExec(pod))
+		if err != nil || replayed < required {
+			return requeue, err
+		}
+
+		patch := client.MergeFrom(standby.DeepCopy())
+		standby.Spec.Standby.Enabled = false
+		err = r.Writer.Patch(ctx, standby, patch)
+
+		if err == nil {
+			setProgressing(switchover, metav1.ConditionTrue, "Promoting",
+				"Promoting PostgresCluster %s", standby.Name)
+			r.Recorder.Eventf(switchover, corev1.EventTypeNormal, "Promoting",
+				"Promoting PostgresCluster %s after it replayed WAL through %s",
+				standby.Name, formatLSN(replayed))
+		}
+		return requeue, err
+
+	default:
+		// Wait for the promoted cluster to have a primary on a new timeline.
+		pod, err := r.findLeader(ctx, standby, patroni.PodIsPrimary)
+		if err != nil || pod == nil {
+			return requeue, err
+		}
+
+		if timeline := patroni.PodTimeline(pod); timeline <= switchover.Status.DemotedTimeline {
+			return requeue, nil
+		} else {
+			switchover.Status.PromotedTimeline = timeline
+		}
+
+		setProgressing(switchover, metav1.ConditionFalse, "Completed",
+			"PostgresCluster %s is primary on timeline %d",
+			standby.Name, switchover.Status.PromotedTimeline)
+		meta.SetStatusCondition(&switchover.Status.Conditions, metav1.Condition{
+			ObservedGeneration: switchover.Generation,
+			Type:               ConditionSucceeded,
+			Status:             metav1.ConditionTrue,
+			Reason:             "SwitchoverSucceeded",
+			Message: fmt.Sprintf("PostgresCluster %s is primary and %s is its standby",
+				standby.Name, primary.Name),
+		})
+		r.Recorder.Eventf(switchover, corev1.EventTypeNormal, "SwitchoverSucceeded",
+			"PostgresCluster %s is primary on timeline %d", standby.Name, switchover.Status.PromotedTimeline)
+
+		return ctrl.Result{}, nil
+	}
+}
+
+// The client used by the controller sets up a cache and an informer for any GVK
+// that it GETs. That informer needs the "watch" permission.
+// - https://github.com/kubernetes-sigs/controller-runtime/issues/1249
+// - https://github.com/kubernetes-sigs/controller-runtime/issues/1454
+//+kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={get,watch}
+
+// observeClusters returns the PostgresClusters of switchover. Either is nil
+// when it does not exist.
+func (r *PGSwitchoverReconciler) observeClusters(
+	ctx context.Context, switchover *v1beta1.PGSwitchover,
+) (*v1beta1.PostgresCluster, *v1beta1.PostgresCluster, error) {
+	get := func(name string) (*v1beta1.PostgresCluster, error) {
+		cluster := v1beta1.NewPostgresCluster()
+		err := r.Reader.Get(ctx, client.ObjectKey{
+			Namespace: switchover.Namespace, Name: name,
+		}, cluster)
+
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err == nil {
+			cluster.Default()
+		}
+		return cluster, err
+	}
+
+	primary, err1 := get(switchover.Spec.PrimaryClusterName)
+	standby, err2 := get(switchover.Spec.StandbyClusterName)
+
+	return primary, standby, errors.Join(err1, err2)
+}
+
+// findLeader returns the Pod of cluster for which isLeader returns true, if any.
+func (r *PGSwitchoverReconciler) findLeader(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, isLeader func(metav1.Object) bool,
+) (*corev1.Pod, error) {
+	pods := &corev1.PodList{}
+	selector, err := naming.AsSelector(naming.ClusterInstances(cluster.Name))
+	if err == nil {
+		err = r.Reader.List(ctx, pods,
+			client.InNamespace(cluster.Namespace),
+			client.MatchingLabelsSelector{Selector: selector},
+		)
+	}
+
+	for i := range pods.Items {
+		if err == nil && pods.Items[i].DeletionTimestamp == nil && isLeader(&pods.Items[i]) {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, err
+}
+
+// postgresExec returns a [postgres.Executor] for the database container of pod.
+func (r *PGSwitchoverReconciler) postgresExec(pod *corev1.Pod) postgres.Executor {
+	return func(
+		ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+	) error {
+		return r.PodExec(ctx, pod.Namespace, pod.Name, naming.ContainerDatabase,
+			stdin, stdout, stderr, command...)
+	}
+}
+
+// isStandby returns whether or not cluster is configured as a standby.
+func isStandby(cluster *v1beta1.PostgresCluster) bool {
+	return cluster.Spec.Standby != nil && cluster.Spec.Standby.Enabled
+}
+
+// standbyOf returns a standby specification that follows promoted from the
+// same kind of source that promoted followed as a standby. A repository is
+// expected to be shared by both clusters; a host becomes the primary Service
+// of promoted, and a cluster name becomes the name of promoted.
+func standbyOf(promoted *v1beta1.PostgresCluster) *v1beta1.PostgresStandbySpec {
+	spec := &v1beta1.PostgresStandbySpec{
+		Enabled:  true,
+		RepoName: promoted.Spec.Standby.RepoName,
+	}
+	if promoted.Spec.Standby.ClusterName != "" {
+		spec.ClusterName = promoted.Name
+	}
+	if promoted.Spec.Standby.Host != "" {
+		service := naming.ClusterPrimaryService(promoted)
+		spec.Host = service.Name + "." + service.Namespace + ".svc"
+		spec.Port = initialize.Int32(*promoted.Spec.Port)
+	}
+	return spec
+}
+
+// timedOut returns whether or not the timeout of switchover has passed since
+// its primary cluster was demoted.
+func timedOut(switchover *v1beta1.PGSwitchover, now time.Time) bool {
+	timeout := 10 * time.Minute
+	if switchover.Spec.Timeout != nil {
+		timeout = switchover.Spec.Timeout.AsDuration().Duration
+	}
+	return switchover.Status.DemotedTime != nil &&
+		now.Sub(switchover.Status.DemotedTime.Time) > timeout
+}
+
+// setProgressing sets the Progressing condition of switchover.
+func setProgressing(
+	switchover *v1beta1.PGSwitchover, status metav1.ConditionStatus,
+	reason, format string, args ...any,
+) {
+	meta.SetStatusCondition(&switchover.Status.Conditions, metav1.Condition{
+		ObservedGeneration: switchover.Generation,
+		Type:               ConditionProgressing,
+		Status:             status,
+		Reason:             reason,
+		Message:            fmt.Sprintf(format, args...),
+	})
+}
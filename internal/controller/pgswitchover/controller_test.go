@@ -0,0 +1,384 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgswitchover
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+type listOnly struct {
+	client.Reader
+	items []v1beta1.PGSwitchover
+}
+
+func (l listOnly) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	list.(*v1beta1.PGSwitchoverList).Items = l.items
+	return nil
+}
+
+func TestFindSwitchoversForPostgresCluster(t *testing.T) {
+	ctx := context.Background()
+
+	switchover := func(name, primary, standby string) v1beta1.PGSwitchover {
+		s := v1beta1.PGSwitchover{}
+		s.Name = name
+		s.Spec.PrimaryClusterName = primary
+		s.Spec.StandbyClusterName = standby
+		return s
+	}
+
+	reconciler := &PGSwitchoverReconciler{Reader: listOnly{items: []v1beta1.PGSwitchover{
+		switchover("one", "east", "west"),
+		switchover("two", "west", "east"),
+		switchover("three", "north", "south"),
+	}}}
+
+	var names []string
+	for _, s := range reconciler.findSwitchoversForPostgresCluster(ctx,
+		client.ObjectKey{Namespace: "ns", Name: "west"}) {
+		names = append(names, s.Name)
+	}
+	assert.DeepEqual(t, names, []string{"one", "two"})
+
+	assert.Assert(t, len(reconciler.findSwitchoversForPostgresCluster(ctx,
+		client.ObjectKey{Namespace: "ns", Name: "other"})) == 0)
+}
+
+func TestStandbyOf(t *testing.T) {
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.ObjectMeta = metav1.ObjectMeta{Namespace: "ns1", Name: "west"}
+	cluster.Spec.Port = initialize.Int32(5433)
+
+	t.Run("Repository", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Standby = &v1beta1.PostgresStandbySpec{
+			Enabled: true, RepoName: "repo2",
+		}
+
+		assert.DeepEqual(t, standbyOf(cluster), &v1beta1.PostgresStandbySpec{
+			Enabled: true, RepoName: "repo2",
+		})
+	})
+
+	t.Run("Host", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Standby = &v1beta1.PostgresStandbySpec{
+			Enabled: true, Host: "east-primary.ns1.svc", Port: initialize.Int32(5432),
+		}
+
+		assert.DeepEqual(t, standbyOf(cluster), &v1beta1.PostgresStandbySpec{
+			Enabled: true, Host: "west-primary.ns1.svc", Port: initialize.Int32(5433),
+		})
+	})
+
+	t.Run("ClusterName", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Standby = &v1beta1.PostgresStandbySpec{
+			Enabled: true, ClusterName: "east",
+		}
+
+		assert.DeepEqual(t, standbyOf(cluster), &v1beta1.PostgresStandbySpec{
+			Enabled: true, ClusterName: "west",
+		})
+	})
+
+	t.Run("Both", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Standby = &v1beta1.PostgresStandbySpec{
+			Enabled: true, Host: "east-primary.ns1.svc", RepoName: "repo1",
+		}
+
+		assert.DeepEqual(t, standbyOf(cluster), &v1beta1.PostgresStandbySpec{
+			Enabled: true, Host: "west-primary.ns1.svc", Port: initialize.Int32(5433),
+			RepoName: "repo1",
+		})
+	})
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	pod := func(cluster, status string) *corev1.Pod {
+		pod := &corev1.Pod{}
+		pod.Namespace, pod.Name = "ns1", cluster+"-0"
+		pod.Labels = map[string]string{
+			naming.LabelCluster:  cluster,
+			naming.LabelInstance: cluster + "-abc",
+		}
+		pod.Annotations = map[string]string{"status": status}
+		return pod
+	}
+
+	// PostgreSQL stopped writing WAL at 0/3000028 on timeline 1. The standby
+	// has replayed through 0/3000000.
+	exec := func(
+		_ context.Context, _, _, _ string, stdin io.Reader, stdout, _ io.Writer, _ ...string,
+	) error {
+		b, err := io.ReadAll(stdin)
+		switch {
+		case strings.Contains(string(b), "pg_control_checkpoint"):
+			_, _ = stdout.Write([]byte(`{"checkpoint":"0/3000028","segmentSize":16777216,"timeline":1}`))
+		case strings.Contains(string(b), "pg_last_wal_replay_lsn"):
+			_, _ = stdout.Write([]byte(`{"replayed":"0/3000000"}`))
+		}
+		return err
+	}
+
+	demoted := metav1.NewTime(time.Now().Add(-time.Minute))
+	waiting := v1beta1.PGSwitchoverStatus{
+		ObservedGeneration: 1,
+		DemotedTime:        &demoted,
+		DemotedTimeline:    1,
+		DemotedLSN:         "0/3000000",
+	}
+
+	for _, tt := range []struct {
+		name     string
+		status   v1beta1.PGSwitchoverStatus
+		timeout  string
+		primary  *v1beta1.PostgresStandbySpec
+		standby  *v1beta1.PostgresStandbySpec
+		pods     []*corev1.Pod
+		requeue  bool
+		events   []string
+		expected func(*testing.T, *v1beta1.PGSwitchover, *v1beta1.PostgresCluster, *v1beta1.PostgresCluster)
+	}{
+		{
+			name:    "Pending",
+			primary: nil,
+			standby: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			requeue: true,
+			events:  []string{"Demoting"},
+			expected: func(t *testing.T, s *v1beta1.PGSwitchover, primary, standby *v1beta1.PostgresCluster) {
+				assert.Equal(t, s.Status.ObservedGeneration, int64(1))
+				assert.Assert(t, s.Status.DemotedTime != nil)
+				assert.Equal(t, meta.FindStatusCondition(s.Status.Conditions, ConditionProgressing).Reason, "Demoting")
+				assert.DeepEqual(t, primary.Spec.Standby,
+					&v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"})
+				assert.Assert(t, standby.Spec.Standby.Enabled)
+			},
+		},
+		{
+			name:    "Failed/InvalidClusterRoles",
+			primary: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			standby: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			expected: func(t *testing.T, s *v1beta1.PGSwitchover, primary, standby *v1beta1.PostgresCluster) {
+				condition := meta.FindStatusCondition(s.Status.Conditions, ConditionProgressing)
+				assert.Equal(t, condition.Status, metav1.ConditionFalse)
+				assert.Equal(t, condition.Reason, "InvalidClusterRoles")
+				assert.Equal(t, s.Status.ObservedGeneration, int64(0))
+			},
+		},
+		{
+			name: "InProgress/Demoted",
+			status: v1beta1.PGSwitchoverStatus{
+				ObservedGeneration: 1, DemotedTime: &demoted,
+			},
+			primary: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			standby: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			pods:    []*corev1.Pod{pod("east", `{"role":"standby_leader","timeline":1}`)},
+			requeue: true,
+			expected: func(t *testing.T, s *v1beta1.PGSwitchover, primary, standby *v1beta1.PostgresCluster) {
+				assert.Equal(t, s.Status.DemotedTimeline, int64(1))
+				assert.Equal(t, s.Status.DemotedLSN, "0/3000000")
+				assert.Equal(t, meta.FindStatusCondition(s.Status.Conditions, ConditionProgressing).Reason, "WaitingForWAL")
+			},
+		},
+		{
+			name: "InProgress/Replaying",
+			status: func() v1beta1.PGSwitchoverStatus {
+				status := *waiting.DeepCopy()
+				status.DemotedLSN = "0/3000028"
+				return status
+			}(),
+			primary: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			standby: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			pods:    []*corev1.Pod{pod("west", `{"role":"standby_leader","timeline":1}`)},
+			requeue: true,
+			expected: func(t *testing.T, s *v1beta1.PGSwitchover, primary, standby *v1beta1.PostgresCluster) {
+				assert.Assert(t, standby.Spec.Standby.Enabled, "expected no promotion")
+			},
+		},
+		{
+			name:    "InProgress/Promoting",
+			status:  waiting,
+			primary: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			standby: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			pods:    []*corev1.Pod{pod("west", `{"role":"standby_leader","timeline":1}`)},
+			requeue: true,
+			events:  []string{"Promoting"},
+			expected: func(t *testing.T, s *v1beta1.PGSwitchover, primary, standby *v1beta1.PostgresCluster) {
+				assert.Assert(t, !standby.Spec.Standby.Enabled)
+				assert.Assert(t, primary.Spec.Standby.Enabled)
+				assert.Equal(t, meta.FindStatusCondition(s.Status.Conditions, ConditionProgressing).Reason, "Promoting")
+			},
+		},
+		{
+			name:    "Succeeded",
+			status:  waiting,
+			primary: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			standby: &v1beta1.PostgresStandbySpec{Enabled: false, RepoName: "repo1"},
+			pods:    []*corev1.Pod{pod("west", `{"role":"primary","timeline":2}`)},
+			events:  []string{"SwitchoverSucceeded"},
+			expected: func(t *testing.T, s *v1beta1.PGSwitchover, primary, standby *v1beta1.PostgresCluster) {
+				assert.Equal(t, s.Status.PromotedTimeline, int64(2))
+				assert.Assert(t, meta.IsStatusConditionTrue(s.Status.Conditions, ConditionSucceeded))
+				assert.Assert(t, meta.IsStatusConditionFalse(s.Status.Conditions, ConditionProgressing))
+			},
+		},
+		{
+			name: "TimedOut/Default",
+			status: func() v1beta1.PGSwitchoverStatus {
+				status := *waiting.DeepCopy()
+				long := metav1.NewTime(time.Now().Add(-time.Hour))
+				status.DemotedTime = &long
+				return status
+			}(),
+			primary: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			standby: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			pods:    []*corev1.Pod{pod("west", `{"role":"standby_leader","timeline":1}`)},
+			events:  []string{"SwitchoverFailed"},
+			expected: func(t *testing.T, s *v1beta1.PGSwitchover, primary, standby *v1beta1.PostgresCluster) {
+				condition := meta.FindStatusCondition(s.Status.Conditions, ConditionSucceeded)
+				assert.Equal(t, condition.Status, metav1.ConditionFalse)
+				assert.Equal(t, condition.Reason, "Timeout")
+
+				// The demoted cluster is promoted again.
+				assert.Assert(t, !primary.Spec.Standby.Enabled)
+				assert.Assert(t, standby.Spec.Standby.Enabled)
+			},
+		},
+		{
+			name:    "TimedOut/Spec",
+			status:  waiting,
+			timeout: "30s",
+			primary: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			standby: &v1beta1.PostgresStandbySpec{Enabled: true, RepoName: "repo1"},
+			events:  []string{"SwitchoverFailed"},
+			expected: func(t *testing.T, s *v1beta1.PGSwitchover, primary, standby *v1beta1.PostgresCluster) {
+				assert.Assert(t, meta.IsStatusConditionFalse(s.Status.Conditions, ConditionSucceeded))
+				assert.Assert(t, !primary.Spec.Standby.Enabled)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			switchover := &v1beta1.PGSwitchover{}
+			switchover.Namespace, switchover.Name = "ns1", "swap"
+			switchover.Generation = 1
+			switchover.Spec.PrimaryClusterName = "east"
+			switchover.Spec.StandbyClusterName = "west"
+			switchover.Status = *tt.status.DeepCopy()
+			if tt.timeout != "" {
+				switchover.Spec.Timeout = initialize.Pointer(v1beta1.Duration{})
+				assert.NilError(t, switchover.Spec.Timeout.UnmarshalJSON([]byte(`"`+tt.timeout+`"`)))
+			}
+
+			cluster := func(name string, standby *v1beta1.PostgresStandbySpec) *v1beta1.PostgresCluster {
+				cluster := v1beta1.NewPostgresCluster()
+				cluster.Namespace, cluster.Name = "ns1", name
+				cluster.Annotations = map[string]string{AnnotationAllowSwitchover: "swap"}
+				cluster.Spec.Port = initialize.Int32(5432)
+				cluster.Spec.Standby = standby.DeepCopy()
+				return cluster
+			}
+
+			objects := []client.Object{
+				switchover, cluster("east", tt.primary), cluster("west", tt.standby),
+			}
+			for _, pod := range tt.pods {
+				objects = append(objects, pod)
+			}
+
+			cc := fake.NewClientBuilder().WithScheme(runtime.Scheme).
+				WithObjects(objects...).WithStatusSubresource(switchover).Build()
+			recorder := events.NewRecorder(t, runtime.Scheme)
+			reconciler := &PGSwitchoverReconciler{
+				PodExec: exec, Reader: cc, Writer: cc, StatusWriter: cc.Status(),
+				Recorder: recorder,
+			}
+
+			request := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(switchover)}
+			result, err := reconciler.Reconcile(ctx, request)
+			assert.NilError(t, err)
+			assert.Equal(t, result.RequeueAfter > 0, tt.requeue, "got %v", result)
+
+			var reasons []string
+			for _, event := range recorder.Events {
+				reasons = append(reasons, event.Reason)
+			}
+			assert.DeepEqual(t, reasons, tt.events)
+
+			actual := &v1beta1.PGSwitchover{}
+			primary, standby := v1beta1.NewPostgresCluster(), v1beta1.NewPostgresCluster()
+			assert.NilError(t, cc.Get(ctx, client.ObjectKeyFromObject(switchover), actual))
+			assert.NilError(t, cc.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "east"}, primary))
+			assert.NilError(t, cc.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "west"}, standby))
+			tt.expected(t, actual, primary, standby)
+
+			// Reconciling again does not repeat any step that finished.
+			before := recorder.Events
+			recorder.Events = nil
+			_, err = reconciler.Reconcile(ctx, request)
+			assert.NilError(t, err)
+
+			for _, event := range recorder.Events {
+				for _, previous := range before {
+					assert.Assert(t, event.Reason != previous.Reason,
+						"repeated event %q", event.Reason)
+				}
+			}
+		})
+	}
+
+	t.Run("Reentrant", func(t *testing.T) {
+		switchover := &v1beta1.PGSwitchover{}
+		switchover.Namespace, switchover.Name = "ns1", "swap"
+		switchover.Spec.PrimaryClusterName = "east"
+		switchover.Spec.StandbyClusterName = "west"
+		meta.SetStatusCondition(&switchover.Status.Conditions, metav1.Condition{
+			Type: ConditionSucceeded, Status: metav1.ConditionTrue, Reason: "SwitchoverSucceeded",
+		})
+
+		cc := fake.NewClientBuilder().WithScheme(runtime.Scheme).
+			WithObjects(switchover).WithStatusSubresource(switchover).Build()
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &PGSwitchoverReconciler{
+			Reader: cc, Writer: cc, StatusWriter: cc.Status(), Recorder: recorder,
+		}
+
+		stored := &v1beta1.PGSwitchover{}
+		assert.NilError(t, cc.Get(ctx, client.ObjectKeyFromObject(switchover), stored))
+
+		// Nothing happens, even though the clusters are gone.
+		result, err := reconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(switchover),
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, result, reconcile.Result{})
+		assert.Equal(t, len(recorder.Events), 0)
+
+		actual := &v1beta1.PGSwitchover{}
+		assert.NilError(t, cc.Get(ctx, client.ObjectKeyFromObject(switchover), actual))
+		assert.DeepEqual(t, actual.Status, stored.Status)
+	})
+}
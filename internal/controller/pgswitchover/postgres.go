@@ -0,0 +1,123 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgswitchover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+)
+
+// walLongPageHeaderSize is the size of the header at the start of every WAL
+// segment, XLogLongPageHeaderData, on 64-bit platforms.
+// - https://git.postgresql.org/gitweb/?p=postgresql.git;f=src/include/access/xlog_internal.h;hb=REL_17_0
+const walLongPageHeaderSize = 40
+
+// parseLSN converts the text representation of a PostgreSQL "pg_lsn" into
+// a number of bytes.
+// - https://www.postgresql.org/docs/current/datatype-pg-lsn.html
+func parseLSN(text string) (uint64, error) {
+	var high, low uint64
+	_, err := fmt.Sscanf(text, "%X/%X", &high, &low)
+	if err == nil && (high > 0xFFFFFFFF || low > 0xFFFFFFFF) {
+		err = fmt.Errorf("LSN out of range: %q", text)
+	}
+	return high<<32 | low, errors.WithStack(err)
+}
+
+// formatLSN converts a number of bytes into the text representation of
+// a PostgreSQL "pg_lsn".
+func formatLSN(lsn uint64) string {
+	return fmt.Sprintf("%X/%X", lsn>>32, lsn&0xFFFFFFFF)
+}
+
+// requiredLSN returns the WAL location a standby must replay to have every
+// record written before checkpoint. When WAL is archived, a clean shutdown
+// switches to a new segment before it writes its checkpoint. In that case,
+// the checkpoint is right after the header of its segment and a standby that
+// follows a repository needs only the segments before it.
+// - https://git.postgresql.org/gitweb/?p=postgresql.git;f=src/backend/access/transam/xlog.c;hb=REL_17_0
+func requiredLSN(checkpoint, segmentSize uint64) uint64 {
+	if offset := checkpoint % segmentSize; offset <= walLongPageHeaderSize {
+		return checkpoint - offset
+	}
+	return checkpoint
+}
+
+// queryJSON uses "psql" to execute sql that returns a single JSON value and
+// unmarshals that value into result.
+func queryJSON(ctx context.Context, exec postgres.Executor, sql string, result any) error {
+	stdout, stderr, err := exec.Exec(ctx, strings.NewReader(`
+		\pset format unaligned
+		\pset tuples_only on
+		`+sql), map[string]string{
+		"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+		"QUIET":         "on", // Do not print successful statements to stdout.
+	})
+
+	logging.FromContext(ctx).V(1).Info("queried postgres", "stdout", stdout, "stderr", stderr)
+
+	if err == nil {
+		err = json.Unmarshal([]byte(strings.TrimSpace(stdout)), result)
+	}
+	return errors.WithStack(err)
+}
+
+// demotedPosition returns the timeline and the WAL location that a standby
+// must replay to have every record written by PostgreSQL before it was
+// demoted. It should be called after PostgreSQL restarts in recovery.
+func demotedPosition(ctx context.Context, exec postgres.Executor) (int64, uint64, error) {
+	var result struct {
+		Checkpoint  string `json:"checkpoint"`
+		SegmentSize uint64 `json:"segmentSize"`
+		Timeline    int64  `json:"timeline"`
+	}
+
+	// The last checkpoint of a demoted primary is its shutdown checkpoint.
+	// - https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-CONTROLDATA
+	err := queryJSON(ctx, exec, `
+		SELECT pg_catalog.json_build_object(
+		  'checkpoint', c.checkpoint_lsn,
+		  'segmentSize', s.setting::bigint,
+		  'timeline', c.timeline_id)
+		  FROM pg_catalog.pg_control_checkpoint() c, pg_catalog.pg_settings s
+		 WHERE s.name = 'wal_segment_size';`, &result)
+
+	var checkpoint uint64
+	if err == nil {
+		checkpoint, err = parseLSN(result.Checkpoint)
+	}
+	if err == nil && result.SegmentSize == 0 {
+		err = errors.New("unable to determine WAL segment size")
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return result.Timeline, requiredLSN(checkpoint, result.SegmentSize), nil
+}
+
+// replayedPosition returns the WAL location that PostgreSQL in recovery has
+// replayed. It returns zero when PostgreSQL is not in recovery.
+func replayedPosition(ctx context.Context, exec postgres.Executor) (uint64, error) {
+	var result struct {
+		Replayed *string `json:"replayed"`
+	}
+
+	// - https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-RECOVERY-CONTROL
+	err := queryJSON(ctx, exec, `
+		SELECT pg_catalog.json_build_object(
+		  'replayed', pg_catalog.pg_last_wal_replay_lsn());`, &result)
+
+	if err != nil || result.Replayed == nil {
+		return 0, err
+	}
+	return parseLSN(*result.Replayed)
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgswitchover
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseLSN(t *testing.T) {
+	for _, tt := range []struct {
+		text   string
+		expect uint64
+	}{
+		{text: "0/0", expect: 0},
+		{text: "0/16B3748", expect: 0x16B3748},
+		{text: "1/0", expect: 1 << 32},
+		{text: "A/FFFFFFFF", expect: 0xAFFFFFFFF},
+	} {
+		lsn, err := parseLSN(tt.text)
+		assert.NilError(t, err, "%q", tt.text)
+		assert.Equal(t, lsn, tt.expect, "%q", tt.text)
+		assert.Equal(t, formatLSN(lsn), tt.text)
+	}
+
+	for _, text := range []string{"", "0", "x/y", "100000000/0"} {
+		_, err := parseLSN(text)
+		assert.Assert(t, err != nil, "%q", text)
+	}
+}
+
+func TestRequiredLSN(t *testing.T) {
+	const segment = 16 << 20
+
+	// A checkpoint right after the segment header needs only prior segments.
+	assert.Equal(t, requiredLSN(3*segment+40, segment), uint64(3*segment))
+	assert.Equal(t, requiredLSN(3*segment, segment), uint64(3*segment))
+
+	// Any other checkpoint is needed itself.
+	assert.Equal(t, requiredLSN(3*segment+41, segment), uint64(3*segment+41))
+	assert.Equal(t, requiredLSN(3*segment+8192, segment), uint64(3*segment+8192))
+}
+
+func TestDemotedPosition(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Arguments", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, strings.Contains(string(b), "pg_control_checkpoint()"))
+			assert.Assert(t, strings.Contains(string(b), "wal_segment_size"))
+			return expected
+		}
+
+		_, _, err := demotedPosition(ctx, exec)
+		assert.Equal(t, expected, errors.Unwrap(err))
+	})
+
+	t.Run("Result", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, stdout, _ io.Writer, _ ...string,
+		) error {
+			_, err := stdout.Write([]byte(`{"checkpoint" : "0/5000028", "segmentSize" : 16777216, "timeline" : 4}` + "\n"))
+			return err
+		}
+
+		timeline, lsn, err := demotedPosition(ctx, exec)
+		assert.NilError(t, err)
+		assert.Equal(t, timeline, int64(4))
+		assert.Equal(t, formatLSN(lsn), "0/5000000")
+	})
+
+	t.Run("MissingSegmentSize", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, stdout, _ io.Writer, _ ...string,
+		) error {
+			_, err := stdout.Write([]byte(`{"checkpoint" : "0/5000028", "segmentSize" : null, "timeline" : 4}`))
+			return err
+		}
+
+		_, _, err := demotedPosition(ctx, exec)
+		assert.ErrorContains(t, err, "segment size")
+	})
+}
+
+func TestReplayedPosition(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("InRecovery", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, stdout, _ io.Writer, _ ...string,
+		) error {
+			_, err := stdout.Write([]byte(`{"replayed" : "0/5000100"}`))
+			return err
+		}
+
+		lsn, err := replayedPosition(ctx, exec)
+		assert.NilError(t, err)
+		assert.Equal(t, formatLSN(lsn), "0/5000100")
+	})
+
+	t.Run("NotInRecovery", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, stdout, _ io.Writer, _ ...string,
+		) error {
+			_, err := stdout.Write([]byte(`{"replayed" : null}`))
+			return err
+		}
+
+		lsn, err := replayedPosition(ctx, exec)
+		assert.NilError(t, err)
+		assert.Equal(t, lsn, uint64(0))
+	})
+}
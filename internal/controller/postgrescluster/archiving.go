@@ -0,0 +1,243 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/feature"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+const (
+	// ConditionArchivingHealthy is the type used in a condition to indicate
+	// whether or not the primary is archiving WAL to pgBackRest
+	ConditionArchivingHealthy = "ArchivingHealthy"
+
+	// EventArchivingUnhealthy is the event reason utilized when WAL archiving
+	// on the primary becomes unhealthy
+	EventArchivingUnhealthy = "ArchivingUnhealthy"
+)
+
+// +kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// reconcileArchivingHealth reads "pg_stat_archiver" and the WAL directory of
+// the primary into status and sets the ArchivingHealthy condition when archive
+// health is configured. When archiving is unhealthy, it records an event and
+// may grow the WAL volume of the primary. It returns how long to wait before
+// checking again, or zero when archive health is not configured.
+func (r *Reconciler) reconcileArchivingHealth(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, instances *observedInstances,
+) time.Duration {
+	status := postgresCluster.Status.PGBackRest
+
+	if postgresCluster.Spec.Backups.PGBackRest.ArchiveHealth == nil {
+		status.Archiving = nil
+		meta.RemoveStatusCondition(&postgresCluster.Status.Conditions, ConditionArchivingHealthy)
+		return 0
+	}
+	spec := *postgresCluster.Spec.Backups.PGBackRest.ArchiveHealth
+
+	interval := time.Minute
+	if spec.CheckInterval != nil {
+		interval = spec.CheckInterval.AsDuration().Duration
+	}
+
+	now := time.Now()
+	if status.Archiving != nil && status.Archiving.CheckTime != nil &&
+		now.Before(status.Archiving.CheckTime.Add(interval)) {
+		return status.Archiving.CheckTime.Add(interval).Sub(now)
+	}
+
+	// Only the primary archives WAL. Leave any existing status until there is one.
+	pod, writable := catalogPod(instances)
+	if pod == nil || !writable {
+		return interval
+	}
+
+	exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+		command ...string) error {
+		return r.PodExec(ctx, pod.Namespace, pod.Name, naming.ContainerDatabase,
+			stdin, stdout, stderr, command...)
+	}
+
+	archiver, err := postgres.Executor(exec).ArchiverStatus(ctx)
+
+	var directory *postgres.WALDirectoryStatus
+	if err == nil {
+		directory, err = postgres.Executor(exec).WALDirectoryStatus(ctx)
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error(err, "unable to read WAL archiving status")
+		return interval
+	}
+
+	status.Archiving = updateArchivingStatus(status.Archiving, archiver, directory, now)
+
+	previous := initialize.FromPointer(
+		meta.FindStatusCondition(postgresCluster.Status.Conditions, ConditionArchivingHealthy))
+	condition := archivingHealthyCondition(postgresCluster, spec, status.Archiving, now)
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, condition)
+
+	if condition.Status == metav1.ConditionFalse {
+		if previous.Status != condition.Status || previous.Reason != condition.Reason {
+			r.Recorder.Event(postgresCluster, corev1.EventTypeWarning,
+				EventArchivingUnhealthy, condition.Message)
+		}
+		if initialize.FromPointer(spec.AutoGrowWALVolume) &&
+			feature.Enabled(ctx, feature.AutoGrowVolumes) {
+			r.requestWALVolumeGrowth(ctx, postgresCluster, pod, directory.Size)
+		}
+	}
+
+	return interval
+}
+
+// updateArchivingStatus returns the state of WAL archiving in archiver and
+// directory as of now. It keeps when archiving first failed from previous.
+func updateArchivingStatus(
+	previous *v1beta1.PGBackRestArchivingStatus,
+	archiver *postgres.ArchiverStatus, directory *postgres.WALDirectoryStatus, now time.Time,
+) *v1beta1.PGBackRestArchivingStatus {
+	timePointer := func(t *time.Time) *metav1.Time {
+		if t == nil {
+			return nil
+		}
+		return initialize.Pointer(metav1.NewTime(*t))
+	}
+
+	status := &v1beta1.PGBackRestArchivingStatus{
+		CheckTime:        initialize.Pointer(metav1.NewTime(now)),
+		LastArchivedWAL:  archiver.LastArchivedWAL,
+		LastArchivedTime: timePointer(archiver.LastArchivedTime),
+		LastFailedWAL:    archiver.LastFailedWAL,
+		LastFailedTime:   timePointer(archiver.LastFailedTime),
+		PendingFiles:     directory.PendingFiles,
+		WALSize:          resource.NewQuantity(directory.Size, resource.BinarySI),
+	}
+
+	// PostgreSQL retries a failed file until it is archived, so archiving is
+	// failing while its latest failure is newer than its latest success.
+	failing := archiver.LastFailedTime != nil &&
+		(archiver.LastArchivedTime == nil || archiver.LastFailedTime.After(*archiver.LastArchivedTime))
+
+	if failing && previous != nil && previous.FailingSince != nil {
+		status.FailingSince = previous.FailingSince
+	} else if failing {
+		status.FailingSince = status.CheckTime
+	}
+
+	return status
+}
+
+// archivingHealthyCondition returns the ArchivingHealthy condition of cluster
+// for the archiving status and thresholds in spec.
+func archivingHealthyCondition(
+	cluster *v1beta1.PostgresCluster, spec v1beta1.PGBackRestArchiveHealth,
+	status *v1beta1.PGBackRestArchivingStatus, now time.Time,
+) metav1.Condition {
+	condition := metav1.Condition{
+		ObservedGeneration: cluster.GetGeneration(),
+		Type:               ConditionArchivingHealthy,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ArchivingHealthy",
+		Message:            "WAL archiving is keeping up",
+	}
+
+	failureThreshold := 5 * time.Minute
+	if spec.FailureThreshold != nil {
+		failureThreshold = spec.FailureThreshold.AsDuration().Duration
+	}
+
+	pendingThreshold := int64(256)
+	if spec.PendingFilesThreshold != nil {
+		pendingThreshold = int64(*spec.PendingFilesThreshold)
+	}
+
+	switch {
+	case status.FailingSince != nil && now.Sub(status.FailingSince.Time) >= failureThreshold:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ArchiveFailing"
+		condition.Message = fmt.Sprintf(
+			"WAL archiving has failed since %s; the last failed file is %s",
+			status.FailingSince.UTC().Format(time.RFC3339), status.LastFailedWAL)
+
+	case status.PendingFiles >= pendingThreshold:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ArchiveLagging"
+		condition.Message = fmt.Sprintf(
+			"%d WAL files are waiting to be archived", status.PendingFiles)
+
+	case spec.WALSizeThreshold != nil && status.WALSize != nil &&
+		status.WALSize.Cmp(*spec.WALSizeThreshold) >= 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "WALDirectoryFull"
+		condition.Message = fmt.Sprintf(
+			"The WAL directory is %s, which is at least %s",
+			status.WALSize.String(), spec.WALSizeThreshold.String())
+
+	case status.FailingSince != nil:
+		condition.Reason = "ArchiveRetrying"
+		condition.Message = fmt.Sprintf(
+			"WAL archiving is failing and will be retried; the last failed file is %s",
+			status.LastFailedWAL)
+	}
+
+	return condition
+}
+
+// requestWALVolumeGrowth stores a larger desired size for the WAL volume of
+// the instance in pod when WAL fills more than three quarters of that volume.
+// The new size is half again as large, the same as the data volume.
+func (r *Reconciler) requestWALVolumeGrowth(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, pod *corev1.Pod, walSize int64,
+) {
+	setName := pod.Labels[naming.LabelInstanceSet]
+	instanceName := pod.Labels[naming.LabelInstance]
+
+	var spec *v1beta1.PostgresInstanceSetSpec
+	for i := range cluster.Spec.InstanceSets {
+		if cluster.Spec.InstanceSets[i].Name == setName {
+			spec = &cluster.Spec.InstanceSets[i]
+		}
+	}
+	if spec == nil || spec.WALVolumeClaimSpec == nil {
+		return
+	}
+
+	for i := range cluster.Status.InstanceSets {
+		status := &cluster.Status.InstanceSets[i]
+		if status.Name != setName {
+			continue
+		}
+
+		current := *spec.WALVolumeClaimSpec.Resources.Requests.Storage()
+		previous := status.DesiredPGWALVolume[instanceName]
+		if desired, err := resource.ParseQuantity(previous); err == nil && desired.Cmp(current) > 0 {
+			current = desired
+		}
+
+		if walSize*4 <= current.Value()*3 {
+			return
+		}
+
+		mebibytes := current.Value() / (1 << 20)
+		initialize.Map(&status.DesiredPGWALVolume)
+		status.DesiredPGWALVolume[instanceName] = r.storeDesiredRequest(ctx, cluster, "pgWAL",
+			setName, fmt.Sprintf("%dMi", mebibytes+mebibytes/2), previous)
+	}
+}
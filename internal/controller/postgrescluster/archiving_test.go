@@ -0,0 +1,297 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestUpdateArchivingStatus(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	earlier := now.Add(-time.Hour)
+	directory := &postgres.WALDirectoryStatus{Size: 1 << 30, PendingFiles: 3}
+
+	t.Run("Healthy", func(t *testing.T) {
+		status := updateArchivingStatus(nil, &postgres.ArchiverStatus{
+			LastArchivedWAL: "00000001000000000000000C", LastArchivedTime: &now,
+			LastFailedWAL: "00000001000000000000000B", LastFailedTime: &earlier,
+		}, directory, now)
+
+		assert.Assert(t, cmp.MarshalMatches(status, `
+checkTime: "2025-01-02T03:04:05Z"
+lastArchivedTime: "2025-01-02T03:04:05Z"
+lastArchivedWAL: 00000001000000000000000C
+lastFailedTime: "2025-01-02T02:04:05Z"
+lastFailedWAL: 00000001000000000000000B
+pendingFiles: 3
+walSize: 1Gi
+		`))
+	})
+
+	t.Run("Failing", func(t *testing.T) {
+		archiver := &postgres.ArchiverStatus{
+			LastArchivedWAL: "00000001000000000000000B", LastArchivedTime: &earlier,
+			LastFailedWAL: "00000001000000000000000C", LastFailedTime: &now,
+		}
+
+		status := updateArchivingStatus(nil, archiver, directory, now)
+		assert.Equal(t, status.FailingSince.Time, now)
+
+		// The first failure is kept while archiving continues to fail.
+		later := updateArchivingStatus(status, archiver, directory, now.Add(time.Minute))
+		assert.Equal(t, later.FailingSince.Time, now)
+		assert.Equal(t, later.CheckTime.Time, now.Add(time.Minute))
+	})
+}
+
+func TestArchivingHealthyCondition(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Generation = 7
+
+	thirtySeconds, err := v1beta1.NewDuration("30s")
+	assert.NilError(t, err)
+
+	for _, tt := range []struct {
+		name   string
+		spec   v1beta1.PGBackRestArchiveHealth
+		status v1beta1.PGBackRestArchivingStatus
+		result metav1.ConditionStatus
+		reason string
+	}{
+		{
+			name:   "Healthy",
+			status: v1beta1.PGBackRestArchivingStatus{PendingFiles: 10},
+			result: metav1.ConditionTrue, reason: "ArchivingHealthy",
+		},
+		{
+			name: "Retrying",
+			status: v1beta1.PGBackRestArchivingStatus{
+				FailingSince: initialize.Pointer(metav1.NewTime(now.Add(-time.Minute))),
+			},
+			result: metav1.ConditionTrue, reason: "ArchiveRetrying",
+		},
+		{
+			name: "Failing",
+			status: v1beta1.PGBackRestArchivingStatus{
+				FailingSince: initialize.Pointer(metav1.NewTime(now.Add(-10 * time.Minute))),
+			},
+			result: metav1.ConditionFalse, reason: "ArchiveFailing",
+		},
+		{
+			name: "FailureThreshold",
+			spec: v1beta1.PGBackRestArchiveHealth{
+				FailureThreshold: thirtySeconds,
+			},
+			status: v1beta1.PGBackRestArchivingStatus{
+				FailingSince: initialize.Pointer(metav1.NewTime(now.Add(-time.Minute))),
+			},
+			result: metav1.ConditionFalse, reason: "ArchiveFailing",
+		},
+		{
+			name:   "Lagging",
+			status: v1beta1.PGBackRestArchivingStatus{PendingFiles: 256},
+			result: metav1.ConditionFalse, reason: "ArchiveLagging",
+		},
+		{
+			name: "PendingFilesThreshold",
+			spec: v1beta1.PGBackRestArchiveHealth{
+				PendingFilesThreshold: initialize.Int32(5),
+			},
+			status: v1beta1.PGBackRestArchivingStatus{PendingFiles: 10},
+			result: metav1.ConditionFalse, reason: "ArchiveLagging",
+		},
+		{
+			name: "WALDirectoryFull",
+			spec: v1beta1.PGBackRestArchiveHealth{
+				WALSizeThreshold: initialize.Pointer(resource.MustParse("1Gi")),
+			},
+			status: v1beta1.PGBackRestArchivingStatus{
+				WALSize: initialize.Pointer(resource.MustParse("2Gi")),
+			},
+			result: metav1.ConditionFalse, reason: "WALDirectoryFull",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			condition := archivingHealthyCondition(cluster, tt.spec, &tt.status, now)
+
+			assert.Equal(t, condition.Type, ConditionArchivingHealthy)
+			assert.Equal(t, condition.ObservedGeneration, int64(7))
+			assert.Equal(t, condition.Status, tt.result)
+			assert.Equal(t, condition.Reason, tt.reason)
+			assert.Assert(t, condition.Message != "")
+		})
+	}
+}
+
+func TestRequestWALVolumeGrowth(t *testing.T) {
+	ctx := context.Background()
+
+	pod := &corev1.Pod{}
+	pod.Labels = map[string]string{
+		naming.LabelInstanceSet: "00",
+		naming.LabelInstance:    "hippo-00-abcd",
+	}
+
+	base := &v1beta1.PostgresCluster{}
+	base.Name = "hippo"
+	base.Spec.InstanceSets = []v1beta1.PostgresInstanceSetSpec{{
+		Name: "00",
+		WALVolumeClaimSpec: &v1beta1.VolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+	}}
+	base.Status.InstanceSets = []v1beta1.PostgresInstanceSetStatus{{Name: "00"}}
+
+	t.Run("NoWALVolume", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		cluster := base.DeepCopy()
+		cluster.Spec.InstanceSets[0].WALVolumeClaimSpec = nil
+
+		reconciler.requestWALVolumeGrowth(ctx, cluster, pod, 1<<30)
+		assert.Assert(t, cluster.Status.InstanceSets[0].DesiredPGWALVolume == nil)
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	t.Run("BelowThreshold", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		cluster := base.DeepCopy()
+
+		reconciler.requestWALVolumeGrowth(ctx, cluster, pod, 700<<20)
+		assert.Assert(t, cluster.Status.InstanceSets[0].DesiredPGWALVolume == nil)
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	t.Run("AboveThreshold", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		cluster := base.DeepCopy()
+
+		reconciler.requestWALVolumeGrowth(ctx, cluster, pod, 800<<20)
+		assert.DeepEqual(t, cluster.Status.InstanceSets[0].DesiredPGWALVolume,
+			map[string]string{"hippo-00-abcd": "1536Mi"})
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "VolumeAutoGrow")
+
+		// The next request grows from the previously desired size.
+		reconciler.requestWALVolumeGrowth(ctx, cluster, pod, 1200<<20)
+		assert.DeepEqual(t, cluster.Status.InstanceSets[0].DesiredPGWALVolume,
+			map[string]string{"hippo-00-abcd": "2304Mi"})
+		assert.Equal(t, len(recorder.Events), 2)
+	})
+}
+
+func TestReconcileArchivingHealth(t *testing.T) {
+	ctx := context.Background()
+
+	pod := &corev1.Pod{}
+	pod.Namespace, pod.Name = "ns1", "hippo-00-abcd-0"
+	pod.Annotations = map[string]string{"status": `{"role":"master"}`}
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  naming.ContainerDatabase,
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}}
+	instances := &observedInstances{forCluster: []*Instance{{Pods: []*corev1.Pod{pod}}}}
+
+	base := &v1beta1.PostgresCluster{}
+	base.Namespace, base.Name = "ns1", "hippo"
+	base.Spec.Backups.PGBackRest.ArchiveHealth = &v1beta1.PGBackRestArchiveHealth{
+		PendingFilesThreshold: initialize.Int32(10),
+	}
+	base.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
+
+	// The WAL directory reports the number of files in pending.
+	var calls, pending int
+	reconciler := func(t *testing.T) (*Reconciler, *events.Recorder) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		return &Reconciler{
+			Recorder: recorder,
+			PodExec: func(
+				_ context.Context, _, _, _ string, stdin io.Reader, stdout, _ io.Writer, _ ...string,
+			) error {
+				calls++
+				b, _ := io.ReadAll(stdin)
+				if strings.Contains(string(b), "pg_stat_archiver") {
+					_, _ = stdout.Write([]byte(`{"last_archived_wal":"000000010000000000000001"}`))
+				} else {
+					_, _ = fmt.Fprintf(stdout, `{"size":16777216,"pending_files":%d}`, pending)
+				}
+				return nil
+			},
+		}, recorder
+	}
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		calls = 0
+		reconciler, recorder := reconciler(t)
+		cluster := base.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.ArchiveHealth = nil
+		cluster.Status.PGBackRest.Archiving = &v1beta1.PGBackRestArchivingStatus{PendingFiles: 5}
+		cluster.Status.Conditions = []metav1.Condition{{
+			Type: ConditionArchivingHealthy, Status: metav1.ConditionFalse, Reason: "ArchiveLagging",
+		}}
+
+		assert.Equal(t, reconciler.reconcileArchivingHealth(ctx, cluster, instances), time.Duration(0))
+		assert.Equal(t, calls, 0, "expected no exec")
+		assert.Assert(t, cluster.Status.PGBackRest.Archiving == nil)
+		assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions, ConditionArchivingHealthy) == nil)
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	t.Run("Transitions", func(t *testing.T) {
+		reconciler, recorder := reconciler(t)
+		cluster := base.DeepCopy()
+
+		check := func() *metav1.Condition {
+			t.Helper()
+			// Pretend the interval has elapsed.
+			if cluster.Status.PGBackRest.Archiving != nil {
+				cluster.Status.PGBackRest.Archiving.CheckTime = nil
+			}
+			assert.Equal(t, reconciler.reconcileArchivingHealth(ctx, cluster, instances), time.Minute)
+			return meta.FindStatusCondition(cluster.Status.Conditions, ConditionArchivingHealthy)
+		}
+
+		pending = 0
+		assert.Equal(t, check().Status, metav1.ConditionTrue)
+		assert.Equal(t, len(recorder.Events), 0)
+
+		// True to False records one event.
+		pending = 20
+		assert.Equal(t, check().Status, metav1.ConditionFalse)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, EventArchivingUnhealthy)
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note, "20 WAL files"))
+
+		// Steady False records none.
+		pending = 30
+		assert.Equal(t, check().Status, metav1.ConditionFalse)
+		assert.Equal(t, len(recorder.Events), 1)
+	})
+}
@@ -6,13 +6,19 @@ package postgrescluster
 
 import (
 	"context"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crunchydata/postgres-operator/internal/feature"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/logging"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -58,9 +64,31 @@ func (r *Reconciler) storeDesiredRequest(
 	limitSet := limitIsSet(cluster, volumeType, host)
 
 	if limitSet && current.Value() > previous.Value() {
-		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "VolumeAutoGrow",
-			"%s volume expansion to %v requested for %s/%s.",
-			volumeType, current.String(), cluster.Name, host)
+		key := volumeType + "/" + host
+		policy := autogrowPolicy(cluster, volumeType)
+
+		// Wait until the cooldown has passed since the last request to grow
+		// this kind of volume. Until then, the previous value remains.
+		if last, ok := cluster.Status.VolumeAutoGrowTimes[key]; ok &&
+			policy != nil && policy.Cooldown != nil &&
+			time.Since(last.Time) < policy.Cooldown.AsDuration().Duration {
+			log.V(1).Info("Waiting to grow "+volumeType+" volume for "+cluster.Name+"/"+host,
+				"desired", current.String(), "since", last.Time)
+			return desiredRequestBackup
+		}
+
+		initialize.Map(&cluster.Status.VolumeAutoGrowTimes)
+		cluster.Status.VolumeAutoGrowTimes[key] = metav1.Now()
+
+		if autogrowDryRun(cluster) {
+			r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "VolumeAutoGrowDryRun",
+				"%s volume expansion to %v would be requested for %s/%s.",
+				volumeType, current.String(), cluster.Name, host)
+		} else {
+			r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "VolumeAutoGrow",
+				"%s volume expansion to %v requested for %s/%s.",
+				volumeType, current.String(), cluster.Name, host)
+		}
 	}
 
 	// If the desired size was not observed, update with previously stored value.
@@ -73,6 +101,48 @@ func (r *Reconciler) storeDesiredRequest(
 	return desiredRequest
 }
 
+// largerQuantity returns whichever of a and b is the larger quantity. When
+// either cannot be parsed, it returns the other.
+func largerQuantity(a, b string) string {
+	qa, errA := resource.ParseQuantity(a)
+	qb, errB := resource.ParseQuantity(b)
+
+	switch {
+	case errA != nil:
+		return b
+	case errB != nil:
+		return a
+	case qb.Cmp(qa) > 0:
+		return b
+	}
+	return a
+}
+
+// autogrowPolicy returns the policy that applies to volumes of volumeType in
+// cluster, if any.
+func autogrowPolicy(cluster *v1beta1.PostgresCluster, volumeType string) *v1beta1.VolumeAutoGrowPolicy {
+	spec := cluster.Spec.AutoGrow
+
+	switch {
+	case spec == nil:
+		return nil
+	case volumeType == "pgData":
+		return spec.PGData
+	case volumeType == "pgWAL":
+		return spec.PGWAL
+	case strings.HasPrefix(volumeType, "tablespace-"):
+		return spec.Tablespaces
+	case strings.HasPrefix(volumeType, "repo"):
+		return spec.Repos
+	}
+	return nil
+}
+
+// autogrowDryRun returns true when volumes of cluster should not actually grow.
+func autogrowDryRun(cluster *v1beta1.PostgresCluster) bool {
+	return cluster.Spec.AutoGrow != nil && cluster.Spec.AutoGrow.DryRun
+}
+
 // limitIsSet determines if the limit is set for a given volume type and returns
 // a corresponding boolean value
 func limitIsSet(cluster *v1beta1.PostgresCluster, volumeType, instanceSetName string) bool {
@@ -98,8 +168,24 @@ func limitIsSet(cluster *v1beta1.PostgresCluster, volumeType, instanceSetName st
 				limitSet = !specRepo.Volume.VolumeClaimSpec.Resources.Limits.Storage().IsZero()
 			}
 		}
+
+	case volumeType == "pgWAL":
+		for _, specInstance := range cluster.Spec.InstanceSets {
+			if specInstance.Name == instanceSetName && specInstance.WALVolumeClaimSpec != nil {
+				limitSet = !specInstance.WALVolumeClaimSpec.Resources.Limits.Storage().IsZero()
+			}
+		}
+
+	// VolumeType for tablespace volumes should be in the form 'tablespace-NAME'.
+	case strings.HasPrefix(volumeType, "tablespace-"):
+		for _, specInstance := range cluster.Spec.InstanceSets {
+			for _, specVolume := range specInstance.TablespaceVolumes {
+				if specInstance.Name == instanceSetName && volumeType == "tablespace-"+specVolume.Name {
+					limitSet = !specVolume.DataVolumeClaimSpec.Resources.Limits.Storage().IsZero()
+				}
+			}
+		}
 	}
-	// TODO: Add case for pgWAL
 
 	return limitSet
 
@@ -136,8 +222,11 @@ func (r *Reconciler) setVolumeSize(ctx context.Context, cluster *v1beta1.Postgre
 		// Otherwise, if the feature gate is not enabled, do not autogrow.
 	} else if feature.Enabled(ctx, feature.AutoGrowVolumes) {
 
-		// determine the appropriate volume request based on what's set in the status
-		if dpv, err := getDesiredVolumeSize(
+		// determine the appropriate volume request based on what's set in the status,
+		// unless the desired size should only be reported
+		if autogrowDryRun(cluster) {
+			// leave the volume request as is
+		} else if dpv, err := getDesiredVolumeSize(
 			cluster, volumeType, host, volumeRequestSize,
 		); err != nil {
 			log.Error(err, "For "+cluster.Name+"/"+host+
@@ -177,10 +266,14 @@ func getDesiredVolumeSize(cluster *v1beta1.PostgresCluster,
 	volumeRequestSize *resource.Quantity) (string, error) {
 
 	switch {
-	case volumeType == "pgData":
+	case volumeType == "pgData", volumeType == "pgWAL":
 		for i := range cluster.Status.InstanceSets {
+			desired := cluster.Status.InstanceSets[i].DesiredPGDataVolume
+			if volumeType == "pgWAL" {
+				desired = cluster.Status.InstanceSets[i].DesiredPGWALVolume
+			}
 			if instanceSpecName == cluster.Status.InstanceSets[i].Name {
-				for _, dpv := range cluster.Status.InstanceSets[i].DesiredPGDataVolume {
+				for _, dpv := range desired {
 					if dpv != "" {
 						desiredRequest, err := resource.ParseQuantity(dpv)
 						if err == nil {
@@ -195,6 +288,24 @@ func getDesiredVolumeSize(cluster *v1beta1.PostgresCluster,
 			}
 		}
 
+	// VolumeType for tablespace volumes should be in the form 'tablespace-NAME'.
+	case strings.HasPrefix(volumeType, "tablespace-"):
+		for i := range cluster.Status.InstanceSets {
+			if instanceSpecName == cluster.Status.InstanceSets[i].Name {
+				dpv := cluster.Status.InstanceSets[i].DesiredTablespaceVolumes[strings.TrimPrefix(volumeType, "tablespace-")]
+				if dpv != "" {
+					desiredRequest, err := resource.ParseQuantity(dpv)
+					if err == nil {
+						if desiredRequest.Value() > volumeRequestSize.Value() {
+							*volumeRequestSize = desiredRequest
+						}
+					} else {
+						return dpv, err
+					}
+				}
+			}
+		}
+
 	// VolumeType for the repository host volumes should be in the form 'repoN'
 	// where N is 1-4. As above, cycle through any defined repositories and ensure
 	// the correct limit is identified.
@@ -218,6 +329,132 @@ func getDesiredVolumeSize(cluster *v1beta1.PostgresCluster,
 			}
 		}
 	}
-	// TODO: Add case for pgWAL
 	return "", nil
 }
+
+const (
+	// ConditionAutogrowLimitReached is the type used in a condition to indicate
+	// whether or not any automatically grown volume has reached its size limit
+	ConditionAutogrowLimitReached = "PersistentVolumeAutogrowLimitReached"
+
+	// EventAutogrowLimitReached is the event reason utilized when a volume
+	// first reaches its size limit
+	EventAutogrowLimitReached = "PersistentVolumeAutogrowLimitReached"
+)
+
+// reconcileAutogrowLimits sets the PersistentVolumeAutogrowLimitReached
+// condition of cluster and records an event when some volume first reaches
+// its limit. It returns how long to wait until a growth that is held back by
+// a cooldown can be requested.
+func (r *Reconciler) reconcileAutogrowLimits(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) time.Duration {
+	if !feature.Enabled(ctx, feature.AutoGrowVolumes) {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionAutogrowLimitReached)
+		return 0
+	}
+
+	previous := initialize.FromPointer(
+		meta.FindStatusCondition(cluster.Status.Conditions, ConditionAutogrowLimitReached))
+	condition := autogrowLimitCondition(cluster)
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+
+	if condition.Status == metav1.ConditionTrue &&
+		(previous.Status != condition.Status || previous.Message != condition.Message) {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning,
+			EventAutogrowLimitReached, condition.Message)
+	}
+
+	return autogrowCooldownRequeue(cluster, time.Now())
+}
+
+// autogrowLimitCondition returns the PersistentVolumeAutogrowLimitReached
+// condition of cluster by comparing the desired size of each volume in status
+// to its limit in spec.
+func autogrowLimitCondition(cluster *v1beta1.PostgresCluster) metav1.Condition {
+	var reached []string
+
+	check := func(name string, spec *v1beta1.VolumeClaimSpec, desired ...string) {
+		if spec == nil {
+			return
+		}
+		limit := spec.Resources.Limits.Storage()
+		if limit.IsZero() {
+			return
+		}
+		for _, dpv := range desired {
+			if quantity, err := resource.ParseQuantity(dpv); err == nil && quantity.Cmp(*limit) >= 0 {
+				reached = append(reached, name+" ("+limit.String()+")")
+				return
+			}
+		}
+	}
+
+	for i := range cluster.Spec.InstanceSets {
+		set := &cluster.Spec.InstanceSets[i]
+		var status v1beta1.PostgresInstanceSetStatus
+		for j := range cluster.Status.InstanceSets {
+			if cluster.Status.InstanceSets[j].Name == set.Name {
+				status = cluster.Status.InstanceSets[j]
+			}
+		}
+
+		check("pgData/"+set.Name, &set.DataVolumeClaimSpec,
+			slices.Sorted(maps.Values(status.DesiredPGDataVolume))...)
+		check("pgWAL/"+set.Name, set.WALVolumeClaimSpec,
+			slices.Sorted(maps.Values(status.DesiredPGWALVolume))...)
+
+		for j := range set.TablespaceVolumes {
+			volume := &set.TablespaceVolumes[j]
+			check("tablespace-"+volume.Name+"/"+set.Name, &volume.DataVolumeClaimSpec,
+				status.DesiredTablespaceVolumes[volume.Name])
+		}
+	}
+
+	if cluster.Status.PGBackRest != nil {
+		for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+			for _, status := range cluster.Status.PGBackRest.Repos {
+				if repo.Volume != nil && repo.Name == status.Name {
+					check(repo.Name+"/repo-host", &repo.Volume.VolumeClaimSpec, status.DesiredRepoVolume)
+				}
+			}
+		}
+	}
+
+	condition := metav1.Condition{
+		ObservedGeneration: cluster.GetGeneration(),
+		Type:               ConditionAutogrowLimitReached,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BelowLimit",
+		Message:            "No volume has grown to its size limit",
+	}
+
+	if len(reached) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "LimitReached"
+		condition.Message = "Volumes have grown to their size limit: " + strings.Join(reached, ", ")
+	}
+
+	return condition
+}
+
+// autogrowCooldownRequeue returns how long until the cooldown of the most
+// recently grown volume of cluster passes, or zero when none are cooling down.
+func autogrowCooldownRequeue(cluster *v1beta1.PostgresCluster, now time.Time) time.Duration {
+	var requeue time.Duration
+
+	for key, last := range cluster.Status.VolumeAutoGrowTimes {
+		volumeType, _, _ := strings.Cut(key, "/")
+		policy := autogrowPolicy(cluster, volumeType)
+		if policy == nil || policy.Cooldown == nil {
+			continue
+		}
+
+		remaining := last.Add(policy.Cooldown.AsDuration().Duration).Sub(now)
+		if remaining > 0 && (requeue == 0 || remaining < requeue) {
+			requeue = remaining
+		}
+	}
+
+	return requeue
+}
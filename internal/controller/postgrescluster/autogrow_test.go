@@ -7,6 +7,7 @@ package postgrescluster
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr/funcr"
 	"gotest.tools/v3/assert"
@@ -20,6 +21,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/logging"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
 	"github.com/crunchydata/postgres-operator/internal/testing/events"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
@@ -136,6 +138,157 @@ func TestStoreDesiredRequest(t *testing.T) {
 		assert.Equal(t, len(*logs), 0)
 		assert.Equal(t, len(recorder.Events), 0)
 	})
+
+	t.Run("Cooldown", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		ctx, _ := setupLogCapture(ctx)
+
+		cluster := cluster.DeepCopy()
+		cluster.Status.VolumeAutoGrowTimes = nil
+		cooldown, err := v1beta1.NewDuration("1h")
+		assert.NilError(t, err)
+		cluster.Spec.AutoGrow = &v1beta1.VolumeAutoGrowSpec{
+			PGData: &v1beta1.VolumeAutoGrowPolicy{Cooldown: cooldown},
+		}
+
+		value := reconciler.storeDesiredRequest(ctx, cluster, "pgData", "red", "2Gi", "1Gi")
+		assert.Equal(t, value, "2Gi")
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Assert(t, cluster.Status.VolumeAutoGrowTimes["pgData/red"].Time.After(time.Now().Add(-time.Minute)))
+
+		// Growth within the cooldown keeps the previous value.
+		value = reconciler.storeDesiredRequest(ctx, cluster, "pgData", "red", "3Gi", "2Gi")
+		assert.Equal(t, value, "2Gi")
+		assert.Equal(t, len(recorder.Events), 1)
+
+		// Growth after the cooldown is requested.
+		cluster.Status.VolumeAutoGrowTimes["pgData/red"] = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		value = reconciler.storeDesiredRequest(ctx, cluster, "pgData", "red", "3Gi", "2Gi")
+		assert.Equal(t, value, "3Gi")
+		assert.Equal(t, len(recorder.Events), 2)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		ctx, _ := setupLogCapture(ctx)
+
+		cluster := cluster.DeepCopy()
+		cluster.Spec.AutoGrow = &v1beta1.VolumeAutoGrowSpec{DryRun: true}
+
+		value := reconciler.storeDesiredRequest(ctx, cluster, "pgData", "red", "2Gi", "1Gi")
+		assert.Equal(t, value, "2Gi")
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "VolumeAutoGrowDryRun")
+		assert.Equal(t, recorder.Events[0].Note, "pgData volume expansion to 2Gi would be requested for rhino/red.")
+	})
+}
+
+func TestAutogrowLimitCondition(t *testing.T) {
+	limited := func(limit string) v1beta1.VolumeClaimSpec {
+		return v1beta1.VolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(limit)},
+			},
+		}
+	}
+
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Spec.InstanceSets = []v1beta1.PostgresInstanceSetSpec{{
+		Name:                "one",
+		DataVolumeClaimSpec: limited("2Gi"),
+		WALVolumeClaimSpec:  initialize.Pointer(limited("1Gi")),
+		TablespaceVolumes: []v1beta1.TablespaceVolume{
+			{Name: "trial", DataVolumeClaimSpec: limited("5Gi")},
+		},
+	}}
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo1", Volume: &v1beta1.RepoPVC{VolumeClaimSpec: limited("10Gi")}},
+	}
+
+	condition := autogrowLimitCondition(cluster)
+	assert.Equal(t, condition.Type, "PersistentVolumeAutogrowLimitReached")
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, "BelowLimit")
+
+	cluster.Status.InstanceSets = []v1beta1.PostgresInstanceSetStatus{{
+		Name:                     "one",
+		DesiredPGDataVolume:      map[string]string{"one-abc": "1Gi", "one-def": "2Gi"},
+		DesiredPGWALVolume:       map[string]string{"one-abc": "512Mi"},
+		DesiredTablespaceVolumes: map[string]string{"trial": "6Gi"},
+	}}
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1", DesiredRepoVolume: "10Gi"}},
+	}
+
+	condition = autogrowLimitCondition(cluster)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	assert.Equal(t, condition.Reason, "LimitReached")
+	assert.Equal(t, condition.Message, "Volumes have grown to their size limit: "+
+		"pgData/one (2Gi), tablespace-trial/one (5Gi), repo1/repo-host (10Gi)")
+}
+
+func TestReconcileAutogrowLimits(t *testing.T) {
+	gate := feature.NewGate()
+	assert.NilError(t, gate.SetFromMap(map[string]bool{
+		feature.AutoGrowVolumes: true,
+	}))
+	ctx := feature.NewContext(context.Background(), gate)
+
+	cluster := new(v1beta1.PostgresCluster)
+	require.UnmarshalInto(t, &cluster.Spec, `{
+		instances: [{
+			name: one,
+			dataVolumeClaimSpec: { resources: { limits: { storage: 2Gi } } },
+		}],
+	}`)
+	cluster.Status.InstanceSets = []v1beta1.PostgresInstanceSetStatus{{
+		Name: "one", DesiredPGDataVolume: map[string]string{"one-abc": "2Gi"},
+	}}
+
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	reconciler := &Reconciler{Recorder: recorder}
+
+	reconciler.reconcileAutogrowLimits(ctx, cluster)
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Equal(t, recorder.Events[0].Reason, "PersistentVolumeAutogrowLimitReached")
+
+	// The event is not repeated while the limit remains reached.
+	reconciler.reconcileAutogrowLimits(ctx, cluster)
+	assert.Equal(t, len(recorder.Events), 1)
+
+	// The event is recorded again after the limit is raised and reached again.
+	cluster.Status.InstanceSets[0].DesiredPGDataVolume["one-abc"] = "1Gi"
+	reconciler.reconcileAutogrowLimits(ctx, cluster)
+	assert.Equal(t, len(recorder.Events), 1)
+
+	cluster.Status.InstanceSets[0].DesiredPGDataVolume["one-abc"] = "2Gi"
+	reconciler.reconcileAutogrowLimits(ctx, cluster)
+	assert.Equal(t, len(recorder.Events), 2)
+}
+
+func TestAutogrowCooldownRequeue(t *testing.T) {
+	now := time.Now()
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Status.VolumeAutoGrowTimes = map[string]metav1.Time{
+		"pgData/one":         metav1.NewTime(now.Add(-10 * time.Minute)),
+		"pgWAL/one":          metav1.NewTime(now.Add(-50 * time.Minute)),
+		"repo1/repo-host":    metav1.NewTime(now.Add(-2 * time.Hour)),
+		"tablespace-x/other": metav1.NewTime(now),
+	}
+
+	assert.Equal(t, autogrowCooldownRequeue(cluster, now), time.Duration(0))
+
+	cooldown, err := v1beta1.NewDuration("1h")
+	assert.NilError(t, err)
+	cluster.Spec.AutoGrow = &v1beta1.VolumeAutoGrowSpec{
+		PGData: &v1beta1.VolumeAutoGrowPolicy{Cooldown: cooldown},
+		PGWAL:  &v1beta1.VolumeAutoGrowPolicy{Cooldown: cooldown},
+		Repos:  &v1beta1.VolumeAutoGrowPolicy{Cooldown: cooldown},
+	}
+
+	assert.Equal(t, autogrowCooldownRequeue(cluster, now), 10*time.Minute)
 }
 
 func TestLimitIsSet(t *testing.T) {
@@ -155,6 +308,12 @@ func TestLimitIsSet(t *testing.T) {
 						Limits: map[corev1.ResourceName]resource.Quantity{
 							corev1.ResourceStorage: resource.MustParse("1Gi"),
 						}}},
+				WALVolumeClaimSpec: &v1beta1.VolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Resources: corev1.VolumeResourceRequirements{
+						Limits: map[corev1.ResourceName]resource.Quantity{
+							corev1.ResourceStorage: resource.MustParse("1Gi"),
+						}}},
 			}, {
 				Name:     "blue",
 				Replicas: initialize.Int32(1),
@@ -197,6 +356,16 @@ func TestLimitIsSet(t *testing.T) {
 		Voltype:      "pgData",
 		instanceName: "orange",
 		expected:     false,
+	}, {
+		tcName:       "Limit is set for instance PGWAL volume",
+		Voltype:      "pgWAL",
+		instanceName: "red",
+		expected:     true,
+	}, {
+		tcName:       "Instance has no PGWAL volume",
+		Voltype:      "pgWAL",
+		instanceName: "blue",
+		expected:     false,
 	}, {
 		tcName:       "Limit is not set for repo1 volume",
 		Voltype:      "repo1",
@@ -568,6 +737,7 @@ func TestDetermineDesiredVolumeRequest(t *testing.T) {
 			InstanceSets: []v1beta1.PostgresInstanceSetStatus{{
 				Name:                "some-instance",
 				DesiredPGDataVolume: desiredMap,
+				DesiredPGWALVolume:  desiredMap,
 			}},
 			PGBackRest: &v1beta1.PGBackRestStatus{
 				Repos: []v1beta1.RepoStatus{{
@@ -622,6 +792,20 @@ func TestDetermineDesiredVolumeRequest(t *testing.T) {
 		expected:       "1Gi",
 		expectedError:  "quantities must match the regular expression",
 		expectedDPV:    "batman",
+	}, {
+		tcName:         "pgwal-Larger size requested",
+		sizeFromStatus: "3Gi",
+		pvcRequestSize: "2Gi",
+		volType:        "pgWAL",
+		host:           "some-instance",
+		expected:       "3Gi",
+	}, {
+		tcName:         "pgwal-Original larger than status request",
+		sizeFromStatus: "1Gi",
+		pvcRequestSize: "2Gi",
+		volType:        "pgWAL",
+		host:           "some-instance",
+		expected:       "2Gi",
 	}, {
 		tcName:         "repo1-Larger size requested",
 		sizeFromStatus: "3Gi",
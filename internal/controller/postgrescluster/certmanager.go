@@ -0,0 +1,439 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/config"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/pki"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// certManagerCertificateKind identifies the cert-manager Certificate API. The
+// operator does not depend on cert-manager, so Certificates are unstructured.
+// - https://cert-manager.io/docs/reference/api-docs/#cert-manager.io/v1.Certificate
+var certManagerCertificateKind = schema.GroupVersionKind{
+	Group: "cert-manager.io", Version: "v1", Kind: "Certificate",
+}
+
+// certManagerCertificateAnnotation is the annotation that cert-manager puts on
+// the Secret of a Certificate. Its value is the name of the Certificate.
+// - https://cert-manager.io/docs/reference/annotations/#cert-manageriocertificate-name
+const certManagerCertificateAnnotation = "cert-manager.io/certificate-name"
+
+const (
+	// ConditionIssuedCertificatesValid is the type used in a condition to indicate
+	// whether or not certificates issued by cert-manager can be used
+	ConditionIssuedCertificatesValid = "IssuedCertificatesValid"
+
+	// EventIssuedCertificateMissingAuthority is the event reason utilized when
+	// cert-manager issues a certificate without the authority that signed it
+	EventIssuedCertificateMissingAuthority = "IssuedCertificateMissingAuthority"
+)
+
+// certificateIssuer returns the cert-manager issuer of cluster, if any.
+//
+// PostgreSQL authenticates the replication user and users with client
+// certificates by the common name of any certificate the issuer signs. Anyone
+// allowed to create Certificates with the same issuer can assume those roles,
+// so the issuer should be dedicated to cluster.
+func certificateIssuer(cluster *v1beta1.PostgresCluster) *v1beta1.CertManagerIssuerReference {
+	if cluster.Spec.TLS != nil {
+		return cluster.Spec.TLS.IssuerRef
+	}
+	return nil
+}
+
+// generateIssuedCertificate returns a cert-manager Certificate that stores a
+// key and certificate signed by the issuer of cluster in a Secret with the
+// same name. Keys and lifetimes follow the certificate policy of cluster.
+// Private keys are encoded the same as those generated by the operator.
+func generateIssuedCertificate(
+	cluster *v1beta1.PostgresCluster, meta metav1.ObjectMeta,
+	commonName string, dnsNames []string,
+) *unstructured.Unstructured {
+	issuer := certificateIssuer(cluster)
+	policy, _ := config.CertificatePolicy(cluster)
+
+	privateKey := map[string]any{
+		"algorithm": "ECDSA", "size": int64(256),
+		"encoding": "PKCS1", "rotationPolicy": "Always",
+	}
+	switch policy.Algorithm {
+	case pki.AlgorithmECDSAP384:
+		privateKey["size"] = int64(384)
+	case pki.AlgorithmRSA2048:
+		privateKey["algorithm"], privateKey["size"] = "RSA", int64(2048)
+	case pki.AlgorithmRSA4096:
+		privateKey["algorithm"], privateKey["size"] = "RSA", int64(4096)
+	}
+
+	// The operator generates certificates without extended key usages, so
+	// they can be used by both servers and clients. Do the same here.
+	spec := map[string]any{
+		"secretName": meta.Name,
+		"issuerRef": map[string]any{
+			"name": issuer.Name, "kind": issuer.Kind, "group": issuer.Group,
+		},
+		"privateKey": privateKey,
+		"usages": []any{
+			"digital signature", "key encipherment", "server auth", "client auth",
+		},
+		"secretTemplate": map[string]any{
+			"labels": map[string]any{naming.LabelCluster: cluster.Name},
+		},
+	}
+	// RFC 5280 limits the common name to 64 characters, and cert-manager
+	// rejects longer ones. Clients verify servers using their DNS names.
+	// - https://tools.ietf.org/html/rfc5280#appendix-A.1
+	if len(commonName) <= 64 {
+		spec["commonName"] = commonName
+	}
+	if len(dnsNames) > 0 {
+		names := make([]any, len(dnsNames))
+		for i := range dnsNames {
+			names[i] = dnsNames[i]
+		}
+		spec["dnsNames"] = names
+	}
+	if policy.LeafLifetime > 0 {
+		spec["duration"] = policy.LeafLifetime.String()
+	}
+	if policy.LeafRenewBefore > 0 {
+		spec["renewBefore"] = policy.LeafRenewBefore.String()
+	}
+
+	certificate := &unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+	certificate.SetGroupVersionKind(certManagerCertificateKind)
+	certificate.SetNamespace(meta.Namespace)
+	certificate.SetName(meta.Name)
+	if annotations := naming.Merge(
+		cluster.Spec.Metadata.GetAnnotationsOrNil(), meta.Annotations,
+	); len(annotations) > 0 {
+		certificate.SetAnnotations(annotations)
+	}
+	certificate.SetLabels(naming.Merge(
+		cluster.Spec.Metadata.GetLabelsOrNil(), meta.Labels,
+		map[string]string{naming.LabelCluster: cluster.Name}))
+
+	return certificate
+}
+
+// issuedCertificateMissingAuthority reports whether secret has a certificate
+// and private key but not the authority that signed them. Some issuers, such
+// as ACME, do not provide their authority.
+// - https://cert-manager.io/docs/faq/#why-isnt-my-root-certificate-in-my-issued-secrets-tlscrt
+func issuedCertificateMissingAuthority(secret *corev1.Secret) bool {
+	return secret != nil &&
+		len(secret.Data[corev1.TLSCertKey]) > 0 &&
+		len(secret.Data[corev1.TLSPrivateKeyKey]) > 0 &&
+		len(secret.Data[corev1.ServiceAccountRootCAKey]) == 0
+}
+
+// issuedCertificateReady reports whether secret has the certificate, private
+// key, and authority that the operator mounts.
+func issuedCertificateReady(secret *corev1.Secret) bool {
+	return secret != nil &&
+		len(secret.Data[corev1.TLSCertKey]) > 0 &&
+		len(secret.Data[corev1.TLSPrivateKeyKey]) > 0 &&
+		len(secret.Data[corev1.ServiceAccountRootCAKey]) > 0
+}
+
+// +kubebuilder:rbac:groups="cert-manager.io",resources="certificates",verbs={create,patch}
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
+
+// reconcileIssuedCertificate creates or updates a cert-manager Certificate
+// and returns its Secret. It returns nil when the Secret has not been issued.
+func (r *Reconciler) reconcileIssuedCertificate(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	certificate *unstructured.Unstructured,
+) (*corev1.Secret, error) {
+	err := errors.WithStack(r.setControllerReference(cluster, certificate))
+
+	if err == nil {
+		err = errors.WithStack(r.apply(ctx, certificate))
+	}
+
+	secret := &corev1.Secret{}
+	secret.Namespace, secret.Name = certificate.GetNamespace(), certificate.GetName()
+
+	if err == nil {
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		err = errors.WithStack(err)
+	}
+	if err == nil && !issuedCertificateReady(secret) {
+		return nil, nil
+	}
+	return secret, err
+}
+
+// reconcileIssuedCertificates creates cert-manager Certificates for the
+// PostgreSQL server, replication client, and PgBouncer of cluster when it has
+// an issuer and no custom TLS secrets. It returns the names of Certificates
+// that have not been issued yet.
+func (r *Reconciler) reconcileIssuedCertificates(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	primaryService *corev1.Service, replicaServices ...*corev1.Service,
+) ([]string, error) {
+	if certificateIssuer(cluster) == nil {
+		// Delete any Certificates from when cluster had an issuer.
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionIssuedCertificatesValid)
+		return nil, r.deleteIssuedCertificates(ctx, cluster,
+			func(string) bool { return true })
+	}
+
+	var certificates []*unstructured.Unstructured
+
+	if cluster.Spec.CustomTLSSecret == nil {
+		dnsNames := naming.ServiceDNSNames(ctx, primaryService)
+		for _, service := range replicaServices {
+			dnsNames = append(dnsNames, naming.ServiceDNSNames(ctx, service)...)
+		}
+
+		meta := naming.PostgresIssuedCertificate(cluster)
+		meta.Labels = map[string]string{naming.LabelClusterCertificate: "postgres-tls"}
+		certificates = append(certificates,
+			generateIssuedCertificate(cluster, meta, dnsNames[0], dnsNames))
+	}
+
+	// The certificate authenticates the PostgreSQL role with the same name.
+	if cluster.Spec.CustomReplicationClientTLSSecret == nil {
+		meta := naming.ReplicationIssuedCertificate(cluster)
+		meta.Labels = map[string]string{naming.LabelClusterCertificate: "replication-client-tls"}
+		certificates = append(certificates,
+			generateIssuedCertificate(cluster, meta, postgres.ReplicationUser, nil))
+	}
+
+	if cluster.Spec.Proxy != nil && cluster.Spec.Proxy.PGBouncer != nil &&
+		cluster.Spec.Proxy.PGBouncer.CustomTLSSecret == nil {
+		dnsNames := naming.ServiceDNSNames(ctx,
+			&corev1.Service{ObjectMeta: naming.ClusterPGBouncer(cluster)})
+
+		// Clients of read-only pools connect through another Service.
+		if cluster.Spec.Proxy.PGBouncer.ReadOnly != nil {
+			dnsNames = append(dnsNames, naming.ServiceDNSNames(ctx,
+				&corev1.Service{ObjectMeta: naming.ClusterPGBouncerReadOnly(cluster)})...)
+		}
+
+		meta := naming.PGBouncerIssuedCertificate(cluster)
+		meta.Labels = map[string]string{naming.LabelRole: naming.RolePGBouncer}
+		certificates = append(certificates,
+			generateIssuedCertificate(cluster, meta, dnsNames[0], dnsNames))
+	}
+
+	// Delete the Certificates of cluster that are no longer necessary, such
+	// as the one for PgBouncer after it is disabled.
+	unwanted := []string{
+		naming.PostgresIssuedCertificate(cluster).Name,
+		naming.ReplicationIssuedCertificate(cluster).Name,
+		naming.PGBouncerIssuedCertificate(cluster).Name,
+	}
+	unwanted = slices.DeleteFunc(unwanted, func(name string) bool {
+		return slices.ContainsFunc(certificates, func(c *unstructured.Unstructured) bool {
+			return c.GetName() == name
+		})
+	})
+	if err := r.deleteIssuedCertificates(ctx, cluster, func(name string) bool {
+		return slices.Contains(unwanted, name)
+	}); err != nil {
+		return nil, err
+	}
+
+	var missing, pending []string
+	for _, certificate := range certificates {
+		secret, err := r.reconcileIssuedCertificate(ctx, cluster, certificate)
+		if err != nil {
+			return pending, err
+		}
+		if secret == nil {
+			pending = append(pending, certificate.GetName())
+
+			// Look for a Secret that will never be ready.
+			secret = &corev1.Secret{}
+			secret.Namespace, secret.Name = certificate.GetNamespace(), certificate.GetName()
+			if r.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret) == nil &&
+				issuedCertificateMissingAuthority(secret) {
+				missing = append(missing, certificate.GetName())
+			}
+		}
+	}
+
+	r.setIssuedCertificatesValid(cluster, missing)
+	return pending, nil
+}
+
+// setIssuedCertificatesValid sets the IssuedCertificatesValid condition of
+// cluster when cert-manager has issued certificates without their authority,
+// and records an event when that changes. The operator cannot use these
+// certificates: instances and clients verify one another with the authority.
+func (r *Reconciler) setIssuedCertificatesValid(
+	cluster *v1beta1.PostgresCluster, missing []string,
+) {
+	if len(missing) == 0 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionIssuedCertificatesValid)
+		return
+	}
+
+	previous := initialize.FromPointer(
+		meta.FindStatusCondition(cluster.Status.Conditions, ConditionIssuedCertificatesValid))
+	condition := metav1.Condition{
+		ObservedGeneration: cluster.GetGeneration(),
+		Type:               ConditionIssuedCertificatesValid,
+		Status:             metav1.ConditionFalse,
+		Reason:             "MissingAuthority",
+		Message: fmt.Sprintf("cert-manager issued certificates without %q: %s. "+
+			"Use an issuer that provides its authority, such as a CA or Vault issuer; ACME issuers do not.",
+			corev1.ServiceAccountRootCAKey, strings.Join(missing, ", ")),
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+
+	if previous.Status != condition.Status || previous.Message != condition.Message {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning,
+			EventIssuedCertificateMissingAuthority, condition.Message)
+	}
+}
+
+// +kubebuilder:rbac:groups="cert-manager.io",resources="certificates",verbs={list,delete}
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={list,delete}
+
+// deleteIssuedCertificates deletes the cert-manager Certificates of cluster
+// whose names are unwanted along with their Secrets; cert-manager leaves
+// Secrets behind by default. It looks for Certificates only when an unwanted
+// Secret exists, so clusters without cert-manager do not call its API. An
+// unwanted Certificate that is never issued remains until cluster is deleted.
+// - https://cert-manager.io/docs/usage/certificate/#cleaning-up-secrets-when-certificates-are-deleted
+func (r *Reconciler) deleteIssuedCertificates(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	unwanted func(name string) bool,
+) error {
+	selector := client.MatchingLabels{naming.LabelCluster: cluster.Name}
+
+	secrets := &corev1.SecretList{}
+	err := errors.WithStack(
+		r.Client.List(ctx, secrets, client.InNamespace(cluster.Namespace), selector))
+
+	var issued []*corev1.Secret
+	for i := range secrets.Items {
+		if name, ok := secrets.Items[i].Annotations[certManagerCertificateAnnotation]; ok && unwanted(name) {
+			issued = append(issued, &secrets.Items[i])
+		}
+	}
+	if err != nil || len(issued) == 0 {
+		return err
+	}
+
+	certificates := &unstructured.UnstructuredList{}
+	certificates.SetGroupVersionKind(
+		certManagerCertificateKind.GroupVersion().WithKind("CertificateList"))
+	err = r.Client.List(ctx, certificates, client.InNamespace(cluster.Namespace), selector)
+
+	// There are no Certificates when cert-manager is not installed.
+	if meta.IsNoMatchError(err) {
+		err = nil
+	}
+	err = errors.WithStack(err)
+
+	for i := range certificates.Items {
+		if err == nil && unwanted(certificates.Items[i].GetName()) {
+			err = errors.WithStack(client.IgnoreNotFound(
+				r.deleteControlled(ctx, cluster, &certificates.Items[i])))
+		}
+	}
+
+	// The Secrets are not owned by cluster, so delete exactly those found.
+	for _, secret := range issued {
+		if err == nil {
+			uid := secret.GetUID()
+			err = errors.WithStack(client.IgnoreNotFound(
+				r.Client.Delete(ctx, secret, client.Preconditions{UID: &uid})))
+		}
+	}
+	return err
+}
+
+// issuedSecretProjection returns a projection of the certificate, private
+// key, and authority in the Secret of a cert-manager Certificate. It has the
+// same keys and paths as custom TLS secrets.
+func issuedSecretProjection(meta metav1.ObjectMeta) *corev1.SecretProjection {
+	return clusterCertSecretProjection(&corev1.Secret{ObjectMeta: meta})
+}
+
+// reconcileInstanceIssuedCertificate creates or updates a cert-manager
+// Certificate for the Patroni API of instance. It returns the issued
+// certificate and the authorities that issued it, or nil when the certificate
+// has not been issued or cannot be read.
+func (r *Reconciler) reconcileInstanceIssuedCertificate(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, instance metav1.Object,
+	dnsNames []string,
+) (pki.CertificateBundle, *pki.LeafCertificate, error) {
+	meta := naming.InstanceIssuedCertificate(instance)
+	meta.Labels = map[string]string{naming.LabelInstance: instance.GetName()}
+
+	secret, err := r.reconcileIssuedCertificate(ctx, cluster,
+		generateIssuedCertificate(cluster, meta, dnsNames[0], dnsNames))
+	if err != nil || secret == nil {
+		return nil, nil, err
+	}
+
+	var authorities pki.CertificateBundle
+	leaf := &pki.LeafCertificate{}
+
+	if authorities.UnmarshalText(secret.Data[corev1.ServiceAccountRootCAKey]) != nil ||
+		leaf.Certificate.UnmarshalText(secret.Data[corev1.TLSCertKey]) != nil ||
+		leaf.PrivateKey.UnmarshalText(secret.Data[corev1.TLSPrivateKeyKey]) != nil {
+		logging.FromContext(ctx).Info("unable to read issued certificate", "secret", secret.Name)
+		return nil, nil, nil
+	}
+	return authorities, leaf, nil
+}
+
+// reconcilePostgresUserIssuedCertificate creates or updates a cert-manager
+// Certificate for the client certificate of a PostgreSQL user. It copies the
+// issued certificate into intent, if any.
+func (r *Reconciler) reconcilePostgresUserIssuedCertificate(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	spec *v1beta1.PostgresUserSpec, intent *corev1.Secret,
+) error {
+	meta := naming.PostgresUserIssuedCertificate(cluster, string(spec.Name))
+	meta.Labels = map[string]string{
+		naming.LabelRole:         naming.RolePostgresUser,
+		naming.LabelPostgresUser: string(spec.Name),
+	}
+
+	// The certificate authenticates the PostgreSQL role with the same name.
+	// - https://www.postgresql.org/docs/current/auth-cert.html
+	secret, err := r.reconcileIssuedCertificate(ctx, cluster,
+		generateIssuedCertificate(cluster, meta, string(spec.Name), nil))
+
+	if err == nil && secret != nil {
+		for _, key := range []string{
+			corev1.TLSCertKey, corev1.TLSPrivateKeyKey, corev1.ServiceAccountRootCAKey,
+		} {
+			intent.Data[key] = secret.Data[key]
+		}
+	}
+	return err
+}
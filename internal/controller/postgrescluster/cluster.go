@@ -8,12 +8,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crunchydata/postgres-operator/internal/collector"
 	"github.com/crunchydata/postgres-operator/internal/initialize"
@@ -30,7 +34,8 @@ import (
 // files (etc) that apply to the entire cluster.
 func (r *Reconciler) reconcileClusterConfigMap(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
-	pgHBAs *postgres.OrderedHBAs, pgParameters *postgres.ParameterSet,
+	pgHBAs *postgres.OrderedHBAs, pgIdentMaps *postgres.OrderedIdentMaps,
+	pgParameters *postgres.ParameterSet,
 ) (*corev1.ConfigMap, error) {
 	clusterConfigMap := &corev1.ConfigMap{ObjectMeta: naming.ClusterConfigMap(cluster)}
 	clusterConfigMap.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
@@ -44,7 +49,7 @@ func (r *Reconciler) reconcileClusterConfigMap(
 		})
 
 	if err == nil {
-		err = patroni.ClusterConfigMap(ctx, cluster, pgHBAs, pgParameters,
+		err = patroni.ClusterConfigMap(ctx, cluster, pgHBAs, pgIdentMaps, pgParameters,
 			clusterConfigMap, r.patroniLogSize(ctx, cluster))
 	}
 	if err == nil {
@@ -215,13 +220,81 @@ func (r *Reconciler) reconcileClusterPrimaryService(
 func (r *Reconciler) generateClusterReplicaService(
 	cluster *v1beta1.PostgresCluster) (*corev1.Service, error,
 ) {
-	service := &corev1.Service{ObjectMeta: naming.ClusterReplicaService(cluster)}
+	service, err := r.generateReplicaService(cluster,
+		naming.ClusterReplicaService(cluster), cluster.Spec.ReplicaService,
+		map[string]string{
+			naming.LabelCluster: cluster.Name,
+			naming.LabelRole:    naming.RoleReplica,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// Allocate an IP address and let Kubernetes manage the Endpoints by
+	// selecting Pods with the Patroni replica role.
+	// - https://docs.k8s.io/concepts/services-networking/service/#defining-a-service
+	service.Spec.Selector = map[string]string{
+		naming.LabelCluster: cluster.Name,
+		naming.LabelRole:    naming.RolePatroniReplica,
+	}
+
+	// Delayed instances have the Patroni replica role, too. When there are
+	// any, or when replicas should be removed while they lag, allocate an IP
+	// address and manage the Endpoints ourselves.
+	// - https://docs.k8s.io/concepts/services-networking/service/#services-without-selectors
+	for i := range cluster.Spec.InstanceSets {
+		if patroni.RecoveryMinApplyDelay(&cluster.Spec.InstanceSets[i]) != "" ||
+			cluster.Spec.InstanceSets[i].MaximumLag != nil {
+			service.Spec.Selector = nil
+		}
+	}
+
+	return service, nil
+}
+
+// generateInstanceSetReplicaService returns a v1.Service that exposes
+// PostgreSQL replica instances of set.
+func (r *Reconciler) generateInstanceSetReplicaService(
+	cluster *v1beta1.PostgresCluster, set *v1beta1.PostgresInstanceSetSpec,
+) (*corev1.Service, error) {
+	service, err := r.generateReplicaService(cluster,
+		naming.InstanceSetReplicaService(cluster, set), set.Service,
+		map[string]string{
+			naming.LabelCluster:     cluster.Name,
+			naming.LabelInstanceSet: set.Name,
+			naming.LabelRole:        naming.RoleReplica,
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// Allocate an IP address and let Kubernetes manage the Endpoints by
+	// selecting Pods of set with the Patroni replica role. When replicas
+	// should be removed while they lag, manage the Endpoints ourselves.
+	if set.MaximumLag == nil {
+		service.Spec.Selector = map[string]string{
+			naming.LabelCluster:     cluster.Name,
+			naming.LabelInstanceSet: set.Name,
+			naming.LabelRole:        naming.RolePatroniReplica,
+		}
+	}
+
+	return service, nil
+}
+
+// generateReplicaService returns a v1.Service without a selector that exposes
+// PostgreSQL replica instances according to spec. The labels are applied last.
+func (r *Reconciler) generateReplicaService(
+	cluster *v1beta1.PostgresCluster, meta metav1.ObjectMeta,
+	spec *v1beta1.ServiceSpec, labels map[string]string,
+) (*corev1.Service, error) {
+	service := &corev1.Service{ObjectMeta: meta}
 	service.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
 
 	service.Annotations = cluster.Spec.Metadata.GetAnnotationsOrNil()
 	service.Labels = cluster.Spec.Metadata.GetLabelsOrNil()
 
-	if spec := cluster.Spec.ReplicaService; spec != nil {
+	if spec != nil {
 		service.Annotations = naming.Merge(service.Annotations,
 			spec.Metadata.GetAnnotationsOrNil())
 		service.Labels = naming.Merge(service.Labels,
@@ -229,12 +302,7 @@ func (r *Reconciler) generateClusterReplicaService(
 	}
 
 	// add our labels last so they aren't overwritten
-	service.Labels = naming.Merge(
-		service.Labels,
-		map[string]string{
-			naming.LabelCluster: cluster.Name,
-			naming.LabelRole:    naming.RoleReplica,
-		})
+	service.Labels = naming.Merge(service.Labels, labels)
 
 	// The TargetPort must be the name (not the number) of the PostgreSQL
 	// ContainerPort. This name allows the port number to differ between Pods,
@@ -250,7 +318,7 @@ func (r *Reconciler) generateClusterReplicaService(
 	service.Spec.Type = corev1.ServiceTypeClusterIP
 
 	// Check user provided spec for a specified type
-	if spec := cluster.Spec.ReplicaService; spec != nil {
+	if spec != nil {
 		service.Spec.Type = corev1.ServiceType(spec.Type)
 		if spec.NodePort != nil {
 			if service.Spec.Type == corev1.ServiceTypeClusterIP {
@@ -280,34 +348,212 @@ func (r *Reconciler) generateClusterReplicaService(
 	}
 	service.Spec.Ports = []corev1.ServicePort{servicePort}
 
-	// Allocate an IP address and let Kubernetes manage the Endpoints by
-	// selecting Pods with the Patroni replica role.
-	// - https://docs.k8s.io/concepts/services-networking/service/#defining-a-service
-	service.Spec.Selector = map[string]string{
-		naming.LabelCluster: cluster.Name,
-		naming.LabelRole:    naming.RolePatroniReplica,
-	}
-
 	err := errors.WithStack(r.setControllerReference(cluster, service))
 
 	return service, err
 }
 
+// generateReplicaEndpoints returns the v1.Endpoints of service that resolve to
+// the PostgreSQL replica instances in instances. Instances that lag more than
+// the maximum of their set, according to lag, are left out.
+func generateReplicaEndpoints(
+	service *corev1.Service, instances []*Instance, lag map[string]int64,
+) *corev1.Endpoints {
+	// Endpoints for a Service have the same name as the Service. Copy labels,
+	// annotations, and ownership, too.
+	endpoints := &corev1.Endpoints{}
+	service.ObjectMeta.DeepCopyInto(&endpoints.ObjectMeta)
+	endpoints.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Endpoints"))
+
+	// The PostgreSQL port can differ between Pods during a rolling update.
+	// Group the addresses of each Pod by the number of its PostgreSQL port.
+	subsets := make(map[int32]*corev1.EndpointSubset)
+
+	for _, instance := range instances {
+		if len(instance.Pods) != 1 {
+			continue
+		}
+
+		pod := instance.Pods[0]
+		if pod.DeletionTimestamp != nil || pod.Status.PodIP == "" ||
+			pod.Labels[naming.LabelRole] != naming.RolePatroniReplica {
+			continue
+		}
+
+		// Leave out replicas that lag too much or by an unknown amount.
+		if instance.Spec != nil && instance.Spec.MaximumLag != nil {
+			if behind, known := lag[pod.Name]; !known || behind > instance.Spec.MaximumLag.Value() {
+				continue
+			}
+		}
+
+		var port int32
+		for _, container := range pod.Spec.Containers {
+			for _, cp := range container.Ports {
+				if cp.Name == naming.PortPostgreSQL {
+					port = cp.ContainerPort
+				}
+			}
+		}
+		if port == 0 {
+			continue
+		}
+
+		subset := subsets[port]
+		if subset == nil {
+			subset = &corev1.EndpointSubset{}
+			for _, sp := range service.Spec.Ports {
+				subset.Ports = append(subset.Ports, corev1.EndpointPort{
+					Name:     sp.Name,
+					Port:     port,
+					Protocol: sp.Protocol,
+				})
+			}
+			subsets[port] = subset
+		}
+
+		address := corev1.EndpointAddress{
+			IP: pod.Status.PodIP,
+			TargetRef: &corev1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				UID:       pod.UID,
+			},
+		}
+		if pod.Spec.NodeName != "" {
+			address.NodeName = initialize.String(pod.Spec.NodeName)
+		}
+
+		if ready, _ := instance.IsReady(); ready {
+			subset.Addresses = append(subset.Addresses, address)
+		} else {
+			subset.NotReadyAddresses = append(subset.NotReadyAddresses, address)
+		}
+	}
+
+	for _, port := range slices.Sorted(maps.Keys(subsets)) {
+		endpoints.Subsets = append(endpoints.Subsets, *subsets[port])
+	}
+
+	return endpoints
+}
+
+// +kubebuilder:rbac:groups="",resources="endpoints",verbs={create,patch}
 // +kubebuilder:rbac:groups="",resources="services",verbs={create,patch}
 
 // reconcileClusterReplicaService writes the Service that exposes PostgreSQL
-// replica instances.
+// replica instances. When the Service has no selector, it writes its Endpoints, too.
 func (r *Reconciler) reconcileClusterReplicaService(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	instances *observedInstances, lag map[string]int64,
 ) (*corev1.Service, error) {
 	service, err := r.generateClusterReplicaService(cluster)
 
 	if err == nil {
 		err = errors.WithStack(r.apply(ctx, service))
 	}
+	if err == nil && service.Spec.Selector == nil {
+		// Delayed instances are never exposed by this Service.
+		replicas := slices.DeleteFunc(slices.Clone(instances.forCluster),
+			func(instance *Instance) bool {
+				return patroni.RecoveryMinApplyDelay(instance.Spec) != ""
+			})
+
+		err = errors.WithStack(r.apply(ctx,
+			generateReplicaEndpoints(service, replicas, lag)))
+	}
 	return service, err
 }
 
+// +kubebuilder:rbac:groups="",resources="endpoints",verbs={create,patch}
+// +kubebuilder:rbac:groups="",resources="services",verbs={create,patch}
+
+// reconcileInstanceSetReplicaServices writes the Services that expose PostgreSQL
+// replica instances of each instance set that has one. It deletes the Services
+// of any other instance sets.
+func (r *Reconciler) reconcileInstanceSetReplicaServices(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	instances *observedInstances, lag map[string]int64,
+) ([]*corev1.Service, error) {
+	var services []*corev1.Service
+	var err error
+
+	wanted := sets.New[string]()
+	for i := range cluster.Spec.InstanceSets {
+		set := &cluster.Spec.InstanceSets[i]
+		if err != nil || set.Service == nil {
+			continue
+		}
+
+		var service *corev1.Service
+		service, err = r.generateInstanceSetReplicaService(cluster, set)
+
+		if err == nil {
+			err = errors.WithStack(r.apply(ctx, service))
+		}
+		if err == nil && service.Spec.Selector == nil {
+			err = errors.WithStack(r.apply(ctx,
+				generateReplicaEndpoints(service, instances.bySet[set.Name], lag)))
+		}
+		if err == nil {
+			services = append(services, service)
+			wanted.Insert(service.Name)
+		}
+	}
+
+	if err == nil {
+		err = r.cleanupInstanceSetReplicaServices(ctx, cluster, wanted)
+	}
+
+	return services, err
+}
+
+// +kubebuilder:rbac:groups="",resources="endpoints",verbs={list,delete}
+// +kubebuilder:rbac:groups="",resources="services",verbs={list,delete}
+
+// cleanupInstanceSetReplicaServices removes the Services and Endpoints of
+// instance sets that are not in wanted.
+func (r *Reconciler) cleanupInstanceSetReplicaServices(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, wanted sets.Set[string],
+) error {
+	selector, err := naming.AsSelector(naming.ClusterInstanceSets(cluster.Name))
+
+	services := &corev1.ServiceList{}
+	endpoints := &corev1.EndpointsList{}
+	if err == nil {
+		err = r.Client.List(ctx, services,
+			client.InNamespace(cluster.Namespace), client.MatchingLabelsSelector{
+				Selector: selector,
+			})
+	}
+	if err == nil {
+		err = r.Client.List(ctx, endpoints,
+			client.InNamespace(cluster.Namespace), client.MatchingLabelsSelector{
+				Selector: selector,
+			})
+	}
+
+	if err == nil {
+		var objects []client.Object
+		for i := range services.Items {
+			objects = append(objects, &services.Items[i])
+		}
+		for i := range endpoints.Items {
+			objects = append(objects, &endpoints.Items[i])
+		}
+		for _, object := range objects {
+			if err == nil &&
+				object.GetLabels()[naming.LabelRole] == naming.RoleReplica &&
+				!wanted.Has(object.GetName()) {
+				err = client.IgnoreNotFound(r.deleteControlled(ctx, cluster, object))
+			}
+		}
+	}
+
+	return errors.WithStack(err)
+}
+
 // reconcileDataSource is responsible for reconciling the data source for a PostgreSQL cluster.
 // This involves ensuring the PostgreSQL data directory for the cluster is properly populated
 // prior to bootstrapping the cluster, specifically according to any data source configured in the
@@ -343,7 +589,8 @@ func (r *Reconciler) reconcileDataSource(ctx context.Context,
 	// determine if the user wants to initialize the PG data directory
 	postgresDataInitRequested := cluster.Spec.DataSource != nil &&
 		(cluster.Spec.DataSource.PostgresCluster != nil ||
-			cluster.Spec.DataSource.PGBackRest != nil)
+			cluster.Spec.DataSource.PGBackRest != nil ||
+			cluster.Spec.DataSource.VolumeSnapshot != nil)
 
 	// determine if the user has requested an in-place restore
 	restoreID := cluster.GetAnnotations()[naming.PGBackRestRestore]
@@ -368,6 +615,16 @@ func (r *Reconciler) reconcileDataSource(ctx context.Context,
 		if dataSource == nil {
 			cloudDataSource = cluster.Spec.DataSource.PGBackRest
 		}
+		// A VolumeSnapshot populates the data volume, and then pgBackRest
+		// replays WAL from the repository of the source cluster.
+		if snapshot := cluster.Spec.DataSource.VolumeSnapshot; snapshot != nil {
+			dataSource = &v1beta1.PostgresClusterDataSource{
+				ClusterName: snapshot.ClusterName,
+				RepoName:    snapshot.RepoName,
+				Options:     snapshot.Options,
+			}
+			cloudDataSource = nil
+		}
 	default:
 		return false, nil
 	}
@@ -405,6 +662,9 @@ func (r *Reconciler) reconcileDataSource(ctx context.Context,
 	case dataSource != nil:
 		configs = []string{dataSource.ClusterName, dataSource.RepoName}
 		configs = append(configs, dataSource.Options...)
+		if cluster.Spec.DataSource != nil && cluster.Spec.DataSource.VolumeSnapshot != nil {
+			configs = append(configs, cluster.Spec.DataSource.VolumeSnapshot.Name)
+		}
 	case cloudDataSource != nil:
 		configs = []string{cloudDataSource.Stanza, cloudDataSource.Repo.Name}
 		configs = append(configs, cloudDataSource.Options...)
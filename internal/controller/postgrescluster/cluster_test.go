@@ -18,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
@@ -785,6 +786,218 @@ postgres-operator.crunchydata.com/cluster: pg2
 postgres-operator.crunchydata.com/role: replica
 		`))
 	})
+
+	t.Run("Delayed", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec.InstanceSets, `[
+			{ name: one },
+			{ name: two, role: delayed, applyDelay: 1h },
+		]`)
+
+		service, err := reconciler.generateClusterReplicaService(cluster)
+		assert.NilError(t, err)
+		alwaysExpect(t, service)
+
+		// No selector; the controller manages the Endpoints.
+		assert.Assert(t, service.Spec.Selector == nil,
+			"got %v", service.Spec.Selector)
+	})
+
+	t.Run("InstanceSet", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec.InstanceSets, `[
+			{ name: analytics, service: { type: NodePort } },
+		]`)
+
+		set := &cluster.Spec.InstanceSets[0]
+		service, err := reconciler.generateInstanceSetReplicaService(cluster, set)
+		assert.NilError(t, err)
+
+		assert.Assert(t, cmp.MarshalMatches(service.ObjectMeta, `
+creationTimestamp: null
+labels:
+  postgres-operator.crunchydata.com/cluster: pg2
+  postgres-operator.crunchydata.com/instance-set: analytics
+  postgres-operator.crunchydata.com/role: replica
+name: pg2-analytics-replicas
+namespace: ns1
+ownerReferences:
+- apiVersion: postgres-operator.crunchydata.com/v1beta1
+  blockOwnerDeletion: true
+  controller: true
+  kind: PostgresCluster
+  name: pg2
+  uid: ""
+		`))
+		assert.Assert(t, cmp.MarshalMatches(service.Spec, `
+ports:
+- name: postgres
+  port: 9876
+  protocol: TCP
+  targetPort: postgres
+selector:
+  postgres-operator.crunchydata.com/cluster: pg2
+  postgres-operator.crunchydata.com/instance-set: analytics
+  postgres-operator.crunchydata.com/role: replica
+type: NodePort
+		`))
+
+		// No selector when replicas are removed while they lag.
+		set.MaximumLag = resource.NewQuantity(1<<20, resource.BinarySI)
+		service, err = reconciler.generateInstanceSetReplicaService(cluster, set)
+		assert.NilError(t, err)
+		assert.Assert(t, service.Spec.Selector == nil,
+			"got %v", service.Spec.Selector)
+	})
+}
+
+func TestReconcileClusterReplicaService(t *testing.T) {
+	ctx := context.Background()
+
+	reconciler := &Reconciler{Owner: client.FieldOwner(t.Name())}
+	reconciler.Client = fake.NewClientBuilder().WithScheme(runtime.Scheme).Build()
+
+	cluster := testCluster()
+	cluster.Namespace, cluster.Name = "ns1", "pg2"
+	cluster.Spec.Port = initialize.Int32(5432)
+	require.UnmarshalInto(t, &cluster.Spec.InstanceSets, `[
+		{ name: a },
+		{ name: b, role: delayed, applyDelay: 1h },
+	]`)
+
+	replica := func(name, ip string) *corev1.Pod {
+		pod := &corev1.Pod{}
+		pod.Namespace = "ns1"
+		pod.Name = name
+		pod.Labels = map[string]string{
+			"postgres-operator.crunchydata.com/role": "replica",
+		}
+		pod.Spec.Containers = []corev1.Container{{
+			Name:  "database",
+			Ports: []corev1.ContainerPort{{Name: "postgres", ContainerPort: 5432}},
+		}}
+		pod.Status.PodIP = ip
+		pod.Status.Conditions = []corev1.PodCondition{{
+			Type: corev1.PodReady, Status: corev1.ConditionTrue,
+		}}
+		return pod
+	}
+
+	observed := &observedInstances{forCluster: []*Instance{
+		{Spec: &cluster.Spec.InstanceSets[0], Pods: []*corev1.Pod{replica("a-0", "10.0.0.1")}},
+		{Spec: &cluster.Spec.InstanceSets[1], Pods: []*corev1.Pod{replica("b-0", "10.0.0.2")}},
+	}}
+
+	service, err := reconciler.reconcileClusterReplicaService(ctx, cluster, observed, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, service.Spec.Selector == nil,
+		"got %v", service.Spec.Selector)
+
+	// The delayed instance is not selected.
+	endpoints := &corev1.Endpoints{}
+	assert.NilError(t, reconciler.Client.Get(ctx, client.ObjectKeyFromObject(service), endpoints))
+	assert.Assert(t, cmp.Len(endpoints.Subsets, 1))
+	assert.Assert(t, cmp.Len(endpoints.Subsets[0].Addresses, 1))
+	assert.Equal(t, endpoints.Subsets[0].Addresses[0].IP, "10.0.0.1")
+	assert.Equal(t, endpoints.Subsets[0].Addresses[0].TargetRef.Name, "a-0")
+	assert.Assert(t, cmp.Len(endpoints.Subsets[0].NotReadyAddresses, 0))
+}
+
+func TestGenerateReplicaEndpoints(t *testing.T) {
+	service := &corev1.Service{}
+	service.Namespace = "ns1"
+	service.Name = "pg2-replicas"
+	service.Spec.Ports = []corev1.ServicePort{{
+		Name: "postgres", Port: 9876, Protocol: corev1.ProtocolTCP,
+	}}
+
+	replica := func(name, ip string, port int32, ready corev1.ConditionStatus) *corev1.Pod {
+		pod := &corev1.Pod{}
+		pod.Namespace = "ns1"
+		pod.Name = name
+		pod.Labels = map[string]string{
+			"postgres-operator.crunchydata.com/role": "replica",
+		}
+		pod.Spec.NodeName = "node-" + name
+		pod.Spec.Containers = []corev1.Container{{
+			Name:  "database",
+			Ports: []corev1.ContainerPort{{Name: "postgres", ContainerPort: port}},
+		}}
+		pod.Status.PodIP = ip
+		pod.Status.Conditions = []corev1.PodCondition{{
+			Type: corev1.PodReady, Status: ready,
+		}}
+		return pod
+	}
+
+	leader := replica("a-0", "10.0.0.1", 5432, corev1.ConditionTrue)
+	leader.Labels["postgres-operator.crunchydata.com/role"] = "master"
+
+	normal := &v1beta1.PostgresInstanceSetSpec{Name: "a"}
+	lagging := new(v1beta1.PostgresInstanceSetSpec)
+	require.UnmarshalInto(t, lagging, `{ name: b, maximumLag: 16Mi }`)
+
+	instances := []*Instance{
+		{Spec: normal, Pods: []*corev1.Pod{leader}},
+		{Spec: normal, Pods: []*corev1.Pod{replica("a-1", "10.0.0.2", 5432, corev1.ConditionTrue)}},
+		{Spec: normal, Pods: []*corev1.Pod{replica("a-2", "10.0.0.3", 5432, corev1.ConditionFalse)}},
+		{Spec: normal, Pods: []*corev1.Pod{replica("a-3", "10.0.0.4", 2345, corev1.ConditionTrue)}},
+		{Spec: lagging, Pods: []*corev1.Pod{replica("b-0", "10.0.0.5", 5432, corev1.ConditionTrue)}},
+		{Spec: lagging, Pods: []*corev1.Pod{replica("b-1", "10.0.0.6", 5432, corev1.ConditionTrue)}},
+		{Spec: lagging, Pods: []*corev1.Pod{replica("b-2", "10.0.0.7", 5432, corev1.ConditionTrue)}},
+	}
+
+	// Replicas "a-1" and "a-2" have no maximum. Replica "b-1" lags too much,
+	// and "b-2" lags an unknown amount.
+	lag := map[string]int64{
+		"a-0": 0, "a-1": 999 << 20,
+		"b-0": 1 << 20, "b-1": 32 << 20,
+	}
+
+	endpoints := generateReplicaEndpoints(service, instances, lag)
+	assert.Assert(t, cmp.MarshalMatches(endpoints, `
+apiVersion: v1
+kind: Endpoints
+metadata:
+  name: pg2-replicas
+  namespace: ns1
+subsets:
+- addresses:
+  - ip: 10.0.0.4
+    nodeName: node-a-3
+    targetRef:
+      kind: Pod
+      name: a-3
+      namespace: ns1
+  ports:
+  - name: postgres
+    port: 2345
+    protocol: TCP
+- addresses:
+  - ip: 10.0.0.2
+    nodeName: node-a-1
+    targetRef:
+      kind: Pod
+      name: a-1
+      namespace: ns1
+  - ip: 10.0.0.5
+    nodeName: node-b-0
+    targetRef:
+      kind: Pod
+      name: b-0
+      namespace: ns1
+  notReadyAddresses:
+  - ip: 10.0.0.3
+    nodeName: node-a-2
+    targetRef:
+      kind: Pod
+      name: a-2
+      namespace: ns1
+  ports:
+  - name: postgres
+    port: 5432
+    protocol: TCP
+	`))
 }
 
 func TestPatroniLogSize(t *testing.T) {
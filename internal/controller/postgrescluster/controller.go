@@ -9,6 +9,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -24,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -34,6 +37,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/kubernetes"
 	"github.com/crunchydata/postgres-operator/internal/logging"
 	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/patroni"
 	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/internal/postgres"
 	"github.com/crunchydata/postgres-operator/internal/registration"
@@ -140,16 +144,35 @@ func (r *Reconciler) Reconcile(
 	if cluster.Spec.Standby != nil &&
 		cluster.Spec.Standby.Enabled &&
 		cluster.Spec.Standby.Host == "" &&
+		cluster.Spec.Standby.ClusterName == "" &&
 		cluster.Spec.Standby.RepoName == "" {
 		// When a standby cluster is requested but a repoName or host is not provided
 		// the cluster will be created as a non-standby. Reject any clusters with
 		// this configuration and provide an event
 		path := field.NewPath("spec", "standby")
-		err := field.Invalid(path, cluster.Name, "Standby requires a host or repoName to be enabled")
+		err := field.Invalid(path, cluster.Name, "Standby requires a host, clusterName, or repoName to be enabled")
 		r.Recorder.Event(cluster, corev1.EventTypeWarning, "InvalidStandbyConfiguration", err.Error())
 		return runtime.ErrorWithBackoff(tracing.Escape(span, err))
 	}
 
+	// A standby that follows another cluster by name streams from that cluster's
+	// primary Service. Resolve it now so the rest of reconciliation sees a host.
+	standbySourceFound, standbyErr := r.reconcileStandbySource(ctx, cluster)
+	if standbyErr != nil {
+		return runtime.ErrorWithBackoff(tracing.Escape(span, standbyErr))
+	}
+
+	// Delayed instances never become primary. Reject any clusters that have
+	// nothing else; Patroni would never elect a leader.
+	if len(cluster.Spec.InstanceSets) > 0 && !slices.ContainsFunc(cluster.Spec.InstanceSets, func(set v1beta1.PostgresInstanceSetSpec) bool {
+		return patroni.RecoveryMinApplyDelay(&set) == ""
+	}) {
+		path := field.NewPath("spec", "instances")
+		err := field.Required(path, "at least one instance set must not be delayed")
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "InvalidInstanceSets", err.Error())
+		return runtime.ErrorWithBackoff(tracing.Escape(span, err))
+	}
+
 	var (
 		clusterConfigMap             *corev1.ConfigMap
 		clusterReplicationSecret     *corev1.Secret
@@ -169,6 +192,8 @@ func (r *Reconciler) Reconcile(
 		backupsSpecFound             bool
 		backupsReconciliationAllowed bool
 		dedicatedSnapshotPVC         *corev1.PersistentVolumeClaim
+		replicaLag                   map[string]int64
+		setReplicaServices           []*corev1.Service
 	)
 
 	patchClusterStatus := func() error {
@@ -228,6 +253,7 @@ func (r *Reconciler) Reconcile(
 	}
 
 	pgHBAs := r.generatePostgresHBAs(ctx, cluster)
+	pgIdentMaps := r.generatePostgresIdentMaps(cluster)
 	pgParameters := r.generatePostgresParameters(ctx, cluster, backupsSpecFound)
 
 	otelConfig := collector.NewConfigForPostgresPod(ctx, cluster, pgParameters)
@@ -258,6 +284,10 @@ func (r *Reconciler) Reconcile(
 	if err == nil {
 		instances, err = r.observeInstances(ctx, cluster)
 	}
+	if err == nil {
+		r.reconcileParametersValid(cluster, instances)
+		r.reconcileIdentMapsValid(cluster, pgHBAs, pgIdentMaps)
+	}
 
 	result := reconcile.Result{}
 
@@ -267,6 +297,19 @@ func (r *Reconciler) Reconcile(
 			result.RequeueAfter = requeue
 		}
 	}
+	if err == nil {
+		var requeue time.Duration
+		if replicaLag, requeue = r.observeReplicationLag(ctx, cluster, instances); requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
+	}
+	if err == nil {
+		if requeue := rootCertificateRequeue(rootCA); requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
+	}
 	if err == nil {
 		err = r.reconcilePatroniSwitchover(ctx, cluster, instances)
 	}
@@ -300,7 +343,7 @@ func (r *Reconciler) Reconcile(
 		}
 	}
 	if err == nil {
-		clusterConfigMap, err = r.reconcileClusterConfigMap(ctx, cluster, pgHBAs, pgParameters)
+		clusterConfigMap, err = r.reconcileClusterConfigMap(ctx, cluster, pgHBAs, pgIdentMaps, pgParameters)
 	}
 	if err == nil {
 		clusterReplicationSecret, err = r.reconcileReplicationSecret(ctx, cluster, rootCA)
@@ -312,16 +355,44 @@ func (r *Reconciler) Reconcile(
 		primaryService, err = r.reconcileClusterPrimaryService(ctx, cluster, patroniLeaderService)
 	}
 	if err == nil {
-		replicaService, err = r.reconcileClusterReplicaService(ctx, cluster)
+		replicaService, err = r.reconcileClusterReplicaService(ctx, cluster, instances, replicaLag)
 	}
 	if err == nil {
-		primaryCertificate, err = r.reconcileClusterCertificate(ctx, rootCA, cluster, primaryService, replicaService)
+		setReplicaServices, err = r.reconcileInstanceSetReplicaServices(ctx, cluster, instances, replicaLag)
 	}
 	if err == nil {
-		err = r.reconcilePatroniDistributedConfiguration(ctx, cluster)
+		// Instances and PgBouncer mount the Secrets of cert-manager Certificates.
+		// Continue with any that exist while others are issued; pods wait for
+		// Secrets that do not exist yet.
+		var pending []string
+		pending, err = r.reconcileIssuedCertificates(ctx, cluster,
+			primaryService, append([]*corev1.Service{replicaService}, setReplicaServices...)...)
+
+		if err == nil && len(pending) > 0 {
+			meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+				Type:    v1beta1.PostgresClusterProgressing,
+				Status:  metav1.ConditionFalse,
+				Reason:  "CertificatesPending",
+				Message: fmt.Sprintf("Waiting for cert-manager to issue certificates: %s", strings.Join(pending, ", ")),
+
+				ObservedGeneration: cluster.GetGeneration(),
+			})
+			if requeue := 10 * time.Second; result.RequeueAfter == 0 || requeue < result.RequeueAfter {
+				result.RequeueAfter = requeue
+			}
+		}
 	}
 	if err == nil {
-		err = r.reconcilePatroniDynamicConfiguration(ctx, cluster, instances, pgHBAs, pgParameters)
+		primaryCertificate, err = r.reconcileClusterCertificate(ctx, rootCA, cluster,
+			primaryService, append([]*corev1.Service{replicaService}, setReplicaServices...)...)
+	}
+	if err == nil {
+		err = r.reconcilePatroniDistributedConfiguration(ctx, cluster)
+	}
+	// Leave the standby settings in Patroni as they are until the cluster
+	// that a standby follows exists.
+	if err == nil && standbySourceFound {
+		err = r.reconcilePatroniDynamicConfiguration(ctx, cluster, instances, pgHBAs, pgIdentMaps, pgParameters)
 	}
 	if err == nil {
 		monitoringSecret, err = r.reconcileMonitoringSecret(ctx, cluster)
@@ -345,7 +416,20 @@ func (r *Reconciler) Reconcile(
 		err = r.reconcilePostgresDatabases(ctx, cluster, instances)
 	}
 	if err == nil {
-		err = r.reconcilePostgresUsers(ctx, cluster, instances)
+		err = r.reconcilePostgresUsers(ctx, cluster, instances, rootCA)
+	}
+	if err == nil {
+		if requeue := passwordRotationRequeue(cluster, time.Now()); requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
+	}
+	if err == nil {
+		var requeue time.Duration
+		if requeue, err = r.reconcilePostgresAudit(ctx, cluster, instances); err == nil && requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
 	}
 
 	if err == nil {
@@ -358,15 +442,33 @@ func (r *Reconciler) Reconcile(
 			}
 		}
 	}
+	if err == nil {
+		if requeue := r.reconcileAutogrowLimits(ctx, cluster); requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
+	}
 	if err == nil {
 		dedicatedSnapshotPVC, err = r.reconcileDedicatedSnapshotVolume(ctx, cluster, clusterVolumes)
 	}
 	if err == nil {
 		err = r.reconcileVolumeSnapshots(ctx, cluster, dedicatedSnapshotPVC)
 	}
+	if err == nil {
+		if requeue := snapshotIntervalRequeue(cluster, time.Now()); requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
+	}
 	if err == nil {
 		err = r.reconcilePGBouncer(ctx, cluster, instances, primaryCertificate, rootCA)
 	}
+	if err == nil {
+		if requeue := pgbouncerAutoscalingRequeue(cluster); requeue > 0 &&
+			(result.RequeueAfter == 0 || requeue < result.RequeueAfter) {
+			result.RequeueAfter = requeue
+		}
+	}
 	if err == nil {
 		err = r.reconcilePGMonitorExporter(ctx, cluster, instances, monitoringSecret)
 	}
@@ -482,6 +584,10 @@ func (r *Reconciler) SetupWithManager(mgr manager.Manager) error {
 		Owns(&batchv1.CronJob{}).
 		Owns(&policyv1.PodDisruptionBudget{}).
 		Watches(&corev1.Pod{}, r.watchPods()).
+		Watches(v1beta1.NewPostgresCluster(),
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, source client.Object) []reconcile.Request {
+				return runtime.Requests(r.findStandbysForPostgresCluster(ctx, client.ObjectKeyFromObject(source))...)
+			})).
 		Watches(&appsv1.StatefulSet{},
 			r.controllerRefHandlerFuncs()). // watch all StatefulSets
 		Complete(r)
@@ -10,6 +10,7 @@ import (
 	"io"
 	"maps"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -318,11 +319,19 @@ func (r *Reconciler) observeInstances(
 	// This may happen in cases where the Pod is restarted, the cluster
 	// is shutdown, etc. Only save values for instances defined in the spec.
 	previousDesiredRequests := make(map[string]string)
+	previousDesiredWALRequests := make(map[string]string)
+	previousDesiredTablespaceRequests := make(map[string]map[string]string)
 	if autogrow {
 		for _, statusIS := range cluster.Status.InstanceSets {
 			if statusIS.DesiredPGDataVolume != nil {
 				maps.Copy(previousDesiredRequests, statusIS.DesiredPGDataVolume)
 			}
+			if statusIS.DesiredPGWALVolume != nil {
+				maps.Copy(previousDesiredWALRequests, statusIS.DesiredPGWALVolume)
+			}
+			if statusIS.DesiredTablespaceVolumes != nil {
+				previousDesiredTablespaceRequests[statusIS.Name] = maps.Clone(statusIS.DesiredTablespaceVolumes)
+			}
 		}
 	}
 
@@ -331,6 +340,8 @@ func (r *Reconciler) observeInstances(
 	for _, name := range sets.List(observed.setNames) {
 		status := v1beta1.PostgresInstanceSetStatus{Name: name}
 		status.DesiredPGDataVolume = make(map[string]string)
+		desiredWALRequests := make(map[string]string)
+		desiredTablespaceRequests := make(map[string]string)
 
 		for _, instance := range observed.bySet[name] {
 			//nolint:gosec // This slice is always small.
@@ -352,6 +363,20 @@ func (r *Reconciler) observeInstances(
 					if pod.Annotations["suggested-pgdata-pvc-size"] != "" {
 						status.DesiredPGDataVolume[instance.Name] = pod.Annotations["suggested-pgdata-pvc-size"]
 					}
+					if pod.Annotations["suggested-pgwal-pvc-size"] != "" {
+						desiredWALRequests[instance.Name] = pod.Annotations["suggested-pgwal-pvc-size"]
+					}
+
+					// Every instance of the set has the same tablespaces; keep
+					// the largest suggested size of each.
+					for key, value := range pod.Annotations {
+						tablespace, ok := strings.CutPrefix(key, "suggested-tablespace-")
+						tablespace, ok2 := strings.CutSuffix(tablespace, "-pvc-size")
+						if ok && ok2 && value != "" {
+							desiredTablespaceRequests[tablespace] = largerQuantity(
+								desiredTablespaceRequests[tablespace], value)
+						}
+					}
 				}
 			}
 		}
@@ -364,15 +389,69 @@ func (r *Reconciler) observeInstances(
 			for _, instance := range observed.bySet[name] {
 				status.DesiredPGDataVolume[instance.Name] = r.storeDesiredRequest(ctx, cluster, "pgData",
 					name, status.DesiredPGDataVolume[instance.Name], previousDesiredRequests[instance.Name])
+
+				// The desired pgWAL volume size is also raised while WAL archiving
+				// is unhealthy; see [Reconciler.reconcileArchivingHealth]. Keep
+				// whichever is larger.
+				previous := previousDesiredWALRequests[instance.Name]
+				if observed := desiredWALRequests[instance.Name]; observed != "" {
+					initialize.Map(&status.DesiredPGWALVolume)
+					status.DesiredPGWALVolume[instance.Name] = r.storeDesiredRequest(ctx, cluster, "pgWAL",
+						name, largerQuantity(observed, previous), previous)
+				} else if previous != "" {
+					initialize.Map(&status.DesiredPGWALVolume)
+					status.DesiredPGWALVolume[instance.Name] = previous
+				}
+			}
+
+			previous := previousDesiredTablespaceRequests[name]
+			for _, tablespace := range sets.List(sets.KeySet(naming.Merge(desiredTablespaceRequests, previous))) {
+				if dpv := r.storeDesiredRequest(ctx, cluster, "tablespace-"+tablespace,
+					name, desiredTablespaceRequests[tablespace], previous[tablespace],
+				); dpv != "" {
+					initialize.Map(&status.DesiredTablespaceVolumes)
+					status.DesiredTablespaceVolumes[tablespace] = dpv
+				}
 			}
 		}
 
+		status.ConfiguredApplyDelay = observedApplyDelay(observed.bySet[name])
+
 		cluster.Status.InstanceSets = append(cluster.Status.InstanceSets, status)
 	}
 
 	return observed, err
 }
 
+// observedApplyDelay returns the smallest "recovery_min_apply_delay" that
+// running instances apply. Pods record the value with which Patroni started;
+// see [patroni.InstancePod]. It is empty when any running instance has no delay.
+func observedApplyDelay(instances []*Instance) string {
+	var delay string
+	var seconds int64 = -1
+
+	for _, instance := range instances {
+		if running, known := instance.IsRunning(naming.ContainerDatabase); !running || !known {
+			continue
+		}
+		for _, pod := range instance.Pods {
+			value := pod.Annotations[naming.PatroniApplyDelay]
+			parsed, err := strconv.ParseInt(strings.TrimSuffix(value, "s"), 10, 64)
+			if err != nil {
+				parsed = 0
+			}
+			if seconds < 0 || parsed < seconds {
+				delay, seconds = value, parsed
+			}
+		}
+	}
+
+	if seconds <= 0 {
+		return ""
+	}
+	return delay
+}
+
 // +kubebuilder:rbac:groups="",resources="pods",verbs={list}
 // +kubebuilder:rbac:groups="apps",resources="statefulsets",verbs={patch}
 
@@ -747,7 +826,12 @@ func (r *Reconciler) rolloutInstance(
 		ctx, span := tracing.Start(ctx, "patroni-change-primary")
 		defer span.End()
 
-		success, err := patroni.Executor(exec).ChangePrimaryAndWait(ctx, pod.Name, "")
+		var success bool
+		err := r.changePrimaryWithPGBouncerPaused(ctx, cluster, func(ctx context.Context) error {
+			var err error
+			success, err = patroni.Executor(exec).ChangePrimaryAndWait(ctx, pod.Name, "")
+			return err
+		})
 		if err = errors.WithStack(err); err == nil && !success {
 			err = errors.New("unable to switchover")
 		}
@@ -1457,10 +1541,25 @@ func (r *Reconciler) reconcileInstanceCertificates(
 	if err == nil {
 		leafCert, err = r.instanceCertificate(ctx, instance, existing, instanceCerts, root)
 	}
+
+	// The Patroni API uses the certificate issued by cert-manager, if any.
+	// Until it is issued, the API uses the one signed by root. Trust both
+	// authorities so members can verify one another during the change.
+	patroniRoot, patroniLeaf := root.Bundle(), leafCert
+	if err == nil && certificateIssuer(cluster) != nil {
+		var authorities pki.CertificateBundle
+		var issued *pki.LeafCertificate
+
+		authorities, issued, err = r.reconcileInstanceIssuedCertificate(
+			ctx, cluster, instance, naming.InstancePodDNSNames(ctx, instance))
+		if issued != nil {
+			patroniRoot, patroniLeaf = append(authorities, patroniRoot...), issued
+		}
+	}
 	if err == nil {
 		err = patroni.InstanceCertificates(ctx,
-			root.Certificate, leafCert.Certificate,
-			leafCert.PrivateKey, instanceCerts)
+			patroniRoot, patroniLeaf.Certificate,
+			patroniLeaf.PrivateKey, instanceCerts)
 	}
 	if err == nil && backupsSpecFound {
 		err = pgbackrest.InstanceCertificates(ctx, cluster,
@@ -94,6 +94,41 @@ func TestInstanceIsRunning(t *testing.T) {
 	assert.Assert(t, running)
 }
 
+func TestObservedApplyDelay(t *testing.T) {
+	running := func(delay string) *Instance {
+		pod := &corev1.Pod{}
+		if delay != "" {
+			pod.Annotations = map[string]string{naming.PatroniApplyDelay: delay}
+		}
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name: naming.ContainerDatabase,
+			State: corev1.ContainerState{
+				Running: new(corev1.ContainerStateRunning),
+			},
+		}}
+		return &Instance{Pods: []*corev1.Pod{pod}}
+	}
+
+	assert.Equal(t, observedApplyDelay(nil), "")
+	assert.Equal(t, observedApplyDelay([]*Instance{running("")}), "")
+	assert.Equal(t, observedApplyDelay([]*Instance{running("3600s")}), "3600s")
+
+	// The smallest delay is reported during a rolling update.
+	assert.Equal(t, observedApplyDelay([]*Instance{
+		running("14400s"), running("3600s"),
+	}), "3600s")
+	assert.Equal(t, observedApplyDelay([]*Instance{
+		running("3600s"), running(""),
+	}), "")
+
+	// Instances that are not running are ignored.
+	stopped := running("60s")
+	stopped.Pods[0].Status.ContainerStatuses[0].State.Running = nil
+	assert.Equal(t, observedApplyDelay([]*Instance{
+		running("3600s"), stopped,
+	}), "3600s")
+}
+
 func TestInstanceIsWritable(t *testing.T) {
 	var instance Instance
 	var known, writable bool
@@ -557,7 +592,8 @@ func TestAddPGBackRestToInstancePodSpec(t *testing.T) {
     # Manage autogrow annotation.
     # Return size in Mebibytes.
     manageAutogrowAnnotation() {
-      local volume=$1
+      local volume=$1 threshold percent mebibytes
+      read -r threshold percent mebibytes <<< "$2"
 
       size=$(df --block-size=M "/pgbackrest/${volume}")
       read -r _ size _ <<< "${size#*$'\n'}"
@@ -566,9 +602,9 @@ func TestAddPGBackRestToInstancePodSpec(t *testing.T) {
       sizeInt="${size//M/}"
       # Use the sed punctuation class, because the shell will not accept the percent sign in an expansion.
       useInt=${use//[[:punct:]]/}
-      triggerExpansion="$((useInt > 75))"
+      triggerExpansion="$((useInt > threshold))"
       if [[ ${triggerExpansion} -eq 1 ]]; then
-        newSize="$(((sizeInt / 2)+sizeInt))"
+        newSize="$((sizeInt + (sizeInt * percent / 100) + mebibytes))"
         newSizeMi="${newSize}Mi"
         d='[{"op": "add", "path": "/metadata/annotations/suggested-'"${volume}"'-pvc-size", "value": "'"${newSizeMi}"'"}]'
         curl --cacert "${CACERT}" --header "Authorization: Bearer ${TOKEN}" -XPATCH "${APISERVER}/api/v1/namespaces/${NAMESPACE}/pods/${HOSTNAME}?fieldManager=kubectl-annotate" -H "Content-Type: application/json-patch+json" --data "${d}"
@@ -595,22 +631,22 @@ func TestAddPGBackRestToInstancePodSpec(t *testing.T) {
 
       # manage autogrow annotation for the repo1 volume, if it exists
       if [[ -d /pgbackrest/repo1 ]]; then
-        manageAutogrowAnnotation "repo1"
+        manageAutogrowAnnotation "repo1" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo2 volume, if it exists
       if [[ -d /pgbackrest/repo2 ]]; then
-        manageAutogrowAnnotation "repo2"
+        manageAutogrowAnnotation "repo2" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo3 volume, if it exists
       if [[ -d /pgbackrest/repo3 ]]; then
-        manageAutogrowAnnotation "repo3"
+        manageAutogrowAnnotation "repo3" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo4 volume, if it exists
       if [[ -d /pgbackrest/repo4 ]]; then
-        manageAutogrowAnnotation "repo4"
+        manageAutogrowAnnotation "repo4" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
     done
@@ -723,7 +759,8 @@ func TestAddPGBackRestToInstancePodSpec(t *testing.T) {
     # Manage autogrow annotation.
     # Return size in Mebibytes.
     manageAutogrowAnnotation() {
-      local volume=$1
+      local volume=$1 threshold percent mebibytes
+      read -r threshold percent mebibytes <<< "$2"
 
       size=$(df --block-size=M "/pgbackrest/${volume}")
       read -r _ size _ <<< "${size#*$'\n'}"
@@ -732,9 +769,9 @@ func TestAddPGBackRestToInstancePodSpec(t *testing.T) {
       sizeInt="${size//M/}"
       # Use the sed punctuation class, because the shell will not accept the percent sign in an expansion.
       useInt=${use//[[:punct:]]/}
-      triggerExpansion="$((useInt > 75))"
+      triggerExpansion="$((useInt > threshold))"
       if [[ ${triggerExpansion} -eq 1 ]]; then
-        newSize="$(((sizeInt / 2)+sizeInt))"
+        newSize="$((sizeInt + (sizeInt * percent / 100) + mebibytes))"
         newSizeMi="${newSize}Mi"
         d='[{"op": "add", "path": "/metadata/annotations/suggested-'"${volume}"'-pvc-size", "value": "'"${newSizeMi}"'"}]'
         curl --cacert "${CACERT}" --header "Authorization: Bearer ${TOKEN}" -XPATCH "${APISERVER}/api/v1/namespaces/${NAMESPACE}/pods/${HOSTNAME}?fieldManager=kubectl-annotate" -H "Content-Type: application/json-patch+json" --data "${d}"
@@ -761,22 +798,22 @@ func TestAddPGBackRestToInstancePodSpec(t *testing.T) {
 
       # manage autogrow annotation for the repo1 volume, if it exists
       if [[ -d /pgbackrest/repo1 ]]; then
-        manageAutogrowAnnotation "repo1"
+        manageAutogrowAnnotation "repo1" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo2 volume, if it exists
       if [[ -d /pgbackrest/repo2 ]]; then
-        manageAutogrowAnnotation "repo2"
+        manageAutogrowAnnotation "repo2" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo3 volume, if it exists
       if [[ -d /pgbackrest/repo3 ]]; then
-        manageAutogrowAnnotation "repo3"
+        manageAutogrowAnnotation "repo3" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo4 volume, if it exists
       if [[ -d /pgbackrest/repo4 ]]; then
-        manageAutogrowAnnotation "repo4"
+        manageAutogrowAnnotation "repo4" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
     done
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
 	"time"
 
 	"github.com/pkg/errors"
@@ -173,7 +174,8 @@ func (r *Reconciler) reconcilePatroniDistributedConfiguration(
 
 func (r *Reconciler) reconcilePatroniDynamicConfiguration(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
-	pgHBAs *postgres.OrderedHBAs, pgParameters *postgres.ParameterSet,
+	pgHBAs *postgres.OrderedHBAs, pgIdentMaps *postgres.OrderedIdentMaps,
+	pgParameters *postgres.ParameterSet,
 ) error {
 	if !patroni.ClusterBootstrapped(cluster) {
 		// Patroni has not yet bootstrapped. Dynamic configuration happens through
@@ -206,7 +208,7 @@ func (r *Reconciler) reconcilePatroniDynamicConfiguration(
 
 	return errors.WithStack(
 		patroni.Executor(exec).ReplaceConfiguration(ctx,
-			patroni.DynamicConfiguration(&cluster.Spec, pgHBAs, pgParameters)))
+			patroni.DynamicConfiguration(&cluster.Spec, pgHBAs, pgIdentMaps, pgParameters)))
 }
 
 // generatePatroniLeaderLeaseService returns a v1.Service that exposes the
@@ -346,6 +348,55 @@ func (r *Reconciler) reconcilePatroniStatus(
 	return requeue, err
 }
 
+// +kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// observeReplicationLag returns the replication lag, in bytes, of instance
+// Pods by name when any instance set has a maximum lag. It also returns how
+// long to wait before observing again.
+func (r *Reconciler) observeReplicationLag(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	observedInstances *observedInstances,
+) (map[string]int64, time.Duration) {
+	if !slices.ContainsFunc(cluster.Spec.InstanceSets, func(set v1beta1.PostgresInstanceSetSpec) bool {
+		return set.MaximumLag != nil
+	}) {
+		return nil, 0
+	}
+
+	// Lag changes without any change to Kubernetes objects. Check again
+	// about as often as Patroni does.
+	requeue := time.Duration(*cluster.Spec.Patroni.SyncPeriodSeconds) * time.Second
+
+	// Find a running Pod that can be used to define a PodExec function.
+	var runningPod *corev1.Pod
+	for _, instance := range observedInstances.forCluster {
+		if running, known := instance.IsRunning(naming.ContainerDatabase); running &&
+			known && len(instance.Pods) == 1 {
+
+			runningPod = instance.Pods[0]
+			break
+		}
+	}
+	if runningPod == nil {
+		return nil, requeue
+	}
+
+	exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+		command ...string) error {
+		return r.PodExec(ctx, runningPod.Namespace, runningPod.Name, naming.ContainerDatabase, stdin,
+			stdout, stderr, command...)
+	}
+
+	// Replicas with an unknown lag are removed from read Services, so log
+	// rather than interrupt reconciliation.
+	lag, err := patroni.Executor(exec).GetMemberLag(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error(err, "unable to observe replication lag")
+	}
+
+	return lag, requeue
+}
+
 // reconcileReplicationSecret creates a secret containing the TLS
 // certificate, key and CA certificate for use with the replication and
 // pg_rewind accounts in Postgres.
@@ -367,6 +418,12 @@ func (r *Reconciler) reconcileReplicationSecret(
 		return custom, err
 	}
 
+	// if cert-manager issues the certificate, return its secret; it is issued
+	// before any instance mounts it
+	if certificateIssuer(cluster) != nil {
+		return &corev1.Secret{ObjectMeta: naming.ReplicationIssuedCertificate(cluster)}, nil
+	}
+
 	existing := &corev1.Secret{ObjectMeta: naming.ReplicationClientCertSecret(cluster)}
 	err := errors.WithStack(client.IgnoreNotFound(
 		r.Client.Get(ctx, client.ObjectKeyFromObject(existing), existing)))
@@ -412,7 +469,7 @@ func (r *Reconciler) reconcileReplicationSecret(
 		err = errors.WithStack(err)
 	}
 	if err == nil {
-		intent.Data[naming.ReplicationCACert], err = root.Certificate.MarshalText()
+		intent.Data[naming.ReplicationCACert], err = root.Bundle().MarshalText()
 		err = errors.WithStack(err)
 	}
 	if err == nil {
@@ -591,7 +648,12 @@ func (r *Reconciler) reconcilePatroniSwitchover(ctx context.Context,
 		nextPrimary = targetInstance.Pods[0].Name
 	}
 
-	success, err := action(ctx, exec, nextPrimary)
+	var success bool
+	err = r.changePrimaryWithPGBouncerPaused(ctx, cluster, func(ctx context.Context) error {
+		var err error
+		success, err = action(ctx, exec, nextPrimary)
+		return err
+	})
 	if err = errors.WithStack(err); err == nil && !success {
 		err = errors.New("unable to switchover")
 	}
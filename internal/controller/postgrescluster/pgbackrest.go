@@ -67,6 +67,10 @@ const (
 	// and in-place pgBackRest restore is in progress
 	ConditionPGBackRestRestoreProgressing = "PGBackRestoreProgressing"
 
+	// ConditionFullBackupCurrent is the type used in a condition to indicate whether or not
+	// a pgBackRest repository has a full backup newer than the configured maximum age
+	ConditionFullBackupCurrent = "PGBackRestFullBackupCurrent"
+
 	// EventRepoHostNotFound is used to indicate that a pgBackRest repository was not
 	// found when reconciling
 	EventRepoHostNotFound = "RepoDeploymentNotFound"
@@ -110,6 +114,7 @@ type RepoResources struct {
 	cronjobs                []*batchv1.CronJob
 	manualBackupJobs        []*batchv1.Job
 	replicaCreateBackupJobs []*batchv1.Job
+	verificationJobs        []*batchv1.Job
 	pvcs                    []*corev1.PersistentVolumeClaim
 	sas                     []*corev1.ServiceAccount
 	roles                   []*rbacv1.Role
@@ -340,6 +345,17 @@ func (r *Reconciler) cleanupRepoResources(ctx context.Context,
 					delete = false
 				}
 			}
+		case hasLabel(naming.LabelPGBackRestVerification):
+			if !backupsSpecFound {
+				break
+			}
+			// Keep the CronJob and Jobs that verify the repository configured
+			// for verification. Delete any others.
+			if name := verificationRepoName(postgresCluster); name != "" &&
+				name == owned.GetLabels()[naming.LabelPGBackRestRepo] {
+				ownedNoDelete = append(ownedNoDelete, owned)
+				delete = false
+			}
 		case hasLabel(naming.LabelPGBackRestCronJob):
 			if !backupsSpecFound {
 				break
@@ -454,8 +470,13 @@ func unstructuredToRepoResources(kind string, repoResources *RepoResources,
 		if err != nil {
 			return errors.WithStack(err)
 		}
-		// we care about replica create backup jobs and manual backup jobs
+		// we care about replica create backup jobs, manual backup jobs, and
+		// backup verification jobs
 		for i, job := range jobList.Items {
+			if _, ok := job.GetLabels()[naming.LabelPGBackRestVerification]; ok {
+				repoResources.verificationJobs =
+					append(repoResources.verificationJobs, &jobList.Items[i])
+			}
 			switch job.GetLabels()[naming.LabelPGBackRestBackup] {
 			case string(naming.BackupReplicaCreate):
 				repoResources.replicaCreateBackupJobs =
@@ -1228,7 +1249,7 @@ func (r *Reconciler) reconcileRestoreJob(ctx context.Context,
 	var deltaOptFound, foundTarget bool
 	for _, opt := range opts {
 		switch {
-		case targetRegex.MatchString(opt):
+		case targetRegex.MatchString(opt), opt == "--type=immediate":
 			foundTarget = true
 		case strings.Contains(opt, "--delta"):
 			deltaOptFound = true
@@ -1239,7 +1260,8 @@ func (r *Reconciler) reconcileRestoreJob(ctx context.Context,
 	}
 
 	// Note on the pgBackRest option `--target-action` in the restore job:
-	// (a) `--target-action` is only allowed if `--target` and `type` are set;
+	// (a) `--target-action` is only allowed if `--target` and `type` are set,
+	// or when `type` is "immediate";
 	// TODO(benjaminjb): ensure that `type` is set as well before accepting `target-action`
 	// (b) our restore job assumes the `hot_standby: on` default, which is true of Postgres >= 10;
 	// (c) pgBackRest passes the `--target-action` setting as `recovery-target-action`
@@ -1461,6 +1483,7 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 	// clear the status and exit
 	if !backupsSpecFound {
 		postgresCluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
+		meta.RemoveStatusCondition(&postgresCluster.Status.Conditions, ConditionArchivingHealthy)
 		return result, nil
 	}
 
@@ -1569,6 +1592,13 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 		result.RequeueAfter = 10 * time.Second
 	}
 
+	// reconcile the CronJob that verifies backups as configured
+	if next := r.reconcileBackupVerification(ctx, postgresCluster,
+		repoResources.verificationJobs); next > 0 &&
+		(result.RequeueAfter == 0 || next < result.RequeueAfter) {
+		result.RequeueAfter = next
+	}
+
 	// Reconcile the initial backup that is needed to enable replica creation using pgBackRest.
 	// This is done once stanza creation is successful
 	if err := r.reconcileReplicaCreateBackup(ctx, postgresCluster, instances,
@@ -1585,6 +1615,18 @@ func (r *Reconciler) reconcilePGBackRest(ctx context.Context,
 		result.Requeue = true
 	}
 
+	// Read the backups in each repository into status as configured
+	if next := r.reconcileBackupCatalog(ctx, postgresCluster, instances); next > 0 &&
+		(result.RequeueAfter == 0 || next < result.RequeueAfter) {
+		result.RequeueAfter = next
+	}
+
+	// Check that the primary is archiving WAL
+	if next := r.reconcileArchivingHealth(ctx, postgresCluster, instances); next > 0 &&
+		(result.RequeueAfter == 0 || next < result.RequeueAfter) {
+		result.RequeueAfter = next
+	}
+
 	return result, nil
 }
 
@@ -1741,8 +1783,9 @@ func (r *Reconciler) reconcilePostgresClusterDataSource(ctx context.Context,
 		return errors.WithStack(err)
 	}
 
-	// TODO(snapshots): If pgdata is being sourced by a VolumeSnapshot then don't perform a typical restore job;
-	// we only want to replay the WAL.
+	// When pgdata is populated from a VolumeSnapshot, the restore Job below is a
+	// delta restore that only copies files that differ from the backup before
+	// PostgreSQL replays WAL to a consistent point.
 
 	// reconcile the pgBackRest restore Job to populate the cluster's data directory
 	if err := r.reconcileRestoreJob(ctx, cluster, sourceCluster, pgdata, pgwal, pgtablespaces,
@@ -2979,7 +3022,19 @@ func (r *Reconciler) reconcileStanzaCreate(ctx context.Context,
 
 	// if no errors then stanza(s) created successfully
 	for i := range postgresCluster.Status.PGBackRest.Repos {
-		postgresCluster.Status.PGBackRest.Repos[i].StanzaCreated = true
+		repoStatus := &postgresCluster.Status.PGBackRest.Repos[i]
+
+		// Record the passphrase of each new stanza. It protects every backup
+		// set in the repository until the stanza is created again.
+		if !repoStatus.StanzaCreated {
+			repoStatus.Encryption = nil
+			for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+				if repo.Name == repoStatus.Name {
+					repoStatus.Encryption = pgbackrest.RepoEncryption(postgresCluster, repo)
+				}
+			}
+		}
+		repoStatus.StanzaCreated = true
 	}
 
 	return false, nil
@@ -3367,3 +3422,228 @@ func authorizeBackupRemovalAnnotationPresent(postgresCluster *v1beta1.PostgresCl
 	}
 	return false
 }
+
+// +kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// reconcileBackupCatalog reads the backups in each pgBackRest repository into
+// status when the catalog is configured. It runs "pgbackrest info" in an
+// instance Pod, preferring one that is writable. It returns how long to wait
+// before reading the backups again.
+func (r *Reconciler) reconcileBackupCatalog(ctx context.Context,
+	postgresCluster *v1beta1.PostgresCluster, instances *observedInstances,
+) time.Duration {
+	catalog := postgresCluster.Spec.Backups.PGBackRest.Catalog
+	status := postgresCluster.Status.PGBackRest
+
+	if catalog == nil {
+		status.CatalogTime = nil
+		for i := range status.Repos {
+			status.Repos[i].Backups = nil
+			status.Repos[i].WAL = nil
+			status.Repos[i].RecoveryWindow = nil
+		}
+		meta.RemoveStatusCondition(&postgresCluster.Status.Conditions, ConditionFullBackupCurrent)
+		return 0
+	}
+
+	interval := 5 * time.Minute
+	if catalog.RefreshInterval != nil {
+		interval = catalog.RefreshInterval.AsDuration().Duration
+	}
+
+	now := time.Now()
+	next := interval
+
+	if !backupCatalogDue(status, interval, now) {
+		next = status.CatalogTime.Add(interval).Sub(now)
+
+	} else if pod, writable := catalogPod(instances); pod != nil {
+		exec := func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer,
+			command ...string) error {
+			return r.PodExec(ctx, pod.Namespace, pod.Name, naming.ContainerDatabase,
+				stdin, stdout, stderr, command...)
+		}
+
+		info, err := pgbackrest.Executor(exec).Info(ctx)
+
+		// PostgreSQL reports when it last archived WAL, but only while writable.
+		var archiver *postgres.ArchiverStatus
+		if err == nil && writable {
+			archiver, err = postgres.Executor(exec).ArchiverStatus(ctx)
+		}
+
+		if err == nil {
+			updateBackupCatalog(status, info, archiver)
+			status.CatalogTime = initialize.Pointer(metav1.NewTime(now))
+		} else {
+			logging.FromContext(ctx).Error(err, "unable to read pgBackRest backups")
+		}
+	}
+
+	// Check again when the newest full backup becomes too old.
+	if stale := setFullBackupCondition(postgresCluster, catalog, now); stale > 0 && stale < next {
+		next = stale
+	}
+
+	return next
+}
+
+// catalogPod returns the Pod of a running instance, preferring one that is
+// writable. It returns nil when no instance is running.
+func catalogPod(instances *observedInstances) (*corev1.Pod, bool) {
+	var found *corev1.Pod
+	for _, instance := range instances.forCluster {
+		if running, known := instance.IsRunning(naming.ContainerDatabase); !running ||
+			!known || len(instance.Pods) != 1 {
+			continue
+		}
+		if writable, known := instance.IsWritable(); writable && known {
+			return instance.Pods[0], true
+		}
+		if found == nil {
+			found = instance.Pods[0]
+		}
+	}
+	return found, false
+}
+
+// backupCatalogDue returns whether or not the backups in each repository
+// should be read again. They are read after interval or any backup Job that
+// finished since they were last read.
+func backupCatalogDue(status *v1beta1.PGBackRestStatus, interval time.Duration, now time.Time) bool {
+	if status.CatalogTime == nil || !now.Before(status.CatalogTime.Add(interval)) {
+		return true
+	}
+
+	finished := func(completion *metav1.Time) bool {
+		return completion != nil && status.CatalogTime.Before(completion)
+	}
+	if status.ManualBackup != nil && finished(status.ManualBackup.CompletionTime) {
+		return true
+	}
+	for _, scheduled := range status.ScheduledBackups {
+		if finished(scheduled.CompletionTime) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxCatalogBackups is the most backups of each repository stored in status.
+// It matches the MaxItems of the field and keeps the status object small.
+const maxCatalogBackups = 20
+
+// updateBackupCatalog stores the backups and WAL that pgBackRest reports for
+// each repository in status. When archiver is not nil, it is used to find
+// the latest time a restore can target.
+func updateBackupCatalog(
+	status *v1beta1.PGBackRestStatus, info *pgbackrest.Info, archiver *postgres.ArchiverStatus,
+) {
+	for i := range status.Repos {
+		repo := &status.Repos[i]
+		backups := info.RepoBackups(repo.Name)
+		repo.Backups = recentBackups(backups, maxCatalogBackups)
+
+		// Every backup in an encrypted repository is protected by the
+		// passphrase its stanza was created with.
+		if cipher := info.RepoCipher(repo.Name); repo.Encryption != nil &&
+			cipher != "" && cipher != "none" {
+			for j := range repo.Backups {
+				repo.Backups[j].Encryption = repo.Encryption.DeepCopy()
+			}
+		}
+		repo.WAL = info.RepoWAL(repo.Name)
+		repo.RecoveryWindow = nil
+
+		if len(backups) == 0 {
+			continue
+		}
+
+		// A restore can target any time after the oldest backup is consistent.
+		repo.RecoveryWindow = &v1beta1.PGBackRestRecoveryWindow{
+			Start: backups[0].StopTime,
+		}
+
+		// WAL segment names sort by timeline then position. When the segment
+		// that PostgreSQL last archived is in the repository, a restore can
+		// target the time it was archived.
+		if archiver != nil && archiver.LastArchivedTime != nil && repo.WAL != nil &&
+			len(archiver.LastArchivedWAL) >= 24 && len(repo.WAL.Stop) >= 24 &&
+			archiver.LastArchivedWAL[:24] <= repo.WAL.Stop[:24] {
+			repo.RecoveryWindow.End = initialize.Pointer(metav1.NewTime(*archiver.LastArchivedTime))
+		}
+	}
+}
+
+// recentBackups returns at most limit of the newest backups, oldest first.
+// The newest full backup without errors is always among them so that its age
+// can be checked.
+func recentBackups(backups []v1beta1.PGBackRestBackupInfo, limit int) []v1beta1.PGBackRestBackupInfo {
+	if len(backups) <= limit {
+		return backups
+	}
+
+	first := len(backups) - limit
+	for i := len(backups) - 1; i >= 0; i-- {
+		if backups[i].Type == full && !backups[i].Error {
+			if i < first {
+				return append([]v1beta1.PGBackRestBackupInfo{backups[i]}, backups[first+1:]...)
+			}
+			break
+		}
+	}
+	return backups[first:]
+}
+
+// setFullBackupCondition sets the ConditionFullBackupCurrent condition based
+// on the newest full backup in any repository. It returns how long until that
+// backup is too old, or zero when it already is.
+func setFullBackupCondition(
+	postgresCluster *v1beta1.PostgresCluster, catalog *v1beta1.PGBackRestCatalog, now time.Time,
+) time.Duration {
+	status := postgresCluster.Status.PGBackRest
+
+	if catalog.MaximumFullBackupAge == nil {
+		meta.RemoveStatusCondition(&postgresCluster.Status.Conditions, ConditionFullBackupCurrent)
+		return 0
+	}
+	if status.CatalogTime == nil {
+		// Leave any existing condition until the backups have been read.
+		return 0
+	}
+
+	condition := metav1.Condition{
+		ObservedGeneration: postgresCluster.GetGeneration(),
+		Type:               ConditionFullBackupCurrent,
+	}
+	maximum := catalog.MaximumFullBackupAge.AsDuration().Duration
+
+	var newest *v1beta1.PGBackRestBackupInfo
+	for i := range status.Repos {
+		for j, backup := range status.Repos[i].Backups {
+			if backup.Type == full && !backup.Error &&
+				(newest == nil || newest.StopTime.Before(&backup.StopTime)) {
+				newest = &status.Repos[i].Backups[j]
+			}
+		}
+	}
+
+	var remaining time.Duration
+	if newest == nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NoFullBackup"
+		condition.Message = "No repository has a full backup"
+	} else if remaining = newest.StopTime.Add(maximum).Sub(now); remaining <= 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "FullBackupStale"
+		condition.Message = fmt.Sprintf("The newest full backup, %s, is older than %s",
+			newest.Label, maximum)
+	} else {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "FullBackupCurrent"
+		condition.Message = fmt.Sprintf("The newest full backup is %s", newest.Label)
+	}
+
+	meta.SetStatusCondition(&postgresCluster.Status.Conditions, condition)
+	return max(remaining, 0)
+}
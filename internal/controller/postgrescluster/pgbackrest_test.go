@@ -39,6 +39,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/pgbackrest"
 	"github.com/crunchydata/postgres-operator/internal/pki"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
 	"github.com/crunchydata/postgres-operator/internal/testing/events"
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
@@ -2028,6 +2029,21 @@ func TestReconcilePostgresClusterDataSource(t *testing.T) {
 				expectedClusterCondition: nil,
 				expectedCommandPieces:    []string{"--stanza=", "--pg1-path=", "--repo=", "--delta", "--target=some-date", "--target-action=promote"},
 			},
+		}, {
+			desc: "valid option: type immediate",
+			dataSource: &v1beta1.DataSource{PostgresCluster: &v1beta1.PostgresClusterDataSource{
+				ClusterName: "valid-type-immediate-option", RepoName: "repo1",
+				Options: []string{"--type=immediate", "--set=20250102-030405F"},
+			}},
+			clusterBootstrapped: false,
+			sourceClusterName:   "valid-type-immediate-option",
+			sourceClusterRepos:  []v1beta1.PGBackRestRepo{{Name: "repo1"}},
+			result: testResult{
+				configCount: 1, jobCount: 1, pvcCount: 1,
+				invalidSourceRepo: false, invalidSourceCluster: false, invalidOptions: false,
+				expectedClusterCondition: nil,
+				expectedCommandPieces:    []string{"--stanza=", "--pg1-path=", "--repo=", "--delta", "--type=immediate", "--set=20250102-030405F", "--target-action=promote"},
+			},
 		}, {
 			desc: "cluster bootstrapped init condition missing",
 			dataSource: &v1beta1.DataSource{PostgresCluster: &v1beta1.PostgresClusterDataSource{
@@ -4542,3 +4558,242 @@ func TestGetRepoHostVolumeRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestBackupCatalogDue(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	before := func(d time.Duration) *metav1.Time {
+		return initialize.Pointer(metav1.NewTime(now.Add(-d)))
+	}
+
+	status := &v1beta1.PGBackRestStatus{}
+	assert.Assert(t, backupCatalogDue(status, time.Minute, now), "never read")
+
+	status.CatalogTime = before(30 * time.Second)
+	assert.Assert(t, !backupCatalogDue(status, time.Minute, now))
+
+	status.CatalogTime = before(time.Minute)
+	assert.Assert(t, backupCatalogDue(status, time.Minute, now), "interval elapsed")
+
+	t.Run("ManualBackup", func(t *testing.T) {
+		status := &v1beta1.PGBackRestStatus{CatalogTime: before(30 * time.Second)}
+		status.ManualBackup = &v1beta1.PGBackRestJobStatus{CompletionTime: before(time.Minute)}
+		assert.Assert(t, !backupCatalogDue(status, time.Minute, now))
+
+		status.ManualBackup.CompletionTime = before(10 * time.Second)
+		assert.Assert(t, backupCatalogDue(status, time.Minute, now))
+	})
+
+	t.Run("ScheduledBackup", func(t *testing.T) {
+		status := &v1beta1.PGBackRestStatus{CatalogTime: before(30 * time.Second)}
+		status.ScheduledBackups = []v1beta1.PGBackRestScheduledBackupStatus{
+			{CompletionTime: before(time.Minute)},
+			{},
+		}
+		assert.Assert(t, !backupCatalogDue(status, time.Minute, now))
+
+		status.ScheduledBackups[1].CompletionTime = before(10 * time.Second)
+		assert.Assert(t, backupCatalogDue(status, time.Minute, now))
+	})
+}
+
+func TestUpdateBackupCatalog(t *testing.T) {
+	info := &pgbackrest.Info{
+		Archive: []pgbackrest.InfoArchive{{
+			Database: pgbackrest.InfoDatabase{ID: 1, RepoKey: 1},
+			Min:      "000000010000000000000001",
+			Max:      "000000010000000000000007",
+		}},
+		Backup: []pgbackrest.InfoBackup{{
+			Database: pgbackrest.InfoDatabase{ID: 1, RepoKey: 1},
+			Label:    "20250102-030405F",
+			Type:     "full",
+		}},
+	}
+	info.Backup[0].Timestamp.Start = 1735787045
+	info.Backup[0].Timestamp.Stop = 1735787050
+
+	archived := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	t.Run("NoArchiver", func(t *testing.T) {
+		status := &v1beta1.PGBackRestStatus{
+			Repos: []v1beta1.RepoStatus{{Name: "repo1"}, {Name: "repo2"}},
+		}
+		updateBackupCatalog(status, info, nil)
+
+		assert.Equal(t, len(status.Repos[0].Backups), 1)
+		assert.DeepEqual(t, status.Repos[0].WAL, &v1beta1.PGBackRestWALRange{
+			Start: "000000010000000000000001", Stop: "000000010000000000000007",
+		})
+		assert.Assert(t, cmp.MarshalMatches(status.Repos[0].RecoveryWindow, `
+start: "2025-01-02T03:04:10Z"
+		`))
+
+		assert.Equal(t, len(status.Repos[1].Backups), 0)
+		assert.Assert(t, status.Repos[1].WAL == nil)
+		assert.Assert(t, status.Repos[1].RecoveryWindow == nil)
+	})
+
+	t.Run("Encrypted", func(t *testing.T) {
+		encryption := &v1beta1.RepoEncryptionStatus{
+			Cipher: "aes-256-cbc", SecretName: "some-secret", Key: "repo1-cipher-pass",
+		}
+		status := &v1beta1.PGBackRestStatus{
+			Repos: []v1beta1.RepoStatus{{Name: "repo1", Encryption: encryption}},
+		}
+
+		// Nothing is recorded until pgBackRest reports the cipher.
+		updateBackupCatalog(status, info, nil)
+		assert.Assert(t, status.Repos[0].Backups[0].Encryption == nil)
+
+		info := *info
+		info.Repo = []pgbackrest.InfoRepo{{Cipher: "aes-256-cbc", Key: 1}}
+		updateBackupCatalog(status, &info, nil)
+		assert.DeepEqual(t, status.Repos[0].Backups[0].Encryption, encryption)
+
+		info.Repo[0].Cipher = "none"
+		updateBackupCatalog(status, &info, nil)
+		assert.Assert(t, status.Repos[0].Backups[0].Encryption == nil)
+	})
+
+	t.Run("Archived", func(t *testing.T) {
+		status := &v1beta1.PGBackRestStatus{Repos: []v1beta1.RepoStatus{{Name: "repo1"}}}
+		updateBackupCatalog(status, info, &postgres.ArchiverStatus{
+			LastArchivedWAL:  "000000010000000000000007",
+			LastArchivedTime: &archived,
+		})
+
+		assert.Assert(t, cmp.MarshalMatches(status.Repos[0].RecoveryWindow, `
+end: "2025-01-03T00:00:00Z"
+start: "2025-01-02T03:04:10Z"
+		`))
+	})
+
+	t.Run("NotArchivedHere", func(t *testing.T) {
+		status := &v1beta1.PGBackRestStatus{Repos: []v1beta1.RepoStatus{{Name: "repo1"}}}
+		updateBackupCatalog(status, info, &postgres.ArchiverStatus{
+			LastArchivedWAL:  "000000010000000000000008",
+			LastArchivedTime: &archived,
+		})
+
+		assert.Assert(t, status.Repos[0].RecoveryWindow.End == nil)
+
+		// History files are not segments.
+		updateBackupCatalog(status, info, &postgres.ArchiverStatus{
+			LastArchivedWAL:  "00000002.history",
+			LastArchivedTime: &archived,
+		})
+
+		assert.Assert(t, status.Repos[0].RecoveryWindow.End == nil)
+	})
+}
+
+func TestRecentBackups(t *testing.T) {
+	backups := func(types ...string) []v1beta1.PGBackRestBackupInfo {
+		var result []v1beta1.PGBackRestBackupInfo
+		for i, kind := range types {
+			result = append(result, v1beta1.PGBackRestBackupInfo{
+				Label: fmt.Sprint(i), Type: kind,
+			})
+		}
+		return result
+	}
+	labels := func(backups []v1beta1.PGBackRestBackupInfo) []string {
+		var result []string
+		for _, backup := range backups {
+			result = append(result, backup.Label)
+		}
+		return result
+	}
+
+	t.Run("UnderLimit", func(t *testing.T) {
+		assert.DeepEqual(t, labels(recentBackups(backups("full", "incr"), 3)),
+			[]string{"0", "1"})
+	})
+
+	t.Run("Newest", func(t *testing.T) {
+		assert.DeepEqual(t, labels(recentBackups(backups("full", "incr", "full", "incr"), 3)),
+			[]string{"1", "2", "3"})
+	})
+
+	t.Run("KeepsFull", func(t *testing.T) {
+		assert.DeepEqual(t, labels(recentBackups(backups("full", "incr", "incr", "incr", "incr"), 3)),
+			[]string{"0", "3", "4"})
+	})
+
+	t.Run("NoFull", func(t *testing.T) {
+		assert.DeepEqual(t, labels(recentBackups(backups("incr", "incr", "incr", "incr"), 3)),
+			[]string{"1", "2", "3"})
+	})
+}
+
+func TestSetFullBackupCondition(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	day, err := v1beta1.NewDuration("1d")
+	assert.NilError(t, err)
+
+	backup := func(label, kind string, stop time.Time) v1beta1.PGBackRestBackupInfo {
+		return v1beta1.PGBackRestBackupInfo{Label: label, Type: kind, StopTime: metav1.NewTime(stop)}
+	}
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		CatalogTime: initialize.Pointer(metav1.NewTime(now)),
+		Repos: []v1beta1.RepoStatus{
+			{Name: "repo1", Backups: []v1beta1.PGBackRestBackupInfo{
+				backup("old", "full", now.Add(-72*time.Hour)),
+				backup("incr", "incr", now.Add(-time.Hour)),
+			}},
+			{Name: "repo2", Backups: []v1beta1.PGBackRestBackupInfo{
+				backup("newer", "full", now.Add(-30*time.Hour)),
+			}},
+		},
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+			Type: ConditionFullBackupCurrent, Status: metav1.ConditionFalse, Reason: "x",
+		})
+
+		assert.Equal(t, setFullBackupCondition(cluster, &v1beta1.PGBackRestCatalog{}, now), time.Duration(0))
+		assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions, ConditionFullBackupCurrent) == nil)
+	})
+
+	t.Run("Stale", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		catalog := &v1beta1.PGBackRestCatalog{MaximumFullBackupAge: day}
+
+		assert.Equal(t, setFullBackupCondition(cluster, catalog, now), time.Duration(0))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionFullBackupCurrent)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "FullBackupStale")
+		assert.Assert(t, cmp.Contains(condition.Message, "newer"))
+	})
+
+	t.Run("Current", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Status.PGBackRest.Repos[1].Backups[0].StopTime = metav1.NewTime(now.Add(-20 * time.Hour))
+		catalog := &v1beta1.PGBackRestCatalog{MaximumFullBackupAge: day}
+
+		assert.Equal(t, setFullBackupCondition(cluster, catalog, now), 4*time.Hour)
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionFullBackupCurrent)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionTrue)
+	})
+
+	t.Run("NoFullBackup", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Status.PGBackRest.Repos = cluster.Status.PGBackRest.Repos[:1]
+		cluster.Status.PGBackRest.Repos[0].Backups[0].Error = true
+		catalog := &v1beta1.PGBackRestCatalog{MaximumFullBackupAge: day}
+
+		setFullBackupCondition(cluster, catalog, now)
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionFullBackupCurrent)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Reason, "NoFullBackup")
+	})
+}
@@ -8,6 +8,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -25,6 +28,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/pgbouncer"
 	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/internal/tracing"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
@@ -40,6 +44,9 @@ func (r *Reconciler) reconcilePGBouncer(
 	)
 
 	service, err := r.reconcilePGBouncerService(ctx, cluster)
+	if err == nil {
+		_, err = r.reconcilePGBouncerReadOnlyService(ctx, cluster)
+	}
 	if err == nil {
 		secret, err = r.reconcilePGBouncerSecret(ctx, cluster, root, service)
 	}
@@ -59,6 +66,44 @@ func (r *Reconciler) reconcilePGBouncer(
 	return err
 }
 
+const (
+	// ConditionPGBouncerSettingsValid is the type used in a condition to indicate
+	// whether or not the PgBouncer settings in spec.users are applied
+	ConditionPGBouncerSettingsValid = "PGBouncerSettingsValid"
+
+	// EventInvalidPGBouncerSettings is the event reason utilized when some
+	// PgBouncer settings in spec.users are invalid and not applied
+	EventInvalidPGBouncerSettings = "InvalidPGBouncerSettings"
+)
+
+// reconcilePGBouncerSettingsValid sets the PGBouncerSettingsValid condition of
+// cluster when some PgBouncer settings in spec.users are not applied, and
+// removes it otherwise. It records an event when those problems change.
+func (r *Reconciler) reconcilePGBouncerSettingsValid(cluster *v1beta1.PostgresCluster) {
+	problems := pgbouncer.UserSettingsProblems(cluster)
+	if len(problems) == 0 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionPGBouncerSettingsValid)
+		return
+	}
+
+	previous := initialize.FromPointer(
+		meta.FindStatusCondition(cluster.Status.Conditions, ConditionPGBouncerSettingsValid))
+	condition := metav1.Condition{
+		ObservedGeneration: cluster.GetGeneration(),
+		Type:               ConditionPGBouncerSettingsValid,
+		Status:             metav1.ConditionFalse,
+		Reason:             "InvalidSettings",
+		Message: "PgBouncer settings in spec.users are not applied: " +
+			strings.Join(problems, "; "),
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+
+	if previous.Status != condition.Status || previous.Message != condition.Message {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, EventInvalidPGBouncerSettings,
+			condition.Message)
+	}
+}
+
 // +kubebuilder:rbac:groups="",resources="configmaps",verbs={get}
 // +kubebuilder:rbac:groups="",resources="configmaps",verbs={create,delete,patch}
 
@@ -71,6 +116,8 @@ func (r *Reconciler) reconcilePGBouncerConfigMap(
 	configmap.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
 
 	if cluster.Spec.Proxy == nil || cluster.Spec.Proxy.PGBouncer == nil {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionPGBouncerSettingsValid)
+
 		// PgBouncer is disabled; delete the ConfigMap if it exists. Check the
 		// client cache first using Get.
 		key := client.ObjectKeyFromObject(configmap)
@@ -97,6 +144,7 @@ func (r *Reconciler) reconcilePGBouncerConfigMap(
 	if err == nil {
 		pgbouncer.ConfigMap(ctx, cluster, configmap)
 	}
+	r.reconcilePGBouncerSettingsValid(cluster)
 	// If OTel logging or metrics is enabled, add collector config
 	if collector.OpenTelemetryLogsOrMetricsEnabled(ctx, cluster) {
 		err = collector.AddToConfigMap(ctx, otelConfig, configmap)
@@ -107,6 +155,10 @@ func (r *Reconciler) reconcilePGBouncerConfigMap(
 			LogFiles:         []string{naming.PGBouncerFullLogPath},
 			PostrotateScript: collector.PGBouncerPostRotateScript,
 		}
+		if cluster.Spec.Proxy.PGBouncer.ReadOnly != nil {
+			logrotateConfig.LogFiles = append(logrotateConfig.LogFiles,
+				naming.PGBouncerReadOnlyFullLogPath)
+		}
 		collector.AddLogrotateConfigs(ctx, cluster.Spec.Instrumentation, configmap,
 			[]collector.LogrotateConfig{logrotateConfig})
 	}
@@ -263,6 +315,50 @@ func (r *Reconciler) generatePGBouncerService(
 		return service, false, nil
 	}
 
+	err := r.populatePGBouncerService(cluster, service,
+		cluster.Spec.Proxy.PGBouncer.Service,
+		*cluster.Spec.Proxy.PGBouncer.Port, naming.PortPGBouncer)
+	if err != nil {
+		return nil, true, err
+	}
+
+	err = errors.WithStack(r.setControllerReference(cluster, service))
+
+	return service, true, err
+}
+
+// generatePGBouncerReadOnlyService returns a v1.Service that exposes the
+// read-only pools of PgBouncer pods. The ServiceType comes from the read-only
+// spec of the cluster proxy.
+func (r *Reconciler) generatePGBouncerReadOnlyService(
+	cluster *v1beta1.PostgresCluster) (*corev1.Service, bool, error,
+) {
+	service := &corev1.Service{ObjectMeta: naming.ClusterPGBouncerReadOnly(cluster)}
+	service.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Service"))
+
+	if cluster.Spec.Proxy == nil || cluster.Spec.Proxy.PGBouncer == nil ||
+		cluster.Spec.Proxy.PGBouncer.ReadOnly == nil {
+		return service, false, nil
+	}
+
+	err := r.populatePGBouncerService(cluster, service,
+		cluster.Spec.Proxy.PGBouncer.ReadOnly.Service,
+		*cluster.Spec.Proxy.PGBouncer.ReadOnly.Port, naming.PortPGBouncerReadOnly)
+	if err != nil {
+		return nil, true, err
+	}
+
+	err = errors.WithStack(r.setControllerReference(cluster, service))
+
+	return service, true, err
+}
+
+// populatePGBouncerService fills in service so that it selects PgBouncer pods
+// on port. The ServiceType comes from spec.
+func (r *Reconciler) populatePGBouncerService(
+	cluster *v1beta1.PostgresCluster, service *corev1.Service,
+	spec *v1beta1.ServiceSpec, port int32, portName string,
+) error {
 	service.Annotations = naming.Merge(
 		cluster.Spec.Metadata.GetAnnotationsOrNil(),
 		cluster.Spec.Proxy.PGBouncer.Metadata.GetAnnotationsOrNil())
@@ -270,7 +366,7 @@ func (r *Reconciler) generatePGBouncerService(
 		cluster.Spec.Metadata.GetLabelsOrNil(),
 		cluster.Spec.Proxy.PGBouncer.Metadata.GetLabelsOrNil())
 
-	if spec := cluster.Spec.Proxy.PGBouncer.Service; spec != nil {
+	if spec != nil {
 		service.Annotations = naming.Merge(service.Annotations,
 			spec.Metadata.GetAnnotationsOrNil())
 		service.Labels = naming.Merge(service.Labels,
@@ -296,13 +392,13 @@ func (r *Reconciler) generatePGBouncerService(
 	// ContainerPort. This name allows the port number to differ between Pods,
 	// which can happen during a rolling update.
 	servicePort := corev1.ServicePort{
-		Name:       naming.PortPGBouncer,
-		Port:       *cluster.Spec.Proxy.PGBouncer.Port,
+		Name:       portName,
+		Port:       port,
 		Protocol:   corev1.ProtocolTCP,
-		TargetPort: intstr.FromString(naming.PortPGBouncer),
+		TargetPort: intstr.FromString(portName),
 	}
 
-	if spec := cluster.Spec.Proxy.PGBouncer.Service; spec == nil {
+	if spec == nil {
 		service.Spec.Type = corev1.ServiceTypeClusterIP
 	} else {
 		service.Spec.Type = corev1.ServiceType(spec.Type)
@@ -316,7 +412,7 @@ func (r *Reconciler) generatePGBouncerService(
 				// and event could potentially be removed in favor of that validation
 				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "MisconfiguredClusterIP",
 					"NodePort cannot be set with type ClusterIP on Service %q", service.Name)
-				return nil, true, fmt.Errorf("NodePort cannot be set with type ClusterIP on Service %q", service.Name)
+				return fmt.Errorf("NodePort cannot be set with type ClusterIP on Service %q", service.Name)
 			}
 			servicePort.NodePort = *spec.NodePort
 		}
@@ -333,9 +429,7 @@ func (r *Reconciler) generatePGBouncerService(
 	}
 	service.Spec.Ports = []corev1.ServicePort{servicePort}
 
-	err := errors.WithStack(r.setControllerReference(cluster, service))
-
-	return service, true, err
+	return nil
 }
 
 // +kubebuilder:rbac:groups="",resources="services",verbs={get}
@@ -364,6 +458,33 @@ func (r *Reconciler) reconcilePGBouncerService(
 	return service, err
 }
 
+// +kubebuilder:rbac:groups="",resources="services",verbs={get}
+// +kubebuilder:rbac:groups="",resources="services",verbs={create,delete,patch}
+
+// reconcilePGBouncerReadOnlyService writes the Service that resolves to the
+// read-only pools of PgBouncer.
+func (r *Reconciler) reconcilePGBouncerReadOnlyService(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) (*corev1.Service, error) {
+	service, specified, err := r.generatePGBouncerReadOnlyService(cluster)
+
+	if err == nil && !specified {
+		// Read-only pools are disabled; delete the Service if it exists. Check
+		// the client cache first using Get.
+		key := client.ObjectKeyFromObject(service)
+		err := errors.WithStack(r.Client.Get(ctx, key, service))
+		if err == nil {
+			err = errors.WithStack(r.deleteControlled(ctx, cluster, service))
+		}
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	if err == nil {
+		err = errors.WithStack(r.apply(ctx, service))
+	}
+	return service, err
+}
+
 // generatePGBouncerDeployment returns an appsv1.Deployment that runs PgBouncer pods.
 func (r *Reconciler) generatePGBouncerDeployment(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
@@ -505,6 +626,12 @@ func (r *Reconciler) reconcilePGBouncerDeployment(
 		cluster.Status.Proxy.PGBouncer.Replicas = deploy.Status.Replicas
 		cluster.Status.Proxy.PGBouncer.ReadyReplicas = deploy.Status.ReadyReplicas
 
+		// The scale subresource reports this selector of PgBouncer pods.
+		cluster.Status.Proxy.PGBouncer.Selector = ""
+		if selector, err := naming.AsSelector(naming.ClusterPGBouncerSelector(cluster)); specified && err == nil {
+			cluster.Status.Proxy.PGBouncer.Selector = selector.String()
+		}
+
 		// NOTE(cbandy): This should be somewhere else when there is more than
 		// one proxy implementation.
 
@@ -542,11 +669,174 @@ func (r *Reconciler) reconcilePGBouncerDeployment(
 	}
 
 	if err == nil {
+		if replicas := r.autoscalePGBouncer(ctx, cluster, secret); replicas != nil {
+			deploy.Spec.Replicas = replicas
+		}
 		err = errors.WithStack(r.apply(ctx, deploy))
 	}
 	return err
 }
 
+// pgbouncerAutoscalingInterval is how often autoscaling observes PgBouncer.
+const pgbouncerAutoscalingInterval = 30 * time.Second
+
+// +kubebuilder:rbac:groups="",resources="pods",verbs={list}
+// +kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+
+// autoscalePGBouncer counts client connections in the ready PgBouncer pods of
+// cluster and returns the number of pods that autoscaling chooses. It records
+// its observations in the status of cluster and observes again only after
+// [pgbouncerAutoscalingInterval]. It returns nil when autoscaling is disabled
+// or the cluster is shutdown.
+func (r *Reconciler) autoscalePGBouncer(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, secret *corev1.Secret,
+) *int32 {
+	spec := cluster.Spec.Proxy.PGBouncer.Autoscaling
+	if spec == nil {
+		cluster.Status.Proxy.PGBouncer.Autoscaling = nil
+		return nil
+	}
+	if initialize.FromPointer(cluster.Spec.Shutdown) {
+		return nil
+	}
+
+	log := logging.FromContext(ctx)
+	now := metav1.Now()
+
+	status := cluster.Status.Proxy.PGBouncer.Autoscaling
+	if status == nil {
+		status = &v1beta1.PGBouncerAutoscalingStatus{
+			DesiredReplicas: initialize.FromPointer(cluster.Spec.Proxy.PGBouncer.Replicas),
+		}
+		cluster.Status.Proxy.PGBouncer.Autoscaling = status
+	}
+
+	// Stay within the limits, even when they just changed.
+	minimum := max(1, initialize.FromPointer(spec.MinReplicas))
+	maximum := max(minimum, spec.MaxReplicas)
+	current := min(max(status.DesiredReplicas, minimum), maximum)
+	status.DesiredReplicas = current
+
+	// Observe PgBouncer at most once per interval; reconciles for other
+	// reasons should not exec into every pod.
+	if last := status.LastObservedTime; last != nil &&
+		now.Sub(last.Time) < pgbouncerAutoscalingInterval {
+		return initialize.Int32(current)
+	}
+
+	// Count client connections in every ready pod.
+	pods := &corev1.PodList{}
+	selector, err := naming.AsSelector(naming.ClusterPGBouncerSelector(cluster))
+	if err == nil {
+		err = errors.WithStack(
+			r.Client.List(ctx, pods,
+				client.InNamespace(cluster.Namespace),
+				client.MatchingLabelsSelector{Selector: selector},
+			))
+	}
+
+	var ready int
+	var value int
+	password := string(secret.Data["pgbouncer-password"])
+	port := *cluster.Spec.Proxy.PGBouncer.Port
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if err != nil || pod.DeletionTimestamp != nil || !slices.ContainsFunc(pod.Status.Conditions,
+			func(c corev1.PodCondition) bool {
+				return c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue
+			}) {
+			continue
+		}
+
+		var clients pgbouncer.Clients
+		clients, err = r.pgbouncerExecutor(pod).Clients(ctx, password, port)
+		if err != nil {
+			err = fmt.Errorf("pod %q: %w", pod.Name, err)
+			continue
+		}
+
+		ready++
+		value += clients.Waiting
+		if spec.Metric != v1beta1.PGBouncerAutoscalingWaitingClients {
+			value += clients.Active
+		}
+	}
+
+	// Keep the current number of pods when there is nothing to observe.
+	if err == nil && ready == 0 {
+		err = errors.New("no PgBouncer pods are ready")
+	}
+	if err != nil {
+		log.Error(err, "Unable to count PgBouncer client connections")
+		status.Message = fmt.Sprintf("Unable to count client connections: %v", err)
+		return initialize.Int32(current)
+	}
+
+	counted := "client connections"
+	if spec.Metric == v1beta1.PGBouncerAutoscalingWaitingClients {
+		counted = "waiting clients"
+	}
+
+	// #nosec G115 -- the number of client connections fits in an int32
+	status.CurrentValue = int32(value)
+	status.LastObservedTime = &now
+	status.Message = fmt.Sprintf("%d %s in %d pods for a target of %d per pod",
+		value, counted, ready, spec.Target)
+
+	// Run enough pods to keep each at or below the target.
+	target := int(max(1, spec.Target))
+	// #nosec G115 -- the result is between minimum and maximum
+	desired := min(max(int32((value+target-1)/target), minimum), maximum)
+
+	// Wait for the cooldown after the last change.
+	up, down := time.Minute, 5*time.Minute
+	if spec.ScaleUpCooldown != nil {
+		up = spec.ScaleUpCooldown.AsDuration().Duration
+	}
+	if spec.ScaleDownCooldown != nil {
+		down = spec.ScaleDownCooldown.AsDuration().Duration
+	}
+	if last := status.LastScaleTime; last != nil {
+		if desired > current && now.Sub(last.Time) < up {
+			status.Message += fmt.Sprintf("; waiting %v to scale up", up)
+			desired = current
+		}
+		if desired < current && now.Sub(last.Time) < down {
+			status.Message += fmt.Sprintf("; waiting %v to scale down", down)
+			desired = current
+		}
+	}
+
+	if desired != current {
+		status.DesiredReplicas = desired
+		status.LastScaleTime = &now
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "PGBouncerScaled",
+			"Scaled PgBouncer from %d to %d pods; %s", current, desired, status.Message)
+	}
+
+	return initialize.Int32(status.DesiredReplicas)
+}
+
+// pgbouncerAutoscalingRequeue returns how long to wait before autoscaling
+// observes the PgBouncer pods of cluster again. It returns zero when
+// autoscaling is disabled.
+func pgbouncerAutoscalingRequeue(cluster *v1beta1.PostgresCluster) time.Duration {
+	if cluster.Spec.Proxy == nil || cluster.Spec.Proxy.PGBouncer == nil ||
+		cluster.Spec.Proxy.PGBouncer.Autoscaling == nil ||
+		initialize.FromPointer(cluster.Spec.Shutdown) {
+		return 0
+	}
+	return pgbouncerAutoscalingInterval
+}
+
+// pgbouncerExecutor returns an Executor that runs commands in the PgBouncer
+// container of pod.
+func (r *Reconciler) pgbouncerExecutor(pod *corev1.Pod) pgbouncer.Executor {
+	return func(ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string) error {
+		return r.PodExec(ctx, pod.Namespace, pod.Name, naming.ContainerPGBouncer, stdin, stdout, stderr, command...)
+	}
+}
+
 // +kubebuilder:rbac:groups="policy",resources="poddisruptionbudgets",verbs={create,patch,get,delete}
 
 // reconcilePGBouncerPodDisruptionBudget creates a PDB for the PGBouncer deployment.
@@ -574,13 +864,17 @@ func (r *Reconciler) reconcilePGBouncerPodDisruptionBudget(
 		// Replicas should always have a value because of defaults in the spec
 		return errors.New("Replicas should be defined")
 	}
-	minAvailable := getMinAvailable(cluster.Spec.Proxy.PGBouncer.MinAvailable,
-		*cluster.Spec.Proxy.PGBouncer.Replicas)
+	replicas := *cluster.Spec.Proxy.PGBouncer.Replicas
+	if cluster.Spec.Proxy.PGBouncer.Autoscaling != nil &&
+		cluster.Status.Proxy.PGBouncer.Autoscaling != nil {
+		replicas = cluster.Status.Proxy.PGBouncer.Autoscaling.DesiredReplicas
+	}
+	minAvailable := getMinAvailable(cluster.Spec.Proxy.PGBouncer.MinAvailable, replicas)
 
 	// If 'minAvailable' is set to '0', we will not reconcile the PDB. If one
 	// already exists, we will remove it.
 	scaled, err := intstr.GetScaledValueFromIntOrPercent(minAvailable,
-		int(*cluster.Spec.Proxy.PGBouncer.Replicas), true)
+		int(replicas), true)
 	if err == nil && scaled <= 0 {
 		return deleteExistingPDB(cluster)
 	}
@@ -606,3 +900,134 @@ func (r *Reconciler) reconcilePGBouncerPodDisruptionBudget(
 	}
 	return err
 }
+
+// +kubebuilder:rbac:groups="",resources="pods",verbs={list}
+// +kubebuilder:rbac:groups="",resources="pods/exec",verbs={create}
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
+
+// changePrimaryWithPGBouncerPaused calls change while PgBouncer pauses client
+// connections, when that is enabled for cluster. When PgBouncer does not pause
+// in time, it resumes and change is called anyway. The outcome is recorded in
+// an Event and in the switchover status of cluster.
+func (r *Reconciler) changePrimaryWithPGBouncerPaused(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	change func(context.Context) error,
+) error {
+	if cluster.Spec.Proxy == nil || cluster.Spec.Proxy.PGBouncer == nil ||
+		cluster.Spec.Proxy.PGBouncer.PauseDuringSwitchover == nil {
+		return change(ctx)
+	}
+
+	ctx, span := tracing.Start(ctx, "pgbouncer-pause")
+	defer span.End()
+	log := logging.FromContext(ctx)
+
+	spec := cluster.Spec.Proxy.PGBouncer.PauseDuringSwitchover
+	port := *cluster.Spec.Proxy.PGBouncer.Port
+	timeout, limit := 10*time.Second, time.Minute
+	if spec.Timeout != nil {
+		timeout = spec.Timeout.AsDuration().Duration
+	}
+	if spec.MaximumDuration != nil {
+		limit = spec.MaximumDuration.AsDuration().Duration
+	}
+
+	// Find the PgBouncer pods that are running and the password of the user
+	// that can pause them.
+	pods := &corev1.PodList{}
+	secret := &corev1.Secret{ObjectMeta: naming.ClusterPGBouncer(cluster)}
+	selector, err := naming.AsSelector(naming.ClusterPGBouncerSelector(cluster))
+	if err == nil {
+		err = errors.WithStack(
+			r.Client.List(ctx, pods,
+				client.InNamespace(cluster.Namespace),
+				client.MatchingLabelsSelector{Selector: selector},
+			))
+	}
+	if err == nil {
+		err = errors.WithStack(r.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret))
+	}
+
+	running := make([]*corev1.Pod, 0, len(pods.Items))
+	for i := range pods.Items {
+		if pod := &pods.Items[i]; pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil {
+			running = append(running, pod)
+		}
+	}
+
+	password := string(secret.Data["pgbouncer-password"])
+	executor := r.pgbouncerExecutor
+
+	// Pause every running pod within the timeout. When one does not, resume
+	// those that did and change the primary without pausing.
+	var paused []*corev1.Pod
+	deadline := time.Now().Add(timeout)
+	for _, pod := range running {
+		if err != nil {
+			break
+		}
+		if remaining := time.Until(deadline); remaining <= 0 {
+			err = errors.Errorf("timed out after %v", timeout)
+		} else {
+			paused = append(paused, pod)
+			err = executor(pod).Pause(ctx, password, port, remaining, limit)
+		}
+		if err != nil {
+			err = fmt.Errorf("pod %q: %w", pod.Name, err)
+		}
+	}
+
+	resume := func() error {
+		var first error
+		for _, pod := range paused {
+			if err := executor(pod).Resume(ctx, password, port); err != nil && first == nil {
+				first = fmt.Errorf("pod %q: %w", pod.Name, err)
+			}
+		}
+		return first
+	}
+
+	if err != nil || len(running) == 0 {
+		if err == nil {
+			err = errors.New("no PgBouncer pods are running")
+		}
+		if rerr := resume(); rerr != nil {
+			log.Error(rerr, "Unable to resume PgBouncer")
+		}
+
+		cluster.Status.Patroni.SwitchoverProxy = &v1beta1.PatroniSwitchoverProxyStatus{
+			Result:  v1beta1.PatroniSwitchoverProxyNotPaused,
+			Message: fmt.Sprintf("Unable to pause PgBouncer: %v", err),
+		}
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, "PGBouncerNotPaused",
+			cluster.Status.Patroni.SwitchoverProxy.Message)
+
+		return change(ctx)
+	}
+
+	// Change the primary while PgBouncer is paused. The change functions wait
+	// for the new leader to be writable, so resume right after.
+	start := time.Now()
+	err = change(ctx)
+	rerr := resume()
+	now := metav1.Now()
+
+	cluster.Status.Patroni.SwitchoverProxy = &v1beta1.PatroniSwitchoverProxyStatus{
+		Result: v1beta1.PatroniSwitchoverProxyPaused,
+		Message: fmt.Sprintf("Paused client connections on %d PgBouncer pods for %v",
+			len(paused), now.Sub(start).Round(time.Millisecond)),
+		PausedPods: int32(len(paused)), // #nosec G115 -- the number of pods is small
+		ResumeTime: &now,
+	}
+	r.Recorder.Event(cluster, corev1.EventTypeNormal, "PGBouncerPaused",
+		cluster.Status.Patroni.SwitchoverProxy.Message)
+
+	// PgBouncer resumes on its own after the limit; report the failure to
+	// resume now but do not fail the change.
+	if rerr != nil {
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "PGBouncerNotResumed",
+			"Unable to resume PgBouncer; it resumes after %v: %v", limit, rerr)
+	}
+
+	return tracing.Escape(span, err)
+}
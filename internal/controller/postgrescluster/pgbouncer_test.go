@@ -7,21 +7,29 @@ package postgrescluster
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
 	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -258,6 +266,84 @@ ownerReferences:
 	}
 }
 
+func TestGeneratePGBouncerReadOnlyService(t *testing.T) {
+	_, cc := setupKubernetes(t)
+	require.ParallelCapacity(t, 0)
+
+	reconciler := &Reconciler{
+		Client:   cc,
+		Recorder: new(record.FakeRecorder),
+	}
+
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace = "ns5"
+	cluster.Name = "pg7"
+	cluster.Spec.Proxy = &v1beta1.PostgresProxySpec{
+		PGBouncer: &v1beta1.PGBouncerPodSpec{
+			Port: initialize.Int32(9651),
+		},
+	}
+
+	t.Run("Unspecified", func(t *testing.T) {
+		service, specified, err := reconciler.generatePGBouncerReadOnlyService(cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, !specified)
+
+		assert.Assert(t, cmp.MarshalMatches(service.ObjectMeta, `
+creationTimestamp: null
+name: pg7-pgbouncer-ro
+namespace: ns5
+		`))
+	})
+
+	cluster.Spec.Proxy.PGBouncer.ReadOnly = &v1beta1.PGBouncerReadOnlySpec{
+		Port: initialize.Int32(9652),
+	}
+
+	t.Run("NoServiceSpec", func(t *testing.T) {
+		service, specified, err := reconciler.generatePGBouncerReadOnlyService(cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, specified)
+
+		assert.Equal(t, service.Name, "pg7-pgbouncer-ro")
+		assert.Equal(t, service.Spec.Type, corev1.ServiceTypeClusterIP)
+		assert.DeepEqual(t, service.Spec.Selector, map[string]string{
+			"postgres-operator.crunchydata.com/cluster": "pg7",
+			"postgres-operator.crunchydata.com/role":    "pgbouncer",
+		})
+		assert.Assert(t, cmp.MarshalMatches(service.Spec.Ports, `
+- name: pgbouncer-ro
+  port: 9652
+  protocol: TCP
+  targetPort: pgbouncer-ro
+		`))
+	})
+
+	t.Run("ServiceSpec", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Proxy.PGBouncer.Service = &v1beta1.ServiceSpec{Type: "LoadBalancer"}
+		cluster.Spec.Proxy.PGBouncer.ReadOnly.Service = &v1beta1.ServiceSpec{
+			Metadata: &v1beta1.Metadata{Labels: map[string]string{"c": "v3"}},
+			NodePort: initialize.Int32(32003),
+			Type:     "NodePort",
+		}
+
+		service, specified, err := reconciler.generatePGBouncerReadOnlyService(cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, specified)
+
+		assert.Equal(t, service.Labels["c"], "v3")
+		assert.Equal(t, service.Spec.Type, corev1.ServiceTypeNodePort)
+		assert.Assert(t, cmp.MarshalMatches(service.Spec.Ports, `
+- name: pgbouncer-ro
+  nodePort: 32003
+  port: 9652
+  protocol: TCP
+  targetPort: pgbouncer-ro
+		`))
+	})
+}
+
 func TestReconcilePGBouncerService(t *testing.T) {
 	ctx := context.Background()
 	_, cc := setupKubernetes(t)
@@ -663,3 +749,418 @@ func TestReconcilePGBouncerDisruptionBudget(t *testing.T) {
 		})
 	})
 }
+
+func TestChangePrimaryWithPGBouncerPaused(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Namespace = "ns1"
+	cluster.Name = "hippo"
+
+	secret := &corev1.Secret{ObjectMeta: naming.ClusterPGBouncer(cluster)}
+	secret.Data = map[string][]byte{"pgbouncer-password": []byte("secret")}
+
+	pod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		pod := &corev1.Pod{}
+		pod.Namespace, pod.Name = "ns1", name
+		pod.Labels = map[string]string{
+			naming.LabelCluster: "hippo",
+			naming.LabelRole:    naming.RolePGBouncer,
+		}
+		pod.Status.Phase = phase
+		return pod
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		reconciler := &Reconciler{}
+		cluster := cluster.DeepCopy()
+
+		calls := 0
+		assert.NilError(t, reconciler.changePrimaryWithPGBouncerPaused(ctx, cluster,
+			func(context.Context) error { calls++; return nil }))
+		assert.Equal(t, calls, 1)
+		assert.Assert(t, cluster.Status.Patroni.SwitchoverProxy == nil)
+	})
+
+	cluster.Spec.Proxy = &v1beta1.PostgresProxySpec{
+		PGBouncer: &v1beta1.PGBouncerPodSpec{
+			Port:                  initialize.Int32(6432),
+			PauseDuringSwitchover: &v1beta1.PGBouncerPauseSpec{},
+		},
+	}
+
+	t.Run("Paused", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		reconciler.Client = fake.NewClientBuilder().WithObjects(secret,
+			pod("one", corev1.PodRunning), pod("two", corev1.PodRunning),
+			pod("three", corev1.PodPending)).Build()
+
+		var commands []string
+		reconciler.PodExec = func(
+			_ context.Context, namespace, pod, container string, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			assert.Equal(t, namespace, "ns1")
+			assert.Equal(t, container, "pgbouncer")
+
+			b, _ := io.ReadAll(stdin)
+			assert.Equal(t, string(b), "secret")
+
+			if strings.Contains(command[3], "PAUSE") {
+				commands = append(commands, "pause "+pod)
+			} else {
+				commands = append(commands, "resume "+pod)
+			}
+			return nil
+		}
+
+		assert.NilError(t, reconciler.changePrimaryWithPGBouncerPaused(ctx, cluster,
+			func(context.Context) error {
+				commands = append(commands, "change")
+				return nil
+			}))
+
+		// Running pods pause before the change and resume after.
+		assert.DeepEqual(t, commands, []string{
+			"pause one", "pause two", "change", "resume one", "resume two",
+		})
+
+		status := cluster.Status.Patroni.SwitchoverProxy
+		assert.Assert(t, status != nil)
+		assert.Equal(t, status.Result, "Paused")
+		assert.Equal(t, status.PausedPods, int32(2))
+		assert.Assert(t, status.ResumeTime != nil)
+		assert.Assert(t, cmp.Contains(status.Message, "Paused client connections on 2 PgBouncer pods"))
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Type, "Normal")
+		assert.Equal(t, recorder.Events[0].Reason, "PGBouncerPaused")
+	})
+
+	t.Run("NotPaused", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		reconciler.Client = fake.NewClientBuilder().WithObjects(secret,
+			pod("one", corev1.PodRunning), pod("two", corev1.PodRunning)).Build()
+
+		var commands []string
+		reconciler.PodExec = func(
+			_ context.Context, _, pod, _ string, _ io.Reader, _, stderr io.Writer, command ...string,
+		) error {
+			if strings.Contains(command[3], "PAUSE") {
+				commands = append(commands, "pause "+pod)
+				if pod == "two" {
+					_, _ = stderr.Write([]byte("timed out"))
+					return errors.New("exit status 124")
+				}
+			} else {
+				commands = append(commands, "resume "+pod)
+			}
+			return nil
+		}
+
+		expected := errors.New("boom")
+		err := reconciler.changePrimaryWithPGBouncerPaused(ctx, cluster,
+			func(context.Context) error {
+				commands = append(commands, "change")
+				return expected
+			})
+		assert.Assert(t, errors.Is(err, expected))
+
+		// Pods that paused or may be pausing resume before the change.
+		assert.DeepEqual(t, commands, []string{
+			"pause one", "pause two", "resume one", "resume two", "change",
+		})
+
+		status := cluster.Status.Patroni.SwitchoverProxy
+		assert.Assert(t, status != nil)
+		assert.Equal(t, status.Result, "NotPaused")
+		assert.Assert(t, cmp.Contains(status.Message, `pod "two": exit status 124: timed out`))
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Type, "Warning")
+		assert.Equal(t, recorder.Events[0].Reason, "PGBouncerNotPaused")
+	})
+
+	t.Run("NoPods", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		reconciler.Client = fake.NewClientBuilder().WithObjects(secret).Build()
+
+		calls := 0
+		assert.NilError(t, reconciler.changePrimaryWithPGBouncerPaused(ctx, cluster,
+			func(context.Context) error { calls++; return nil }))
+		assert.Equal(t, calls, 1)
+
+		status := cluster.Status.Patroni.SwitchoverProxy
+		assert.Assert(t, status != nil)
+		assert.Equal(t, status.Result, "NotPaused")
+		assert.Assert(t, cmp.Contains(status.Message, "no PgBouncer pods are running"))
+	})
+}
+
+func TestAutoscalePGBouncer(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Namespace = "ns1"
+	cluster.Name = "hippo"
+	cluster.Spec.Proxy = &v1beta1.PostgresProxySpec{
+		PGBouncer: &v1beta1.PGBouncerPodSpec{
+			Port:     initialize.Int32(6432),
+			Replicas: initialize.Int32(2),
+		},
+	}
+
+	secret := &corev1.Secret{ObjectMeta: naming.ClusterPGBouncer(cluster)}
+	secret.Data = map[string][]byte{"pgbouncer-password": []byte("secret")}
+
+	pod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		pod := &corev1.Pod{}
+		pod.Namespace, pod.Name = "ns1", name
+		pod.Labels = map[string]string{
+			naming.LabelCluster: "hippo",
+			naming.LabelRole:    naming.RolePGBouncer,
+		}
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}
+		return pod
+	}
+
+	// reconciler returns a Reconciler with two ready pods and one that is not.
+	// Each ready pod reports active and waiting clients.
+	reconciler := func(t *testing.T, active, waiting int) (*Reconciler, *events.Recorder) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		reconciler.Client = fake.NewClientBuilder().WithObjects(
+			pod("one", corev1.ConditionTrue), pod("two", corev1.ConditionTrue),
+			pod("three", corev1.ConditionFalse)).Build()
+		reconciler.PodExec = func(
+			_ context.Context, _, pod, _ string, stdin io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			assert.Assert(t, pod != "three")
+			assert.Assert(t, cmp.Contains(command[3], "SHOW POOLS"))
+
+			b, _ := io.ReadAll(stdin)
+			assert.Equal(t, string(b), "secret")
+
+			_, _ = fmt.Fprintf(stdout, "database,cl_active,cl_waiting\napp,%d,%d\n", active, waiting)
+			return nil
+		}
+		return reconciler, recorder
+	}
+
+	t.Run("Disabled", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Status.Proxy.PGBouncer.Autoscaling = &v1beta1.PGBouncerAutoscalingStatus{}
+
+		reconciler, _ := reconciler(t, 0, 0)
+		assert.Assert(t, reconciler.autoscalePGBouncer(ctx, cluster, secret) == nil)
+		assert.Assert(t, cluster.Status.Proxy.PGBouncer.Autoscaling == nil)
+		assert.Equal(t, pgbouncerAutoscalingRequeue(cluster), time.Duration(0))
+	})
+
+	cluster.Spec.Proxy.PGBouncer.Autoscaling = &v1beta1.PGBouncerAutoscalingSpec{
+		MinReplicas: initialize.Int32(1),
+		MaxReplicas: 5,
+		Target:      10,
+	}
+
+	t.Run("Shutdown", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Shutdown = initialize.Bool(true)
+
+		reconciler, _ := reconciler(t, 0, 0)
+		assert.Assert(t, reconciler.autoscalePGBouncer(ctx, cluster, secret) == nil)
+		assert.Equal(t, pgbouncerAutoscalingRequeue(cluster), time.Duration(0))
+	})
+
+	t.Run("ScaleUp", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		reconciler, recorder := reconciler(t, 20, 5)
+
+		// 50 clients need five pods.
+		replicas := reconciler.autoscalePGBouncer(ctx, cluster, secret)
+		assert.Equal(t, initialize.FromPointer(replicas), int32(5))
+		assert.Equal(t, pgbouncerAutoscalingRequeue(cluster), 30*time.Second)
+
+		status := cluster.Status.Proxy.PGBouncer.Autoscaling
+		assert.Assert(t, status != nil)
+		assert.Equal(t, status.DesiredReplicas, int32(5))
+		assert.Equal(t, status.CurrentValue, int32(50))
+		assert.Assert(t, status.LastObservedTime != nil)
+		assert.Assert(t, status.LastScaleTime != nil)
+		assert.Equal(t, status.Message,
+			"50 client connections in 2 pods for a target of 10 per pod")
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Type, "Normal")
+		assert.Equal(t, recorder.Events[0].Reason, "PGBouncerScaled")
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note, "Scaled PgBouncer from 2 to 5 pods"))
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Proxy.PGBouncer.Autoscaling.Metric = "WaitingClients"
+		reconciler, _ := reconciler(t, 200, 100)
+
+		// 200 waiting clients need more than the maximum of five pods.
+		replicas := reconciler.autoscalePGBouncer(ctx, cluster, secret)
+		assert.Equal(t, initialize.FromPointer(replicas), int32(5))
+		assert.Equal(t, cluster.Status.Proxy.PGBouncer.Autoscaling.Message,
+			"200 waiting clients in 2 pods for a target of 10 per pod")
+	})
+
+	t.Run("Cooldown", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Status.Proxy.PGBouncer.Autoscaling = &v1beta1.PGBouncerAutoscalingStatus{
+			DesiredReplicas: 4,
+			LastScaleTime:   initialize.Pointer(metav1.NewTime(time.Now().Add(-2 * time.Minute))),
+		}
+		reconciler, recorder := reconciler(t, 0, 0)
+
+		// The default cooldown to scale down is five minutes.
+		replicas := reconciler.autoscalePGBouncer(ctx, cluster, secret)
+		assert.Equal(t, initialize.FromPointer(replicas), int32(4))
+		assert.Assert(t, cmp.Contains(
+			cluster.Status.Proxy.PGBouncer.Autoscaling.Message, "waiting 5m0s to scale down"))
+		assert.Equal(t, len(recorder.Events), 0)
+
+		// A shorter cooldown allows it after the next observation.
+		cooldown, err := v1beta1.NewDuration("1m")
+		assert.NilError(t, err)
+		cluster.Spec.Proxy.PGBouncer.Autoscaling.ScaleDownCooldown = cooldown
+		cluster.Status.Proxy.PGBouncer.Autoscaling.LastObservedTime =
+			initialize.Pointer(metav1.NewTime(time.Now().Add(-time.Minute)))
+
+		replicas = reconciler.autoscalePGBouncer(ctx, cluster, secret)
+		assert.Equal(t, initialize.FromPointer(replicas), int32(1))
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Assert(t, cmp.Contains(recorder.Events[0].Note, "Scaled PgBouncer from 4 to 1 pods"))
+	})
+
+	t.Run("Interval", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Status.Proxy.PGBouncer.Autoscaling = &v1beta1.PGBouncerAutoscalingStatus{
+			DesiredReplicas:  3,
+			LastObservedTime: initialize.Pointer(metav1.NewTime(time.Now().Add(-10 * time.Second))),
+		}
+		reconciler, recorder := reconciler(t, 100, 0)
+		reconciler.PodExec = func(
+			context.Context, string, string, string, io.Reader, io.Writer, io.Writer, ...string,
+		) error {
+			t.Fatal("expected no exec before the interval")
+			return nil
+		}
+
+		// The number of pods does not change until the interval passes.
+		replicas := reconciler.autoscalePGBouncer(ctx, cluster, secret)
+		assert.Equal(t, initialize.FromPointer(replicas), int32(3))
+		assert.Equal(t, len(recorder.Events), 0)
+
+		// Limits apply regardless.
+		cluster.Spec.Proxy.PGBouncer.Autoscaling.MaxReplicas = 2
+		replicas = reconciler.autoscalePGBouncer(ctx, cluster, secret)
+		assert.Equal(t, initialize.FromPointer(replicas), int32(2))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		reconciler, recorder := reconciler(t, 0, 0)
+		reconciler.PodExec = func(
+			_ context.Context, _, _, _ string, _ io.Reader, _, stderr io.Writer, _ ...string,
+		) error {
+			_, _ = stderr.Write([]byte("connection refused"))
+			return errors.New("exit status 2")
+		}
+
+		// The number of pods does not change.
+		replicas := reconciler.autoscalePGBouncer(ctx, cluster, secret)
+		assert.Equal(t, initialize.FromPointer(replicas), int32(2))
+
+		status := cluster.Status.Proxy.PGBouncer.Autoscaling
+		assert.Assert(t, status.LastObservedTime == nil)
+		assert.Assert(t, cmp.Contains(status.Message, "Unable to count client connections"))
+		assert.Assert(t, cmp.Contains(status.Message, "connection refused"))
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+}
+
+func TestReconcilePGBouncerSettingsValid(t *testing.T) {
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	reconciler := &Reconciler{Recorder: recorder}
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Name = "hippo"
+	require.UnmarshalInto(t, &cluster.Spec, `{
+		port: 5432,
+		users: [
+			{ name: first, databases: [graph], pgBouncer: {
+				databases: [{ name: missing }],
+			} },
+		],
+	}`)
+
+	// Invalid settings set the condition and record an event.
+	reconciler.reconcilePGBouncerSettingsValid(cluster)
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionPGBouncerSettingsValid)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Assert(t, cmp.Contains(condition.Message, `database "missing"`))
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Equal(t, recorder.Events[0].Reason, "InvalidPGBouncerSettings")
+
+	// The same problems record no more events.
+	reconciler.reconcilePGBouncerSettingsValid(cluster)
+	assert.Equal(t, len(recorder.Events), 1)
+
+	// Different problems record another event.
+	cluster.Spec.Users[0].PGBouncer.Databases[0].Name = "other"
+	reconciler.reconcilePGBouncerSettingsValid(cluster)
+	assert.Equal(t, len(recorder.Events), 2)
+	assert.Assert(t, cmp.Contains(recorder.Events[1].Note, `database "other"`))
+
+	// Valid settings remove the condition.
+	cluster.Spec.Users[0].PGBouncer.Databases[0].Name = "graph"
+	reconciler.reconcilePGBouncerSettingsValid(cluster)
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions, ConditionPGBouncerSettingsValid) == nil)
+	assert.Equal(t, len(recorder.Events), 2)
+}
+
+func TestReconcilePGBouncerDeploymentStatus(t *testing.T) {
+	ctx := context.Background()
+	reconciler := &Reconciler{
+		Owner:    client.FieldOwner(t.Name()),
+		Recorder: events.NewRecorder(t, runtime.Scheme),
+	}
+	reconciler.Client = fake.NewClientBuilder().WithScheme(runtime.Scheme).Build()
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace = "ns1"
+	cluster.Name = "hippo"
+	cluster.UID = "some-uid"
+	cluster.Spec.Proxy = &v1beta1.PostgresProxySpec{
+		PGBouncer: &v1beta1.PGBouncerPodSpec{Image: "pgbouncer"},
+	}
+	cluster.Default()
+
+	configmap := &corev1.ConfigMap{}
+	configmap.Name = "some-cm"
+	secret := &corev1.Secret{}
+	secret.Name = "some-secret"
+
+	// The scale subresource finds PgBouncer pods using this selector.
+	assert.NilError(t, reconciler.reconcilePGBouncerDeployment(
+		ctx, cluster, &corev1.SecretProjection{}, configmap, secret))
+	assert.Equal(t, cluster.Status.Proxy.PGBouncer.Selector,
+		"postgres-operator.crunchydata.com/cluster=hippo,"+
+			"postgres-operator.crunchydata.com/role=pgbouncer")
+
+	// The selector is removed with PgBouncer.
+	cluster.Spec.Proxy = nil
+	assert.NilError(t, reconciler.reconcilePGBouncerDeployment(
+		ctx, cluster, nil, nil, nil))
+	assert.Equal(t, cluster.Status.Proxy.PGBouncer.Selector, "")
+}
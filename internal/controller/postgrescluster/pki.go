@@ -6,12 +6,16 @@ package postgrescluster
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crunchydata/postgres-operator/internal/config"
+	"github.com/crunchydata/postgres-operator/internal/logging"
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
@@ -31,31 +35,89 @@ const (
 // in the relevant secret, has been created and is not 'bad' due
 // to being expired, formatted incorrectly, etc.
 // If it is bad for some reason, a new root certificate is
-// generated for use.
+// generated for use. It also advances a rotation of the root certificate
+// that is due or requested through an annotation on the secret. The returned
+// authority follows the certificate policy of cluster.
 func (r *Reconciler) reconcileRootCertificate(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
 ) (
 	*pki.RootCertificateAuthority, error,
 ) {
 	const keyCertificate, keyPrivateKey = "root.crt", "root.key"
+	const keyNextCertificate, keyNextPrivateKey = "root-next.crt", "root-next.key"
+	const keyPreviousCertificate = "root-previous.crt"
+
+	log := logging.FromContext(ctx)
+
+	rootPolicy, policyErr := config.RootCertificatePolicy()
+	leafPolicy, _ := config.CertificatePolicy(cluster)
+	if policyErr != nil {
+		log.Error(policyErr, "using default certificate settings")
+	}
 
 	existing := &corev1.Secret{}
 	existing.Namespace, existing.Name = cluster.Namespace, naming.RootCertSecret
 	err := errors.WithStack(client.IgnoreNotFound(
 		r.Client.Get(ctx, client.ObjectKeyFromObject(existing), existing)))
 
-	root := &pki.RootCertificateAuthority{}
+	rotation := pki.RootRotation{Current: &pki.RootCertificateAuthority{}}
+	requested := existing.Annotations[naming.RotateRootCertificate]
+	handled := existing.Annotations[naming.RootCertificateRotated]
 
 	if err == nil {
-		// Unmarshal and validate the stored root. These first errors can
-		// be ignored because they result in an invalid root which is then
-		// correctly regenerated.
-		_ = root.Certificate.UnmarshalText(existing.Data[keyCertificate])
-		_ = root.PrivateKey.UnmarshalText(existing.Data[keyPrivateKey])
+		// Unmarshal the stored roots. These first errors can be ignored
+		// because they result in invalid roots which are then correctly
+		// regenerated or discarded.
+		_ = rotation.Current.Certificate.UnmarshalText(existing.Data[keyCertificate])
+		_ = rotation.Current.PrivateKey.UnmarshalText(existing.Data[keyPrivateKey])
+
+		if len(existing.Data[keyNextCertificate]) > 0 {
+			rotation.Next = &pki.RootCertificateAuthority{}
+			_ = rotation.Next.Certificate.UnmarshalText(existing.Data[keyNextCertificate])
+			_ = rotation.Next.PrivateKey.UnmarshalText(existing.Data[keyNextPrivateKey])
+		}
+		if len(existing.Data[keyPreviousCertificate]) > 0 {
+			rotation.Previous = &pki.Certificate{}
+			if rotation.Previous.UnmarshalText(existing.Data[keyPreviousCertificate]) != nil {
+				rotation.Previous = nil
+			}
+		}
+
+		// Ignore a stored time that cannot be parsed; the phase restarts.
+		rotation.PhaseStart, _ = time.Parse(time.RFC3339,
+			existing.Annotations[naming.RootCertificatePhaseStart])
 
-		if !pki.RootIsValid(root) {
-			root, err = pki.NewRootCertificateAuthority()
-			err = errors.WithStack(err)
+		// The root is shared by clusters in the namespace, and some of their
+		// leaf certificates may not be reissued yet. Keep trusting the
+		// previous root until none are signed by it.
+		if rotation.Previous != nil {
+			rotation.PreviousInUse, err = r.rootCertificateInUse(ctx,
+				cluster.Namespace, *rotation.Previous)
+		}
+	}
+
+	if err == nil {
+		phase := rotation.Phase()
+
+		_, err = rotation.Advance(rootPolicy, requested != "" && requested != handled)
+		err = errors.WithStack(err)
+
+		// A rotation that started or is in progress satisfies a request.
+		if requested != "" && rotation.Phase() != "" {
+			handled = requested
+		}
+		if err == nil && phase != rotation.Phase() {
+			switch rotation.Phase() {
+			case pki.RotationPublish:
+				r.Recorder.Event(cluster, corev1.EventTypeNormal, "RootCertificateRotation",
+					"Started rotating the root certificate; publishing the next root alongside the current one")
+			case pki.RotationReissue:
+				r.Recorder.Event(cluster, corev1.EventTypeNormal, "RootCertificateRotation",
+					"Reissuing certificates with the next root; the previous root is still trusted")
+			default:
+				r.Recorder.Event(cluster, corev1.EventTypeNormal, "RootCertificateRotation",
+					"Finished rotating the root certificate; the previous root is no longer trusted")
+			}
 		}
 	}
 
@@ -65,6 +127,11 @@ func (r *Reconciler) reconcileRootCertificate(
 	intent.Data = make(map[string][]byte)
 	intent.OwnerReferences = existing.OwnerReferences
 
+	// Other clusters in the namespace reconcile this secret, too. Apply
+	// changes only to the version that was read so that two reconciles do
+	// not advance a rotation differently. A conflict is retried.
+	intent.ResourceVersion = existing.ResourceVersion
+
 	// A root secret is scoped to the namespace where postgrescluster(s)
 	// are deployed. For operator deployments with postgresclusters in more than
 	// one namespace, there will be one root per namespace.
@@ -78,26 +145,92 @@ func (r *Reconciler) reconcileRootCertificate(
 		err = errors.WithStack(r.setOwnerReference(cluster, intent))
 	}
 	if err == nil {
-		intent.Data[keyCertificate], err = root.Certificate.MarshalText()
+		intent.Data[keyCertificate], err = rotation.Current.Certificate.MarshalText()
 		err = errors.WithStack(err)
 	}
 	if err == nil {
-		intent.Data[keyPrivateKey], err = root.PrivateKey.MarshalText()
+		intent.Data[keyPrivateKey], err = rotation.Current.PrivateKey.MarshalText()
+		err = errors.WithStack(err)
+	}
+	if err == nil && rotation.Next != nil {
+		intent.Data[keyNextCertificate], err = rotation.Next.Certificate.MarshalText()
+		err = errors.WithStack(err)
+	}
+	if err == nil && rotation.Next != nil {
+		intent.Data[keyNextPrivateKey], err = rotation.Next.PrivateKey.MarshalText()
+		err = errors.WithStack(err)
+	}
+	if err == nil && rotation.Previous != nil {
+		intent.Data[keyPreviousCertificate], err = rotation.Previous.MarshalText()
 		err = errors.WithStack(err)
 	}
+
+	// Record the progress of a rotation. Fields that are not applied are
+	// removed from the secret.
+	if handled != "" {
+		intent.Annotations = map[string]string{naming.RootCertificateRotated: handled}
+	}
+	if !rotation.PhaseStart.IsZero() {
+		if intent.Annotations == nil {
+			intent.Annotations = make(map[string]string)
+		}
+		intent.Annotations[naming.RootCertificatePhaseStart] =
+			rotation.PhaseStart.UTC().Format(time.RFC3339)
+	}
+
 	if err == nil {
 		err = errors.WithStack(r.apply(ctx, intent))
 	}
 
+	root := rotation.Root()
+	root.Policy = leafPolicy
+
 	return root, err
 }
 
+// +kubebuilder:rbac:groups="",resources="secrets",verbs={list}
+
+// rootCertificateInUse reports whether any certificate in the Secrets of
+// PostgresClusters in namespace is signed by root.
+func (r *Reconciler) rootCertificateInUse(
+	ctx context.Context, namespace string, root pki.Certificate,
+) (bool, error) {
+	secrets := &corev1.SecretList{}
+	err := errors.WithStack(r.Client.List(ctx, secrets,
+		client.InNamespace(namespace), client.HasLabels{naming.LabelCluster}))
+
+	for i := range secrets.Items {
+		for key, value := range secrets.Items[i].Data {
+			var bundle pki.CertificateBundle
+			if !strings.HasSuffix(key, ".crt") || bundle.UnmarshalText(value) != nil {
+				continue
+			}
+			for _, certificate := range bundle {
+				if certificate.SignedBy(root) {
+					return true, err
+				}
+			}
+		}
+	}
+	return false, err
+}
+
+// rootCertificateRequeue returns how long to wait before advancing a rotation
+// of root, if any.
+func rootCertificateRequeue(root *pki.RootCertificateAuthority) time.Duration {
+	if root != nil && len(root.Trusted) > 0 {
+		return 5 * time.Minute
+	}
+	return 0
+}
+
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={get}
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={create,patch}
 
 // reconcileClusterCertificate first checks if a custom certificate
 // secret is configured. If so, that secret projection is returned.
-// Otherwise, a secret containing a generated leaf certificate, stored in
+// Next, it checks for a cert-manager issuer and returns the projection of
+// the issued secret. Otherwise, a secret containing a generated leaf certificate, stored in
 // the relevant secret, has been created and is not 'bad' due to being
 // expired, formatted incorrectly, etc. If it is bad for any reason, a new
 // leaf certificate is generated using the current root certificate.
@@ -107,7 +240,7 @@ func (r *Reconciler) reconcileRootCertificate(
 func (r *Reconciler) reconcileClusterCertificate(
 	ctx context.Context, root *pki.RootCertificateAuthority,
 	cluster *v1beta1.PostgresCluster, primaryService *corev1.Service,
-	replicaService *corev1.Service,
+	replicaServices ...*corev1.Service,
 ) (
 	*corev1.SecretProjection, error,
 ) {
@@ -116,6 +249,11 @@ func (r *Reconciler) reconcileClusterCertificate(
 		return cluster.Spec.CustomTLSSecret, nil
 	}
 
+	// if cert-manager issues the certificate, return its secret
+	if certificateIssuer(cluster) != nil {
+		return issuedSecretProjection(naming.PostgresIssuedCertificate(cluster)), nil
+	}
+
 	const keyCertificate, keyPrivateKey, rootCA = "tls.crt", "tls.key", "ca.crt"
 
 	existing := &corev1.Secret{ObjectMeta: naming.PostgresTLSSecret(cluster)}
@@ -123,7 +261,10 @@ func (r *Reconciler) reconcileClusterCertificate(
 		r.Client.Get(ctx, client.ObjectKeyFromObject(existing), existing)))
 
 	leaf := &pki.LeafCertificate{}
-	dnsNames := append(naming.ServiceDNSNames(ctx, primaryService), naming.ServiceDNSNames(ctx, replicaService)...)
+	dnsNames := naming.ServiceDNSNames(ctx, primaryService)
+	for _, service := range replicaServices {
+		dnsNames = append(dnsNames, naming.ServiceDNSNames(ctx, service)...)
+	}
 	dnsFQDN := dnsNames[0]
 
 	if err == nil {
@@ -163,7 +304,7 @@ func (r *Reconciler) reconcileClusterCertificate(
 		err = errors.WithStack(err)
 	}
 	if err == nil {
-		intent.Data[rootCA], err = root.Certificate.MarshalText()
+		intent.Data[rootCA], err = root.Bundle().MarshalText()
 		err = errors.WithStack(err)
 	}
 
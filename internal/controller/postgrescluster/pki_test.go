@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 	appsv1 "k8s.io/api/apps/v1"
@@ -18,9 +19,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/pki"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
@@ -160,6 +164,44 @@ func TestReconcileCerts(t *testing.T) {
 			assert.DeepEqual(t, *fromSecret, returnedRoot.Certificate)
 		})
 
+		t.Run("root certificate rotation", func(t *testing.T) {
+			r := *r
+			recorder := events.NewRecorder(t, runtime.Scheme)
+			r.Recorder = recorder
+
+			before, err := r.reconcileRootCertificate(ctx, cluster1)
+			assert.NilError(t, err)
+			assert.Equal(t, len(before.Trusted), 0)
+
+			// request a rotation through an annotation on the secret
+			assert.NilError(t, tClient.Get(ctx, client.ObjectKeyFromObject(rootSecret), rootSecret))
+			rootSecret.Annotations = map[string]string{naming.RotateRootCertificate: "one"}
+			assert.NilError(t, tClient.Update(ctx, rootSecret))
+
+			during, err := r.reconcileRootCertificate(ctx, cluster1)
+			assert.NilError(t, err)
+
+			// the current root continues to sign while the next is published
+			assert.DeepEqual(t, during.Certificate, before.Certificate)
+			assert.Equal(t, len(during.Trusted), 1)
+			assert.Equal(t, len(during.Bundle()), 2)
+			assert.Equal(t, rootCertificateRequeue(during), 5*time.Minute)
+
+			assert.NilError(t, tClient.Get(ctx, client.ObjectKeyFromObject(rootSecret), rootSecret))
+			assert.Assert(t, len(rootSecret.Data["root-next.crt"]) > 0)
+			assert.Assert(t, len(rootSecret.Data["root-next.key"]) > 0)
+			assert.Equal(t, rootSecret.Annotations[naming.RootCertificateRotated], "one")
+			assert.Assert(t, rootSecret.Annotations[naming.RootCertificatePhaseStart] != "")
+
+			assert.Equal(t, len(recorder.Events), 1)
+			assert.Equal(t, recorder.Events[0].Reason, "RootCertificateRotation")
+
+			// the same request does not start another rotation
+			again, err := r.reconcileRootCertificate(ctx, cluster1)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, again.Trusted, during.Trusted)
+			assert.Equal(t, len(recorder.Events), 1)
+		})
 	})
 
 	t.Run("check leaf certificate reconciliation", func(t *testing.T) {
@@ -373,6 +415,62 @@ func TestReconcileCerts(t *testing.T) {
 	})
 }
 
+func TestRootCertificateInUse(t *testing.T) {
+	ctx := context.Background()
+
+	previous, err := pki.NewRootCertificateAuthority()
+	assert.NilError(t, err)
+	current, err := pki.NewRootCertificateAuthority()
+	assert.NilError(t, err)
+
+	secret := func(name string, root *pki.RootCertificateAuthority, labels map[string]string) *corev1.Secret {
+		leaf, err := root.GenerateLeafCertificate(name, nil)
+		assert.NilError(t, err)
+
+		s := &corev1.Secret{Data: map[string][]byte{}}
+		s.Namespace, s.Name, s.Labels = "ns1", name, labels
+		s.Data["ca.crt"], err = pki.CertificateBundle{
+			current.Certificate, previous.Certificate,
+		}.MarshalText()
+		assert.NilError(t, err)
+		s.Data["some.crt"], err = leaf.Certificate.MarshalText()
+		assert.NilError(t, err)
+		return s
+	}
+
+	cluster := map[string]string{naming.LabelCluster: "hippo"}
+	reconciler := &Reconciler{}
+
+	t.Run("Reissued", func(t *testing.T) {
+		reconciler.Client = fake.NewClientBuilder().WithObjects(
+			secret("reissued", current, cluster),
+			secret("unrelated", previous, nil),
+		).Build()
+
+		// The previous root in "ca.crt" and Secrets without the cluster label
+		// do not count.
+		used, err := reconciler.rootCertificateInUse(ctx, "ns1", previous.Certificate)
+		assert.NilError(t, err)
+		assert.Assert(t, !used)
+	})
+
+	t.Run("NotReissued", func(t *testing.T) {
+		reconciler.Client = fake.NewClientBuilder().WithObjects(
+			secret("reissued", current, cluster),
+			secret("waiting", previous, cluster),
+		).Build()
+
+		used, err := reconciler.rootCertificateInUse(ctx, "ns1", previous.Certificate)
+		assert.NilError(t, err)
+		assert.Assert(t, used)
+
+		// Another namespace does not count.
+		used, err = reconciler.rootCertificateInUse(ctx, "ns2", previous.Certificate)
+		assert.NilError(t, err)
+		assert.Assert(t, !used)
+	})
+}
+
 // getCertFromSecret returns a parsed certificate from the named secret
 func getCertFromSecret(
 	ctx context.Context, tClient client.Client, name, namespace, dataKey string,
@@ -13,12 +13,16 @@ import (
 	"net"
 	"net/url"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
+	volumesnapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v8/apis/volumesnapshot/v1"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -35,6 +39,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/pgbackrest"
 	"github.com/crunchydata/postgres-operator/internal/pgbouncer"
 	"github.com/crunchydata/postgres-operator/internal/pgmonitor"
+	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/internal/postgis"
 	"github.com/crunchydata/postgres-operator/internal/postgres"
 	pgpassword "github.com/crunchydata/postgres-operator/internal/postgres/password"
@@ -91,6 +96,18 @@ func (r *Reconciler) generatePostgresHBAs(
 	pgmonitor.PostgreSQLHBAs(ctx, cluster, &builtin)
 	pgbouncer.PostgreSQL(cluster, &builtin)
 
+	// Users with client certificates must use them on TLS connections.
+	// - https://www.postgresql.org/docs/current/auth-cert.html
+	for _, user := range cluster.Spec.Users {
+		if initialize.FromPointer(user.ClientCertificate) {
+			hba := postgres.NewHBA().TLS().Users(user.Name).Method("cert")
+			if len(user.Databases) > 0 {
+				hba.Databases(user.Databases[0], user.Databases[1:]...)
+			}
+			builtin.Mandatory = append(builtin.Mandatory, hba)
+		}
+	}
+
 	// Postgres processes HBA rules in order. Start with mandatory rules
 	// so connections are matched against them first.
 	result := new(postgres.OrderedHBAs)
@@ -120,6 +137,77 @@ func (r *Reconciler) generatePostgresHBAs(
 	return result
 }
 
+// generatePostgresIdentMaps produces the user name maps for cluster that
+// incorporates, from highest to lowest precedence:
+//  1. maps in cluster.spec.authentication
+//  2. maps in cluster.spec.patroni.dynamicConfiguration
+func (*Reconciler) generatePostgresIdentMaps(
+	cluster *v1beta1.PostgresCluster,
+) *postgres.OrderedIdentMaps {
+	result := new(postgres.OrderedIdentMaps)
+
+	// Postgres uses the first entry of a map that matches the system user.
+	if authn := cluster.Spec.Authentication; authn != nil {
+		for _, in := range authn.IdentMaps {
+			result.Append(postgres.NewIdentMap(in.Map, in.SystemUser, in.DatabaseUser))
+		}
+	}
+
+	// Append any maps specified in the Patroni section.
+	result.AppendUnstructured(patroni.PostgresIdentMaps(cluster.Spec.Patroni)...)
+
+	return result
+}
+
+const (
+	// ConditionIdentMapsValid is the type used in a condition to indicate
+	// whether or not HBA rules refer to user name maps that are defined
+	ConditionIdentMapsValid = "IdentMapsValid"
+
+	// EventUndefinedIdentMaps is the event reason utilized when HBA rules
+	// refer to user name maps that are not defined
+	EventUndefinedIdentMaps = "UndefinedIdentMaps"
+)
+
+// reconcileIdentMapsValid sets the IdentMapsValid condition of cluster when
+// hbas refer to user name maps that are not in idents. PostgreSQL refuses
+// connections that match those rules.
+func (r *Reconciler) reconcileIdentMapsValid(
+	cluster *v1beta1.PostgresCluster,
+	hbas *postgres.OrderedHBAs, idents *postgres.OrderedIdentMaps,
+) {
+	defined := sets.New(idents.Names()...)
+
+	var undefined []string
+	for _, name := range hbas.IdentMapNames() {
+		if !defined.Has(name) {
+			undefined = append(undefined, name)
+		}
+	}
+
+	if len(undefined) == 0 {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionIdentMapsValid)
+		return
+	}
+
+	previous := initialize.FromPointer(
+		meta.FindStatusCondition(cluster.Status.Conditions, ConditionIdentMapsValid))
+	condition := metav1.Condition{
+		ObservedGeneration: cluster.GetGeneration(),
+		Type:               ConditionIdentMapsValid,
+		Status:             metav1.ConditionFalse,
+		Reason:             "UndefinedMaps",
+		Message: "HBA rules refer to user name maps that are not defined: " +
+			strings.Join(undefined, ", "),
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+
+	if previous.Status != condition.Status || previous.Message != condition.Message {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, EventUndefinedIdentMaps, condition.Message)
+	}
+}
+
 // generatePostgresParameters produces the parameter set for cluster that
 // incorporates, from highest to lowest precedence:
 //  1. mandatory values determined by controllers
@@ -131,10 +219,11 @@ func (*Reconciler) generatePostgresParameters(
 ) *postgres.ParameterSet {
 	builtin := postgres.NewParameters()
 	collector.PostgreSQLParameters(ctx, cluster, &builtin)
-	pgaudit.PostgreSQLParameters(&builtin)
+	pgaudit.PostgreSQLParameters(cluster, &builtin)
 	pgbackrest.PostgreSQLParameters(cluster, &builtin, backupsSpecFound)
 	pgmonitor.PostgreSQLParameters(ctx, cluster, &builtin)
 	postgres.SetHugePages(cluster, &builtin)
+	postgres.SetAutotune(cluster, &builtin)
 
 	// Last write wins, so start with the recommended defaults.
 	result := cmp.Or(builtin.Default.DeepCopy(), postgres.NewParameterSet())
@@ -145,9 +234,13 @@ func (*Reconciler) generatePostgresParameters(
 	}
 
 	// Overwrite the above with any parameters specified in the Config section.
+	// Leave out values that are invalid; see [Reconciler.reconcileParametersValid].
 	if config := cluster.Spec.Config; config != nil {
+		invalid := validateConfigParameters(cluster)
 		for k, v := range config.Parameters {
-			result.Add(k, v.String())
+			if _, ok := invalid[k]; !ok {
+				result.Add(k, v.String())
+			}
 		}
 	}
 
@@ -182,14 +275,143 @@ func (*Reconciler) generatePostgresParameters(
 	return result
 }
 
+const (
+	// ConditionParametersValid is the type used in a condition to indicate
+	// whether or not spec.config.parameters are valid for the PostgreSQL version
+	ConditionParametersValid = "ParametersValid"
+
+	// EventInvalidParameters is the event reason utilized when some parameters
+	// in spec.config.parameters are invalid and not applied
+	EventInvalidParameters = "InvalidParameters"
+
+	// EventParametersRequireRestart is the event reason utilized when changes to
+	// spec.config.parameters take effect only after PostgreSQL restarts
+	EventParametersRequireRestart = "ParametersRequireRestart"
+)
+
+// validateConfigParameters checks cluster.spec.config.parameters against the
+// parameter catalog of the PostgreSQL version. It returns a message for each
+// parameter that is invalid or not in the catalog.
+func validateConfigParameters(cluster *v1beta1.PostgresCluster) map[string]string {
+	var invalid map[string]string
+
+	if cluster.Spec.Config == nil {
+		return invalid
+	}
+
+	version := cluster.Spec.PostgresVersion
+	for _, name := range sets.List(sets.KeySet(cluster.Spec.Config.Parameters)) {
+		value := cluster.Spec.Config.Parameters[name]
+		err := postgres.ValidateParameter(version, name, value.String())
+
+		switch {
+		case errors.Is(err, postgres.ErrUnrecognizedParameter):
+			initialize.Map(&invalid)
+			invalid[name] = fmt.Sprintf("%s is not a parameter of PostgreSQL %d", name, version)
+		case err != nil:
+			initialize.Map(&invalid)
+			invalid[name] = err.Error()
+		}
+	}
+
+	return invalid
+}
+
+// reconcileParametersValid sets the ParametersValid condition of cluster and
+// records which parameters take effect only after PostgreSQL restarts.
+func (r *Reconciler) reconcileParametersValid(
+	cluster *v1beta1.PostgresCluster, instances *observedInstances,
+) {
+	invalid := validateConfigParameters(cluster)
+	version := cluster.Spec.PostgresVersion
+
+	// Compare the parameters that need a restart to their previous values.
+	var postmaster map[string]string
+	if config := cluster.Spec.Config; config != nil {
+		for k, v := range config.Parameters {
+			if _, bad := invalid[k]; !bad && postgres.ParameterRequiresRestart(version, k) {
+				initialize.Map(&postmaster)
+				postmaster[k] = v.String()
+			}
+		}
+	}
+
+	var changed []string
+	if patroni.ClusterBootstrapped(cluster) {
+		for k := range naming.Merge(postmaster, cluster.Status.PostmasterParameters) {
+			if postmaster[k] != cluster.Status.PostmasterParameters[k] {
+				changed = append(changed, k)
+			}
+		}
+	}
+
+	// Changes are pending until no instance needs to restart.
+	restarting := false
+	if instances != nil {
+		for _, instance := range instances.forCluster {
+			for _, pod := range instance.Pods {
+				restarting = restarting || patroni.PodRequiresRestart(pod)
+			}
+		}
+	}
+	pending := sets.New(changed...)
+	if len(changed) > 0 || restarting {
+		pending.Insert(cluster.Status.PendingRestartParameters...)
+	}
+
+	cluster.Status.PostmasterParameters = postmaster
+	cluster.Status.PendingRestartParameters = nil
+	if pending.Len() > 0 {
+		cluster.Status.PendingRestartParameters = sets.List(pending)
+	}
+
+	previous := initialize.FromPointer(
+		meta.FindStatusCondition(cluster.Status.Conditions, ConditionParametersValid))
+	condition := metav1.Condition{
+		ObservedGeneration: cluster.GetGeneration(),
+		Type:               ConditionParametersValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ParametersValid",
+		Message:            "Parameters are valid for PostgreSQL " + fmt.Sprint(version),
+	}
+
+	if pending.Len() > 0 {
+		condition.Reason = "RestartRequired"
+		condition.Message = "Changes to these parameters take effect after PostgreSQL restarts: " +
+			strings.Join(sets.List(pending), ", ")
+	}
+	if len(invalid) > 0 {
+		var messages []string
+		for _, name := range sets.List(sets.KeySet(invalid)) {
+			messages = append(messages, invalid[name])
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InvalidParameters"
+		condition.Message = "These parameters are not applied: " + strings.Join(messages, "; ")
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+
+	if condition.Status == metav1.ConditionFalse &&
+		(previous.Status != condition.Status || previous.Message != condition.Message) {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, EventInvalidParameters, condition.Message)
+	}
+	if len(changed) > 0 {
+		slices.Sort(changed)
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, EventParametersRequireRestart,
+			"Changes to these parameters take effect after PostgreSQL restarts: %s",
+			strings.Join(changed, ", "))
+	}
+}
+
 // generatePostgresUserSecret returns a Secret containing a password and
 // connection details for the first database in spec. When existing is nil or
 // lacks a password or verifier, a new password and verifier are generated.
 func (r *Reconciler) generatePostgresUserSecret(
 	cluster *v1beta1.PostgresCluster, spec *v1beta1.PostgresUserSpec, existing *corev1.Secret,
 ) (*corev1.Secret, error) {
-	username := spec.Name
-	intent := &corev1.Secret{ObjectMeta: naming.PostgresUserSecret(cluster, username)}
+	username := postgresUserRole(spec, existing)
+	intent := &corev1.Secret{ObjectMeta: naming.PostgresUserSecret(cluster, spec.Name)}
 	intent.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
 	initialize.Map(&intent.Data)
 
@@ -305,6 +527,41 @@ func (r *Reconciler) generatePostgresUserSecret(
 		}
 	}
 
+	// When PgBouncer has read-only pools, include values for connecting to
+	// replicas through it.
+	if cluster.Spec.Proxy != nil && cluster.Spec.Proxy.PGBouncer != nil &&
+		cluster.Spec.Proxy.PGBouncer.ReadOnly != nil {
+		pgBouncer := naming.ClusterPGBouncerReadOnly(cluster)
+		hostname := pgBouncer.Name + "." + pgBouncer.Namespace + ".svc"
+		port := fmt.Sprint(*cluster.Spec.Proxy.PGBouncer.ReadOnly.Port)
+
+		intent.Data["pgbouncer-ro-host"] = []byte(hostname)
+		intent.Data["pgbouncer-ro-port"] = []byte(port)
+
+		if len(spec.Databases) > 0 {
+			database := spec.Databases[0]
+
+			intent.Data["pgbouncer-ro-uri"] = []byte((&url.URL{
+				Scheme: "postgresql",
+				User:   url.UserPassword(username, string(intent.Data["password"])),
+				Host:   net.JoinHostPort(hostname, port),
+				Path:   database,
+			}).String())
+
+			// Disable prepared statements the same as above.
+			query := url.Values{}
+			query.Set("user", username)
+			query.Set("password", string(intent.Data["password"]))
+			query.Set("prepareThreshold", "0")
+			intent.Data["pgbouncer-ro-jdbc-uri"] = []byte((&url.URL{
+				Scheme:   "jdbc:postgresql",
+				Host:     net.JoinHostPort(hostname, port),
+				Path:     database,
+				RawQuery: query.Encode(),
+			}).String())
+		}
+	}
+
 	intent.Annotations = cluster.Spec.Metadata.GetAnnotationsOrNil()
 	intent.Labels = naming.Merge(
 		cluster.Spec.Metadata.GetLabelsOrNil(),
@@ -319,6 +576,47 @@ func (r *Reconciler) generatePostgresUserSecret(
 	return intent, err
 }
 
+// generatePostgresUserCertificate adds a client certificate for the user in
+// spec to intent. The certificate in existing is kept until it is invalid or
+// due to be renewed.
+func generatePostgresUserCertificate(
+	root *pki.RootCertificateAuthority, spec *v1beta1.PostgresUserSpec,
+	existing, intent *corev1.Secret,
+) error {
+	const keyCertificate, keyPrivateKey, rootCA = "tls.crt", "tls.key", "ca.crt"
+
+	// The certificate authenticates the PostgreSQL role with the same name.
+	// - https://www.postgresql.org/docs/current/auth-cert.html
+	leaf := &pki.LeafCertificate{}
+	commonName := spec.Name
+
+	if existing != nil {
+		// Unmarshal and validate the stored leaf. These first errors can
+		// be ignored because they result in an invalid leaf which is then
+		// correctly regenerated.
+		_ = leaf.Certificate.UnmarshalText(existing.Data[keyCertificate])
+		_ = leaf.PrivateKey.UnmarshalText(existing.Data[keyPrivateKey])
+	}
+
+	// A client certificate needs no subject alternative names.
+	leaf, err := root.RegenerateLeafWhenNecessary(leaf, commonName, nil)
+	err = errors.WithStack(err)
+
+	if err == nil {
+		intent.Data[keyCertificate], err = leaf.Certificate.MarshalText()
+		err = errors.WithStack(err)
+	}
+	if err == nil {
+		intent.Data[keyPrivateKey], err = leaf.PrivateKey.MarshalText()
+		err = errors.WithStack(err)
+	}
+	if err == nil {
+		intent.Data[rootCA], err = root.Bundle().MarshalText()
+		err = errors.WithStack(err)
+	}
+	return err
+}
+
 // reconcilePostgresDatabases creates databases inside of PostgreSQL.
 func (r *Reconciler) reconcilePostgresDatabases(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
@@ -431,10 +729,11 @@ func (r *Reconciler) reconcilePostgresDatabases(
 // passwords in PostgreSQL.
 func (r *Reconciler) reconcilePostgresUsers(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
+	root *pki.RootCertificateAuthority,
 ) error {
 	r.validatePostgresUsers(cluster)
 
-	users, secrets, err := r.reconcilePostgresUserSecrets(ctx, cluster)
+	users, secrets, err := r.reconcilePostgresUserSecrets(ctx, cluster, root)
 	if err == nil {
 		err = r.reconcilePostgresUsersInPostgreSQL(ctx, cluster, instances, users, secrets)
 	}
@@ -485,12 +784,98 @@ func (r *Reconciler) validatePostgresUsers(cluster *v1beta1.PostgresCluster) {
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={list}
 // +kubebuilder:rbac:groups="",resources="secrets",verbs={create,delete,patch}
 
+// postgresUserRole returns the PostgreSQL role of the password in the user
+// Secret of spec. In DualPassword mode, this is the role in existing, if any.
+func postgresUserRole(spec *v1beta1.PostgresUserSpec, existing *corev1.Secret) string {
+	if postgres.DualPasswordRotation(spec) && existing != nil &&
+		string(existing.Data["user"]) == postgres.NextUserName(spec.Name) {
+		return postgres.NextUserName(spec.Name)
+	}
+	return spec.Name
+}
+
+// postgresUserPasswordStatus returns the password rotation status of the user
+// called name in cluster, if any.
+func postgresUserPasswordStatus(
+	cluster *v1beta1.PostgresCluster, name string,
+) *v1beta1.PostgresUserPasswordStatus {
+	for i := range cluster.Status.UserPasswords {
+		if cluster.Status.UserPasswords[i].Name == name {
+			return &cluster.Status.UserPasswords[i]
+		}
+	}
+	return nil
+}
+
+// passwordGracePeriod returns how long the previous password of spec remains
+// valid in DualPassword mode.
+func passwordGracePeriod(spec *v1beta1.PostgresUserSpec) time.Duration {
+	if spec.PasswordRotation != nil && spec.PasswordRotation.GracePeriod != nil {
+		return spec.PasswordRotation.GracePeriod.AsDuration().Duration
+	}
+	return time.Hour
+}
+
+// rotatePostgresUserSecret returns a copy of existing without a password when
+// it is time to replace the password of spec. In DualPassword mode, the copy
+// refers to the other role of spec. Otherwise, it returns existing.
+func rotatePostgresUserSecret(
+	cluster *v1beta1.PostgresCluster, spec *v1beta1.PostgresUserSpec,
+	existing *corev1.Secret, now time.Time,
+) *corev1.Secret {
+	status := postgresUserPasswordStatus(cluster, spec.Name)
+	if spec.PasswordRotation == nil || status == nil || existing == nil ||
+		now.Before(status.LastRotationTime.Add(spec.PasswordRotation.Interval.AsDuration().Duration)) {
+		return existing
+	}
+
+	rotated := existing.DeepCopy()
+	delete(rotated.Data, "password")
+	delete(rotated.Data, "verifier")
+
+	if postgres.DualPasswordRotation(spec) {
+		if postgresUserRole(spec, existing) == spec.Name {
+			rotated.Data["user"] = []byte(postgres.NextUserName(spec.Name))
+		} else {
+			rotated.Data["user"] = []byte(spec.Name)
+		}
+	}
+	return rotated
+}
+
+// passwordRotationRequeue returns how long until a password of cluster should
+// be replaced or a previous password should stop working, if ever.
+func passwordRotationRequeue(cluster *v1beta1.PostgresCluster, now time.Time) time.Duration {
+	var requeue time.Duration
+	for i := range cluster.Spec.Users {
+		spec := &cluster.Spec.Users[i]
+		status := postgresUserPasswordStatus(cluster, spec.Name)
+		if spec.PasswordRotation == nil || status == nil {
+			continue
+		}
+
+		deadlines := []time.Time{
+			status.LastRotationTime.Add(spec.PasswordRotation.Interval.AsDuration().Duration),
+		}
+		if postgres.DualPasswordRotation(spec) {
+			deadlines = append(deadlines, status.LastRotationTime.Add(passwordGracePeriod(spec)))
+		}
+		for _, deadline := range deadlines {
+			if wait := deadline.Sub(now); wait > 0 && (requeue == 0 || wait < requeue) {
+				requeue = wait
+			}
+		}
+	}
+	return requeue
+}
+
 // reconcilePostgresUserSecrets writes Secrets for the PostgreSQL users
 // specified in cluster and deletes existing Secrets that are not specified.
 // It returns the user specifications it acted on (because defaults) and the
 // Secrets it wrote.
 func (r *Reconciler) reconcilePostgresUserSecrets(
 	ctx context.Context, cluster *v1beta1.PostgresCluster,
+	root *pki.RootCertificateAuthority,
 ) (
 	[]v1beta1.PostgresUserSpec, map[string]*corev1.Secret, error,
 ) {
@@ -602,7 +987,15 @@ func (r *Reconciler) reconcilePostgresUserSecrets(
 		}
 	}
 
+	// Forget the password rotation of users that no longer rotate.
+	cluster.Status.UserPasswords = slices.DeleteFunc(cluster.Status.UserPasswords,
+		func(status v1beta1.PostgresUserPasswordStatus) bool {
+			spec, specified := userSpecs[status.Name]
+			return !specified || spec.PasswordRotation == nil
+		})
+
 	// Reconcile each PostgreSQL user in the cluster spec.
+	now := time.Now()
 	for userName, user := range userSpecs {
 		secret := userSecrets[userName]
 
@@ -612,14 +1005,45 @@ func (r *Reconciler) reconcilePostgresUserSecrets(
 			secret = defaultSecret
 		}
 
+		// Replace the password when it is time.
+		rotated := rotatePostgresUserSecret(cluster, user, secret, now)
+
 		if err == nil {
-			userSecrets[userName], err = r.generatePostgresUserSecret(cluster, user, secret)
+			userSecrets[userName], err = r.generatePostgresUserSecret(cluster, user, rotated)
+		}
+		if err == nil && initialize.FromPointer(user.ClientCertificate) {
+			if certificateIssuer(cluster) != nil {
+				err = r.reconcilePostgresUserIssuedCertificate(ctx, cluster, user, userSecrets[userName])
+			} else {
+				err = generatePostgresUserCertificate(root, user, secret, userSecrets[userName])
+			}
 		}
 		if err == nil {
 			err = errors.WithStack(r.apply(ctx, userSecrets[userName]))
 		}
+
+		// Record the rotation after the new password is stored. The clock
+		// starts when rotation is enabled.
+		if err == nil && user.PasswordRotation != nil {
+			if status := postgresUserPasswordStatus(cluster, userName); status == nil {
+				cluster.Status.UserPasswords = append(cluster.Status.UserPasswords,
+					v1beta1.PostgresUserPasswordStatus{
+						Name: userName, LastRotationTime: metav1.NewTime(now),
+					})
+			} else if rotated != secret {
+				status.LastRotationTime = metav1.NewTime(now)
+				r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "PasswordRotated",
+					"Replaced the password of PostgreSQL user %q in Secret %q; restart clients to use it",
+					userName, userSecrets[userName].Name)
+			}
+		}
 	}
 
+	slices.SortFunc(cluster.Status.UserPasswords,
+		func(a, b v1beta1.PostgresUserPasswordStatus) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+
 	return specUsers, userSecrets, err
 }
 
@@ -662,8 +1086,30 @@ func (r *Reconciler) reconcilePostgresUsersInPostgreSQL(
 	// Calculate a hash of the SQL that should be executed in PostgreSQL.
 
 	verifiers := make(map[string]string, len(userSecrets))
-	for userName := range userSecrets {
-		verifiers[userName] = string(userSecrets[userName].Data["verifier"])
+	now := time.Now()
+	for i := range specUsers {
+		spec := &specUsers[i]
+		secret := userSecrets[spec.Name]
+		if secret == nil {
+			continue
+		}
+		if !postgres.DualPasswordRotation(spec) {
+			verifiers[spec.Name] = string(secret.Data["verifier"])
+			continue
+		}
+
+		// The previous role keeps its password until the grace period passes.
+		// After that, it has no password.
+		role, previous := postgresUserRole(spec, secret), spec.Name
+		if role == spec.Name {
+			previous = postgres.NextUserName(spec.Name)
+		}
+		verifiers[role] = string(secret.Data["verifier"])
+
+		if status := postgresUserPasswordStatus(cluster, spec.Name); status == nil ||
+			!now.Before(status.LastRotationTime.Add(passwordGracePeriod(spec))) {
+			verifiers[previous] = ""
+		}
 	}
 
 	write := func(ctx context.Context, exec postgres.Executor) error {
@@ -705,6 +1151,107 @@ func (r *Reconciler) reconcilePostgresUsersInPostgreSQL(
 	return err
 }
 
+const (
+	// ConditionPGAuditPoliciesApplied is the type used in a condition to
+	// indicate whether or not every pgAudit policy in spec.audit is applied
+	ConditionPGAuditPoliciesApplied = "PGAuditPoliciesApplied"
+
+	// EventPGAuditPolicyPending is the event reason utilized when some pgAudit
+	// policies refer to databases, roles, or tables that do not exist yet
+	EventPGAuditPolicyPending = "pgAuditPolicyPending"
+)
+
+// reconcilePostgresAudit applies the pgAudit policies of cluster to databases,
+// roles, and tables inside of PostgreSQL. It returns how long to wait before
+// applying policies of objects that do not exist yet, if any.
+func (r *Reconciler) reconcilePostgresAudit(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, instances *observedInstances,
+) (time.Duration, error) {
+	const container = naming.ContainerDatabase
+	var podExecutor postgres.Executor
+
+	// Nothing has been applied and nothing should be; return early.
+	if cluster.Spec.Audit == nil && cluster.Status.AuditRevision == "" {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionPGAuditPoliciesApplied)
+		return 0, nil
+	}
+
+	// Find the PostgreSQL instance that can execute SQL that writes system
+	// catalogs. When there is none, return early.
+	pod, _ := instances.writablePod(container)
+	if pod == nil {
+		return 0, nil
+	}
+
+	ctx = logging.NewContext(ctx, logging.FromContext(ctx).WithValues("pod", pod.Name))
+	podExecutor = func(
+		ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+	) error {
+		return r.PodExec(ctx, pod.Namespace, pod.Name, container, stdin, stdout, stderr, command...)
+	}
+
+	var missing []string
+	write := func(ctx context.Context, exec postgres.Executor) error {
+		var err error
+		missing, err = pgaudit.WritePoliciesInPostgreSQL(ctx, exec, cluster.Spec.Audit)
+		return err
+	}
+
+	// Calculate a hash of the SQL that should be executed in PostgreSQL.
+	revision, err := safeHash32(func(hasher io.Writer) error {
+		// Discard log messages about executing SQL.
+		return write(logging.NewContext(ctx, logging.Discard()), func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			_, err := fmt.Fprint(hasher, command)
+			if err == nil && stdin != nil {
+				_, err = io.Copy(hasher, stdin)
+			}
+			return err
+		})
+	})
+
+	if err == nil && revision == cluster.Status.AuditRevision {
+		// The necessary SQL has already been applied; there's nothing more to do.
+		return 0, nil
+	}
+
+	// Apply the necessary SQL and record its hash in cluster.Status. Include
+	// the hash in any log messages.
+
+	if err == nil {
+		log := logging.FromContext(ctx).WithValues("revision", revision)
+		err = errors.WithStack(write(logging.NewContext(ctx, log), podExecutor))
+	}
+
+	// Policies of databases, roles, and tables that do not exist yet are
+	// applied after they are created. Try again later.
+	if err == nil && len(missing) > 0 {
+		previous := initialize.FromPointer(
+			meta.FindStatusCondition(cluster.Status.Conditions, ConditionPGAuditPoliciesApplied))
+		condition := metav1.Condition{
+			ObservedGeneration: cluster.GetGeneration(),
+			Type:               ConditionPGAuditPoliciesApplied,
+			Status:             metav1.ConditionFalse,
+			Reason:             "PolicyPending",
+			Message:            "Unable to apply pgAudit policies to " + strings.Join(missing, ", "),
+		}
+
+		meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+
+		if previous.Status != condition.Status || previous.Message != condition.Message {
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, EventPGAuditPolicyPending, condition.Message)
+		}
+		return time.Minute, err
+	}
+	if err == nil {
+		cluster.Status.AuditRevision = revision
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionPGAuditPoliciesApplied)
+	}
+
+	return 0, err
+}
+
 // +kubebuilder:rbac:groups="",resources="persistentvolumeclaims",verbs={create,patch}
 
 // reconcilePostgresDataVolume writes the PersistentVolumeClaim for instance's
@@ -750,29 +1297,25 @@ func (r *Reconciler) reconcilePostgresDataVolume(
 
 	pvc.Spec = instanceSpec.DataVolumeClaimSpec.AsPersistentVolumeClaimSpec()
 
-	// If a source cluster was provided and VolumeSnapshots are turned on in the source cluster and
-	// there is a VolumeSnapshot available for the source cluster that is ReadyToUse, use it as the
-	// source for the PVC. If there is an error when retrieving VolumeSnapshots, or no ReadyToUse
-	// snapshots were found, create a warning event, but continue creating PVC in the usual fashion.
-	if sourceCluster != nil && sourceCluster.Spec.Backups.Snapshots != nil && feature.Enabled(ctx, feature.VolumeSnapshots) {
-		snapshots, err := r.getSnapshotsForCluster(ctx, sourceCluster)
-		if err == nil {
-			snapshot := getLatestReadySnapshot(snapshots)
-			if snapshot != nil {
-				r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "BootstrappingWithSnapshot",
-					"Snapshot found for %v; bootstrapping cluster with snapshot.", sourceCluster.Name)
-				pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
-					APIGroup: initialize.String("snapshot.storage.k8s.io"),
-					Kind:     snapshot.Kind,
-					Name:     snapshot.Name,
-				}
-			} else {
-				r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "SnapshotNotFound",
-					"No ReadyToUse snapshots were found for %v; proceeding with typical restore process.", sourceCluster.Name)
+	// If a source cluster was provided, the PVC may be populated from a VolumeSnapshot
+	// of PostgreSQL data. A pgBackRest delta restore then brings that data forward.
+	// Without a snapshot, the PVC is created in the usual fashion.
+	if sourceCluster != nil && err == nil {
+		var snapshot *volumesnapshotv1.VolumeSnapshot
+		snapshot, err = r.getDataSourceSnapshot(ctx, cluster, sourceCluster)
+
+		if snapshot != nil {
+			pvc.Spec.DataSource = &corev1.TypedLocalObjectReference{
+				APIGroup: initialize.String(volumesnapshotv1.GroupName),
+				Kind:     "VolumeSnapshot",
+				Name:     snapshot.Name,
+			}
+
+			// The volume must be at least as large as the snapshot.
+			if size := snapshot.Status.RestoreSize; size != nil &&
+				size.Cmp(*pvc.Spec.Resources.Requests.Storage()) > 0 {
+				pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *size
 			}
-		} else {
-			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "SnapshotNotFound",
-				"Could not get snapshots for %v, proceeding with typical restore process.", sourceCluster.Name)
 		}
 	}
 
@@ -844,6 +1387,12 @@ func (r *Reconciler) reconcileTablespaceVolumes(
 
 		pvc.Spec = vol.DataVolumeClaimSpec.AsPersistentVolumeClaimSpec()
 
+		r.setVolumeSize(ctx, cluster, &pvc.Spec, "tablespace-"+vol.Name, instanceSpec.Name)
+
+		// Clear any set limit before applying PVC. This is needed to allow the limit
+		// value to change later.
+		pvc.Spec.Resources.Limits = nil
+
 		if err == nil {
 			err = r.handlePersistentVolumeClaimError(cluster,
 				errors.WithStack(r.apply(ctx, pvc)))
@@ -951,6 +1500,8 @@ func (r *Reconciler) reconcilePostgresWALVolume(
 
 	pvc.Spec = instanceSpec.WALVolumeClaimSpec.AsPersistentVolumeClaimSpec()
 
+	r.setVolumeSize(ctx, cluster, &pvc.Spec, "pgWAL", instanceSpec.Name)
+
 	if err == nil {
 		err = r.handlePersistentVolumeClaimError(cluster,
 			errors.WithStack(r.apply(ctx, pvc)))
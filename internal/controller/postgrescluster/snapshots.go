@@ -7,6 +7,7 @@ package postgrescluster
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crunchydata/postgres-operator/internal/config"
@@ -47,8 +49,13 @@ import (
 //  5. If there are no snapshots at all, we take a snapshot and put the backup job's completion
 //     timestamp on the snapshot annotation.
 //  6. If an earlier snapshot is found, we take a new snapshot, annotate it and delete the old
-//     snapshot.
+//     snapshot. When a retention policy is defined, old snapshots are deleted only when the
+//     policy does not keep them.
 //  7. When the snapshot job completes, we delete the restore job.
+//
+// When an interval is defined, another snapshot of the dedicated volume is taken whenever
+// that much time has passed since the most recent one. The remaining snapshots are reported
+// in status.
 func (r *Reconciler) reconcileVolumeSnapshots(ctx context.Context,
 	postgrescluster *v1beta1.PostgresCluster, pvc *corev1.PersistentVolumeClaim) error {
 
@@ -87,11 +94,19 @@ func (r *Reconciler) reconcileVolumeSnapshots(ctx context.Context,
 
 	// If snapshots are disabled, delete any existing snapshots and return early.
 	if postgrescluster.Spec.Backups.Snapshots == nil {
+		postgrescluster.Status.VolumeSnapshots = nil
 		return r.deleteSnapshots(ctx, postgrescluster, snapshots)
 	}
 
 	// If we got here, then the snapshots are enabled (feature gate is enabled and the
 	// cluster has a Spec.Backups.Snapshots section defined).
+	spec := postgrescluster.Spec.Backups.Snapshots
+
+	// Report the snapshots that remain when this function returns.
+	deleted := sets.New[string]()
+	defer func() {
+		postgrescluster.Status.VolumeSnapshots = snapshotInventory(snapshots, deleted)
+	}()
 
 	// Check snapshots for errors; if present, create an event. If there are
 	// multiple snapshots with errors, create event for the latest error and
@@ -107,6 +122,7 @@ func (r *Reconciler) reconcileVolumeSnapshots(ctx context.Context,
 				if err != nil {
 					return err
 				}
+				deleted.Insert(snapshot.Name)
 			}
 		}
 	}
@@ -119,42 +135,209 @@ func (r *Reconciler) reconcileVolumeSnapshots(ctx context.Context,
 	}
 
 	// Check to see if snapshot exists for the latest backup that has been restored into
-	// the dedicated pvc.
+	// the dedicated pvc. When there is more than one, use the most recent.
 	var snapshotForPvcUpdateIdx int
 	snapshotFoundForPvcUpdate := false
 	for idx, snapshot := range snapshots {
-		if snapshot.GetAnnotations()[naming.PGBackRestBackupJobCompletion] == pvcUpdateTimeStamp {
+		if snapshot.GetAnnotations()[naming.PGBackRestBackupJobCompletion] == pvcUpdateTimeStamp &&
+			!deleted.Has(snapshot.Name) && (!snapshotFoundForPvcUpdate ||
+			snapshotTime(snapshot).After(snapshotTime(snapshots[snapshotForPvcUpdateIdx]))) {
 			snapshotForPvcUpdateIdx = idx
 			snapshotFoundForPvcUpdate = true
 		}
 	}
 
 	// If a snapshot exists for the latest backup that has been restored into the dedicated pvc
-	// and the snapshot is Ready, delete all other snapshots.
-	if snapshotFoundForPvcUpdate && snapshots[snapshotForPvcUpdateIdx].Status.ReadyToUse != nil &&
-		*snapshots[snapshotForPvcUpdateIdx].Status.ReadyToUse {
+	// and the snapshot is Ready, delete all other snapshots unless a retention policy keeps them.
+	if snapshotFoundForPvcUpdate && snapshotReady(snapshots[snapshotForPvcUpdateIdx]) {
+		var retained sets.Set[string]
+		if spec.Retention != nil {
+			retained = retainedSnapshots(snapshots, *spec.Retention, time.Now())
+		}
 		for idx, snapshot := range snapshots {
-			if idx != snapshotForPvcUpdateIdx {
-				err = r.deleteControlled(ctx, postgrescluster, snapshot)
-				if err != nil {
-					return err
-				}
+			if idx == snapshotForPvcUpdateIdx || deleted.Has(snapshot.Name) {
+				continue
+			}
+			// Snapshots that are not yet ready are only deleted when
+			// there is no retention policy.
+			if spec.Retention != nil && (retained.Has(snapshot.Name) || !snapshotReady(snapshot)) {
+				continue
 			}
+			err = r.deleteControlled(ctx, postgrescluster, snapshot)
+			if err != nil {
+				return err
+			}
+			deleted.Insert(snapshot.Name)
 		}
 	}
 
 	// If a snapshot for the latest backup/restore does not exist, create a snapshot.
-	if !snapshotFoundForPvcUpdate {
+	// Otherwise, create one when the interval has passed since the most recent one.
+	if !snapshotFoundForPvcUpdate ||
+		snapshotIntervalElapsed(spec, snapshots, deleted, time.Now()) {
 		var snapshot *volumesnapshotv1.VolumeSnapshot
 		snapshot, err = r.generateSnapshotOfDedicatedSnapshotVolume(postgrescluster, pvc)
 		if err == nil {
 			err = errors.WithStack(r.apply(ctx, snapshot))
 		}
+		if err == nil {
+			snapshots = append(snapshots, snapshot)
+		}
 	}
 
 	return err
 }
 
+// snapshotReady returns whether or not snapshot can be used to provision a volume.
+func snapshotReady(snapshot *volumesnapshotv1.VolumeSnapshot) bool {
+	return snapshot.Status != nil && initialize.FromPointer(snapshot.Status.ReadyToUse)
+}
+
+// snapshotTime returns when snapshot was taken or, before then, when it was created.
+func snapshotTime(snapshot *volumesnapshotv1.VolumeSnapshot) time.Time {
+	if snapshot.Status != nil && snapshot.Status.CreationTime != nil {
+		return snapshot.Status.CreationTime.Time
+	}
+	return snapshot.CreationTimestamp.Time
+}
+
+// snapshotIntervalElapsed returns true when spec has an interval and the most
+// recent remaining snapshot is ready to use and older than that interval as of
+// now. It does not matter whether the dedicated volume was restored since then.
+func snapshotIntervalElapsed(
+	spec *v1beta1.VolumeSnapshots, snapshots []*volumesnapshotv1.VolumeSnapshot,
+	deleted sets.Set[string], now time.Time,
+) bool {
+	if spec.Interval == nil {
+		return false
+	}
+
+	var latest *volumesnapshotv1.VolumeSnapshot
+	for _, snapshot := range snapshots {
+		if !deleted.Has(snapshot.Name) &&
+			(latest == nil || snapshotTime(snapshot).After(snapshotTime(latest))) {
+			latest = snapshot
+		}
+	}
+
+	return latest != nil && snapshotReady(latest) &&
+		now.Sub(snapshotTime(latest)) >= spec.Interval.AsDuration().Duration
+}
+
+// snapshotIntervalRequeue returns how long to wait before the next snapshot of
+// cluster is due according to its interval and the snapshots in its status.
+// It returns zero when there is no interval or no snapshot.
+func snapshotIntervalRequeue(cluster *v1beta1.PostgresCluster, now time.Time) time.Duration {
+	spec := cluster.Spec.Backups.Snapshots
+	if spec == nil || spec.Interval == nil {
+		return 0
+	}
+
+	var latest time.Time
+	for _, snapshot := range cluster.Status.VolumeSnapshots {
+		if snapshot.CreationTime != nil && snapshot.CreationTime.After(latest) {
+			latest = snapshot.CreationTime.Time
+		}
+	}
+	if latest.IsZero() {
+		return 0
+	}
+
+	// Wait at least a minute so that a snapshot has time to become ready.
+	return max(time.Minute, latest.Add(spec.Interval.AsDuration().Duration).Sub(now))
+}
+
+// retainedSnapshots returns the names of the ready snapshots that retention
+// keeps as of now: the most recent snapshots up to its KeepLast, and the most
+// recent snapshot of each of its KeepDaily days and KeepWeekly weeks.
+func retainedSnapshots(
+	snapshots []*volumesnapshotv1.VolumeSnapshot,
+	retention v1beta1.VolumeSnapshotRetention, now time.Time,
+) sets.Set[string] {
+	var ready []*volumesnapshotv1.VolumeSnapshot
+	for _, snapshot := range snapshots {
+		if snapshotReady(snapshot) {
+			ready = append(ready, snapshot)
+		}
+	}
+
+	// Sort the snapshots from newest to oldest.
+	slices.SortStableFunc(ready, func(a, b *volumesnapshotv1.VolumeSnapshot) int {
+		return snapshotTime(b).Compare(snapshotTime(a))
+	})
+
+	const day = 24 * time.Hour
+	startOfDay := func(t time.Time) time.Time { return t.UTC().Truncate(day) }
+	startOfWeek := func(t time.Time) time.Time {
+		t = startOfDay(t)
+		return t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+	}
+
+	// keepPeriods keeps the newest snapshot in each of the count periods that
+	// end with the one containing now.
+	retained := sets.New[string]()
+	keepPeriods := func(count int32, start func(time.Time) time.Time, length int) {
+		oldest := start(now).AddDate(0, 0, -length*int(count-1))
+		seen := sets.New[time.Time]()
+		for _, snapshot := range ready {
+			period := start(snapshotTime(snapshot))
+			if !period.Before(oldest) && !seen.Has(period) {
+				seen.Insert(period)
+				retained.Insert(snapshot.Name)
+			}
+		}
+	}
+
+	keepLast := 1
+	if retention.KeepLast != nil {
+		keepLast = int(*retention.KeepLast)
+	}
+	for i := 0; i < keepLast && i < len(ready); i++ {
+		retained.Insert(ready[i].Name)
+	}
+	if retention.KeepDaily != nil {
+		keepPeriods(*retention.KeepDaily, startOfDay, 1)
+	}
+	if retention.KeepWeekly != nil {
+		keepPeriods(*retention.KeepWeekly, startOfWeek, 7)
+	}
+
+	return retained
+}
+
+// snapshotInventory returns the status of snapshots that are not deleted, oldest first.
+func snapshotInventory(
+	snapshots []*volumesnapshotv1.VolumeSnapshot, deleted sets.Set[string],
+) []v1beta1.VolumeSnapshotStatus {
+	snapshots = slices.Clone(snapshots)
+	slices.SortStableFunc(snapshots, func(a, b *volumesnapshotv1.VolumeSnapshot) int {
+		return snapshotTime(a).Compare(snapshotTime(b))
+	})
+
+	var inventory []v1beta1.VolumeSnapshotStatus
+	for _, snapshot := range snapshots {
+		if deleted.Has(snapshot.Name) {
+			continue
+		}
+
+		status := v1beta1.VolumeSnapshotStatus{
+			Name:       snapshot.Name,
+			ReadyToUse: snapshotReady(snapshot),
+		}
+		if snapshot.Status != nil {
+			status.CreationTime = snapshot.Status.CreationTime
+			status.RestoreSize = snapshot.Status.RestoreSize
+		}
+		if completion, err := time.Parse(time.RFC3339,
+			snapshot.GetAnnotations()[naming.PGBackRestBackupJobCompletion]); err == nil {
+			status.BackupCompletionTime = initialize.Pointer(metav1.NewTime(completion))
+		}
+		inventory = append(inventory, status)
+	}
+
+	return inventory
+}
+
 // +kubebuilder:rbac:groups="",resources="persistentvolumeclaims",verbs={get}
 // +kubebuilder:rbac:groups="",resources="persistentvolumeclaims",verbs={create,delete,patch}
 
@@ -563,6 +746,70 @@ func (r *Reconciler) getSnapshotsForCluster(ctx context.Context, cluster *v1beta
 	return initialize.Pointers(snapshots.Items...), err
 }
 
+// getDataSourceSnapshot returns the VolumeSnapshot with which to populate the
+// PostgreSQL data volume of cluster as it bootstraps from sourceCluster. When
+// the data source of cluster names a VolumeSnapshot, it must exist and be ready
+// to use. Otherwise, it is the latest ready VolumeSnapshot of sourceCluster, if
+// any. It records events that explain which, if any, VolumeSnapshot is used.
+func (r *Reconciler) getDataSourceSnapshot(ctx context.Context,
+	cluster, sourceCluster *v1beta1.PostgresCluster,
+) (*volumesnapshotv1.VolumeSnapshot, error) {
+	var spec *v1beta1.VolumeSnapshotDataSource
+	if cluster.Spec.DataSource != nil {
+		spec = cluster.Spec.DataSource.VolumeSnapshot
+	}
+
+	switch {
+	case spec != nil && !feature.Enabled(ctx, feature.VolumeSnapshots):
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "FeatureGateDisabled",
+			"The %v feature gate is not enabled; proceeding with typical restore process.",
+			feature.VolumeSnapshots)
+		return nil, nil
+
+	case spec != nil && spec.Name != "":
+		snapshot := &volumesnapshotv1.VolumeSnapshot{}
+		err := errors.WithStack(r.Client.Get(ctx,
+			client.ObjectKey{Namespace: cluster.Namespace, Name: spec.Name}, snapshot))
+
+		// The snapshot was explicitly requested, so wait for it rather than
+		// proceeding without it.
+		if err == nil && (snapshot.Status == nil || !initialize.FromPointer(snapshot.Status.ReadyToUse)) {
+			err = errors.Errorf("VolumeSnapshot %q is not ready to use", spec.Name)
+		}
+		if err != nil {
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "SnapshotNotFound",
+				"Unable to bootstrap cluster with snapshot %v: %v", spec.Name, err)
+			return nil, err
+		}
+
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "BootstrappingWithSnapshot",
+			"Bootstrapping cluster with snapshot %v.", spec.Name)
+		return snapshot, nil
+
+	case spec != nil,
+		sourceCluster.Spec.Backups.Snapshots != nil && feature.Enabled(ctx, feature.VolumeSnapshots):
+		snapshots, err := r.getSnapshotsForCluster(ctx, sourceCluster)
+		if err != nil {
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "SnapshotNotFound",
+				"Could not get snapshots for %v, proceeding with typical restore process.", sourceCluster.Name)
+			return nil, nil
+		}
+
+		snapshot := getLatestReadySnapshot(snapshots)
+		if snapshot == nil {
+			r.Recorder.Eventf(cluster, corev1.EventTypeWarning, "SnapshotNotFound",
+				"No ReadyToUse snapshots were found for %v; proceeding with typical restore process.", sourceCluster.Name)
+			return nil, nil
+		}
+
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, "BootstrappingWithSnapshot",
+			"Snapshot found for %v; bootstrapping cluster with snapshot.", sourceCluster.Name)
+		return snapshot, nil
+	}
+
+	return nil, nil
+}
+
 // getLatestReadySnapshot takes a VolumeSnapshotList and returns the latest ready VolumeSnapshot.
 func getLatestReadySnapshot(snapshots []*volumesnapshotv1.VolumeSnapshot) *volumesnapshotv1.VolumeSnapshot {
 	zeroTime := metav1.NewTime(time.Time{})
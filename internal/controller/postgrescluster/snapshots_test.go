@@ -19,7 +19,9 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
 	"github.com/crunchydata/postgres-operator/internal/feature"
@@ -350,6 +352,89 @@ func TestReconcileVolumeSnapshots(t *testing.T) {
 	})
 }
 
+func TestReconcileVolumeSnapshotsInterval(t *testing.T) {
+	ctx := context.Background()
+	cc := fake.NewClientBuilder().WithScheme(runtime.Scheme).Build()
+	r := &Reconciler{
+		Client:   cc,
+		Owner:    client.FieldOwner(t.Name()),
+		Recorder: events.NewRecorder(t, runtime.Scheme),
+	}
+
+	gate := feature.NewGate()
+	assert.NilError(t, gate.SetFromMap(map[string]bool{
+		feature.VolumeSnapshots: true,
+	}))
+	ctx = feature.NewContext(ctx, gate)
+	ctx = kubernetes.NewAPIContext(ctx, kubernetes.NewAPISet(
+		volumesnapshotv1.SchemeGroupVersion.WithKind("VolumeSnapshot"),
+	))
+
+	interval, err := v1beta1.NewDuration("1h")
+	assert.NilError(t, err)
+
+	cluster := testCluster()
+	cluster.Namespace = "ns1"
+	cluster.UID = "the-uid-123"
+	cluster.Spec.Backups.Snapshots = &v1beta1.VolumeSnapshots{
+		VolumeSnapshotClassName: "my-snapshotclass",
+		Interval:                interval,
+	}
+
+	// The dedicated volume has not been restored since the only snapshot.
+	pvc := &corev1.PersistentVolumeClaim{}
+	pvc.Namespace, pvc.Name = "ns1", "dedicated-snapshot-volume"
+	pvc.Annotations = map[string]string{
+		naming.PGBackRestBackupJobCompletion: "backup-timestamp",
+	}
+
+	snapshot := &volumesnapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "old-snapshot",
+			Namespace: "ns1",
+			Annotations: map[string]string{
+				naming.PGBackRestBackupJobCompletion: "backup-timestamp",
+			},
+			Labels: map[string]string{
+				naming.LabelCluster: "hippo",
+			},
+		},
+		Spec: volumesnapshotv1.VolumeSnapshotSpec{
+			Source: volumesnapshotv1.VolumeSnapshotSource{
+				PersistentVolumeClaimName: &pvc.Name,
+			},
+		},
+		Status: &volumesnapshotv1.VolumeSnapshotStatus{
+			CreationTime: initialize.Pointer(metav1.NewTime(time.Now().Add(-30 * time.Minute))),
+			ReadyToUse:   initialize.Bool(true),
+		},
+	}
+	assert.NilError(t, r.setControllerReference(cluster, snapshot))
+	assert.NilError(t, cc.Create(ctx, snapshot))
+
+	list := func(t *testing.T) []volumesnapshotv1.VolumeSnapshot {
+		snapshots := &volumesnapshotv1.VolumeSnapshotList{}
+		assert.NilError(t, cc.List(ctx, snapshots, client.InNamespace("ns1")))
+		return snapshots.Items
+	}
+
+	// Nothing happens before the interval.
+	assert.NilError(t, r.reconcileVolumeSnapshots(ctx, cluster, pvc))
+	assert.Equal(t, len(list(t)), 1)
+
+	// Another snapshot of the same restore is taken after the interval.
+	snapshot.Status.CreationTime = initialize.Pointer(metav1.NewTime(time.Now().Add(-2 * time.Hour)))
+	assert.NilError(t, cc.Update(ctx, snapshot))
+
+	assert.NilError(t, r.reconcileVolumeSnapshots(ctx, cluster, pvc))
+	snapshots := list(t)
+	assert.Equal(t, len(snapshots), 2)
+	for _, snapshot := range snapshots {
+		assert.Equal(t, snapshot.Annotations[naming.PGBackRestBackupJobCompletion], "backup-timestamp")
+	}
+	assert.Equal(t, len(cluster.Status.VolumeSnapshots), 2)
+}
+
 func TestReconcileDedicatedSnapshotVolume(t *testing.T) {
 	ctx := context.Background()
 	_, cc := setupKubernetes(t)
@@ -1145,6 +1230,136 @@ func TestGetSnapshotsForCluster(t *testing.T) {
 	})
 }
 
+func TestGetDataSourceSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	gate := feature.NewGate()
+	assert.NilError(t, gate.SetFromMap(map[string]bool{
+		feature.VolumeSnapshots: true,
+	}))
+	enabled := feature.NewContext(ctx, gate)
+
+	snapshot := func(name, cluster string, ready bool, created time.Time) *volumesnapshotv1.VolumeSnapshot {
+		return &volumesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns1", Name: name,
+				Labels: map[string]string{naming.LabelCluster: cluster},
+			},
+			Status: &volumesnapshotv1.VolumeSnapshotStatus{
+				CreationTime: initialize.Pointer(metav1.NewTime(created)),
+				ReadyToUse:   initialize.Bool(ready),
+			},
+		}
+	}
+
+	earlier := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	reconciler := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(
+			snapshot("old", "source", true, earlier),
+			snapshot("new", "source", true, earlier.Add(time.Hour)),
+			snapshot("newest", "source", false, earlier.Add(2*time.Hour)),
+		).Build(),
+	}
+
+	source := testCluster()
+	source.Namespace, source.Name = "ns1", "source"
+
+	cluster := testCluster()
+	cluster.Namespace = "ns1"
+
+	t.Run("NoDataSource", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler.Recorder = recorder
+
+		found, err := reconciler.getDataSourceSnapshot(enabled, cluster, source)
+		assert.NilError(t, err)
+		assert.Assert(t, found == nil)
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	t.Run("SourceSnapshotsEnabled", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler.Recorder = recorder
+
+		source := source.DeepCopy()
+		source.Spec.Backups.Snapshots = &v1beta1.VolumeSnapshots{}
+
+		found, err := reconciler.getDataSourceSnapshot(enabled, cluster, source)
+		assert.NilError(t, err)
+		assert.Equal(t, found.Name, "new")
+		assert.Equal(t, recorder.Events[0].Reason, "BootstrappingWithSnapshot")
+	})
+
+	t.Run("LatestReady", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler.Recorder = recorder
+
+		cluster := cluster.DeepCopy()
+		cluster.Spec.DataSource = &v1beta1.DataSource{
+			VolumeSnapshot: &v1beta1.VolumeSnapshotDataSource{
+				ClusterName: "source", RepoName: "repo1",
+			},
+		}
+
+		found, err := reconciler.getDataSourceSnapshot(enabled, cluster, source)
+		assert.NilError(t, err)
+		assert.Equal(t, found.Name, "new")
+		assert.Equal(t, recorder.Events[0].Reason, "BootstrappingWithSnapshot")
+
+		t.Run("FeatureDisabled", func(t *testing.T) {
+			recorder := events.NewRecorder(t, runtime.Scheme)
+			reconciler.Recorder = recorder
+
+			found, err := reconciler.getDataSourceSnapshot(ctx, cluster, source)
+			assert.NilError(t, err)
+			assert.Assert(t, found == nil)
+			assert.Equal(t, recorder.Events[0].Type, "Warning")
+			assert.Equal(t, recorder.Events[0].Reason, "FeatureGateDisabled")
+		})
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.DataSource = &v1beta1.DataSource{
+			VolumeSnapshot: &v1beta1.VolumeSnapshotDataSource{
+				Name: "old", ClusterName: "source", RepoName: "repo1",
+			},
+		}
+
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler.Recorder = recorder
+
+		found, err := reconciler.getDataSourceSnapshot(enabled, cluster, source)
+		assert.NilError(t, err)
+		assert.Equal(t, found.Name, "old")
+		assert.Equal(t, recorder.Events[0].Reason, "BootstrappingWithSnapshot")
+
+		t.Run("NotReady", func(t *testing.T) {
+			recorder := events.NewRecorder(t, runtime.Scheme)
+			reconciler.Recorder = recorder
+
+			cluster := cluster.DeepCopy()
+			cluster.Spec.DataSource.VolumeSnapshot.Name = "newest"
+
+			_, err := reconciler.getDataSourceSnapshot(enabled, cluster, source)
+			assert.ErrorContains(t, err, "not ready")
+			assert.Equal(t, recorder.Events[0].Reason, "SnapshotNotFound")
+		})
+
+		t.Run("NotFound", func(t *testing.T) {
+			recorder := events.NewRecorder(t, runtime.Scheme)
+			reconciler.Recorder = recorder
+
+			cluster := cluster.DeepCopy()
+			cluster.Spec.DataSource.VolumeSnapshot.Name = "missing"
+
+			_, err := reconciler.getDataSourceSnapshot(enabled, cluster, source)
+			assert.Assert(t, apierrors.IsNotFound(err))
+			assert.Equal(t, recorder.Events[0].Reason, "SnapshotNotFound")
+		})
+	})
+}
+
 func TestGetLatestReadySnapshot(t *testing.T) {
 	t.Run("NoSnapshots", func(t *testing.T) {
 		snapshots := []*volumesnapshotv1.VolumeSnapshot{}
@@ -1405,3 +1620,165 @@ func succeededJobStatus(startTime, completionTime metav1.Time) batchv1.JobStatus
 		},
 	}
 }
+
+func TestRetainedSnapshots(t *testing.T) {
+	now := time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC) // Wednesday
+
+	snapshot := func(name string, ready bool, created time.Time) *volumesnapshotv1.VolumeSnapshot {
+		return &volumesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: &volumesnapshotv1.VolumeSnapshotStatus{
+				CreationTime: initialize.Pointer(metav1.NewTime(created)),
+				ReadyToUse:   initialize.Bool(ready),
+			},
+		}
+	}
+
+	snapshots := []*volumesnapshotv1.VolumeSnapshot{
+		snapshot("wed-late", true, now.Add(-1*time.Hour)),
+		snapshot("wed-early", true, now.Add(-10*time.Hour)),
+		snapshot("wed-pending", false, now.Add(-30*time.Minute)),
+		snapshot("tue", true, now.Add(-24*time.Hour)),
+		snapshot("mon", true, now.Add(-48*time.Hour)),
+		snapshot("sun", true, now.Add(-72*time.Hour)),
+		snapshot("last-thu", true, now.Add(-6*24*time.Hour)),
+		snapshot("two-weeks", true, now.Add(-14*24*time.Hour)),
+	}
+
+	sorted := func(names sets.Set[string]) []string { return sets.List(names) }
+
+	t.Run("Default", func(t *testing.T) {
+		retained := retainedSnapshots(snapshots, v1beta1.VolumeSnapshotRetention{}, now)
+		assert.DeepEqual(t, sorted(retained), []string{"wed-late"})
+	})
+
+	t.Run("KeepLast", func(t *testing.T) {
+		retained := retainedSnapshots(snapshots, v1beta1.VolumeSnapshotRetention{
+			KeepLast: initialize.Int32(3),
+		}, now)
+		assert.DeepEqual(t, sorted(retained), []string{"tue", "wed-early", "wed-late"})
+	})
+
+	t.Run("KeepDaily", func(t *testing.T) {
+		retained := retainedSnapshots(snapshots, v1beta1.VolumeSnapshotRetention{
+			KeepDaily: initialize.Int32(3),
+		}, now)
+		assert.DeepEqual(t, sorted(retained), []string{"mon", "tue", "wed-late"})
+	})
+
+	t.Run("KeepWeekly", func(t *testing.T) {
+		// Weeks begin on Monday, so Sunday belongs to the previous week.
+		retained := retainedSnapshots(snapshots, v1beta1.VolumeSnapshotRetention{
+			KeepWeekly: initialize.Int32(2),
+		}, now)
+		assert.DeepEqual(t, sorted(retained), []string{"sun", "wed-late"})
+
+		retained = retainedSnapshots(snapshots, v1beta1.VolumeSnapshotRetention{
+			KeepWeekly: initialize.Int32(3),
+		}, now)
+		assert.DeepEqual(t, sorted(retained), []string{"sun", "two-weeks", "wed-late"})
+	})
+
+	t.Run("Combined", func(t *testing.T) {
+		retained := retainedSnapshots(snapshots, v1beta1.VolumeSnapshotRetention{
+			KeepLast:   initialize.Int32(2),
+			KeepDaily:  initialize.Int32(2),
+			KeepWeekly: initialize.Int32(2),
+		}, now)
+		assert.DeepEqual(t, sorted(retained), []string{"sun", "tue", "wed-early", "wed-late"})
+	})
+}
+
+func TestSnapshotInterval(t *testing.T) {
+	now := time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC)
+	interval, err := v1beta1.NewDuration("6h")
+	assert.NilError(t, err)
+
+	snapshot := func(name string, ready bool, created time.Time) *volumesnapshotv1.VolumeSnapshot {
+		return &volumesnapshotv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{
+				naming.PGBackRestBackupJobCompletion: created.Add(-time.Minute).Format(time.RFC3339),
+			}},
+			Status: &volumesnapshotv1.VolumeSnapshotStatus{
+				CreationTime: initialize.Pointer(metav1.NewTime(created)),
+				ReadyToUse:   initialize.Bool(ready),
+			},
+		}
+	}
+
+	t.Run("Elapsed", func(t *testing.T) {
+		spec := &v1beta1.VolumeSnapshots{}
+		old := []*volumesnapshotv1.VolumeSnapshot{snapshot("old", true, now.Add(-7*time.Hour))}
+		none := sets.New[string]()
+
+		assert.Assert(t, !snapshotIntervalElapsed(spec, old, none, now), "expected no interval")
+
+		spec.Interval = interval
+		assert.Assert(t, !snapshotIntervalElapsed(spec, nil, none, now))
+		assert.Assert(t, snapshotIntervalElapsed(spec, old, none, now))
+		assert.Assert(t, !snapshotIntervalElapsed(spec, append(old,
+			snapshot("new", true, now.Add(-time.Hour))), none, now))
+		assert.Assert(t, !snapshotIntervalElapsed(spec, append(old,
+			snapshot("pending", false, now.Add(-time.Hour))), none, now))
+		assert.Assert(t, snapshotIntervalElapsed(spec, append(old,
+			snapshot("stuck", false, now.Add(-8*time.Hour))), none, now))
+		assert.Assert(t, snapshotIntervalElapsed(spec, append(old,
+			snapshot("new", true, now.Add(-time.Hour))), sets.New("new"), now))
+	})
+
+	t.Run("Requeue", func(t *testing.T) {
+		cluster := testCluster()
+		assert.Equal(t, snapshotIntervalRequeue(cluster, now), time.Duration(0))
+
+		cluster.Spec.Backups.Snapshots = &v1beta1.VolumeSnapshots{Interval: interval}
+		assert.Equal(t, snapshotIntervalRequeue(cluster, now), time.Duration(0))
+
+		cluster.Status.VolumeSnapshots = []v1beta1.VolumeSnapshotStatus{
+			{Name: "a", CreationTime: initialize.Pointer(metav1.NewTime(now.Add(-5 * time.Hour)))},
+			{Name: "b", CreationTime: initialize.Pointer(metav1.NewTime(now.Add(-2 * time.Hour)))},
+		}
+		assert.Equal(t, snapshotIntervalRequeue(cluster, now), 4*time.Hour)
+
+		cluster.Status.VolumeSnapshots = cluster.Status.VolumeSnapshots[:1]
+		cluster.Status.VolumeSnapshots[0].CreationTime.Time = now.Add(-7 * time.Hour)
+		assert.Equal(t, snapshotIntervalRequeue(cluster, now), time.Minute)
+	})
+}
+
+func TestSnapshotInventory(t *testing.T) {
+	created := time.Date(2025, 3, 12, 15, 0, 0, 0, time.UTC)
+	size := resource.MustParse("10Gi")
+
+	snapshots := []*volumesnapshotv1.VolumeSnapshot{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "second",
+				Annotations: map[string]string{
+					naming.PGBackRestBackupJobCompletion: "2025-03-12T14:00:00Z",
+				},
+			},
+			Status: &volumesnapshotv1.VolumeSnapshotStatus{
+				CreationTime: initialize.Pointer(metav1.NewTime(created)),
+				ReadyToUse:   initialize.Bool(true),
+				RestoreSize:  &size,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "first",
+				CreationTimestamp: metav1.NewTime(created.Add(-time.Hour)),
+			},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "deleted"}},
+	}
+
+	assert.Assert(t, cmp.MarshalMatches(snapshotInventory(snapshots, sets.New("deleted")), `
+- name: first
+  readyToUse: false
+- backupCompletionTime: "2025-03-12T14:00:00Z"
+  creationTime: "2025-03-12T15:00:00Z"
+  name: second
+  readyToUse: true
+  restoreSize: 10Gi
+	`))
+}
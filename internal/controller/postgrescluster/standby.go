@@ -0,0 +1,158 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+const (
+	// ConditionStandbySourceFound is the type used in a condition to indicate
+	// whether or not a standby can follow the PostgresCluster it names
+	ConditionStandbySourceFound = "StandbySourceFound"
+
+	// EventStandbySourceNotFound is the event reason utilized when a standby
+	// cannot follow the PostgresCluster it names
+	EventStandbySourceNotFound = "StandbySourceNotFound"
+)
+
+// reconcileStandbySource sets the connection details of a standby cluster that
+// follows another cluster by name. It returns false when that cluster does not
+// exist and sets the StandbySourceFound condition. The connection details then
+// point to where its primary Service will be, so new instances still start as
+// standbys.
+func (r *Reconciler) reconcileStandbySource(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) (bool, error) {
+	if cluster.Spec.Standby == nil || !cluster.Spec.Standby.Enabled ||
+		cluster.Spec.Standby.ClusterName == "" {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionStandbySourceFound)
+		return true, nil
+	}
+
+	var err error
+	var reason, message string
+	var source *v1beta1.PostgresCluster
+
+	if cluster.Spec.Standby.ClusterName == cluster.Name {
+		reason, message = "InvalidClusterName", "Standby cannot follow itself"
+	} else if source, err = r.observeStandbySource(ctx, cluster); err == nil && source == nil {
+		reason, message = "NotFound", fmt.Sprintf(
+			"PostgresCluster %q does not exist", cluster.Spec.Standby.ClusterName)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if source != nil {
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionStandbySourceFound)
+		applyStandbySource(cluster, source)
+		return true, nil
+	}
+
+	placeholder := v1beta1.NewPostgresCluster()
+	placeholder.Namespace = cluster.Namespace
+	placeholder.Name = cluster.Spec.Standby.ClusterName
+	placeholder.Default()
+	applyStandbySource(cluster, placeholder)
+
+	previous := initialize.FromPointer(
+		meta.FindStatusCondition(cluster.Status.Conditions, ConditionStandbySourceFound))
+	condition := metav1.Condition{
+		ObservedGeneration: cluster.GetGeneration(),
+		Type:               ConditionStandbySourceFound,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+	}
+
+	meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+
+	if previous.Status != condition.Status || previous.Message != condition.Message {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, EventStandbySourceNotFound, condition.Message)
+	}
+	return false, nil
+}
+
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={get}
+
+// observeStandbySource returns the PostgresCluster that cluster follows by
+// name. It returns nil when that cluster does not exist.
+func (r *Reconciler) observeStandbySource(
+	ctx context.Context, cluster *v1beta1.PostgresCluster,
+) (*v1beta1.PostgresCluster, error) {
+	source := v1beta1.NewPostgresCluster()
+	err := r.Client.Get(ctx, client.ObjectKey{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Spec.Standby.ClusterName,
+	}, source)
+
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Fill in the port and other defaults of the source cluster.
+	source.Default()
+
+	return source, nil
+}
+
+// applyStandbySource sets the connection details of cluster to stream from
+// the primary Service of source. When source is itself a standby, that Service
+// sends traffic to its standby leader. The Service follows the leader of source
+// through any failover, so cluster continues to stream from the same address.
+// These details are not stored in the API.
+func applyStandbySource(cluster, source *v1beta1.PostgresCluster) {
+	service := naming.ClusterPrimaryService(source)
+
+	cluster.Spec.Standby.Host = service.Name + "." + service.Namespace + ".svc"
+	cluster.Spec.Standby.Port = initialize.Int32(*source.Spec.Port)
+
+	// Connect with the replication certificate of source when cluster does not
+	// have one. The certificate authority of cluster must also trust it; see
+	// [v1beta1.PostgresClusterSpec.CustomTLSSecret].
+	if cluster.Spec.CustomReplicationClientTLSSecret == nil {
+		cluster.Spec.CustomReplicationClientTLSSecret =
+			source.Spec.CustomReplicationClientTLSSecret.DeepCopy()
+	}
+}
+
+// +kubebuilder:rbac:groups="postgres-operator.crunchydata.com",resources="postgresclusters",verbs={list}
+
+// findStandbysForPostgresCluster returns PostgresClusters that follow source
+// by name.
+func (r *Reconciler) findStandbysForPostgresCluster(
+	ctx context.Context, source client.ObjectKey,
+) []*v1beta1.PostgresCluster {
+	var matching []*v1beta1.PostgresCluster
+	var clusters v1beta1.PostgresClusterList
+
+	if r.Client.List(ctx, &clusters, &client.ListOptions{
+		Namespace: source.Namespace,
+	}) == nil {
+		for i := range clusters.Items {
+			if clusters.Items[i].Spec.Standby != nil &&
+				clusters.Items[i].Spec.Standby.ClusterName == source.Name {
+				matching = append(matching, &clusters.Items[i])
+			}
+		}
+	}
+	return matching
+}
@@ -0,0 +1,209 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestObserveStandbySource(t *testing.T) {
+	ctx := context.Background()
+
+	source := v1beta1.NewPostgresCluster()
+	source.Namespace, source.Name = "ns1", "east"
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace, cluster.Name = "ns1", "west"
+	cluster.Spec.Standby = &v1beta1.PostgresStandbySpec{Enabled: true, ClusterName: "east"}
+
+	t.Run("NotFound", func(t *testing.T) {
+		reconciler := &Reconciler{
+			Client: fake.NewClientBuilder().WithScheme(runtime.Scheme).Build(),
+		}
+
+		found, err := reconciler.observeStandbySource(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, found == nil)
+	})
+
+	t.Run("Found", func(t *testing.T) {
+		reconciler := &Reconciler{
+			Client: fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(source).Build(),
+		}
+
+		found, err := reconciler.observeStandbySource(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Equal(t, found.Name, "east")
+		assert.Equal(t, *found.Spec.Port, int32(5432), "expected defaults")
+	})
+}
+
+func TestReconcileStandbySource(t *testing.T) {
+	ctx := context.Background()
+
+	source := v1beta1.NewPostgresCluster()
+	source.Namespace, source.Name = "ns1", "east"
+	source.Spec.Port = initialize.Int32(5433)
+
+	t.Run("NotStandby", func(t *testing.T) {
+		reconciler := &Reconciler{}
+		cluster := v1beta1.NewPostgresCluster()
+
+		found, err := reconciler.reconcileStandbySource(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, found)
+		assert.Assert(t, cmp.Len(cluster.Status.Conditions, 0))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{
+			Client:   fake.NewClientBuilder().WithScheme(runtime.Scheme).Build(),
+			Recorder: recorder,
+		}
+
+		cluster := v1beta1.NewPostgresCluster()
+		cluster.Namespace, cluster.Name = "ns1", "west"
+		cluster.Spec.Standby = &v1beta1.PostgresStandbySpec{Enabled: true, ClusterName: "east"}
+
+		found, err := reconciler.reconcileStandbySource(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, !found)
+
+		// Instances still start as standbys of the expected Service.
+		assert.Equal(t, cluster.Spec.Standby.Host, "east-primary.ns1.svc")
+		assert.Equal(t, *cluster.Spec.Standby.Port, int32(5432))
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionStandbySourceFound)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Status, metav1.ConditionFalse)
+		assert.Equal(t, condition.Reason, "NotFound")
+		assert.Equal(t, condition.Message, `PostgresCluster "east" does not exist`)
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "StandbySourceNotFound")
+
+		// No event without changes.
+		cluster.Spec.Standby.Host = ""
+		found, err = reconciler.reconcileStandbySource(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, !found)
+		assert.Equal(t, len(recorder.Events), 1)
+
+		// The condition goes away when the source exists.
+		assert.NilError(t, reconciler.Client.Create(ctx, source.DeepCopy()))
+		cluster.Spec.Standby.Host = ""
+		found, err = reconciler.reconcileStandbySource(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, found)
+		assert.Equal(t, *cluster.Spec.Standby.Port, int32(5433))
+		assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions, ConditionStandbySourceFound) == nil)
+	})
+
+	t.Run("Itself", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+
+		cluster := v1beta1.NewPostgresCluster()
+		cluster.Namespace, cluster.Name = "ns1", "east"
+		cluster.Spec.Standby = &v1beta1.PostgresStandbySpec{Enabled: true, ClusterName: "east"}
+
+		found, err := reconciler.reconcileStandbySource(ctx, cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, !found)
+
+		condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionStandbySourceFound)
+		assert.Assert(t, condition != nil)
+		assert.Equal(t, condition.Reason, "InvalidClusterName")
+		assert.Equal(t, len(recorder.Events), 1)
+	})
+}
+
+func TestApplyStandbySource(t *testing.T) {
+	source := v1beta1.NewPostgresCluster()
+	source.Namespace, source.Name = "ns1", "east"
+	source.Spec.Port = initialize.Int32(5433)
+
+	cluster := v1beta1.NewPostgresCluster()
+	cluster.Namespace, cluster.Name = "ns1", "west"
+	cluster.Spec.Standby = &v1beta1.PostgresStandbySpec{Enabled: true, ClusterName: "east"}
+
+	t.Run("Service", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		applyStandbySource(cluster, source)
+
+		assert.Equal(t, cluster.Spec.Standby.Host, "east-primary.ns1.svc")
+		assert.Equal(t, *cluster.Spec.Standby.Port, int32(5433))
+		assert.Assert(t, cluster.Spec.CustomReplicationClientTLSSecret == nil)
+	})
+
+	t.Run("ReplicationCertificate", func(t *testing.T) {
+		source := source.DeepCopy()
+		source.Spec.CustomReplicationClientTLSSecret = &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "east-replication"},
+		}
+
+		cluster := cluster.DeepCopy()
+		applyStandbySource(cluster, source)
+
+		assert.DeepEqual(t, cluster.Spec.CustomReplicationClientTLSSecret,
+			source.Spec.CustomReplicationClientTLSSecret)
+
+		// The certificate of cluster takes precedence.
+		cluster.Spec.CustomReplicationClientTLSSecret.Name = "west-replication"
+		applyStandbySource(cluster, source)
+
+		assert.Equal(t, cluster.Spec.CustomReplicationClientTLSSecret.Name, "west-replication")
+	})
+}
+
+func TestFindStandbysForPostgresCluster(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := func(namespace, name, follows string) client.Object {
+		c := v1beta1.NewPostgresCluster()
+		c.Namespace, c.Name = namespace, name
+		if follows != "" {
+			c.Spec.Standby = &v1beta1.PostgresStandbySpec{Enabled: true, ClusterName: follows}
+		}
+		return c
+	}
+
+	reconciler := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(runtime.Scheme).WithObjects(
+			cluster("ns1", "east", ""),
+			cluster("ns1", "west", "east"),
+			cluster("ns1", "north", "west"),
+			cluster("ns2", "south", "east"),
+		).Build(),
+	}
+
+	var names []string
+	for _, c := range reconciler.findStandbysForPostgresCluster(ctx,
+		client.ObjectKey{Namespace: "ns1", Name: "east"}) {
+		names = append(names, c.Name)
+	}
+	assert.DeepEqual(t, names, []string{"west"})
+
+	names = nil
+	for _, c := range reconciler.findStandbysForPostgresCluster(ctx,
+		client.ObjectKey{Namespace: "ns1", Name: "west"}) {
+		names = append(names, c.Name)
+	}
+	assert.DeepEqual(t, names, []string{"north"})
+}
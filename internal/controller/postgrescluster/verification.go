@@ -0,0 +1,369 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/config"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/logging"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/patroni"
+	"github.com/crunchydata/postgres-operator/internal/pgbackrest"
+	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+const (
+	// ConditionBackupVerificationReady is the type used in a condition to
+	// indicate whether or not backups can be verified
+	ConditionBackupVerificationReady = "BackupVerificationReady"
+
+	// EventBackupVerificationSucceeded is the event reason utilized when a
+	// backup verification Job succeeds
+	EventBackupVerificationSucceeded = "BackupVerificationSucceeded"
+
+	// EventBackupVerificationFailed is the event reason utilized when a backup
+	// verification Job fails
+	EventBackupVerificationFailed = "BackupVerificationFailed"
+
+	// verificationCheckPath is where the check SQL of backup verification is
+	// mounted in the verification container.
+	verificationCheckPath = "/etc/pgbackrest-verification/check.sql"
+)
+
+// verificationRepoName returns the name of the repository that cluster
+// verifies, or an empty string when verification is not configured.
+func verificationRepoName(cluster *v1beta1.PostgresCluster) string {
+	spec := cluster.Spec.Backups.PGBackRest.Verification
+	switch {
+	case spec == nil:
+		return ""
+	case spec.RepoName != "":
+		return spec.RepoName
+	case len(cluster.Spec.Backups.PGBackRest.Repos) > 0:
+		return cluster.Spec.Backups.PGBackRest.Repos[0].Name
+	}
+	return ""
+}
+
+// +kubebuilder:rbac:groups="batch",resources="cronjobs",verbs={create,patch}
+
+// reconcileBackupVerification creates the CronJob that verifies backups on the
+// schedule in cluster and reports the most recent of its Jobs in status. It
+// returns how long to wait before checking on a running Job, if any.
+func (r *Reconciler) reconcileBackupVerification(ctx context.Context,
+	cluster *v1beta1.PostgresCluster, jobs []*batchv1.Job,
+) time.Duration {
+	log := logging.FromContext(ctx).WithValues("reconcileResource", "backupVerification")
+
+	spec := cluster.Spec.Backups.PGBackRest.Verification
+	if spec == nil {
+		if cluster.Status.PGBackRest != nil {
+			cluster.Status.PGBackRest.Verification = nil
+		}
+		meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionBackupVerificationReady)
+		return 0
+	}
+
+	r.setBackupVerificationStatus(cluster, jobs)
+
+	// Do not verify until there is a cluster and a backup from which to
+	// restore. See [Reconciler.reconcilePGBackRestCronJob].
+	repoName := verificationRepoName(cluster)
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionReplicaCreate)
+	if !patroni.ClusterBootstrapped(cluster) ||
+		condition == nil || condition.Status != metav1.ConditionTrue {
+		return 0
+	}
+
+	var stanzaCreated bool
+	if cluster.Status.PGBackRest != nil {
+		for _, repoStatus := range cluster.Status.PGBackRest.Repos {
+			if repoStatus.Name == repoName {
+				stanzaCreated = repoStatus.StanzaCreated
+			}
+		}
+	}
+	if !stanzaCreated {
+		previous := initialize.FromPointer(
+			meta.FindStatusCondition(cluster.Status.Conditions, ConditionBackupVerificationReady))
+		condition := metav1.Condition{
+			ObservedGeneration: cluster.GetGeneration(),
+			Type:               ConditionBackupVerificationReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             "StanzaNotCreated",
+			Message: fmt.Sprintf(
+				"Stanza not created for %q as specified for backup verification", repoName),
+		}
+
+		meta.SetStatusCondition(&cluster.Status.Conditions, condition)
+
+		if previous.Status != condition.Status || previous.Message != condition.Message {
+			r.Recorder.Event(cluster, corev1.EventTypeWarning, "StanzaNotCreated", condition.Message)
+		}
+		return 0
+	}
+	meta.RemoveStatusCondition(&cluster.Status.Conditions, ConditionBackupVerificationReady)
+
+	cronjob := generateBackupVerificationIntent(cluster, repoName)
+	err := errors.WithStack(r.setControllerReference(cluster, cronjob))
+	if err == nil {
+		err = r.apply(ctx, cronjob)
+	}
+	if err != nil {
+		r.Recorder.Event(cluster, corev1.EventTypeWarning, EventUnableToCreatePGBackRestCronJob,
+			err.Error())
+		log.Error(err, "error when attempting to create backup verification CronJob")
+		return 10 * time.Second
+	}
+
+	// Events and status are written when a Job finishes, but the Jobs of a
+	// CronJob do not belong to cluster. Check again while one is running.
+	for _, job := range jobs {
+		if !jobCompleted(job) && !jobFailed(job) {
+			return time.Minute
+		}
+	}
+	return 0
+}
+
+// generateBackupVerificationIntent returns the CronJob that verifies backups in
+// the repository named repoName.
+func generateBackupVerificationIntent(
+	cluster *v1beta1.PostgresCluster, repoName string,
+) *batchv1.CronJob {
+	spec := cluster.Spec.Backups.PGBackRest.Verification
+
+	annotations := naming.Merge(
+		cluster.Spec.Metadata.GetAnnotationsOrNil(),
+		cluster.Spec.Backups.PGBackRest.Metadata.GetAnnotationsOrNil(),
+		map[string]string{
+			naming.DefaultContainerAnnotation: naming.PGBackRestRestoreContainerName,
+		})
+	labels := naming.Merge(
+		cluster.Spec.Metadata.GetLabelsOrNil(),
+		cluster.Spec.Backups.PGBackRest.Metadata.GetLabelsOrNil(),
+		naming.PGBackRestVerificationLabels(cluster.Name, repoName))
+
+	database := spec.Database
+	if database == "" {
+		database = "postgres"
+	}
+
+	var checkFile string
+	if spec.CheckSQL != nil {
+		checkFile = verificationCheckPath
+	}
+
+	container := corev1.Container{
+		Command: pgbackrest.VerifyCommand(
+			postgres.DataDirectory(cluster), config.FetchKeyCommand(&cluster.Spec),
+			database, checkFile, strings.Join([]string{
+				"--stanza=" + pgbackrest.DefaultStanzaName,
+				"--repo=" + strings.TrimPrefix(repoName, "repo"),
+			}, " ")),
+		Image:           config.PostgresContainerImage(cluster),
+		ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+		Name:            naming.PGBackRestRestoreContainerName,
+		Resources:       spec.Resources,
+		SecurityContext: initialize.RestrictedSecurityContext(),
+		VolumeMounts:    []corev1.VolumeMount{postgres.DataVolumeMount()},
+	}
+
+	// The restored data is in a volume that is created with the Pod and
+	// deleted with it.
+	// - https://docs.k8s.io/concepts/storage/ephemeral-volumes/#generic-ephemeral-volumes
+	volumes := []corev1.Volume{{
+		Name: postgres.DataVolumeMount().Name,
+		VolumeSource: corev1.VolumeSource{
+			Ephemeral: &corev1.EphemeralVolumeSource{
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: cluster.Spec.Metadata.GetAnnotationsOrNil(),
+						Labels: naming.Merge(
+							cluster.Spec.Metadata.GetLabelsOrNil(),
+							map[string]string{
+								naming.LabelCluster:                cluster.Name,
+								naming.LabelPGBackRestVerification: "",
+							}),
+					},
+					Spec: corev1.PersistentVolumeClaimSpec(spec.VolumeClaimSpec),
+				},
+			},
+		},
+	}}
+
+	if spec.CheckSQL != nil {
+		projection := spec.CheckSQL.AsProjection("check.sql")
+		volumes = append(volumes, corev1.Volume{
+			Name: "check-sql",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{ConfigMap: &projection}},
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      "check-sql",
+			MountPath: strings.TrimSuffix(verificationCheckPath, "/check.sql"),
+			ReadOnly:  true,
+		})
+	}
+
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: annotations,
+			Labels:      labels,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{container},
+
+			// Report a failed verification rather than retrying it.
+			RestartPolicy: corev1.RestartPolicyNever,
+
+			// See [Reconciler.generateRestoreJobIntent] about the ServiceAccount.
+			AutomountServiceAccountToken: initialize.Bool(false),
+			ServiceAccountName:           naming.ClusterInstanceRBAC(cluster).Name,
+
+			// Do not add environment variables describing services in this namespace.
+			EnableServiceLinks: initialize.Bool(false),
+
+			// Set the image pull secrets, if any exist.
+			// This is set here rather than using the service account due to the lack
+			// of propagation to existing pods when the CRD is updated:
+			// https://github.com/kubernetes/kubernetes/issues/88456
+			ImagePullSecrets: cluster.Spec.ImagePullSecrets,
+
+			SecurityContext: postgres.PodSecurityContext(cluster),
+			Volumes:         volumes,
+		},
+	}
+
+	// The verification Job uses the same pgBackRest configuration as instances.
+	pgbackrest.AddConfigToInstancePod(cluster, &template.Spec)
+	AddTMPEmptyDir(&template)
+
+	// Stop verification that runs too long; a restore that hangs would
+	// otherwise keep its volume and block the next scheduled Job.
+	jobSpec := batchv1.JobSpec{
+		ActiveDeadlineSeconds: initialize.Int64(6 * 60 * 60),
+		BackoffLimit:          initialize.Int32(0),
+		Template:              template,
+	}
+	if spec.ActiveDeadlineSeconds != nil {
+		jobSpec.ActiveDeadlineSeconds = spec.ActiveDeadlineSeconds
+	}
+
+	// Schedule verification Pods like backup Job Pods.
+	if jobs := cluster.Spec.Backups.PGBackRest.Jobs; jobs != nil {
+		jobSpec.TTLSecondsAfterFinished = jobs.TTLSecondsAfterFinished
+		jobSpec.Template.Spec.Affinity = jobs.Affinity
+		jobSpec.Template.Spec.Tolerations = jobs.Tolerations
+		jobSpec.Template.Spec.PriorityClassName =
+			initialize.FromPointer(jobs.PriorityClassName)
+	}
+
+	// Suspend verification when the cluster is shutdown. Any Job that has
+	// already started will continue.
+	suspend := cluster.Spec.Shutdown != nil && *cluster.Spec.Shutdown
+
+	cronjob := &batchv1.CronJob{
+		ObjectMeta: naming.PGBackRestCronJob(cluster, "verify", repoName),
+		Spec: batchv1.CronJobSpec{
+			Schedule:          spec.Schedule,
+			Suspend:           &suspend,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+					Labels:      labels,
+				},
+				Spec: jobSpec,
+			},
+		},
+	}
+	cronjob.Annotations = annotations
+	cronjob.Labels = labels
+	cronjob.SetGroupVersionKind(batchv1.SchemeGroupVersion.WithKind("CronJob"))
+
+	return cronjob
+}
+
+// setBackupVerificationStatus reports the most recent verification Job that
+// finished in the status of cluster. An event is recorded the first time each
+// Job is reported.
+func (r *Reconciler) setBackupVerificationStatus(
+	cluster *v1beta1.PostgresCluster, jobs []*batchv1.Job,
+) {
+	var latest *batchv1.Job
+	for _, job := range jobs {
+		if (jobCompleted(job) || jobFailed(job)) &&
+			(latest == nil || latest.CreationTimestamp.Before(&job.CreationTimestamp)) {
+			latest = job
+		}
+	}
+	if latest == nil {
+		return
+	}
+
+	if cluster.Status.PGBackRest == nil {
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{}
+	}
+	previous := cluster.Status.PGBackRest.Verification
+	if previous != nil && previous.JobName == latest.Name {
+		return
+	}
+
+	status := &v1beta1.PGBackRestVerificationStatus{
+		JobName:   latest.Name,
+		RepoName:  latest.Labels[naming.LabelPGBackRestRepo],
+		Succeeded: jobCompleted(latest),
+		StartTime: latest.Status.StartTime,
+	}
+	if previous != nil {
+		status.LastSuccessTime = previous.LastSuccessTime
+	}
+
+	// A Job has a completion time only when it succeeds. Otherwise, use the
+	// time it was marked failed.
+	status.CompletionTime = latest.Status.CompletionTime
+	for _, condition := range latest.Status.Conditions {
+		if status.CompletionTime == nil && condition.Type == batchv1.JobFailed {
+			status.CompletionTime = condition.LastTransitionTime.DeepCopy()
+		}
+	}
+	if status.StartTime != nil && status.CompletionTime != nil {
+		status.Duration = &metav1.Duration{
+			Duration: status.CompletionTime.Sub(status.StartTime.Time),
+		}
+	}
+
+	duration := "an unknown time"
+	if status.Duration != nil {
+		duration = status.Duration.Duration.String()
+	}
+	if status.Succeeded {
+		status.LastSuccessTime = status.CompletionTime
+		r.Recorder.Eventf(cluster, corev1.EventTypeNormal, EventBackupVerificationSucceeded,
+			"Verified the latest backup in %q in %s", status.RepoName, duration)
+	} else {
+		r.Recorder.Eventf(cluster, corev1.EventTypeWarning, EventBackupVerificationFailed,
+			"Unable to verify the latest backup in %q after %s; see Job %q",
+			status.RepoName, duration, status.JobName)
+	}
+
+	cluster.Status.PGBackRest.Verification = status
+}
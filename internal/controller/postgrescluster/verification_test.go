@@ -0,0 +1,277 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgrescluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/crunchydata/postgres-operator/internal/controller/runtime"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/events"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestVerificationRepoName(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	assert.Equal(t, verificationRepoName(cluster), "")
+
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+		{Name: "repo2"}, {Name: "repo3"},
+	}
+	assert.Equal(t, verificationRepoName(cluster), "")
+
+	cluster.Spec.Backups.PGBackRest.Verification = &v1beta1.PGBackRestVerification{}
+	assert.Equal(t, verificationRepoName(cluster), "repo2")
+
+	cluster.Spec.Backups.PGBackRest.Verification.RepoName = "repo3"
+	assert.Equal(t, verificationRepoName(cluster), "repo3")
+}
+
+func TestGenerateBackupVerificationIntent(t *testing.T) {
+	cluster := &v1beta1.PostgresCluster{}
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+	cluster.Spec.PostgresVersion = 16
+	cluster.Spec.Image = "some-image"
+	cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{Name: "repo1"}}
+	cluster.Spec.Backups.PGBackRest.Verification = &v1beta1.PGBackRestVerification{
+		Schedule: "0 3 * * *",
+		VolumeClaimSpec: v1beta1.VolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		cronjob := generateBackupVerificationIntent(cluster, "repo1")
+
+		assert.Equal(t, cronjob.Namespace, "ns1")
+		assert.Equal(t, cronjob.Name, "hippo-repo1-verify")
+		assert.Equal(t, cronjob.Spec.Schedule, "0 3 * * *")
+		assert.Equal(t, *cronjob.Spec.Suspend, false)
+		assert.Equal(t, cronjob.Spec.ConcurrencyPolicy, batchv1.ForbidConcurrent)
+		assert.DeepEqual(t, cronjob.Spec.JobTemplate.Labels, cronjob.Labels)
+		assert.Equal(t, cronjob.Labels[naming.LabelPGBackRestRepo], "repo1")
+		assert.Equal(t, *cronjob.Spec.JobTemplate.Spec.BackoffLimit, int32(0))
+		assert.Equal(t, *cronjob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds, int64(21600))
+
+		pod := cronjob.Spec.JobTemplate.Spec.Template.Spec
+		assert.Equal(t, len(pod.Containers), 1)
+		assert.Equal(t, pod.Containers[0].Image, "some-image")
+		assert.DeepEqual(t, pod.Containers[0].Command[4:], []string{
+			"-", "/pgdata/pg16", "postgres", "", "--stanza=db --repo=1",
+		})
+		assert.Equal(t, pod.ServiceAccountName, "hippo-instance")
+		assert.Equal(t, *pod.AutomountServiceAccountToken, false)
+
+		assert.Assert(t, cmp.MarshalMatches(pod.Containers[0].VolumeMounts, `
+- mountPath: /pgdata
+  name: postgres-data
+- mountPath: /etc/pgbackrest/conf.d
+  name: pgbackrest-config
+  readOnly: true
+- mountPath: /tmp
+  name: tmp
+		`))
+		assert.Assert(t, cmp.MarshalMatches(pod.Volumes[0], `
+ephemeral:
+  volumeClaimTemplate:
+    metadata:
+      labels:
+        postgres-operator.crunchydata.com/cluster: hippo
+        postgres-operator.crunchydata.com/pgbackrest-verification: ""
+    spec:
+      accessModes:
+      - ReadWriteOnce
+      resources:
+        requests:
+          storage: 1Gi
+name: postgres-data
+		`))
+	})
+
+	t.Run("ActiveDeadlineSeconds", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Verification.ActiveDeadlineSeconds = initialize.Int64(900)
+
+		cronjob := generateBackupVerificationIntent(cluster, "repo1")
+		assert.Equal(t, *cronjob.Spec.JobTemplate.Spec.ActiveDeadlineSeconds, int64(900))
+	})
+
+	t.Run("CheckSQL", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Shutdown = initialize.Bool(true)
+		cluster.Spec.Backups.PGBackRest.Verification.Database = "graphs"
+		cluster.Spec.Backups.PGBackRest.Verification.CheckSQL = &v1beta1.ConfigMapKeyRef{
+			Name: "checks", Key: "counts.sql",
+		}
+
+		cronjob := generateBackupVerificationIntent(cluster, "repo1")
+		assert.Equal(t, *cronjob.Spec.Suspend, true)
+
+		pod := cronjob.Spec.JobTemplate.Spec.Template.Spec
+		assert.DeepEqual(t, pod.Containers[0].Command[4:], []string{
+			"-", "/pgdata/pg16", "graphs", "/etc/pgbackrest-verification/check.sql",
+			"--stanza=db --repo=1",
+		})
+		assert.DeepEqual(t, pod.Containers[0].VolumeMounts[1], corev1.VolumeMount{
+			Name: "check-sql", MountPath: "/etc/pgbackrest-verification", ReadOnly: true,
+		})
+		assert.Assert(t, cmp.MarshalMatches(pod.Volumes[1], `
+name: check-sql
+projected:
+  sources:
+  - configMap:
+      items:
+      - key: counts.sql
+        path: check.sql
+      name: checks
+		`))
+	})
+}
+
+func TestSetBackupVerificationStatus(t *testing.T) {
+	started := metav1.NewTime(time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC))
+	finished := metav1.NewTime(started.Add(90 * time.Second))
+
+	job := func(name string, created int, conditionType batchv1.JobConditionType) *batchv1.Job {
+		job := &batchv1.Job{}
+		job.Name = name
+		job.Labels = map[string]string{naming.LabelPGBackRestRepo: "repo1"}
+		job.CreationTimestamp = metav1.NewTime(started.Add(time.Duration(created) * time.Hour))
+		job.Status.StartTime = &started
+		if conditionType != "" {
+			job.Status.Conditions = []batchv1.JobCondition{{
+				Type: conditionType, Status: corev1.ConditionTrue, LastTransitionTime: finished,
+			}}
+		}
+		if conditionType == batchv1.JobComplete {
+			job.Status.CompletionTime = &finished
+		}
+		return job
+	}
+
+	t.Run("Running", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		cluster := &v1beta1.PostgresCluster{}
+
+		reconciler.setBackupVerificationStatus(cluster, []*batchv1.Job{job("a", 0, "")})
+		assert.Assert(t, cluster.Status.PGBackRest == nil)
+		assert.Equal(t, len(recorder.Events), 0)
+	})
+
+	t.Run("Succeeded", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		cluster := &v1beta1.PostgresCluster{}
+
+		jobs := []*batchv1.Job{
+			job("a", 0, batchv1.JobFailed), job("b", 1, batchv1.JobComplete), job("c", 2, ""),
+		}
+		reconciler.setBackupVerificationStatus(cluster, jobs)
+
+		assert.Assert(t, cmp.MarshalMatches(cluster.Status.PGBackRest.Verification, `
+completionTime: "2025-01-02T03:01:30Z"
+duration: 1m30s
+jobName: b
+lastSuccessTime: "2025-01-02T03:01:30Z"
+repo: repo1
+startTime: "2025-01-02T03:00:00Z"
+succeeded: true
+		`))
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Reason, "BackupVerificationSucceeded")
+		assert.Equal(t, recorder.Events[0].Note, `Verified the latest backup in "repo1" in 1m30s`)
+
+		// The same Job is reported once.
+		reconciler.setBackupVerificationStatus(cluster, jobs)
+		assert.Equal(t, len(recorder.Events), 1)
+	})
+
+	t.Run("Failed", func(t *testing.T) {
+		recorder := events.NewRecorder(t, runtime.Scheme)
+		reconciler := &Reconciler{Recorder: recorder}
+		cluster := &v1beta1.PostgresCluster{}
+		cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+			Verification: &v1beta1.PGBackRestVerificationStatus{
+				JobName: "a", Succeeded: true, LastSuccessTime: &started,
+			},
+		}
+
+		reconciler.setBackupVerificationStatus(cluster, []*batchv1.Job{
+			job("a", 0, batchv1.JobComplete), job("b", 1, batchv1.JobFailed),
+		})
+
+		status := cluster.Status.PGBackRest.Verification
+		assert.Equal(t, status.JobName, "b")
+		assert.Equal(t, status.Succeeded, false)
+		assert.Equal(t, status.Duration.Duration, 90*time.Second)
+		assert.Equal(t, status.CompletionTime.Time, finished.Time)
+		assert.Equal(t, status.LastSuccessTime.Time, started.Time, "expected previous success")
+
+		assert.Equal(t, len(recorder.Events), 1)
+		assert.Equal(t, recorder.Events[0].Type, corev1.EventTypeWarning)
+		assert.Equal(t, recorder.Events[0].Reason, "BackupVerificationFailed")
+	})
+}
+
+func TestReconcileBackupVerificationStanza(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := testCluster()
+	cluster.Namespace, cluster.Name = "ns1", "hippo"
+	require.UnmarshalInto(t, &cluster.Spec.Backups.PGBackRest, `{
+		repos: [{ name: repo1, volume: { volumeClaimSpec: {} } }],
+		verification: { schedule: "0 3 * * *" },
+	}`)
+	cluster.Status.Patroni.SystemIdentifier = "12345"
+	cluster.Status.PGBackRest = &v1beta1.PGBackRestStatus{
+		Repos: []v1beta1.RepoStatus{{Name: "repo1"}},
+	}
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type: ConditionReplicaCreate, Status: metav1.ConditionTrue, Reason: "RepoBackupComplete",
+	})
+
+	recorder := events.NewRecorder(t, runtime.Scheme)
+	reconciler := &Reconciler{Owner: client.FieldOwner(t.Name()), Recorder: recorder}
+	reconciler.Client = fake.NewClientBuilder().WithScheme(runtime.Scheme).Build()
+
+	assert.Equal(t, reconciler.reconcileBackupVerification(ctx, cluster, nil), time.Duration(0))
+
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ConditionBackupVerificationReady)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, "StanzaNotCreated")
+	assert.Equal(t, len(recorder.Events), 1)
+	assert.Equal(t, recorder.Events[0].Reason, "StanzaNotCreated")
+
+	// No event without changes.
+	reconciler.reconcileBackupVerification(ctx, cluster, nil)
+	assert.Equal(t, len(recorder.Events), 1)
+
+	// The condition goes away once the stanza exists.
+	cluster.Status.PGBackRest.Repos[0].StanzaCreated = true
+	reconciler.reconcileBackupVerification(ctx, cluster, nil)
+	assert.Assert(t, meta.FindStatusCondition(cluster.Status.Conditions, ConditionBackupVerificationReady) == nil)
+	assert.Equal(t, len(recorder.Events), 1)
+}
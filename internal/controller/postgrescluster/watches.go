@@ -6,7 +6,9 @@ package postgrescluster
 
 import (
 	"context"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -61,22 +63,44 @@ func (*Reconciler) watchPods() handler.Funcs {
 				return
 			}
 
-			// auto-grow volume resize annotations
-			volumeAnnotations := []string{
-				"suggested-pgdata-pvc-size",
-				"suggested-repo1-pvc-size",
-				"suggested-repo2-pvc-size",
-				"suggested-repo3-pvc-size",
-				"suggested-repo4-pvc-size",
+			// Queue an event when a PostgreSQL replica pod changes role, address,
+			// or readiness. The replica Service may need its Endpoints updated.
+			// Other changes, such as container statuses, do not affect it.
+			oldPod, _ := e.ObjectOld.(*corev1.Pod)
+			newPod, _ := e.ObjectNew.(*corev1.Pod)
+			if len(cluster) != 0 && oldPod != nil && newPod != nil &&
+				len(labels[naming.LabelInstance]) != 0 &&
+				(oldPod.Labels[naming.LabelRole] == naming.RolePatroniReplica ||
+					newPod.Labels[naming.LabelRole] == naming.RolePatroniReplica) {
+				oldReady, _ := Instance{Pods: []*corev1.Pod{oldPod}}.IsReady()
+				newReady, _ := Instance{Pods: []*corev1.Pod{newPod}}.IsReady()
+
+				if oldReady != newReady ||
+					oldPod.Status.PodIP != newPod.Status.PodIP ||
+					oldPod.Labels[naming.LabelRole] != newPod.Labels[naming.LabelRole] {
+					q.Add(reconcile.Request{NamespacedName: client.ObjectKey{
+						Namespace: e.ObjectNew.GetNamespace(),
+						Name:      cluster,
+					}})
+					return
+				}
+			}
+
+			// auto-grow volume resize annotations, such as "suggested-pgdata-pvc-size",
+			// "suggested-repo1-pvc-size", and "suggested-tablespace-trial-pvc-size"
+			isVolumeAnnotation := func(annotation string) bool {
+				return strings.HasPrefix(annotation, "suggested-") &&
+					strings.HasSuffix(annotation, "-pvc-size")
 			}
 
 			oldAnnotations := e.ObjectOld.GetAnnotations()
 			newAnnotations := e.ObjectNew.GetAnnotations()
 
 			// cycle through each annotation and check for changes
-			for _, annotation := range volumeAnnotations {
-				// If the suggested-pgdata-pvc-size annotation is added or changes, reconcile.
-				if len(cluster) != 0 && oldAnnotations[annotation] != newAnnotations[annotation] {
+			for annotation := range naming.Merge(oldAnnotations, newAnnotations) {
+				// If a suggested volume size annotation is added or changes, reconcile.
+				if len(cluster) != 0 && isVolumeAnnotation(annotation) &&
+					oldAnnotations[annotation] != newAnnotations[annotation] {
 					q.Add(reconcile.Request{NamespacedName: client.ObjectKey{
 						Namespace: e.ObjectNew.GetNamespace(),
 						Name:      cluster,
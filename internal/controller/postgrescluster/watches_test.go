@@ -193,8 +193,93 @@ func TestWatchPodsUpdate(t *testing.T) {
 		startVal:   "1000Mi",
 		endval:     "2000Mi",
 		queueVal:   1,
+	}, {
+		annotation: "suggested-pgwal-pvc-size",
+		startVal:   "1000Mi",
+		endval:     "2000Mi",
+		queueVal:   1,
+	}, {
+		annotation: "suggested-tablespace-trial-pvc-size",
+		startVal:   "1000Mi",
+		endval:     "2000Mi",
+		queueVal:   1,
 	}}
 
+	t.Run("RoleReadiness", func(t *testing.T) {
+		expected := reconcile.Request{}
+		expected.Namespace = "some-ns"
+		expected.Name = "starfish"
+
+		base := &corev1.Pod{}
+		base.Namespace = "some-ns"
+		base.Labels = map[string]string{
+			"postgres-operator.crunchydata.com/cluster":  "starfish",
+			"postgres-operator.crunchydata.com/instance": "starfish-00-abcd",
+			"postgres-operator.crunchydata.com/role":     "replica",
+		}
+
+		// Role changed; one reconcile by label.
+		promoted := base.DeepCopy()
+		promoted.Labels["postgres-operator.crunchydata.com/role"] = "unknown"
+		update(ctx, event.UpdateEvent{
+			ObjectOld: base.DeepCopy(),
+			ObjectNew: promoted,
+		}, queue)
+		assert.Equal(t, queue.Len(), 1, "expected one reconcile")
+
+		item, _ := queue.Get()
+		assert.Equal(t, item, expected)
+		queue.Done(item)
+
+		// Readiness changed; one reconcile by label.
+		ready := base.DeepCopy()
+		ready.Status.Conditions = []corev1.PodCondition{{
+			Type: corev1.PodReady, Status: corev1.ConditionTrue,
+		}}
+		update(ctx, event.UpdateEvent{
+			ObjectOld: base.DeepCopy(),
+			ObjectNew: ready,
+		}, queue)
+		assert.Equal(t, queue.Len(), 1, "expected one reconcile")
+
+		item, _ = queue.Get()
+		assert.Equal(t, item, expected)
+		queue.Done(item)
+
+		// Container statuses changed; no reconcile.
+		restarted := base.DeepCopy()
+		restarted.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name: "database", RestartCount: 1,
+		}}
+		update(ctx, event.UpdateEvent{
+			ObjectOld: base.DeepCopy(),
+			ObjectNew: restarted,
+		}, queue)
+		assert.Equal(t, queue.Len(), 0, "expected no reconcile")
+
+		// Readiness of the primary changed; no reconcile.
+		primary := base.DeepCopy()
+		primary.Labels["postgres-operator.crunchydata.com/role"] = "master"
+		primaryReady := primary.DeepCopy()
+		primaryReady.Status.Conditions = ready.Status.Conditions
+		update(ctx, event.UpdateEvent{
+			ObjectOld: primary,
+			ObjectNew: primaryReady,
+		}, queue)
+		assert.Equal(t, queue.Len(), 0, "expected no reconcile")
+
+		// Readiness of a pod that is not an instance changed; no reconcile.
+		other := base.DeepCopy()
+		delete(other.Labels, "postgres-operator.crunchydata.com/instance")
+		otherReady := other.DeepCopy()
+		otherReady.Status.Conditions = ready.Status.Conditions
+		update(ctx, event.UpdateEvent{
+			ObjectOld: other,
+			ObjectNew: otherReady,
+		}, queue)
+		assert.Equal(t, queue.Len(), 0, "expected no reconcile")
+	})
+
 	for _, tc := range testCases {
 		t.Run(tc.annotation, func(t *testing.T) {
 
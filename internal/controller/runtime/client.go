@@ -7,6 +7,7 @@ package runtime
 import (
 	"context"
 
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -45,6 +46,10 @@ type ClientWriter struct {
 
 var _ client.Writer = ClientWriter{}
 
+func (ClientWriter) Apply(context.Context, k8sruntime.ApplyConfiguration, ...client.ApplyOption) error {
+	return nil
+}
+
 // NOTE: The following implementations can go away following https://go.dev/issue/47487.
 // The function types above would become single-method interfaces.
 
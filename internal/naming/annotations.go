@@ -14,6 +14,10 @@ const (
 	// Patroni Switchover (or Failover).
 	PatroniSwitchover = annotationPrefix + "trigger-switchover"
 
+	// PatroniApplyDelay is the annotation added to the Pods of a delayed instance set. Its value
+	// is the "recovery_min_apply_delay" that Patroni configures when it starts.
+	PatroniApplyDelay = annotationPrefix + "apply-delay"
+
 	// PGBackRestBackup is the annotation that is added to a PostgresCluster to initiate a manual
 	// backup.  The value of the annotation will be a unique identifier for a backup Job (e.g. a
 	// timestamp), which will be stored in the PostgresCluster status to properly track completion
@@ -81,6 +85,20 @@ const (
 	// touch cloud-based backups.
 	AuthorizeBackupRemovalAnnotation = annotationPrefix + "authorizeBackupRemoval"
 
+	// RotateRootCertificate is an annotation on the root certificate secret
+	// that starts a rotation of the root certificate authority. Each new value
+	// starts one rotation.
+	RotateRootCertificate = annotationPrefix + "rotate-root-certificate"
+
+	// RootCertificateRotated is an annotation on the root certificate secret
+	// that records the last value of RotateRootCertificate that started a
+	// rotation.
+	RootCertificateRotated = annotationPrefix + "root-certificate-rotated"
+
+	// RootCertificatePhaseStart is an annotation on the root certificate
+	// secret that records when the current phase of a rotation began.
+	RootCertificatePhaseStart = annotationPrefix + "root-certificate-phase-start"
+
 	// Used from Kubernetes v1.21+ to define a default container used when the
 	// `-c` flag is not passed.
 	// --https://kubernetes.io/docs/reference/labels-annotations-taints/#kubectl-kubernetes-io-default-container
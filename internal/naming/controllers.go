@@ -8,6 +8,8 @@ const (
 	ControllerBridge               = "bridge-controller"
 	ControllerCrunchyBridgeCluster = "crunchybridgecluster-controller"
 	ControllerPGAdmin              = "pgadmin-controller"
+	ControllerPGClone              = "pgclone-controller"
+	ControllerPGSwitchover         = "pgswitchover-controller"
 	ControllerPGUpgrade            = "pgupgrade-controller"
 	ControllerPostgresCluster      = "postgrescluster-controller"
 )
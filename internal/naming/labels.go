@@ -74,6 +74,13 @@ const (
 	// resource (e.g. a ConfigMap or Secret) is for a pgBackRest restore
 	LabelPGBackRestRestoreConfig = labelPrefix + "pgbackrest-restore-config"
 
+	// LabelPGBackRestVerification is used to indicate that a CronJob, Job, or
+	// volume is for verifying pgBackRest backups
+	LabelPGBackRestVerification = labelPrefix + "pgbackrest-verification"
+
+	// LabelPGClone identifies the PGClone that created a PostgresCluster
+	LabelPGClone = labelPrefix + "pgclone"
+
 	// LabelPGMonitorDiscovery is the label added to Pods running the "exporter" container to
 	// support discovery by Prometheus according to pgMonitor configuration
 	LabelPGMonitorDiscovery = labelPrefix + "crunchy-postgres-exporter"
@@ -274,6 +281,17 @@ func PGBackRestCronJobLabels(clusterName, repoName, backupType string) labels.Se
 	return labels.Merge(commonLabels, cronJobLabels)
 }
 
+// PGBackRestVerificationLabels provides labels for the CronJob and Jobs that
+// verify the backups in a pgBackRest repository.
+func PGBackRestVerificationLabels(clusterName, repoName string) labels.Set {
+	commonLabels := PGBackRestLabels(clusterName)
+	verificationLabels := map[string]string{
+		LabelPGBackRestRepo:         repoName,
+		LabelPGBackRestVerification: "",
+	}
+	return labels.Merge(commonLabels, verificationLabels)
+}
+
 // PGBackRestDedicatedLabels provides labels for a pgBackRest dedicated repository host
 func PGBackRestDedicatedLabels(clusterName string) labels.Set {
 	commonLabels := PGBackRestLabels(clusterName)
@@ -31,6 +31,7 @@ func TestLabelsValid(t *testing.T) {
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestRepoVolume))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestRestore))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestRestoreConfig))
+	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGBackRestVerification))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPGMonitorDiscovery))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelPostgresUser))
 	assert.Assert(t, nil == validation.IsQualifiedName(LabelStandalonePGAdmin))
@@ -186,6 +187,14 @@ func TestPGBackRestLabelFuncs(t *testing.T) {
 	assert.Equal(t, pgBackRestCronJobLabels.Get(LabelPGBackRestRepo), repoName)
 	assert.Equal(t, pgBackRestCronJobLabels.Get(LabelPGBackRestBackup), string(BackupScheduled))
 
+	// verify the labels that identify pgBackRest verification resources
+	pgBackRestVerificationLabels := PGBackRestVerificationLabels(clusterName, repoName)
+	assert.Equal(t, pgBackRestVerificationLabels.Get(LabelCluster), clusterName)
+	assert.Check(t, pgBackRestVerificationLabels.Has(LabelPGBackRest))
+	assert.Equal(t, pgBackRestVerificationLabels.Get(LabelPGBackRestRepo), repoName)
+	assert.Check(t, pgBackRestVerificationLabels.Has(LabelPGBackRestVerification))
+	assert.Check(t, !pgBackRestVerificationLabels.Has(LabelPGBackRestBackup))
+
 	// verify the labels that identify pgBackRest dedicated repository host resources
 	pgBackRestDedicatedLabels := PGBackRestDedicatedLabels(clusterName)
 	assert.Equal(t, pgBackRestDedicatedLabels.Get(LabelCluster), clusterName)
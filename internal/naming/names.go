@@ -38,6 +38,9 @@ const (
 	ContainerPGBouncer = "pgbouncer"
 	// ContainerPGBouncerConfig is the name of a container supporting PgBouncer.
 	ContainerPGBouncerConfig = "pgbouncer-config"
+	// ContainerPGBouncerReadOnly is the name of a container running PgBouncer
+	// with pools that connect to replicas.
+	ContainerPGBouncerReadOnly = "pgbouncer-ro"
 
 	// ContainerPostgresStartup is the name of the initialization container
 	// that prepares the filesystem for PostgreSQL.
@@ -76,6 +79,9 @@ const (
 	PortPGAdmin = "pgadmin"
 	// PortPGBouncer is the name of a port that connects to PgBouncer.
 	PortPGBouncer = "pgbouncer"
+	// PortPGBouncerReadOnly is the name of a port that connects to PgBouncer
+	// pools of replicas.
+	PortPGBouncerReadOnly = "pgbouncer-ro"
 	// PortPostgreSQL is the name of a port that connects to PostgreSQL.
 	PortPostgreSQL = "postgres"
 )
@@ -161,6 +167,10 @@ const (
 	// PGbouncerFullLogPath is the full path to the pgbouncer log file
 	PGBouncerFullLogPath = PGBouncerLogPath + "/pgbouncer.log"
 
+	// PGBouncerReadOnlyFullLogPath is the full path to the log file of read-only
+	// pgbouncer pools
+	PGBouncerReadOnlyFullLogPath = PGBouncerLogPath + "/pgbouncer-ro.log"
+
 	// suffix used with postgrescluster name for associated configmap.
 	// for instance, if the cluster is named 'mycluster', the
 	// configmap will be named 'mycluster-pgbackrest-config'
@@ -216,6 +226,15 @@ func ClusterPGBouncer(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	}
 }
 
+// ClusterPGBouncerReadOnly returns the ObjectMeta necessary to lookup the
+// Service that exposes cluster's read-only PgBouncer pools.
+func ClusterPGBouncerReadOnly(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-pgbouncer-ro",
+	}
+}
+
 // ClusterPodService returns the ObjectMeta necessary to lookup the Service
 // that is responsible for the network identity of Pods.
 func ClusterPodService(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
@@ -321,6 +340,16 @@ func InstanceSet(cluster *v1beta1.PostgresCluster,
 	}
 }
 
+// InstanceSetReplicaService returns the ObjectMeta necessary to lookup the
+// Service that exposes PostgreSQL replica instances of a single instance set.
+func InstanceSetReplicaService(cluster *v1beta1.PostgresCluster,
+	set *v1beta1.PostgresInstanceSetSpec) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      cluster.Name + "-" + set.Name + "-replicas",
+		Namespace: cluster.Namespace,
+	}
+}
+
 // InstancePostgresDataVolume returns the ObjectMeta for the PostgreSQL data
 // volume for instance.
 func InstancePostgresDataVolume(instance *appsv1.StatefulSet) metav1.ObjectMeta {
@@ -538,6 +567,51 @@ func PostgresTLSSecret(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	}
 }
 
+// PostgresIssuedCertificate returns the ObjectMeta necessary to lookup the
+// cert-manager Certificate and Secret of the PostgreSQL server.
+func PostgresIssuedCertificate(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-postgres-tls",
+	}
+}
+
+// ReplicationIssuedCertificate returns the ObjectMeta necessary to lookup the
+// cert-manager Certificate and Secret of the replication client.
+func ReplicationIssuedCertificate(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-replication-tls",
+	}
+}
+
+// PGBouncerIssuedCertificate returns the ObjectMeta necessary to lookup the
+// cert-manager Certificate and Secret of PgBouncer.
+func PGBouncerIssuedCertificate(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-pgbouncer-tls",
+	}
+}
+
+// InstanceIssuedCertificate returns the ObjectMeta necessary to lookup the
+// cert-manager Certificate and Secret of the Patroni API of instance.
+func InstanceIssuedCertificate(instance metav1.Object) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: instance.GetNamespace(),
+		Name:      instance.GetName() + "-patroni-tls",
+	}
+}
+
+// PostgresUserIssuedCertificate returns the ObjectMeta necessary to lookup the
+// cert-manager Certificate and Secret of the client certificate of username.
+func PostgresUserIssuedCertificate(cluster *v1beta1.PostgresCluster, username string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name + "-pgcert-" + username,
+	}
+}
+
 // MovePGDataDirJob returns the ObjectMeta for a pgData directory move Job
 func MovePGDataDirJob(cluster *v1beta1.PostgresCluster) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
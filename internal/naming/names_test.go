@@ -141,6 +141,9 @@ func TestClusterNamesUniqueAndValid(t *testing.T) {
 			{"PostgresTLSSecret", PostgresTLSSecret(cluster)},
 			{"ReplicationClientCertSecret", ReplicationClientCertSecret(cluster)},
 			{"MonitoringUserSecret", MonitoringUserSecret(cluster)},
+			{"PostgresIssuedCertificate", PostgresIssuedCertificate(cluster)},
+			{"ReplicationIssuedCertificate", ReplicationIssuedCertificate(cluster)},
+			{"PGBouncerIssuedCertificate", PGBouncerIssuedCertificate(cluster)},
 		})
 
 		// NOTE: This does not fail when a conflict is introduced. When adding a
@@ -161,6 +164,18 @@ func TestClusterNamesUniqueAndValid(t *testing.T) {
 				assert.Assert(t, !strings.HasPrefix(name, prefix), "%q may collide", name)
 			}
 		})
+
+		t.Run("PostgresUserIssuedCertificate", func(t *testing.T) {
+			value := PostgresUserIssuedCertificate(cluster, "some-user")
+
+			assert.Equal(t, value.Namespace, cluster.Namespace)
+			assert.Assert(t, nil == validation.IsDNS1123Label(value.Name))
+
+			prefix := PostgresUserIssuedCertificate(cluster, "").Name
+			for _, name := range append(sets.List(names), PostgresUserSecret(cluster, "").Name) {
+				assert.Assert(t, !strings.HasPrefix(name, prefix), "%q may collide", name)
+			}
+		})
 	})
 
 	t.Run("ServiceAccounts", func(t *testing.T) {
@@ -173,10 +188,12 @@ func TestClusterNamesUniqueAndValid(t *testing.T) {
 	t.Run("Services", func(t *testing.T) {
 		testUniqueAndValid(t, []test{
 			{"ClusterPGBouncer", ClusterPGBouncer(cluster)},
+			{"ClusterPGBouncerReadOnly", ClusterPGBouncerReadOnly(cluster)},
 			{"ClusterPGAdmin", ClusterPGAdmin(cluster)},
 			{"ClusterPodService", ClusterPodService(cluster)},
 			{"ClusterPrimaryService", ClusterPrimaryService(cluster)},
 			{"ClusterReplicaService", ClusterReplicaService(cluster)},
+			{"InstanceSetReplicaService", InstanceSetReplicaService(cluster, instanceSet)},
 			// Patroni can use Endpoints which relate directly to a Service.
 			{"PatroniDistributedConfiguration", PatroniDistributedConfiguration(cluster)},
 			{"PatroniLeaderEndpoints", PatroniLeaderEndpoints(cluster)},
@@ -251,6 +268,7 @@ func TestInstanceNamesUniqueAndValid(t *testing.T) {
 		names := sets.NewString()
 		for _, tt := range []test{
 			{"InstanceCertificates", InstanceCertificates(instance)},
+			{"InstanceIssuedCertificate", InstanceIssuedCertificate(instance)},
 		} {
 			t.Run(tt.name, func(t *testing.T) {
 				assert.Equal(t, tt.value.Namespace, instance.Namespace)
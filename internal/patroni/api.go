@@ -206,3 +206,45 @@ func (exec Executor) GetTimeline(ctx context.Context) (int64, error) {
 
 	return 0, err
 }
+
+// GetMemberLag gets the patronictl status and returns the replication lag, in
+// bytes, of each member by name. Members with an unknown lag, such as those
+// that are not streaming, are omitted. The leader has no lag.
+func (exec Executor) GetMemberLag(ctx context.Context) (map[string]int64, error) {
+	var stdout, stderr bytes.Buffer
+
+	// The following exits zero when it is able to read the DCS and communicate
+	// with the Patroni HTTP API. It prints the result of calling "GET /cluster"
+	// with lag in whole megabytes or "unknown".
+	// - https://github.com/zalando/patroni/blob/v2.1.1/patroni/ctl.py#L849
+	err := exec(ctx, nil, &stdout, &stderr,
+		"patronictl", "list", "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	if stderr.String() != "" {
+		return nil, errors.New(stderr.String())
+	}
+
+	var members []struct {
+		Member string `json:"Member"`
+		Role   string `json:"Role"`
+		Lag    any    `json:"Lag in MB"`
+	}
+	err = json.Unmarshal(stdout.Bytes(), &members)
+	if err != nil {
+		return nil, err
+	}
+
+	lag := make(map[string]int64, len(members))
+	for _, member := range members {
+		if member.Role == "Leader" || member.Role == "Standby Leader" {
+			lag[member.Member] = 0
+		} else if mb, ok := member.Lag.(float64); ok {
+			lag[member.Member] = int64(mb) << 20
+		}
+	}
+
+	return lag, nil
+}
@@ -287,3 +287,46 @@ func TestExecutorGetTimeline(t *testing.T) {
 		assert.Equal(t, tl, int64(4))
 	})
 }
+
+func TestExecutorGetMemberLag(t *testing.T) {
+	t.Run("Error", func(t *testing.T) {
+		expected := errors.New("bang")
+		lag, actual := Executor(func(
+			context.Context, io.Reader, io.Writer, io.Writer, ...string,
+		) error {
+			return expected
+		}).GetMemberLag(context.Background())
+
+		assert.Equal(t, expected, actual)
+		assert.Assert(t, lag == nil)
+	})
+
+	t.Run("Stderr", func(t *testing.T) {
+		lag, actual := Executor(func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			_, _ = stderr.Write([]byte(`no luck`))
+			return nil
+		}).GetMemberLag(context.Background())
+
+		assert.Error(t, actual, "no luck")
+		assert.Assert(t, lag == nil)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		lag, actual := Executor(func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.DeepEqual(t, command, strings.Fields(`patronictl list --format json`))
+			_, _ = stdout.Write([]byte(`[{"Cluster": "hippo-ha", "Member": "hippo-instance1-67mc-0", "Host": "hippo-instance1-67mc-0.hippo-pods", "Role": "Leader", "State": "running", "TL": 4}, {"Cluster": "hippo-ha", "Member": "hippo-instance1-ltcf-0", "Host": "hippo-instance1-ltcf-0.hippo-pods", "Role": "Replica", "State": "streaming", "TL": 4, "Lag in MB": 0}, {"Cluster": "hippo-ha", "Member": "hippo-analytics-x9z2-0", "Host": "hippo-analytics-x9z2-0.hippo-pods", "Role": "Replica", "State": "streaming", "TL": 4, "Lag in MB": 17}, {"Cluster": "hippo-ha", "Member": "hippo-analytics-q7w3-0", "Host": "hippo-analytics-q7w3-0.hippo-pods", "Role": "Replica", "State": "stopped", "TL": 4, "Lag in MB": "unknown"}]`))
+			return nil
+		}).GetMemberLag(context.Background())
+
+		assert.NilError(t, actual)
+		assert.DeepEqual(t, lag, map[string]int64{
+			"hippo-instance1-67mc-0": 0,
+			"hippo-instance1-ltcf-0": 0,
+			"hippo-analytics-x9z2-0": 17 << 20,
+		})
+	})
+}
@@ -40,7 +40,8 @@ const (
 // clusterYAML returns Patroni settings that apply to the entire cluster.
 func clusterYAML(
 	cluster *v1beta1.PostgresCluster,
-	pgHBAs *postgres.OrderedHBAs, parameters *postgres.ParameterSet, patroniLogStorageLimit int64,
+	pgHBAs *postgres.OrderedHBAs, pgIdentMaps *postgres.OrderedIdentMaps,
+	parameters *postgres.ParameterSet, patroniLogStorageLimit int64,
 ) (string, error) {
 	root := map[string]any{
 		// The cluster identifier. This value cannot change during the cluster's
@@ -195,7 +196,7 @@ func clusterYAML(
 		// facilitate it. When Patroni is already bootstrapped, this field is ignored.
 
 		root["bootstrap"] = map[string]any{
-			"dcs": DynamicConfiguration(&cluster.Spec, pgHBAs, parameters),
+			"dcs": DynamicConfiguration(&cluster.Spec, pgHBAs, pgIdentMaps, parameters),
 
 			// Missing here is "users" which runs *after* "post_bootstrap". It is
 			// not possible to use roles created by the former in the latter.
@@ -211,7 +212,8 @@ func clusterYAML(
 // and returns a value that can be marshaled to JSON.
 func DynamicConfiguration(
 	spec *v1beta1.PostgresClusterSpec,
-	pgHBAs *postgres.OrderedHBAs, parameters *postgres.ParameterSet,
+	pgHBAs *postgres.OrderedHBAs, pgIdentMaps *postgres.OrderedIdentMaps,
+	parameters *postgres.ParameterSet,
 ) map[string]any {
 	// Copy the entire configuration before making any changes.
 	root := make(map[string]any)
@@ -246,6 +248,12 @@ func DynamicConfiguration(
 	if pgHBAs != nil {
 		postgresql["pg_hba"] = pgHBAs.AsStrings()
 	}
+	// Patroni writes these to the "pg_ident.conf" file in its "config_dir",
+	// which is the default "ident_file", and reloads PostgreSQL when they change.
+	// - https://patroni.readthedocs.io/en/latest/dynamic_configuration.html
+	if pgIdentMaps != nil {
+		postgresql["pg_ident"] = pgIdentMaps.AsStrings()
+	}
 	root["postgresql"] = postgresql
 
 	// Enabling `pg_rewind` allows a former primary to automatically rejoin the
@@ -483,6 +491,16 @@ func instanceYAML(
 		},
 	}
 
+	// Instances of a delayed set must never be promoted, and they should not
+	// receive read traffic or hold up synchronous commits.
+	// - https://patroni.readthedocs.io/en/latest/yaml_configuration.html#tags
+	if RecoveryMinApplyDelay(instance) != "" {
+		tags := root["tags"].(map[string]any)
+		tags["nofailover"] = true
+		tags["noloadbalance"] = true
+		tags["nosync"] = true
+	}
+
 	postgresql := map[string]any{
 		// TODO(cbandy): "bin_dir"
 
@@ -532,6 +550,23 @@ func instanceYAML(
 	// method? This is a list and cannot be merged.
 	postgresql["create_replica_methods"] = methods
 
+	// Parameters in the local configuration take precedence over those in the
+	// dynamic configuration. Use them for autotuned values that vary between
+	// instance sets, but never for parameters that the user specified.
+	// - https://patroni.readthedocs.io/en/latest/patroni_configuration.html
+	if parameters := instanceParameters(cluster, instance); len(parameters) > 0 {
+		postgresql["parameters"] = parameters
+	}
+
+	// Patroni writes "recovery_conf" settings only when the instance is a
+	// replica. PostgreSQL applies them after a reload.
+	// - https://patroni.readthedocs.io/en/latest/yaml_configuration.html#postgresql
+	if delay := RecoveryMinApplyDelay(instance); delay != "" {
+		postgresql["recovery_conf"] = map[string]any{
+			"recovery_min_apply_delay": delay,
+		}
+	}
+
 	if !ClusterBootstrapped(cluster) {
 		isRestore := (cluster.Status.PGBackRest != nil && cluster.Status.PGBackRest.Restore != nil)
 		isDataSource := (cluster.Spec.DataSource != nil && cluster.Spec.DataSource.Volumes != nil &&
@@ -596,6 +631,28 @@ func instanceYAML(
 	return string(append([]byte(yamlGeneratedWarning), b...)), err
 }
 
+// instanceParameters returns the autotuned PostgreSQL parameters of instance
+// that are not specified in cluster.spec.config.parameters or
+// cluster.spec.patroni.dynamicConfiguration.
+func instanceParameters(
+	cluster *v1beta1.PostgresCluster, instance *v1beta1.PostgresInstanceSetSpec,
+) map[string]string {
+	parameters := postgres.AutotuneInstance(cluster, instance).AsMap()
+	specified := PostgresParameters(cluster.Spec.Patroni)
+
+	for k := range parameters {
+		if specified.Has(k) {
+			delete(parameters, k)
+		}
+		if config := cluster.Spec.Config; config != nil {
+			if _, ok := config.Parameters[k]; ok {
+				delete(parameters, k)
+			}
+		}
+	}
+	return parameters
+}
+
 // probeTiming returns a Probe with thresholds and timeouts set according to spec.
 func probeTiming(spec *v1beta1.PatroniSpec) *corev1.Probe {
 	// "Probes should be configured in such a way that they start failing about
@@ -32,7 +32,7 @@ func TestClusterYAML(t *testing.T) {
 		cluster.Namespace = "some-namespace"
 		cluster.Name = "cluster-name"
 
-		data, err := clusterYAML(cluster, nil, nil, 0)
+		data, err := clusterYAML(cluster, nil, nil, nil, 0)
 		assert.NilError(t, err)
 		assert.Equal(t, data, strings.TrimSpace(`
 # Generated by postgres-operator. DO NOT EDIT.
@@ -90,7 +90,7 @@ watchdog:
 		cluster.Name = "cluster-name"
 		cluster.Spec.PostgresVersion = 14
 
-		data, err := clusterYAML(cluster, nil, nil, 0)
+		data, err := clusterYAML(cluster, nil, nil, nil, 0)
 		assert.NilError(t, err)
 		assert.Equal(t, data, strings.TrimSpace(`
 # Generated by postgres-operator. DO NOT EDIT.
@@ -157,7 +157,7 @@ watchdog:
 			Level:        &logLevel,
 		}
 
-		data, err := clusterYAML(cluster, nil, nil, 1000)
+		data, err := clusterYAML(cluster, nil, nil, nil, 1000)
 		assert.NilError(t, err)
 		assert.Equal(t, data, strings.TrimSpace(`
 # Generated by postgres-operator. DO NOT EDIT.
@@ -233,10 +233,17 @@ func TestDynamicConfiguration(t *testing.T) {
 		return out
 	}
 
+	maps := func(in ...string) *postgres.OrderedIdentMaps {
+		out := new(postgres.OrderedIdentMaps)
+		out.AppendUnstructured(in...)
+		return out
+	}
+
 	for _, tt := range []struct {
 		name     string
 		spec     string
 		hbas     *postgres.OrderedHBAs
+		idents   *postgres.OrderedIdentMaps
 		params   *postgres.ParameterSet
 		expected map[string]any
 	}{
@@ -384,6 +391,28 @@ func TestDynamicConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ident pass through",
+			spec: `{
+				patroni: {
+					dynamicConfiguration: {
+						postgresql: {
+							pg_ident: [calculated, elsewhere],
+						},
+					},
+				},
+			}`,
+			idents: maps("function args"),
+			expected: map[string]any{
+				"loop_wait": int32(10),
+				"ttl":       int32(30),
+				"postgresql": map[string]any{
+					"pg_ident":      []string{"function args"},
+					"use_pg_rewind": true,
+					"use_slots":     false,
+				},
+			},
+		},
 		{
 			name: "standby_cluster: input passes through",
 			spec: `{
@@ -558,7 +587,7 @@ func TestDynamicConfiguration(t *testing.T) {
 				cluster.Spec.PostgresVersion = 14
 			}
 			cluster.Default()
-			actual := DynamicConfiguration(&cluster.Spec, tt.hbas, tt.params)
+			actual := DynamicConfiguration(&cluster.Spec, tt.hbas, tt.idents, tt.params)
 			assert.DeepEqual(t, tt.expected, actual)
 		})
 	}
@@ -764,6 +793,74 @@ restapi: {}
 tags: {}
 	`, "\t\n")+"\n")
 
+	t.Run("Delayed", func(t *testing.T) {
+		cluster := &v1beta1.PostgresCluster{Spec: v1beta1.PostgresClusterSpec{PostgresVersion: 12}}
+		cluster.Status.Patroni.SystemIdentifier = "some"
+
+		instance := new(v1beta1.PostgresInstanceSetSpec)
+		require.UnmarshalInto(t, instance, `{ role: delayed, applyDelay: 4h }`)
+
+		data, err := instanceYAML(cluster, instance, nil)
+		assert.NilError(t, err)
+		assert.Equal(t, data, strings.Trim(`
+# Generated by postgres-operator. DO NOT EDIT.
+# Your changes will not be saved.
+kubernetes: {}
+postgresql:
+  basebackup:
+  - waldir=/pgdata/pg12_wal
+  create_replica_methods:
+  - basebackup
+  pgpass: /tmp/.pgpass
+  recovery_conf:
+    recovery_min_apply_delay: 14400s
+  use_unix_socket: true
+restapi: {}
+tags:
+  nofailover: true
+  noloadbalance: true
+  nosync: true
+		`, "\t\n")+"\n")
+	})
+
+	t.Run("Autotune", func(t *testing.T) {
+		cluster := &v1beta1.PostgresCluster{Spec: v1beta1.PostgresClusterSpec{PostgresVersion: 12}}
+		cluster.Status.Patroni.SystemIdentifier = "some"
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			config: {
+				autotune: {},
+				parameters: { shared_buffers: 512MB },
+			},
+			instances: [
+				{ name: small, resources: { limits: { cpu: 1, memory: 2Gi } } },
+				{ name: large, resources: { limits: { cpu: 1, memory: 4Gi } } },
+			],
+			patroni: {
+				dynamicConfiguration: {
+					postgresql: { parameters: { maintenance_work_mem: 1GB } },
+				},
+			},
+		}`)
+
+		data, err := instanceYAML(cluster, &cluster.Spec.InstanceSets[0], nil)
+		assert.NilError(t, err)
+		assert.Equal(t, data, strings.Trim(`
+# Generated by postgres-operator. DO NOT EDIT.
+# Your changes will not be saved.
+kubernetes: {}
+postgresql:
+  basebackup:
+  - waldir=/pgdata/pg12_wal
+  create_replica_methods:
+  - basebackup
+  parameters:
+    effective_cache_size: 1536MB
+  pgpass: /tmp/.pgpass
+  use_unix_socket: true
+restapi: {}
+tags: {}
+		`, "\t\n")+"\n")
+	})
 }
 
 func TestPGBackRestCreateReplicaCommand(t *testing.T) {
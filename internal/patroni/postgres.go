@@ -7,6 +7,7 @@ package patroni
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/crunchydata/postgres-operator/internal/postgres"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
@@ -39,6 +40,33 @@ func PostgresHBAs(spec *v1beta1.PatroniSpec) []string {
 	return result
 }
 
+// PostgresIdentMaps returns the user name maps in spec, if any.
+func PostgresIdentMaps(spec *v1beta1.PatroniSpec) []string {
+	var result []string
+
+	if spec != nil {
+		// DynamicConfiguration lacks an OpenAPI schema, so it may contain any type
+		// at any depth. Navigate the object and skip map values that aren't string.
+		//
+		// Patroni expects a list of strings:
+		// https://github.com/patroni/patroni/blob/v4.0.0/patroni/validator.py
+		//
+		if root := spec.DynamicConfiguration; root != nil {
+			if postgresql, ok := root["postgresql"].(map[string]any); ok {
+				if section, ok := postgresql["pg_ident"].([]any); ok {
+					for i := range section {
+						if value, ok := section[i].(string); ok {
+							result = append(result, value)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return result
+}
+
 // PostgresParameters returns the Postgres parameters in spec, if any.
 func PostgresParameters(spec *v1beta1.PatroniSpec) *postgres.ParameterSet {
 	result := postgres.NewParameterSet()
@@ -81,3 +109,18 @@ func PostgresParameters(spec *v1beta1.PatroniSpec) *postgres.ParameterSet {
 
 	return result
 }
+
+// RecoveryMinApplyDelay returns the value of the "recovery_min_apply_delay"
+// parameter for instances in instance. It is empty when instance is not delayed.
+func RecoveryMinApplyDelay(instance *v1beta1.PostgresInstanceSetSpec) string {
+	if instance == nil || instance.Role != v1beta1.InstanceSetRoleDelayed ||
+		instance.ApplyDelay == nil {
+		return ""
+	}
+
+	// PostgreSQL understands units up to days, but seconds are exact and
+	// easiest to compare.
+	// - https://www.postgresql.org/docs/current/config-setting.html#CONFIG-SETTING-NAMES-VALUES
+	seconds := int64(instance.ApplyDelay.AsDuration().Seconds())
+	return strconv.FormatInt(seconds, 10) + "s"
+}
@@ -98,6 +98,48 @@ func TestPostgresHBAs(t *testing.T) {
 	})
 }
 
+func TestPostgresIdentMaps(t *testing.T) {
+	t.Run("Zero", func(t *testing.T) {
+		assert.Assert(t, PostgresIdentMaps(nil) == nil)
+		assert.Assert(t, PostgresIdentMaps(new(v1beta1.PatroniSpec)) == nil)
+	})
+
+	t.Run("WrongType", func(t *testing.T) {
+		spec := new(v1beta1.PatroniSpec)
+		require.UnmarshalInto(t, spec, `{
+			dynamicConfiguration: {
+				postgresql: {
+					pg_ident: asdf,
+				},
+			},
+		}`)
+
+		assert.Assert(t, PostgresIdentMaps(spec) == nil)
+	})
+
+	t.Run("Maps", func(t *testing.T) {
+		spec := new(v1beta1.PatroniSpec)
+		require.UnmarshalInto(t, spec, `{
+			dynamicConfiguration: {
+				postgresql: {
+					pg_ident: [
+						"omicron bryanh bryanh",
+						true,
+						"total garbage, yikes",
+						123,
+					],
+				},
+			},
+		}`)
+
+		result := PostgresIdentMaps(spec)
+		assert.DeepEqual(t, result, []string{
+			"omicron bryanh bryanh",
+			"total garbage, yikes",
+		})
+	})
+}
+
 func TestPostgresParameters(t *testing.T) {
 	t.Run("Zero", func(t *testing.T) {
 		result := PostgresParameters(nil)
@@ -195,3 +237,30 @@ func TestPostgresParameters(t *testing.T) {
 		})
 	})
 }
+
+func TestRecoveryMinApplyDelay(t *testing.T) {
+	assert.Equal(t, RecoveryMinApplyDelay(nil), "")
+
+	instance := new(v1beta1.PostgresInstanceSetSpec)
+	assert.Equal(t, RecoveryMinApplyDelay(instance), "")
+
+	t.Run("NotDelayed", func(t *testing.T) {
+		instance := new(v1beta1.PostgresInstanceSetSpec)
+		require.UnmarshalInto(t, instance, `{ role: default, applyDelay: 1h }`)
+		assert.Equal(t, RecoveryMinApplyDelay(instance), "")
+	})
+
+	t.Run("Delayed", func(t *testing.T) {
+		for _, tt := range []struct {
+			delay, expected string
+		}{
+			{delay: "90s", expected: "90s"},
+			{delay: "4h", expected: "14400s"},
+			{delay: "1d 30min", expected: "88200s"},
+		} {
+			instance := new(v1beta1.PostgresInstanceSetSpec)
+			require.UnmarshalInto(t, instance, `{ role: delayed, applyDelay: "`+tt.delay+`" }`)
+			assert.Equal(t, RecoveryMinApplyDelay(instance), tt.expected, "delay %q", tt.delay)
+		}
+	})
+}
@@ -6,6 +6,7 @@ package patroni
 
 import (
 	"context"
+	"encoding/json"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +31,7 @@ func ClusterBootstrapped(postgresCluster *v1beta1.PostgresCluster) bool {
 func ClusterConfigMap(ctx context.Context,
 	inCluster *v1beta1.PostgresCluster,
 	inHBAs *postgres.OrderedHBAs,
+	inIdentMaps *postgres.OrderedIdentMaps,
 	inParameters *postgres.ParameterSet,
 	outClusterConfigMap *corev1.ConfigMap,
 	patroniLogStorageLimit int64,
@@ -39,7 +41,7 @@ func ClusterConfigMap(ctx context.Context,
 	initialize.Map(&outClusterConfigMap.Data)
 
 	outClusterConfigMap.Data[configMapFileKey], err = clusterYAML(inCluster, inHBAs,
-		inParameters, patroniLogStorageLimit)
+		inIdentMaps, inParameters, patroniLogStorageLimit)
 
 	return err
 }
@@ -64,7 +66,7 @@ func InstanceConfigMap(ctx context.Context,
 
 // InstanceCertificates populates the shared Secret with certificates needed to run Patroni.
 func InstanceCertificates(ctx context.Context,
-	inRoot pki.Certificate, inDNS pki.Certificate,
+	inRoot pki.CertificateBundle, inDNS pki.Certificate,
 	inDNSKey pki.PrivateKey, outInstanceCertificates *corev1.Secret,
 ) error {
 	initialize.Map(&outInstanceCertificates.Data)
@@ -98,6 +100,14 @@ func InstancePod(ctx context.Context,
 	// "kubernetes.labels" settings.
 	outInstancePod.Labels[naming.LabelPatroni] = naming.PatroniScope(inCluster)
 
+	// Patroni reads its tags and "recovery_conf" from the instance ConfigMap
+	// when it starts. Record them on the Pod so that changing them causes a
+	// rolling restart.
+	if delay := RecoveryMinApplyDelay(inInstanceSpec); delay != "" {
+		initialize.Annotations(outInstancePod)
+		outInstancePod.Annotations[naming.PatroniApplyDelay] = delay
+	}
+
 	var container *corev1.Container
 	for i := range outInstancePod.Spec.Containers {
 		if outInstancePod.Spec.Containers[i].Name == naming.ContainerDatabase {
@@ -204,6 +214,22 @@ func PodIsStandbyLeader(pod metav1.Object) bool {
 	return strings.Contains(status, `"role":"standby_leader"`)
 }
 
+// PodTimeline returns the PostgreSQL timeline of pod as reported by Patroni.
+// It returns zero when the timeline is unknown.
+func PodTimeline(pod metav1.Object) int64 {
+	if pod == nil {
+		return 0
+	}
+
+	// This works only when using Kubernetes for DCS.
+	// - https://github.com/zalando/patroni/blob/v3.1.1/patroni/ha.py#L296
+	var status struct {
+		Timeline int64 `json:"timeline"`
+	}
+	_ = json.Unmarshal([]byte(pod.GetAnnotations()["status"]), &status)
+	return status.Timeline
+}
+
 // PodRequiresRestart returns whether or not PostgreSQL inside pod has (pending)
 // parameter changes that require a PostgreSQL restart.
 func PodRequiresRestart(pod metav1.Object) bool {
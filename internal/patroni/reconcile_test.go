@@ -15,6 +15,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
@@ -26,15 +27,15 @@ func TestClusterConfigMap(t *testing.T) {
 	cluster.Default()
 
 	config := new(corev1.ConfigMap)
-	assert.NilError(t, ClusterConfigMap(ctx, cluster, nil, nil, config, 0))
+	assert.NilError(t, ClusterConfigMap(ctx, cluster, nil, nil, nil, config, 0))
 
 	// The output of clusterYAML should go into config.
-	data, _ := clusterYAML(cluster, nil, nil, 0)
+	data, _ := clusterYAML(cluster, nil, nil, nil, 0)
 	assert.DeepEqual(t, config.Data["patroni.yaml"], data)
 
 	// No change when called again.
 	before := config.DeepCopy()
-	assert.NilError(t, ClusterConfigMap(ctx, cluster, nil, nil, config, 0))
+	assert.NilError(t, ClusterConfigMap(ctx, cluster, nil, nil, nil, config, 0))
 	assert.DeepEqual(t, config, before)
 }
 
@@ -76,7 +77,7 @@ func TestReconcileInstanceCertificates(t *testing.T) {
 	secret := new(corev1.Secret)
 
 	assert.NilError(t, InstanceCertificates(ctx,
-		root.Certificate, leaf.Certificate, leaf.PrivateKey, secret))
+		root.Bundle(), leaf.Certificate, leaf.PrivateKey, secret))
 
 	assert.DeepEqual(t, secret.Data["patroni.ca-roots"], dataCA)
 	assert.DeepEqual(t, secret.Data["patroni.crt-combined"], dataCert)
@@ -84,7 +85,7 @@ func TestReconcileInstanceCertificates(t *testing.T) {
 	// No change when called again.
 	before := secret.DeepCopy()
 	assert.NilError(t, InstanceCertificates(ctx,
-		root.Certificate, leaf.Certificate, leaf.PrivateKey, secret))
+		root.Bundle(), leaf.Certificate, leaf.PrivateKey, secret))
 	assert.DeepEqual(t, secret, before)
 }
 
@@ -215,6 +216,23 @@ volumes:
         - key: patroni.crt-combined
           path: ~postgres-operator/patroni.crt+key
 	`))
+
+	t.Run("Delayed", func(t *testing.T) {
+		instanceSpec := new(v1beta1.PostgresInstanceSetSpec)
+		require.UnmarshalInto(t, instanceSpec, `{ role: delayed, applyDelay: 15m }`)
+
+		template := new(corev1.PodTemplateSpec)
+		template.Spec.Containers = []corev1.Container{{Name: "database"}}
+
+		assert.NilError(t, InstancePod(context.Background(),
+			cluster, clusterConfigMap, clusterPodService, patroniLeaderService,
+			instanceSpec, instanceCertificates, instanceConfigMap, template))
+
+		assert.DeepEqual(t, template.ObjectMeta, metav1.ObjectMeta{
+			Annotations: map[string]string{naming.PatroniApplyDelay: "900s"},
+			Labels:      map[string]string{naming.LabelPatroni: "some-such-ha"},
+		})
+	})
 }
 
 func TestPodIsPrimary(t *testing.T) {
@@ -267,6 +285,27 @@ func TestPodIsStandbyLeader(t *testing.T) {
 	assert.Assert(t, PodIsStandbyLeader(pod))
 }
 
+func TestPodTimeline(t *testing.T) {
+	// No object
+	assert.Equal(t, PodTimeline(nil), int64(0))
+
+	// No annotations
+	pod := &corev1.Pod{}
+	assert.Equal(t, PodTimeline(pod), int64(0))
+
+	// No timeline
+	pod.Annotations = map[string]string{"status": `{"role":"replica"}`}
+	assert.Equal(t, PodTimeline(pod), int64(0))
+
+	// Unexpected value
+	pod.Annotations["status"] = `{"timeline":"mystery"}`
+	assert.Equal(t, PodTimeline(pod), int64(0))
+
+	// Timeline
+	pod.Annotations["status"] = `{"role":"standby_leader","timeline":7}`
+	assert.Equal(t, PodTimeline(pod), int64(7))
+}
+
 func TestPodRequiresRestart(t *testing.T) {
 	// No object
 	assert.Assert(t, !PodRequiresRestart(nil))
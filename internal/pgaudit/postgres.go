@@ -5,10 +5,15 @@
 package pgaudit
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"slices"
+	"strings"
 
 	"github.com/crunchydata/postgres-operator/internal/logging"
 	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 // When the pgAudit shared library is not loaded, the extension cannot be
@@ -50,12 +55,449 @@ func EnableInPostgreSQL(ctx context.Context, exec postgres.Executor) error {
 	return err
 }
 
-// PostgreSQLParameters sets the parameters required by pgAudit.
-func PostgreSQLParameters(outParameters *postgres.Parameters) {
+// PostgreSQLParameters sets the parameters required by pgAudit and those in
+// the audit section of inCluster.
+func PostgreSQLParameters(inCluster *v1beta1.PostgresCluster, outParameters *postgres.Parameters) {
 
 	// Load the shared library when PostgreSQL starts.
 	// PostgreSQL must be restarted when changing this value.
 	// - https://github.com/pgaudit/pgaudit#settings
 	// - https://www.postgresql.org/docs/current/runtime-config-client.html
 	outParameters.Mandatory.AppendToList("shared_preload_libraries", "pgaudit")
+
+	// These can be changed without restarting PostgreSQL.
+	if inCluster != nil && inCluster.Spec.Audit != nil {
+		for k, v := range policySettings(inCluster.Spec.Audit.Log, inCluster.Spec.Audit.LogParameter) {
+			outParameters.Mandatory.Add(k, v)
+		}
+	}
+}
+
+// policySettings returns the pgAudit parameters for classes and logParameter.
+// Unspecified values are omitted.
+func policySettings(classes []v1beta1.PGAuditClass, logParameter *bool) map[string]string {
+	settings := map[string]string{}
+	if len(classes) > 0 {
+		settings["pgaudit.log"] = strings.Join(classes, ",")
+	}
+	if logParameter != nil && *logParameter {
+		settings["pgaudit.log_parameter"] = "on"
+	} else if logParameter != nil {
+		settings["pgaudit.log_parameter"] = "off"
+	}
+	return settings
+}
+
+// tablePrivileges are the privileges that determine which statements are
+// logged by object audit logging.
+// - https://github.com/pgaudit/pgaudit#object-audit-logging
+var tablePrivileges = []string{"SELECT", "INSERT", "UPDATE", "DELETE"}
+
+// WritePoliciesInPostgreSQL calls exec to apply the database, role, and object
+// policies of spec. Settings of databases and roles that are not in spec are
+// reset, and audit roles that are not in spec lose their table privileges.
+// Audit roles that can login are not used and keep their privileges. It
+// returns the databases, roles, and tables that do not exist yet and the
+// audit roles that can login.
+func WritePoliciesInPostgreSQL(
+	ctx context.Context, exec postgres.Executor, spec *v1beta1.PostgresAuditSpec,
+) ([]string, error) {
+	log := logging.FromContext(ctx)
+
+	type policy struct {
+		Kind     string            `json:"kind"`
+		Name     string            `json:"name"`
+		Settings map[string]string `json:"settings,omitempty"`
+	}
+	type grant struct {
+		Database   string `json:"database"`
+		Role       string `json:"role"`
+		Privileges string `json:"privileges"`
+		Schema     string `json:"schema"`
+		Table      string `json:"table"`
+	}
+
+	var policies []policy
+	var grants []grant
+	var missing []string
+
+	auditors := []string{}
+	if spec != nil {
+		for _, in := range spec.Objects {
+			if !slices.Contains(auditors, in.Role) {
+				auditors = append(auditors, in.Role)
+			}
+		}
+	}
+
+	// Create audit roles that do not already exist. They cannot login. Then
+	// read the audit role of every database before any settings change, and
+	// the audit roles that can login. Those that are no longer in spec lose
+	// their privileges below, unless they can login.
+	// - https://www.postgresql.org/docs/current/sql-createrole.html
+	// - https://www.postgresql.org/docs/current/catalog-pg-db-role-setting.html
+	var observed struct {
+		Current []grant  `json:"current"`
+		Login   []string `json:"login"`
+	}
+	var stdout, stderr string
+	auditorsJSON, err := json.Marshal(auditors)
+	if err == nil {
+		stdout, stderr, err = exec.Exec(ctx, strings.NewReader(`SET synchronous_commit = LOCAL;
+SET search_path TO '';
+\pset format unaligned
+\pset tuples_only on
+SELECT pg_catalog.format('CREATE ROLE %I NOLOGIN', a.name)
+  FROM pg_catalog.json_array_elements_text(:'auditors') WITH ORDINALITY AS a (name, n)
+ WHERE NOT EXISTS (SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = a.name)
+ ORDER BY a.n
+\gexec
+WITH current AS (
+SELECT d.datname AS database,
+       pg_catalog.substr(setting, pg_catalog.strpos(setting, '=') + 1) AS role
+  FROM pg_catalog.pg_db_role_setting s
+  JOIN pg_catalog.pg_database d ON d.oid = s.setdatabase,
+       pg_catalog.unnest(s.setconfig) AS setting
+ WHERE s.setrole = 0 AND pg_catalog.split_part(setting, '=', 1) = 'pgaudit.role')
+SELECT pg_catalog.json_build_object(
+       'current', (SELECT pg_catalog.json_agg(c) FROM current c),
+       'login', (SELECT pg_catalog.json_agg(rolname ORDER BY rolname)
+                   FROM pg_catalog.pg_roles
+                  WHERE rolcanlogin AND (
+                        rolname IN (SELECT role FROM current) OR
+                        rolname IN (SELECT pg_catalog.json_array_elements_text(:'auditors')))));
+`), map[string]string{
+			"auditors": string(auditorsJSON),
+
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+	}
+	if err == nil && strings.TrimSpace(stdout) != "" {
+		err = json.Unmarshal([]byte(stdout), &observed)
+	}
+	if err != nil {
+		log.V(1).Info("read pgAudit roles", "stdout", stdout, "stderr", stderr)
+		return nil, err
+	}
+
+	// An audit role that can login is likely used by applications. Do not
+	// make it an audit role nor change its privileges.
+	login := func(role string) bool { return slices.Contains(observed.Login, role) }
+	for _, role := range auditors {
+		if login(role) {
+			missing = append(missing, "audit role "+role+" that can login")
+		}
+	}
+
+	if spec != nil {
+		for _, in := range spec.Databases {
+			policies = append(policies, policy{
+				Kind: "database", Name: in.Name,
+				Settings: policySettings(in.Log, in.LogParameter),
+			})
+		}
+
+		// The audit role of a database is one of its settings.
+		for _, in := range spec.Objects {
+			if login(in.Role) {
+				continue
+			}
+
+			i := slices.IndexFunc(policies, func(p policy) bool {
+				return p.Kind == "database" && p.Name == in.Database
+			})
+			if i < 0 {
+				i = len(policies)
+				policies = append(policies, policy{
+					Kind: "database", Name: in.Database, Settings: map[string]string{},
+				})
+			}
+			policies[i].Settings["pgaudit.role"] = in.Role
+
+			for _, table := range in.Tables {
+				out := grant{
+					Database: in.Database, Role: in.Role,
+					Schema: table.Schema, Table: table.Table,
+				}
+
+				// Apache AGE stores each label in a table named after it in the
+				// schema of its graph.
+				if table.Label != "" {
+					out.Schema, out.Table = table.Graph, table.Label
+				}
+				if out.Schema == "" {
+					out.Schema = "public"
+				}
+
+				// The privileges are written into SQL without quoting.
+				var privileges []string
+				for _, privilege := range tablePrivileges {
+					if slices.Contains(table.Privileges, privilege) {
+						privileges = append(privileges, privilege)
+					}
+				}
+				if len(privileges) > 0 {
+					out.Privileges = strings.Join(privileges, ", ")
+					grants = append(grants, out)
+				}
+			}
+		}
+
+		for _, in := range spec.Roles {
+			policies = append(policies, policy{
+				Kind: "role", Name: in.Name,
+				Settings: policySettings(in.Log, in.LogParameter),
+			})
+		}
+	}
+
+	// Revoke the privileges of audit roles that are no longer in spec. These
+	// have no table and are not granted anything. The roles are not dropped.
+	// This happens before their settings are reset below so that it is tried
+	// again when it fails.
+	for _, previous := range observed.Current {
+		if !login(previous.Role) && (spec == nil ||
+			!slices.ContainsFunc(spec.Objects, func(in v1beta1.PGAuditObjectsSpec) bool {
+				return in.Database == previous.Database && in.Role == previous.Role
+			})) {
+			grants = append(grants, grant{Database: previous.Database, Role: previous.Role})
+		}
+	}
+
+	var sql bytes.Buffer
+
+	// Grant and revoke table privileges first.
+	if len(grants) > 0 {
+		var databases []string
+		for _, in := range grants {
+			if !slices.Contains(databases, in.Database) {
+				databases = append(databases, in.Database)
+			}
+		}
+
+		encoder := json.NewEncoder(&sql)
+		encoder.SetEscapeHTML(false)
+
+		// Do not wait for changes to be replicated. [Since PostgreSQL v9.1]
+		// - https://www.postgresql.org/docs/current/runtime-config-wal.html
+		//
+		// Prevent unexpected dereferences by emptying "search_path". The
+		// "pg_catalog" schema is still searched, and only temporary objects can
+		// be created.
+		// - https://www.postgresql.org/docs/current/runtime-config-client.html#GUC-SEARCH-PATH
+		_, _ = sql.WriteString(`SET synchronous_commit = LOCAL;SET search_path TO '';`)
+
+		// Print query results one value per line.
+		// - https://www.postgresql.org/docs/current/app-psql.html#APP-PSQL-META-COMMAND-PSET
+		_, _ = sql.WriteString("\n\\pset format unaligned\n\\pset tuples_only on")
+
+		// Fill a temporary table with the JSON of the grants, and remove those
+		// of other databases.
+		_, _ = sql.WriteString(`
+CREATE TEMPORARY TABLE input (id serial, data json);
+\copy input (data) from stdin with (format text)
+`)
+		for i := range grants {
+			if err == nil {
+				err = encoder.Encode(grants[i])
+			}
+		}
+		_, _ = sql.WriteString(`\.` + "\n")
+		_, _ = sql.WriteString(`
+DELETE FROM input
+ WHERE pg_catalog.json_extract_path_text(input.data, 'database') <>
+       pg_catalog.current_database();
+`)
+
+		_, _ = sql.WriteString(`BEGIN;`)
+
+		// Revoke every privilege the audit roles have on tables in this database,
+		// then remove the roles that are not granted anything.
+		// - https://www.postgresql.org/docs/current/functions-info.html#FUNCTIONS-ACLITEM-FN-TABLE
+		_, _ = sql.WriteString(`
+SELECT pg_catalog.format('REVOKE ALL ON TABLE %s FROM %I',
+       c.oid::pg_catalog.regclass, r.rolname)
+  FROM pg_catalog.pg_class c,
+       pg_catalog.aclexplode(c.relacl) AS acl,
+       pg_catalog.pg_roles r
+ WHERE r.oid = acl.grantee
+   AND r.rolname IN (
+       SELECT pg_catalog.json_extract_path_text(input.data, 'role') FROM input)
+ GROUP BY c.oid, r.rolname
+ ORDER BY c.oid, r.rolname
+\gexec
+
+DELETE FROM input
+ WHERE pg_catalog.json_extract_path_text(input.data, 'table') = '';
+`)
+
+		// Grant the specified privileges on tables that exist. The privileges
+		// come from the operator and need no quoting.
+		// - https://www.postgresql.org/docs/current/sql-grant.html
+		_, _ = sql.WriteString(`
+SELECT pg_catalog.format('GRANT %s ON TABLE %I.%I TO %I',
+       pg_catalog.json_extract_path_text(input.data, 'privileges'),
+       pg_catalog.json_extract_path_text(input.data, 'schema'),
+       pg_catalog.json_extract_path_text(input.data, 'table'),
+       pg_catalog.json_extract_path_text(input.data, 'role'))
+  FROM input
+ WHERE pg_catalog.to_regclass(pg_catalog.format('%I.%I',
+       pg_catalog.json_extract_path_text(input.data, 'schema'),
+       pg_catalog.json_extract_path_text(input.data, 'table'))) IS NOT NULL
+ ORDER BY input.id
+\gexec
+`)
+
+		_, _ = sql.WriteString(`COMMIT;`)
+
+		// Print the tables that do not exist.
+		_, _ = sql.WriteString(`
+SELECT pg_catalog.format('table %I.%I in database %I',
+       pg_catalog.json_extract_path_text(input.data, 'schema'),
+       pg_catalog.json_extract_path_text(input.data, 'table'),
+       pg_catalog.current_database())
+  FROM input
+ WHERE pg_catalog.to_regclass(pg_catalog.format('%I.%I',
+       pg_catalog.json_extract_path_text(input.data, 'schema'),
+       pg_catalog.json_extract_path_text(input.data, 'table'))) IS NULL
+ ORDER BY input.id;
+`)
+
+		// Apply the grants in each database that exists and allows connections.
+		var databasesJSON []byte
+		if err == nil {
+			databasesJSON, err = json.Marshal(databases)
+		}
+		if err == nil {
+			stdout, stderr, err = exec.ExecInDatabasesFromQuery(ctx,
+				`SELECT datname FROM pg_catalog.pg_database`+
+					` WHERE datallowconn AND datname IN (`+
+					` SELECT pg_catalog.json_array_elements_text(:'databases'))`,
+				sql.String(),
+				map[string]string{
+					"databases": string(databasesJSON),
+
+					"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+					"QUIET":         "on", // Do not print successful statements to stdout.
+				})
+
+			log.V(1).Info("wrote pgAudit object privileges", "stdout", stdout, "stderr", stderr)
+			missing = append(missing, strings.Split(stdout, "\n")...)
+		}
+	}
+
+	// Then reset and apply settings of databases and roles, as above.
+	sql.Reset()
+	_, _ = sql.WriteString(`SET synchronous_commit = LOCAL;SET search_path TO '';`)
+	_, _ = sql.WriteString("\n\\pset format unaligned\n\\pset tuples_only on")
+
+	// Fill a temporary table with the JSON of the policies.
+	// "\copy" reads from subsequent lines until the special line "\.".
+	// - https://www.postgresql.org/docs/current/app-psql.html#APP-PSQL-META-COMMANDS-COPY
+	_, _ = sql.WriteString(`
+CREATE TEMPORARY TABLE input (id serial, data json);
+\copy input (data) from stdin with (format text)
+`)
+	encoder := json.NewEncoder(&sql)
+	encoder.SetEscapeHTML(false)
+
+	for i := range policies {
+		if err == nil {
+			err = encoder.Encode(policies[i])
+		}
+	}
+	_, _ = sql.WriteString(`\.` + "\n")
+
+	// Change the following in a transaction so sessions see all or none of it.
+	_, _ = sql.WriteString(`BEGIN;`)
+
+	// Reset pgAudit settings of databases and roles that are not specified.
+	// Settings of a role in a particular database are left alone.
+	// - https://www.postgresql.org/docs/current/catalog-pg-db-role-setting.html
+	_, _ = sql.WriteString(`
+SELECT CASE WHEN s.setrole = 0
+       THEN pg_catalog.format('ALTER DATABASE %I RESET %s', d.datname, c.name)
+       ELSE pg_catalog.format('ALTER ROLE %I RESET %s', r.rolname, c.name) END
+  FROM pg_catalog.pg_db_role_setting s
+  LEFT JOIN pg_catalog.pg_database d ON d.oid = s.setdatabase
+  LEFT JOIN pg_catalog.pg_roles r ON r.oid = s.setrole,
+       pg_catalog.unnest(s.setconfig) AS setting,
+       LATERAL (SELECT pg_catalog.split_part(setting, '=', 1) AS name) c
+ WHERE (s.setrole = 0) <> (s.setdatabase = 0)
+   AND c.name IN ('pgaudit.log', 'pgaudit.log_parameter', 'pgaudit.role')
+   AND NOT EXISTS (
+       SELECT 1 FROM input
+        WHERE pg_catalog.json_extract_path_text(input.data, 'kind') =
+              CASE WHEN s.setrole = 0 THEN 'database' ELSE 'role' END
+          AND pg_catalog.json_extract_path_text(input.data, 'name') =
+              COALESCE(d.datname, r.rolname)
+          AND pg_catalog.json_extract_path(input.data, 'settings', c.name) IS NOT NULL)
+\gexec
+`)
+
+	// Apply the settings of databases and roles that exist. The names of
+	// settings come from the operator and need no quoting.
+	// - https://www.postgresql.org/docs/current/sql-alterdatabase.html
+	// - https://www.postgresql.org/docs/current/sql-alterrole.html
+	_, _ = sql.WriteString(`
+SELECT pg_catalog.format('ALTER DATABASE %I SET %s = %L',
+       pg_catalog.json_extract_path_text(input.data, 'name'),
+       setting.key, setting.value)
+  FROM input, pg_catalog.json_each_text(
+       pg_catalog.json_extract_path(input.data, 'settings')) AS setting
+ WHERE pg_catalog.json_extract_path_text(input.data, 'kind') = 'database'
+   AND EXISTS (
+       SELECT 1 FROM pg_catalog.pg_database
+       WHERE datname = pg_catalog.json_extract_path_text(input.data, 'name'))
+ ORDER BY input.id, setting.key
+\gexec
+
+SELECT pg_catalog.format('ALTER ROLE %I SET %s = %L',
+       pg_catalog.json_extract_path_text(input.data, 'name'),
+       setting.key, setting.value)
+  FROM input, pg_catalog.json_each_text(
+       pg_catalog.json_extract_path(input.data, 'settings')) AS setting
+ WHERE pg_catalog.json_extract_path_text(input.data, 'kind') = 'role'
+   AND EXISTS (
+       SELECT 1 FROM pg_catalog.pg_roles
+       WHERE rolname = pg_catalog.json_extract_path_text(input.data, 'name'))
+ ORDER BY input.id, setting.key
+\gexec
+`)
+
+	// Commit (finish) the transaction.
+	_, _ = sql.WriteString(`COMMIT;`)
+
+	// Print the databases and roles that do not exist.
+	_, _ = sql.WriteString(`
+SELECT pg_catalog.format('%s %I',
+       pg_catalog.json_extract_path_text(input.data, 'kind'),
+       pg_catalog.json_extract_path_text(input.data, 'name'))
+  FROM input
+ WHERE (pg_catalog.json_extract_path_text(input.data, 'kind') = 'database'
+   AND NOT EXISTS (
+       SELECT 1 FROM pg_catalog.pg_database
+       WHERE datname = pg_catalog.json_extract_path_text(input.data, 'name')))
+    OR (pg_catalog.json_extract_path_text(input.data, 'kind') = 'role'
+   AND NOT EXISTS (
+       SELECT 1 FROM pg_catalog.pg_roles
+       WHERE rolname = pg_catalog.json_extract_path_text(input.data, 'name')))
+ ORDER BY input.id;
+`)
+
+	if err == nil {
+		stdout, stderr, err = exec.Exec(ctx, &sql,
+			map[string]string{
+				"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+				"QUIET":         "on", // Do not print successful statements to stdout.
+			})
+
+		log.V(1).Info("wrote pgAudit policies", "stdout", stdout, "stderr", stderr)
+		missing = append(missing, strings.Split(stdout, "\n")...)
+	}
+
+	return slices.DeleteFunc(missing, func(s string) bool {
+		return strings.TrimSpace(s) == ""
+	}), err
 }
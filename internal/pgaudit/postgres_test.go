@@ -14,6 +14,9 @@ import (
 	"gotest.tools/v3/assert"
 
 	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 func TestEnableInPostgreSQL(t *testing.T) {
@@ -47,7 +50,7 @@ func TestPostgreSQLParameters(t *testing.T) {
 	}
 
 	// No comma when empty.
-	PostgreSQLParameters(&parameters)
+	PostgreSQLParameters(nil, &parameters)
 
 	assert.Assert(t, parameters.Default == nil)
 	assert.DeepEqual(t, parameters.Mandatory.AsMap(), map[string]string{
@@ -56,10 +59,271 @@ func TestPostgreSQLParameters(t *testing.T) {
 
 	// Appended when not empty.
 	parameters.Mandatory.Add("shared_preload_libraries", "some,existing")
-	PostgreSQLParameters(&parameters)
+	PostgreSQLParameters(nil, &parameters)
 
 	assert.Assert(t, parameters.Default == nil)
 	assert.DeepEqual(t, parameters.Mandatory.AsMap(), map[string]string{
 		"shared_preload_libraries": "some,existing,pgaudit",
 	})
+
+	t.Run("Audit", func(t *testing.T) {
+		cluster := new(v1beta1.PostgresCluster)
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			audit: { log: [write, ddl, -misc], logParameter: false },
+		}`)
+
+		parameters := postgres.Parameters{Mandatory: postgres.NewParameterSet()}
+		PostgreSQLParameters(cluster, &parameters)
+
+		assert.DeepEqual(t, parameters.Mandatory.AsMap(), map[string]string{
+			"pgaudit.log":              "write,ddl,-misc",
+			"pgaudit.log_parameter":    "off",
+			"shared_preload_libraries": "pgaudit",
+		})
+
+		// Unspecified values are left alone.
+		cluster.Spec.Audit = &v1beta1.PostgresAuditSpec{}
+
+		parameters = postgres.Parameters{Mandatory: postgres.NewParameterSet()}
+		PostgreSQLParameters(cluster, &parameters)
+
+		assert.DeepEqual(t, parameters.Mandatory.AsMap(), map[string]string{
+			"shared_preload_libraries": "pgaudit",
+		})
+	})
+}
+
+func TestWritePoliciesInPostgreSQL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Empty", func(t *testing.T) {
+		calls := 0
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+
+			// The audit roles of databases are read first. There are none.
+			if calls == 1 {
+				assert.Assert(t, cmp.Contains(string(b), `'pgaudit.role'`))
+				assert.Assert(t, cmp.Contains(command, "--set=auditors=[]"))
+				_, _ = stdout.Write([]byte(`{"current":null,"login":null}` + "\n"))
+				return nil
+			}
+
+			// Settings of every database and role are reset.
+			assert.Assert(t, cmp.Contains(string(b), "\\copy input (data) from stdin with (format text)\n\\.\n"))
+			assert.Assert(t, cmp.Contains(string(b), `RESET %s`))
+			assert.Assert(t, cmp.Contains(string(b), `'pgaudit.log', 'pgaudit.log_parameter', 'pgaudit.role'`))
+
+			_, _ = stdout.Write([]byte("\n"))
+			return nil
+		}
+
+		missing, err := WritePoliciesInPostgreSQL(ctx, exec, nil)
+		assert.NilError(t, err)
+		assert.Equal(t, calls, 2)
+		assert.Assert(t, cmp.Len(missing, 0))
+	})
+
+	t.Run("Policies", func(t *testing.T) {
+		spec := new(v1beta1.PostgresAuditSpec)
+		require.UnmarshalInto(t, spec, `{
+			databases: [{ name: app, log: [read] }],
+			roles: [
+				{ name: alice, log: [all, -misc], logParameter: true },
+				{ name: bob, log: [none] },
+			],
+			objects: [
+				{ database: app, role: auditor, tables: [
+					{ privileges: [DELETE, SELECT], table: accounts },
+					{ privileges: [INSERT], schema: sales, table: orders },
+					{ privileges: [SELECT, UPDATE], graph: people, label: Person },
+				] },
+				{ database: other, role: auditor, tables: [
+					{ privileges: [SELECT], table: 'some "quoted" table' },
+				] },
+			],
+		}`)
+
+		var commands [][]string
+		var inputs []string
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			commands = append(commands, command)
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			inputs = append(inputs, string(b))
+
+			switch len(commands) {
+			case 1:
+				_, _ = stdout.Write([]byte(`{"current":[{"database":"app","role":"auditor"},` +
+					`{"database":"other","role":"former"},{"database":"old","role":"auditor"}],"login":null}` + "\n"))
+			case 2:
+				_, _ = stdout.Write([]byte("table sales.orders in database app\n\n"))
+			default:
+				_, _ = stdout.Write([]byte("role bob\n"))
+			}
+			return nil
+		}
+
+		missing, err := WritePoliciesInPostgreSQL(ctx, exec, spec)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, missing, []string{
+			"table sales.orders in database app", "role bob",
+		})
+		assert.Equal(t, len(commands), 3)
+
+		// Audit roles are created once before anything else.
+		assert.Assert(t, cmp.Contains(commands[0], `--set=auditors=["auditor"]`))
+		assert.Assert(t, cmp.Contains(inputs[0], `CREATE ROLE %I NOLOGIN`))
+
+		// Privileges are granted in the databases of the objects. Labels are
+		// tables in the schema of their graph. Audit roles that are no longer
+		// in spec have their privileges revoked.
+		assert.Assert(t, cmp.Contains(strings.Join(commands[1], "\n"),
+			`--set=databases=["app","other","old"]`))
+		assert.Assert(t, cmp.Contains(inputs[1], strings.TrimSpace(`
+\copy input (data) from stdin with (format text)
+{"database":"app","role":"auditor","privileges":"SELECT, DELETE","schema":"public","table":"accounts"}
+{"database":"app","role":"auditor","privileges":"INSERT","schema":"sales","table":"orders"}
+{"database":"app","role":"auditor","privileges":"SELECT, UPDATE","schema":"people","table":"Person"}
+{"database":"other","role":"auditor","privileges":"SELECT","schema":"public","table":"some \"quoted\" table"}
+{"database":"other","role":"former","privileges":"","schema":"","table":""}
+{"database":"old","role":"auditor","privileges":"","schema":"","table":""}
+\.
+		`)))
+		assert.Assert(t, cmp.Contains(inputs[1], `REVOKE ALL ON TABLE %s FROM %I`))
+		assert.Assert(t, cmp.Contains(inputs[1], `GRANT %s ON TABLE %I.%I TO %I`))
+
+		// Settings change after privileges. The audit role is a setting of its
+		// databases.
+		assert.Assert(t, cmp.Contains(inputs[2], strings.TrimSpace(`
+\copy input (data) from stdin with (format text)
+{"kind":"database","name":"app","settings":{"pgaudit.log":"read","pgaudit.role":"auditor"}}
+{"kind":"database","name":"other","settings":{"pgaudit.role":"auditor"}}
+{"kind":"role","name":"alice","settings":{"pgaudit.log":"all,-misc","pgaudit.log_parameter":"on"}}
+{"kind":"role","name":"bob","settings":{"pgaudit.log":"none"}}
+\.
+		`)))
+		assert.Assert(t, cmp.Contains(inputs[2], `RESET %s`))
+		assert.Assert(t, cmp.Contains(inputs[2], `ALTER DATABASE %I SET %s = %L`))
+		assert.Assert(t, cmp.Contains(inputs[2], `ALTER ROLE %I SET %s = %L`))
+	})
+
+	t.Run("Login", func(t *testing.T) {
+		spec := new(v1beta1.PostgresAuditSpec)
+		require.UnmarshalInto(t, spec, `{
+			objects: [
+				{ database: app, role: application, tables: [{ privileges: [SELECT], table: t }] },
+				{ database: other, role: auditor, tables: [{ privileges: [SELECT], table: t }] },
+			],
+		}`)
+
+		var inputs []string
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			inputs = append(inputs, string(b))
+
+			if len(inputs) == 1 {
+				_, _ = stdout.Write([]byte(`{"current":[{"database":"old","role":"previous"}],` +
+					`"login":["application","previous"]}` + "\n"))
+			}
+			return nil
+		}
+
+		// Audit roles that can login are reported. They are not audit roles
+		// of any database, and their privileges do not change.
+		missing, err := WritePoliciesInPostgreSQL(ctx, exec, spec)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, missing, []string{"audit role application that can login"})
+		assert.Equal(t, len(inputs), 3)
+		assert.Assert(t, cmp.Contains(inputs[1], strings.TrimSpace(`
+\copy input (data) from stdin with (format text)
+{"database":"other","role":"auditor","privileges":"SELECT","schema":"public","table":"t"}
+\.
+		`)))
+		assert.Assert(t, cmp.Contains(inputs[2], strings.TrimSpace(`
+\copy input (data) from stdin with (format text)
+{"kind":"database","name":"other","settings":{"pgaudit.role":"auditor"}}
+\.
+		`)))
+	})
+
+	t.Run("Removed", func(t *testing.T) {
+		var inputs []string
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			inputs = append(inputs, string(b))
+
+			if len(inputs) == 1 {
+				_, _ = stdout.Write([]byte(`{"current":[{"database":"app","role":"auditor"}]}` + "\n"))
+			}
+			return nil
+		}
+
+		// Privileges are revoked after the last object policy is removed.
+		missing, err := WritePoliciesInPostgreSQL(ctx, exec, nil)
+		assert.NilError(t, err)
+		assert.Assert(t, cmp.Len(missing, 0))
+		assert.Equal(t, len(inputs), 3)
+		assert.Assert(t, cmp.Contains(inputs[1], strings.TrimSpace(`
+\copy input (data) from stdin with (format text)
+{"database":"app","role":"auditor","privileges":"","schema":"","table":""}
+\.
+		`)))
+		assert.Assert(t, cmp.Contains(inputs[1], `REVOKE ALL ON TABLE %s FROM %I`))
+
+		// The audit role of the database is reset afterward. When revoking
+		// fails, the setting remains and is read again next time.
+		assert.Assert(t, cmp.Contains(inputs[2], `RESET %s`))
+	})
+
+	t.Run("RevokeError", func(t *testing.T) {
+		expected := errors.New("whoops")
+		calls := 0
+		exec := func(
+			_ context.Context, _ io.Reader, stdout, _ io.Writer, _ ...string,
+		) error {
+			calls++
+			if calls == 1 {
+				_, _ = stdout.Write([]byte(`{"current":[{"database":"app","role":"auditor"}]}` + "\n"))
+				return nil
+			}
+			return expected
+		}
+
+		_, err := WritePoliciesInPostgreSQL(ctx, exec, nil)
+		assert.Equal(t, err, expected)
+		assert.Equal(t, calls, 2, "expected no settings after an error")
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		expected := errors.New("whoops")
+		calls := 0
+		exec := func(context.Context, io.Reader, io.Writer, io.Writer, ...string) error {
+			calls++
+			return expected
+		}
+
+		spec := new(v1beta1.PostgresAuditSpec)
+		require.UnmarshalInto(t, spec, `{
+			objects: [{ database: app, role: auditor, tables: [{ privileges: [SELECT], table: t }] }],
+		}`)
+
+		_, err := WritePoliciesInPostgreSQL(ctx, exec, spec)
+		assert.Equal(t, err, expected)
+		assert.Equal(t, calls, 1, "expected no grants after an error")
+	})
 }
@@ -169,6 +169,14 @@ func CreatePGBackRestConfigMapIntent(ctx context.Context, postgresCluster *v1bet
 			).String()
 	}
 
+	// pgBackRest reads the public keys of SFTP servers from files.
+	for _, repo := range postgresCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.SFTP != nil {
+			cm.Data[sftpKnownHostsKey(repo.Name)] =
+				strings.Join(repo.SFTP.KnownHosts, "\n") + "\n"
+		}
+	}
+
 	cm.Data[ConfigHashKey] = configHash
 
 	return cm, err
@@ -373,6 +381,57 @@ exit 1`
 	return append([]string{"bash", "-ceu", "--", restoreScript, "-", pgdata}, args...)
 }
 
+// VerifyCommand returns the command for verifying the backups of a repository.
+// The script:
+//   - Runs "pgbackrest verify" to check the backups and WAL in the repository.
+//   - Restores the latest backup into pgdata and recovers only until it is consistent.
+//   - Starts the database using a temporary postgresql.conf file that accepts
+//     connections over the domain socket only, then waits for it to be promoted.
+//   - Runs the SQL in checkFile, if any, against database. The script fails when
+//     any statement fails.
+func VerifyCommand(pgdata, fetchKeyCommand, database, checkFile string, args ...string) []string {
+	ps := postgres.NewParameterSet()
+	ps.Add("data_directory", pgdata)
+	ps.Add("hba_file", "/tmp/pg_hba.verify.conf")
+	ps.Add("huge_pages", "off")
+	ps.Add("listen_addresses", "")
+	ps.Add("unix_socket_directories", "/tmp")
+
+	// Nothing written here should leave the Pod.
+	ps.Add("archive_mode", "off")
+
+	if fetchKeyCommand != "" {
+		ps.Add("encryption_key_command", fetchKeyCommand)
+	}
+
+	script := strings.Join([]string{
+		`declare -r PGDATA="$1" database="$2" check="$3" opts="$4"`,
+		`export PGDATA PGHOST='/tmp'`,
+
+		// Check the checksums of backups and the continuity of WAL first.
+		// - https://pgbackrest.org/command.html#command-verify
+		`bash -xc "pgbackrest verify ${opts}"`,
+
+		// Restore the latest backup and stop recovery as soon as it is consistent.
+		`install --directory --mode=0700 "${PGDATA}"`,
+		`bash -xc "pgbackrest restore ${opts} --type=immediate --target-action=promote"`,
+
+		// See [RestoreCommand] about the timeout of pg_ctl.
+		fmt.Sprintf(`export PGCTLTIMEOUT=%d`, 365*24*time.Hour/time.Second),
+
+		`echo > /tmp/pg_hba.verify.conf 'local all "postgres" peer'`,
+		`cat > /tmp/postgres.verify.conf <<'EOF'`, ps.String(), `EOF`,
+		`pg_ctl start --silent --wait --options='-c config_file=/tmp/postgres.verify.conf'`,
+
+		`until [[ "$(psql -Atc 'SELECT pg_catalog.pg_is_in_recovery()')" == 'f' ]]; do sleep 1; done`,
+		`[[ ! -s "${check}" ]] || psql --no-psqlrc --set=ON_ERROR_STOP=1 --dbname="${database}" --file="${check}"`,
+
+		`pg_ctl stop --silent --wait`,
+	}, "\n")
+
+	return append([]string{"bash", "-ceu", "--", script, "-", pgdata, database, checkFile}, args...)
+}
+
 // populatePGInstanceConfigurationMap returns options representing the pgBackRest configuration for
 // a PostgreSQL instance
 func populatePGInstanceConfigurationMap(
@@ -468,6 +527,11 @@ func populateRepoHostConfigurationMap(
 			}
 		}
 
+		// The repository host encrypts the repositories on its volumes.
+		if encryption := RepoEncryption(nil, repo); encryption != nil && repo.Volume != nil {
+			global.Set(repo.Name+"-cipher-type", encryption.Cipher)
+		}
+
 		if !pgBackRestLogPathSet && repo.Volume != nil {
 			// pgBackRest will log to the first configured repo volume when commands
 			// are run on the pgBackRest repo host. With our previous check in
@@ -597,11 +661,94 @@ func getExternalRepoConfigs(repo v1beta1.PGBackRestRepo) map[string]string {
 		repoConfigs[repo.Name+"-s3-bucket"] = repo.S3.Bucket
 		repoConfigs[repo.Name+"-s3-endpoint"] = repo.S3.Endpoint
 		repoConfigs[repo.Name+"-s3-region"] = repo.S3.Region
+	} else if repo.SFTP != nil {
+		repoConfigs[repo.Name+"-type"] = "sftp"
+		repoConfigs[repo.Name+"-sftp-host"] = repo.SFTP.Host
+		repoConfigs[repo.Name+"-sftp-host-user"] = repo.SFTP.User
+		repoConfigs[repo.Name+"-sftp-private-key-file"] =
+			configDirectory + "/" + sftpPrivateKeyProjectionPath(repo.Name)
+
+		// Connect only to a server that presents one of the known host keys.
+		repoConfigs[repo.Name+"-sftp-host-key-check-type"] = "strict"
+		repoConfigs[repo.Name+"-sftp-known-host"] =
+			configDirectory + "/" + sftpKnownHostsProjectionPath(repo.Name)
+
+		if repo.SFTP.Port != nil {
+			repoConfigs[repo.Name+"-sftp-host-port"] = fmt.Sprint(*repo.SFTP.Port)
+		}
+		if repo.SFTP.Path != "" {
+			repoConfigs[repo.Name+"-path"] = repo.SFTP.Path
+		}
+	} else if repo.POSIX != nil {
+		// The volume of the repository is mounted at its default path.
+		repoConfigs[repo.Name+"-type"] = "posix"
+	}
+
+	if encryption := RepoEncryption(nil, repo); encryption != nil {
+		repoConfigs[repo.Name+"-cipher-type"] = encryption.Cipher
 	}
 
 	return repoConfigs
 }
 
+// RepoEncryption returns the cipher of repo and the Secret key containing its
+// passphrase. It returns nil when repo is not encrypted. Passphrases generated
+// by the operator are stored in the pgBackRest Secret of cluster.
+func RepoEncryption(
+	cluster *v1beta1.PostgresCluster, repo v1beta1.PGBackRestRepo,
+) *v1beta1.RepoEncryptionStatus {
+	if repo.Encryption == nil {
+		return nil
+	}
+
+	out := &v1beta1.RepoEncryptionStatus{Cipher: repo.Encryption.Cipher}
+	if out.Cipher == "" {
+		out.Cipher = "aes-256-cbc"
+	}
+	if ref := repo.Encryption.PassphraseSecretRef; ref != nil {
+		out.SecretName, out.Key = ref.Name, ref.Key
+	} else if cluster != nil {
+		out.SecretName = naming.PGBackRestSecret(cluster).Name
+		out.Key = cipherPassSecretKey(repo.Name)
+	}
+	return out
+}
+
+// cipherPassSecretKey returns the key in the pgBackRest Secret that contains
+// the generated passphrase of the repository named repoName.
+func cipherPassSecretKey(repoName string) string {
+	return repoName + "-cipher-pass"
+}
+
+// sftpKnownHostsKey returns the key in the pgBackRest ConfigMap that contains
+// the known hosts of the SFTP repository named repoName.
+func sftpKnownHostsKey(repoName string) string {
+	return repoName + "-sftp-known-hosts"
+}
+
+// sftpKnownHostsProjectionPath returns where the known hosts of the SFTP
+// repository named repoName are projected in the configuration directory.
+func sftpKnownHostsProjectionPath(repoName string) string {
+	return "~postgres-operator/" + repoName + "-sftp-known-hosts"
+}
+
+// sftpPrivateKeyProjectionPath returns where the private key of the SFTP
+// repository named repoName is projected in the configuration directory.
+func sftpPrivateKeyProjectionPath(repoName string) string {
+	return "~postgres-operator/" + repoName + "-sftp.key"
+}
+
+// autogrowEnvironment returns the environment variables that configure how
+// the repository volumes of cluster grow; see [reloadCommand].
+func autogrowEnvironment(cluster *v1beta1.PostgresCluster) []corev1.EnvVar {
+	if cluster.Spec.AutoGrow == nil || cluster.Spec.AutoGrow.Repos == nil {
+		return nil
+	}
+	return []corev1.EnvVar{{
+		Name: "AUTOGROW_REPOS", Value: postgres.AutoGrowPolicy(cluster.Spec.AutoGrow.Repos),
+	}}
+}
+
 // reloadCommand returns an entrypoint that convinces the pgBackRest TLS server
 // to reload its options and certificate files when they change. The process
 // will appear as name in `ps` and `top`.
@@ -643,7 +790,8 @@ CACERT=${SERVICEACCOUNT}/ca.crt
 # Manage autogrow annotation.
 # Return size in Mebibytes.
 manageAutogrowAnnotation() {
-  local volume=$1
+  local volume=$1 threshold percent mebibytes
+  read -r threshold percent mebibytes <<< "$2"
 
   size=$(df --block-size=M "/pgbackrest/${volume}")
   read -r _ size _ <<< "${size#*$'\n'}"
@@ -652,9 +800,9 @@ manageAutogrowAnnotation() {
   sizeInt="${size//M/}"
   # Use the sed punctuation class, because the shell will not accept the percent sign in an expansion.
   useInt=${use//[[:punct:]]/}
-  triggerExpansion="$((useInt > 75))"
+  triggerExpansion="$((useInt > threshold))"
   if [[ ${triggerExpansion} -eq 1 ]]; then
-    newSize="$(((sizeInt / 2)+sizeInt))"
+    newSize="$((sizeInt + (sizeInt * percent / 100) + mebibytes))"
     newSizeMi="${newSize}Mi"
     d='[{"op": "add", "path": "/metadata/annotations/suggested-'"${volume}"'-pvc-size", "value": "'"${newSizeMi}"'"}]'
     curl --cacert "${CACERT}" --header "Authorization: Bearer ${TOKEN}" -XPATCH "${APISERVER}/api/v1/namespaces/${NAMESPACE}/pods/${HOSTNAME}?fieldManager=kubectl-annotate" -H "Content-Type: application/json-patch+json" --data "${d}"
@@ -681,22 +829,22 @@ until read -r -t 5 -u "${fd}"; do
 
   # manage autogrow annotation for the repo1 volume, if it exists
   if [[ -d /pgbackrest/repo1 ]]; then
-    manageAutogrowAnnotation "repo1"
+    manageAutogrowAnnotation "repo1" "${AUTOGROW_REPOS:-75 50 0}"
   fi
 
   # manage autogrow annotation for the repo2 volume, if it exists
   if [[ -d /pgbackrest/repo2 ]]; then
-    manageAutogrowAnnotation "repo2"
+    manageAutogrowAnnotation "repo2" "${AUTOGROW_REPOS:-75 50 0}"
   fi
 
   # manage autogrow annotation for the repo3 volume, if it exists
   if [[ -d /pgbackrest/repo3 ]]; then
-    manageAutogrowAnnotation "repo3"
+    manageAutogrowAnnotation "repo3" "${AUTOGROW_REPOS:-75 50 0}"
   fi
 
   # manage autogrow annotation for the repo4 volume, if it exists
   if [[ -d /pgbackrest/repo4 ]]; then
-    manageAutogrowAnnotation "repo4"
+    manageAutogrowAnnotation "repo4" "${AUTOGROW_REPOS:-75 50 0}"
   fi
 
 done
@@ -0,0 +1,167 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// Info is the part of "pgbackrest info" output that describes one stanza.
+// - https://pgbackrest.org/command.html#command-info
+type Info struct {
+	Archive []InfoArchive `json:"archive"`
+	Backup  []InfoBackup  `json:"backup"`
+	Name    string        `json:"name"`
+	Repo    []InfoRepo    `json:"repo"`
+}
+
+// InfoArchive describes the range of WAL in one repository for one database
+// system of a stanza.
+type InfoArchive struct {
+	Database InfoDatabase `json:"database"`
+	Max      string       `json:"max"`
+	Min      string       `json:"min"`
+}
+
+// InfoBackup describes one backup in one repository.
+type InfoBackup struct {
+	Archive struct {
+		Start string `json:"start"`
+		Stop  string `json:"stop"`
+	} `json:"archive"`
+	Database InfoDatabase `json:"database"`
+	Error    bool         `json:"error"`
+	Info     struct {
+		Repository struct {
+			Delta int64 `json:"delta"`
+		} `json:"repository"`
+		Size int64 `json:"size"`
+	} `json:"info"`
+	Label     string `json:"label"`
+	Timestamp struct {
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+	} `json:"timestamp"`
+	Type string `json:"type"`
+}
+
+// InfoRepo describes one repository of a stanza.
+type InfoRepo struct {
+	Cipher string `json:"cipher"`
+	Key    int    `json:"key"`
+}
+
+// InfoDatabase identifies the repository and database system of a backup or
+// range of WAL.
+type InfoDatabase struct {
+	ID      int `json:"id"`
+	RepoKey int `json:"repo-key"`
+}
+
+// Info runs the pgBackRest "info" command and returns what it reports about
+// the default stanza in every configured repository.
+func (exec Executor) Info(ctx context.Context) (*Info, error) {
+	var stdout, stderr bytes.Buffer
+
+	err := exec(ctx, nil, &stdout, &stderr,
+		"pgbackrest", "info", "--stanza="+DefaultStanzaName, "--output=json")
+
+	if err != nil {
+		return nil, errors.WithStack(fmt.Errorf("%w: %v", err, stderr.String()))
+	}
+
+	var stanzas []Info
+	if err := json.Unmarshal(stdout.Bytes(), &stanzas); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for i := range stanzas {
+		if stanzas[i].Name == DefaultStanzaName {
+			return &stanzas[i], nil
+		}
+	}
+	return &Info{Name: DefaultStanzaName}, nil
+}
+
+// repoKey returns the number in the name of a pgBackRest repository, e.g.
+// 2 for "repo2", or zero when name is not a repository name.
+func repoKey(name string) int {
+	key, _ := strconv.Atoi(strings.TrimPrefix(name, "repo"))
+	return key
+}
+
+// RepoBackups returns the backups in the repository named repoName, oldest
+// first.
+func (info *Info) RepoBackups(repoName string) []v1beta1.PGBackRestBackupInfo {
+	var backups []v1beta1.PGBackRestBackupInfo
+	key := repoKey(repoName)
+
+	for _, backup := range info.Backup {
+		if backup.Database.RepoKey != key {
+			continue
+		}
+		backups = append(backups, v1beta1.PGBackRestBackupInfo{
+			Label:          backup.Label,
+			Type:           backup.Type,
+			StartTime:      metav1.NewTime(time.Unix(backup.Timestamp.Start, 0)),
+			StopTime:       metav1.NewTime(time.Unix(backup.Timestamp.Stop, 0)),
+			Size:           resource.NewQuantity(backup.Info.Size, resource.BinarySI),
+			RepositorySize: resource.NewQuantity(backup.Info.Repository.Delta, resource.BinarySI),
+			WALStart:       backup.Archive.Start,
+			WALStop:        backup.Archive.Stop,
+			Error:          backup.Error,
+		})
+	}
+
+	slices.SortStableFunc(backups, func(a, b v1beta1.PGBackRestBackupInfo) int {
+		return a.StopTime.Time.Compare(b.StopTime.Time)
+	})
+	return backups
+}
+
+// RepoCipher returns the cipher that encrypts the repository named repoName,
+// "none" when it is not encrypted, or an empty string when it is unknown.
+func (info *Info) RepoCipher(repoName string) string {
+	key := repoKey(repoName)
+
+	for _, repo := range info.Repo {
+		if repo.Key == key {
+			return repo.Cipher
+		}
+	}
+	return ""
+}
+
+// RepoWAL returns the range of WAL in the repository named repoName for the
+// current database system of the stanza. It returns nil when there is none.
+func (info *Info) RepoWAL(repoName string) *v1beta1.PGBackRestWALRange {
+	var current *InfoArchive
+	key := repoKey(repoName)
+
+	// The current database system has the largest identifier. Older systems
+	// remain after "stanza-upgrade" until their backups expire.
+	for i := range info.Archive {
+		if info.Archive[i].Database.RepoKey == key && info.Archive[i].Max != "" &&
+			(current == nil || info.Archive[i].Database.ID > current.Database.ID) {
+			current = &info.Archive[i]
+		}
+	}
+	if current == nil {
+		return nil
+	}
+	return &v1beta1.PGBackRestWALRange{Start: current.Min, Stop: current.Max}
+}
@@ -0,0 +1,140 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbackrest
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// infoOutput is "pgbackrest info --output=json" for a stanza with backups in
+// two repositories, trimmed to the fields that matter.
+const infoOutput = `[{
+  "archive": [
+    {"database": {"id": 1, "repo-key": 1}, "id": "16-1", "max": "000000010000000000000007", "min": "000000010000000000000001"},
+    {"database": {"id": 1, "repo-key": 2}, "id": "16-1", "max": "000000010000000000000004", "min": "000000010000000000000003"},
+    {"database": {"id": 2, "repo-key": 2}, "id": "17-2", "max": "00000002000000000000000A", "min": "000000020000000000000009"}
+  ],
+  "backup": [
+    {
+      "archive": {"start": "000000010000000000000005", "stop": "000000010000000000000005"},
+      "database": {"id": 1, "repo-key": 1}, "error": false,
+      "info": {"repository": {"delta": 1024, "size": 4096}, "size": 31457280},
+      "label": "20250102-030405F_20250103-030405I",
+      "timestamp": {"start": 1735873445, "stop": 1735873450}, "type": "incr"
+    },
+    {
+      "archive": {"start": "000000010000000000000003", "stop": "000000010000000000000003"},
+      "database": {"id": 1, "repo-key": 1}, "error": false,
+      "info": {"repository": {"delta": 4194304, "size": 4194304}, "size": 31457280},
+      "label": "20250102-030405F",
+      "timestamp": {"start": 1735787045, "stop": 1735787050}, "type": "full"
+    },
+    {
+      "archive": {"start": "000000010000000000000003", "stop": "000000010000000000000004"},
+      "database": {"id": 1, "repo-key": 2}, "error": true,
+      "info": {"repository": {"delta": 4194304, "size": 4194304}, "size": 31457280},
+      "label": "20250102-030406F",
+      "timestamp": {"start": 1735787046, "stop": 1735787060}, "type": "full"
+    }
+  ],
+  "name": "db",
+  "repo": [
+    {"cipher": "aes-256-cbc", "key": 1, "status": {"code": 0, "message": "ok"}},
+    {"cipher": "none", "key": 2, "status": {"code": 0, "message": "ok"}}
+  ]
+}]`
+
+func TestInfo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Command", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			assert.DeepEqual(t, command, []string{
+				"pgbackrest", "info", "--stanza=db", "--output=json",
+			})
+			_, _ = stderr.Write([]byte("some problem"))
+			return expected
+		}
+
+		_, err := Executor(exec).Info(ctx)
+		assert.Assert(t, errors.Is(err, expected))
+		assert.ErrorContains(t, err, "some problem")
+	})
+
+	t.Run("NoStanza", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, stdout, _ io.Writer, _ ...string,
+		) error {
+			_, err := stdout.Write([]byte(`[]`))
+			return err
+		}
+
+		info, err := Executor(exec).Info(ctx)
+		assert.NilError(t, err)
+		assert.Equal(t, len(info.Backup), 0)
+		assert.Assert(t, info.RepoWAL("repo1") == nil)
+	})
+
+	t.Run("Output", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, stdout, _ io.Writer, _ ...string,
+		) error {
+			_, err := stdout.Write([]byte(infoOutput))
+			return err
+		}
+
+		info, err := Executor(exec).Info(ctx)
+		assert.NilError(t, err)
+
+		// Backups are ordered by when they stopped.
+		backups := info.RepoBackups("repo1")
+		assert.Equal(t, len(backups), 2)
+		assert.Equal(t, backups[0].Label, "20250102-030405F")
+		assert.Equal(t, backups[1].Label, "20250102-030405F_20250103-030405I")
+
+		assert.Assert(t, cmp.MarshalMatches(backups[0], `
+label: 20250102-030405F
+repositorySize: 4Mi
+size: 30Mi
+startTime: "2025-01-02T03:04:05Z"
+stopTime: "2025-01-02T03:04:10Z"
+type: full
+walStart: "000000010000000000000003"
+walStop: "000000010000000000000003"
+		`))
+		assert.Equal(t, backups[0].StopTime.Time, time.Unix(1735787050, 0))
+
+		backups = info.RepoBackups("repo2")
+		assert.Equal(t, len(backups), 1)
+		assert.Assert(t, backups[0].Error)
+
+		assert.Equal(t, len(info.RepoBackups("repo3")), 0)
+
+		// WAL is from the current database system.
+		assert.DeepEqual(t, info.RepoWAL("repo1"), &v1beta1.PGBackRestWALRange{
+			Start: "000000010000000000000001", Stop: "000000010000000000000007",
+		})
+		assert.DeepEqual(t, info.RepoWAL("repo2"), &v1beta1.PGBackRestWALRange{
+			Start: "000000020000000000000009", Stop: "00000002000000000000000A",
+		})
+		assert.Assert(t, info.RepoWAL("repo3") == nil)
+
+		assert.Equal(t, info.RepoCipher("repo1"), "aes-256-cbc")
+		assert.Equal(t, info.RepoCipher("repo2"), "none")
+		assert.Equal(t, info.RepoCipher("repo3"), "")
+	})
+}
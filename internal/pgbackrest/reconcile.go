@@ -6,6 +6,7 @@ package pgbackrest
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -18,6 +19,7 @@ import (
 	"github.com/crunchydata/postgres-operator/internal/naming"
 	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/internal/postgres"
+	"github.com/crunchydata/postgres-operator/internal/util"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
@@ -120,6 +122,7 @@ func AddConfigToInstancePod(
 	// - https://kubernetes.io/docs/concepts/storage/volumes/#projected
 	sources := append([]corev1.VolumeProjection{},
 		cluster.Spec.Backups.PGBackRest.Configuration...)
+	sources = append(sources, externalRepoProjections(cluster)...)
 
 	if len(secret.Secret.Items) > 0 {
 		sources = append(sources, configmap, secret)
@@ -128,6 +131,8 @@ func AddConfigToInstancePod(
 	}
 
 	addConfigVolumeAndMounts(pod, sources)
+	addExternalRepoVolumesAndMounts(cluster, pod)
+	addRepoEncryptionEnvironment(cluster, externalRepos(cluster.Spec.Backups.PGBackRest.Repos), pod)
 }
 
 // AddConfigToRepoPod adds and mounts the pgBackRest configuration volumes for
@@ -153,8 +158,11 @@ func AddConfigToRepoPod(
 	// - https://kubernetes.io/docs/concepts/storage/volumes/#projected
 	sources := append([]corev1.VolumeProjection{},
 		cluster.Spec.Backups.PGBackRest.Configuration...)
+	sources = append(sources, externalRepoProjections(cluster)...)
 
 	addConfigVolumeAndMounts(pod, append(sources, configmap, secret))
+	addExternalRepoVolumesAndMounts(cluster, pod)
+	addRepoEncryptionEnvironment(cluster, cluster.Spec.Backups.PGBackRest.Repos, pod)
 }
 
 // AddConfigToCloudBackupJob adds and mounts the pgBackRest configuration volumes
@@ -177,8 +185,12 @@ func AddConfigToCloudBackupJob(
 	// - https://kubernetes.io/docs/concepts/storage/volumes/#projected
 	sources := append([]corev1.VolumeProjection{},
 		cluster.Spec.Backups.PGBackRest.Configuration...)
+	sources = append(sources, externalRepoProjections(cluster)...)
 
 	addConfigVolumeAndMounts(&podTemplateSpec.Spec, append(sources, configmap, secret))
+	addExternalRepoVolumesAndMounts(cluster, &podTemplateSpec.Spec)
+	addRepoEncryptionEnvironment(cluster,
+		externalRepos(cluster.Spec.Backups.PGBackRest.Repos), &podTemplateSpec.Spec)
 
 	// Add tmp directory for pgbackrest lock files
 	AddTMPEmptyDir(podTemplateSpec)
@@ -237,6 +249,21 @@ func AddConfigToRestorePod(
 
 		sources = append([]corev1.VolumeProjection{},
 			cluster.Spec.DataSource.PGBackRest.Configuration...)
+
+		// There is no Secret of generated passphrases for a data source.
+		addRepoEncryptionEnvironment(nil,
+			[]v1beta1.PGBackRestRepo{cluster.Spec.DataSource.PGBackRest.Repo}, pod)
+	} else {
+		sources = append(sources, externalRepoProjections(cluster)...)
+		addExternalRepoVolumesAndMounts(cluster, pod)
+
+		// The generated passphrases of another cluster are copied into the
+		// pgBackRest Secret of this one; see [RestoreConfig].
+		repos := cluster.Spec.Backups.PGBackRest.Repos
+		if sourceCluster != nil {
+			repos = sourceCluster.Spec.Backups.PGBackRest.Repos
+		}
+		addRepoEncryptionEnvironment(cluster, externalRepos(repos), pod)
 	}
 
 	// mount any provided configuration files to the restore Job Pod
@@ -295,6 +322,124 @@ func addConfigVolumeAndMounts(
 	pod.Volumes = append(pod.Volumes, configVolume)
 }
 
+// externalRepoProjections returns projections of the files that pgBackRest
+// needs to reach the SFTP repositories of cluster. The private keys are read
+// from Secrets in the spec, and the known hosts are in the pgBackRest ConfigMap.
+func externalRepoProjections(cluster *v1beta1.PostgresCluster) []corev1.VolumeProjection {
+	var sources []corev1.VolumeProjection
+	var knownHosts []corev1.KeyToPath
+
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		if repo.SFTP == nil {
+			continue
+		}
+
+		// pgBackRest requires that private keys not be readable by any other user.
+		key := repo.SFTP.PrivateKeySecretRef.AsProjection(sftpPrivateKeyProjectionPath(repo.Name))
+		key.Items[0].Mode = initialize.Int32(0o600)
+
+		sources = append(sources, corev1.VolumeProjection{Secret: &key})
+		knownHosts = append(knownHosts, corev1.KeyToPath{
+			Key:  sftpKnownHostsKey(repo.Name),
+			Path: sftpKnownHostsProjectionPath(repo.Name),
+		})
+	}
+
+	if len(knownHosts) > 0 {
+		configmap := corev1.VolumeProjection{ConfigMap: &corev1.ConfigMapProjection{}}
+		configmap.ConfigMap.Name = naming.PGBackRestConfig(cluster).Name
+		configmap.ConfigMap.Items = knownHosts
+		sources = append(sources, configmap)
+	}
+
+	return sources
+}
+
+// addExternalRepoVolumesAndMounts adds the volumes of the POSIX repositories of
+// cluster to pod. It mounts them to the same containers as the configuration volume.
+func addExternalRepoVolumesAndMounts(cluster *v1beta1.PostgresCluster, pod *corev1.PodSpec) {
+	for _, repo := range cluster.Spec.Backups.PGBackRest.Repos {
+		if repo.POSIX == nil {
+			continue
+		}
+
+		volume := corev1.Volume{Name: repo.Name}
+		if repo.POSIX.NFS != nil {
+			volume.NFS = &corev1.NFSVolumeSource{
+				Server: repo.POSIX.NFS.Server,
+				Path:   repo.POSIX.NFS.Path,
+			}
+		} else {
+			volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: repo.POSIX.ClaimName,
+			}
+		}
+
+		mount := corev1.VolumeMount{Name: volume.Name, MountPath: defaultRepo1Path + repo.Name}
+		for i := range pod.Containers {
+			container := &pod.Containers[i]
+
+			switch container.Name {
+			case
+				naming.ContainerDatabase,
+				naming.PGBackRestRepoContainerName,
+				naming.PGBackRestRestoreContainerName:
+
+				container.VolumeMounts = append(container.VolumeMounts, mount)
+			}
+		}
+
+		pod.Volumes = append(pod.Volumes, volume)
+	}
+}
+
+// externalRepos returns the repositories in repos that are not on a volume of
+// the repository host.
+func externalRepos(repos []v1beta1.PGBackRestRepo) []v1beta1.PGBackRestRepo {
+	return slices.DeleteFunc(slices.Clone(repos),
+		func(repo v1beta1.PGBackRestRepo) bool { return repo.Volume != nil })
+}
+
+// addRepoEncryptionEnvironment passes the passphrases of the encrypted
+// repositories in repos to pgBackRest through environment variables, so they
+// never appear in its configuration files. Generated passphrases are read from
+// the pgBackRest Secret of cluster; they are skipped when cluster is nil.
+// - https://pgbackrest.org/command.html#introduction
+func addRepoEncryptionEnvironment(
+	cluster *v1beta1.PostgresCluster, repos []v1beta1.PGBackRestRepo, pod *corev1.PodSpec,
+) {
+	var env []corev1.EnvVar
+	for _, repo := range repos {
+		encryption := RepoEncryption(cluster, repo)
+		if encryption == nil || encryption.SecretName == "" {
+			continue
+		}
+
+		env = append(env, corev1.EnvVar{
+			Name: "PGBACKREST_" + strings.ToUpper(repo.Name) + "_CIPHER_PASS",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: encryption.SecretName},
+					Key:                  encryption.Key,
+				},
+			},
+		})
+	}
+
+	for i := range pod.Containers {
+		container := &pod.Containers[i]
+
+		switch container.Name {
+		case
+			naming.ContainerDatabase,
+			naming.PGBackRestRepoContainerName,
+			naming.PGBackRestRestoreContainerName:
+
+			container.Env = append(container.Env, env...)
+		}
+	}
+}
+
 // addServerContainerAndVolume adds the TLS server container and certificate
 // projections to pod. Any PostgreSQL data and WAL volumes in pod are also mounted.
 func addServerContainerAndVolume(
@@ -359,6 +504,7 @@ func addServerContainerAndVolume(
 	reloader := corev1.Container{
 		Name:            naming.ContainerPGBackRestConfig,
 		Command:         reloadCommand(naming.ContainerPGBackRestConfig),
+		Env:             autogrowEnvironment(cluster),
 		Image:           container.Image,
 		ImagePullPolicy: container.ImagePullPolicy,
 		SecurityContext: initialize.RestrictedSecurityContext(),
@@ -521,6 +667,13 @@ func RestoreConfig(
 		for _, item := range clientCertificates() {
 			targetSecret.Data[item.Key] = bytesClone(sourceSecret.Data[item.Key])
 		}
+
+		// Use the generated repository passphrases from the source cluster.
+		for key, value := range sourceSecret.Data {
+			if strings.HasSuffix(key, cipherPassSecretKey("")) {
+				targetSecret.Data[key] = bytesClone(value)
+			}
+		}
 	}
 }
 
@@ -558,7 +711,7 @@ func Secret(ctx context.Context,
 	}
 
 	if err == nil {
-		outSecret.Data[certAuthoritySecretKey], err = certFile(inRoot.Certificate)
+		outSecret.Data[certAuthoritySecretKey], err = certFile(inRoot.Bundle())
 	}
 	if err == nil {
 		outSecret.Data[certClientPrivateKeySecretKey], err = certFile(leaf.PrivateKey)
@@ -594,5 +747,24 @@ func Secret(ctx context.Context,
 		}
 	}
 
+	// Keep or generate a passphrase for each repository that is encrypted
+	// without one from the spec. A passphrase cannot change after the stanza
+	// is created, so an existing one is always kept.
+	// - https://pgbackrest.org/user-guide.html#quickstart/configure-encryption
+	for _, repo := range inCluster.Spec.Backups.PGBackRest.Repos {
+		if repo.Encryption == nil || repo.Encryption.PassphraseSecretRef != nil {
+			continue
+		}
+
+		key := cipherPassSecretKey(repo.Name)
+		if existing := inSecret.Data[key]; len(existing) > 0 {
+			outSecret.Data[key] = existing
+		} else if err == nil {
+			var passphrase string
+			passphrase, err = util.GenerateAlphaNumericPassword(64)
+			outSecret.Data[key] = []byte(passphrase)
+		}
+	}
+
 	return err
 }
@@ -284,6 +284,137 @@ func TestAddConfigToInstancePod(t *testing.T) {
 		`))
 	})
 
+	t.Run("SFTPAndPOSIXRepos", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+			{
+				Name: "repo1",
+				SFTP: &v1beta1.RepoSFTP{
+					Host: "sftp.example.com", User: "backups",
+					KnownHosts: []string{"sftp.example.com ssh-ed25519 AAAA"},
+					PrivateKeySecretRef: v1beta1.SecretKeyRef{
+						Name: "sftp-keys", Key: "id_ed25519",
+					},
+				},
+			},
+			{
+				Name: "repo2",
+				POSIX: &v1beta1.RepoPOSIX{
+					NFS: &v1beta1.RepoNFS{Server: "nfs.example.com", Path: "/exports/pg"},
+				},
+			},
+		}
+
+		out := pod.DeepCopy()
+		AddConfigToInstancePod(cluster, out)
+
+		// The NFS export is mounted where pgBackRest expects the repository.
+		assert.Assert(t, cmp.MarshalMatches(out.Containers, `
+- name: database
+  resources: {}
+  volumeMounts:
+  - mountPath: /etc/pgbackrest/conf.d
+    name: pgbackrest-config
+    readOnly: true
+  - mountPath: /pgbackrest/repo2
+    name: repo2
+- name: other
+  resources: {}
+- name: pgbackrest
+  resources: {}
+  volumeMounts:
+  - mountPath: /etc/pgbackrest/conf.d
+    name: pgbackrest-config
+    readOnly: true
+  - mountPath: /pgbackrest/repo2
+    name: repo2
+		`))
+
+		// The private key and known hosts are projected after custom projections.
+		assert.Assert(t, cmp.MarshalMatches(out.Volumes, `
+- name: pgbackrest-config
+  projected:
+    sources:
+    - secret:
+        items:
+        - key: id_ed25519
+          mode: 384
+          path: ~postgres-operator/repo1-sftp.key
+        name: sftp-keys
+    - configMap:
+        items:
+        - key: repo1-sftp-known-hosts
+          path: ~postgres-operator/repo1-sftp-known-hosts
+        name: hippo-pgbackrest-config
+    - configMap:
+        items:
+        - key: pgbackrest_instance.conf
+          path: pgbackrest_instance.conf
+        - key: config-hash
+          path: config-hash
+        - key: pgbackrest-server.conf
+          path: ~postgres-operator_server.conf
+        name: hippo-pgbackrest-config
+    - secret:
+        items:
+        - key: pgbackrest.ca-roots
+          path: ~postgres-operator/tls-ca.crt
+        - key: pgbackrest-client.crt
+          path: ~postgres-operator/client-tls.crt
+        - key: pgbackrest-client.key
+          mode: 384
+          path: ~postgres-operator/client-tls.key
+        name: hippo-pgbackrest
+        optional: true
+- name: repo2
+  nfs:
+    path: /exports/pg
+    server: nfs.example.com
+		`))
+	})
+
+	t.Run("EncryptedRepos", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+			{
+				Name:       "repo1",
+				S3:         &v1beta1.RepoS3{Bucket: "b", Endpoint: "e", Region: "r"},
+				Encryption: &v1beta1.RepoEncryption{},
+			},
+			{
+				Name:       "repo2",
+				Volume:     new(v1beta1.RepoPVC),
+				Encryption: &v1beta1.RepoEncryption{},
+			},
+			{
+				Name: "repo3",
+				GCS:  &v1beta1.RepoGCS{Bucket: "g"},
+				Encryption: &v1beta1.RepoEncryption{
+					PassphraseSecretRef: &v1beta1.SecretKeyRef{Name: "keys", Key: "gcs"},
+				},
+			},
+		}
+
+		out := pod.DeepCopy()
+		AddConfigToInstancePod(cluster, out)
+
+		// The repository host encrypts the volume; PostgreSQL encrypts the others.
+		assert.Assert(t, cmp.MarshalMatches(out.Containers[0].Env, `
+- name: PGBACKREST_REPO1_CIPHER_PASS
+  valueFrom:
+    secretKeyRef:
+      key: repo1-cipher-pass
+      name: hippo-pgbackrest
+- name: PGBACKREST_REPO3_CIPHER_PASS
+  valueFrom:
+    secretKeyRef:
+      key: gcs
+      name: keys
+		`))
+		assert.DeepEqual(t, out.Containers[0].Env, out.Containers[2].Env)
+		assert.Assert(t, out.Containers[1].Env == nil)
+	})
+
 	t.Run("OneVolumeRepo", func(t *testing.T) {
 		cluster := cluster.DeepCopy()
 		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
@@ -397,6 +528,26 @@ func TestAddConfigToRepoPod(t *testing.T) {
         name: hippo-pgbackrest
 		`))
 	})
+
+	t.Run("EncryptedRepos", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+			{Name: "repo1", Volume: new(v1beta1.RepoPVC)},
+			{Name: "repo2", Volume: new(v1beta1.RepoPVC), Encryption: &v1beta1.RepoEncryption{}},
+		}
+
+		out := pod.DeepCopy()
+		AddConfigToRepoPod(cluster, out)
+
+		// The repository host encrypts the volume.
+		assert.Assert(t, cmp.MarshalMatches(out.Containers[1].Env, `
+- name: PGBACKREST_REPO2_CIPHER_PASS
+  valueFrom:
+    secretKeyRef:
+      key: repo2-cipher-pass
+      name: hippo-pgbackrest
+		`))
+	})
 }
 
 func TestAddConfigToCloudBackupJob(t *testing.T) {
@@ -744,7 +895,8 @@ func TestAddServerToInstancePod(t *testing.T) {
     # Manage autogrow annotation.
     # Return size in Mebibytes.
     manageAutogrowAnnotation() {
-      local volume=$1
+      local volume=$1 threshold percent mebibytes
+      read -r threshold percent mebibytes <<< "$2"
 
       size=$(df --block-size=M "/pgbackrest/${volume}")
       read -r _ size _ <<< "${size#*$'\n'}"
@@ -753,9 +905,9 @@ func TestAddServerToInstancePod(t *testing.T) {
       sizeInt="${size//M/}"
       # Use the sed punctuation class, because the shell will not accept the percent sign in an expansion.
       useInt=${use//[[:punct:]]/}
-      triggerExpansion="$((useInt > 75))"
+      triggerExpansion="$((useInt > threshold))"
       if [[ ${triggerExpansion} -eq 1 ]]; then
-        newSize="$(((sizeInt / 2)+sizeInt))"
+        newSize="$((sizeInt + (sizeInt * percent / 100) + mebibytes))"
         newSizeMi="${newSize}Mi"
         d='[{"op": "add", "path": "/metadata/annotations/suggested-'"${volume}"'-pvc-size", "value": "'"${newSizeMi}"'"}]'
         curl --cacert "${CACERT}" --header "Authorization: Bearer ${TOKEN}" -XPATCH "${APISERVER}/api/v1/namespaces/${NAMESPACE}/pods/${HOSTNAME}?fieldManager=kubectl-annotate" -H "Content-Type: application/json-patch+json" --data "${d}"
@@ -782,22 +934,22 @@ func TestAddServerToInstancePod(t *testing.T) {
 
       # manage autogrow annotation for the repo1 volume, if it exists
       if [[ -d /pgbackrest/repo1 ]]; then
-        manageAutogrowAnnotation "repo1"
+        manageAutogrowAnnotation "repo1" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo2 volume, if it exists
       if [[ -d /pgbackrest/repo2 ]]; then
-        manageAutogrowAnnotation "repo2"
+        manageAutogrowAnnotation "repo2" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo3 volume, if it exists
       if [[ -d /pgbackrest/repo3 ]]; then
-        manageAutogrowAnnotation "repo3"
+        manageAutogrowAnnotation "repo3" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo4 volume, if it exists
       if [[ -d /pgbackrest/repo4 ]]; then
-        manageAutogrowAnnotation "repo4"
+        manageAutogrowAnnotation "repo4" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
     done
@@ -922,7 +1074,8 @@ func TestAddServerToInstancePod(t *testing.T) {
     # Manage autogrow annotation.
     # Return size in Mebibytes.
     manageAutogrowAnnotation() {
-      local volume=$1
+      local volume=$1 threshold percent mebibytes
+      read -r threshold percent mebibytes <<< "$2"
 
       size=$(df --block-size=M "/pgbackrest/${volume}")
       read -r _ size _ <<< "${size#*$'\n'}"
@@ -931,9 +1084,9 @@ func TestAddServerToInstancePod(t *testing.T) {
       sizeInt="${size//M/}"
       # Use the sed punctuation class, because the shell will not accept the percent sign in an expansion.
       useInt=${use//[[:punct:]]/}
-      triggerExpansion="$((useInt > 75))"
+      triggerExpansion="$((useInt > threshold))"
       if [[ ${triggerExpansion} -eq 1 ]]; then
-        newSize="$(((sizeInt / 2)+sizeInt))"
+        newSize="$((sizeInt + (sizeInt * percent / 100) + mebibytes))"
         newSizeMi="${newSize}Mi"
         d='[{"op": "add", "path": "/metadata/annotations/suggested-'"${volume}"'-pvc-size", "value": "'"${newSizeMi}"'"}]'
         curl --cacert "${CACERT}" --header "Authorization: Bearer ${TOKEN}" -XPATCH "${APISERVER}/api/v1/namespaces/${NAMESPACE}/pods/${HOSTNAME}?fieldManager=kubectl-annotate" -H "Content-Type: application/json-patch+json" --data "${d}"
@@ -960,22 +1113,22 @@ func TestAddServerToInstancePod(t *testing.T) {
 
       # manage autogrow annotation for the repo1 volume, if it exists
       if [[ -d /pgbackrest/repo1 ]]; then
-        manageAutogrowAnnotation "repo1"
+        manageAutogrowAnnotation "repo1" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo2 volume, if it exists
       if [[ -d /pgbackrest/repo2 ]]; then
-        manageAutogrowAnnotation "repo2"
+        manageAutogrowAnnotation "repo2" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo3 volume, if it exists
       if [[ -d /pgbackrest/repo3 ]]; then
-        manageAutogrowAnnotation "repo3"
+        manageAutogrowAnnotation "repo3" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo4 volume, if it exists
       if [[ -d /pgbackrest/repo4 ]]; then
-        manageAutogrowAnnotation "repo4"
+        manageAutogrowAnnotation "repo4" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
     done
@@ -1089,7 +1242,8 @@ func TestAddServerToRepoPod(t *testing.T) {
     # Manage autogrow annotation.
     # Return size in Mebibytes.
     manageAutogrowAnnotation() {
-      local volume=$1
+      local volume=$1 threshold percent mebibytes
+      read -r threshold percent mebibytes <<< "$2"
 
       size=$(df --block-size=M "/pgbackrest/${volume}")
       read -r _ size _ <<< "${size#*$'\n'}"
@@ -1098,9 +1252,9 @@ func TestAddServerToRepoPod(t *testing.T) {
       sizeInt="${size//M/}"
       # Use the sed punctuation class, because the shell will not accept the percent sign in an expansion.
       useInt=${use//[[:punct:]]/}
-      triggerExpansion="$((useInt > 75))"
+      triggerExpansion="$((useInt > threshold))"
       if [[ ${triggerExpansion} -eq 1 ]]; then
-        newSize="$(((sizeInt / 2)+sizeInt))"
+        newSize="$((sizeInt + (sizeInt * percent / 100) + mebibytes))"
         newSizeMi="${newSize}Mi"
         d='[{"op": "add", "path": "/metadata/annotations/suggested-'"${volume}"'-pvc-size", "value": "'"${newSizeMi}"'"}]'
         curl --cacert "${CACERT}" --header "Authorization: Bearer ${TOKEN}" -XPATCH "${APISERVER}/api/v1/namespaces/${NAMESPACE}/pods/${HOSTNAME}?fieldManager=kubectl-annotate" -H "Content-Type: application/json-patch+json" --data "${d}"
@@ -1127,22 +1281,22 @@ func TestAddServerToRepoPod(t *testing.T) {
 
       # manage autogrow annotation for the repo1 volume, if it exists
       if [[ -d /pgbackrest/repo1 ]]; then
-        manageAutogrowAnnotation "repo1"
+        manageAutogrowAnnotation "repo1" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo2 volume, if it exists
       if [[ -d /pgbackrest/repo2 ]]; then
-        manageAutogrowAnnotation "repo2"
+        manageAutogrowAnnotation "repo2" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo3 volume, if it exists
       if [[ -d /pgbackrest/repo3 ]]; then
-        manageAutogrowAnnotation "repo3"
+        manageAutogrowAnnotation "repo3" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
       # manage autogrow annotation for the repo4 volume, if it exists
       if [[ -d /pgbackrest/repo4 ]]; then
-        manageAutogrowAnnotation "repo4"
+        manageAutogrowAnnotation "repo4" "${AUTOGROW_REPOS:-75 50 0}"
       fi
 
     done
@@ -1292,6 +1446,33 @@ func TestSecret(t *testing.T) {
 	assert.NilError(t, Secret(ctx, cluster, host, root, existing, intent))
 	assert.DeepEqual(t, before, intent)
 
+	t.Run("Encryption", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{
+			{Name: "repo1", Encryption: &v1beta1.RepoEncryption{}},
+			{Name: "repo2"},
+			{Name: "repo3", Encryption: &v1beta1.RepoEncryption{
+				PassphraseSecretRef: &v1beta1.SecretKeyRef{Name: "keys", Key: "repo3"},
+			}},
+		}
+
+		existing := existing.DeepCopy()
+		intent := new(corev1.Secret)
+		assert.NilError(t, Secret(ctx, cluster, host, root, existing, intent))
+
+		// Only repositories without a passphrase in the spec get one.
+		passphrase := intent.Data["repo1-cipher-pass"]
+		assert.Equal(t, len(passphrase), 64)
+		assert.Assert(t, intent.Data["repo2-cipher-pass"] == nil)
+		assert.Assert(t, intent.Data["repo3-cipher-pass"] == nil)
+
+		// The passphrase never changes.
+		existing.Data = intent.Data
+		intent = new(corev1.Secret)
+		assert.NilError(t, Secret(ctx, cluster, host, root, existing, intent))
+		assert.DeepEqual(t, intent.Data["repo1-cipher-pass"], passphrase)
+	})
+
 	t.Run("Rotation", func(t *testing.T) {
 		// The leaf certificate is regenerated when the root authority changes.
 		root2, err := pki.NewRootCertificateAuthority()
@@ -1306,3 +1487,30 @@ func TestSecret(t *testing.T) {
 		assert.Assert(t, !reflect.DeepEqual(leaf.PrivateKey, leaf2.PrivateKey))
 	})
 }
+
+func TestRestoreConfig(t *testing.T) {
+	sourceConfig := &corev1.ConfigMap{Data: map[string]string{
+		"pgbackrest_instance.conf": "some-config", "other": "value",
+	}}
+	sourceSecret := &corev1.Secret{Data: map[string][]byte{
+		"pgbackrest.ca-roots":   []byte("ca"),
+		"pgbackrest-client.crt": []byte("crt"),
+		"pgbackrest-client.key": []byte("key"),
+		"pgbackrest-server.crt": []byte("server"),
+		"repo2-cipher-pass":     []byte("secret"),
+	}}
+
+	targetConfig := new(corev1.ConfigMap)
+	targetSecret := new(corev1.Secret)
+	RestoreConfig(sourceConfig, targetConfig, sourceSecret, targetSecret)
+
+	assert.DeepEqual(t, targetConfig.Data, map[string]string{
+		"pgbackrest_instance.conf": "some-config",
+	})
+	assert.DeepEqual(t, targetSecret.Data, map[string][]byte{
+		"pgbackrest.ca-roots":   []byte("ca"),
+		"pgbackrest-client.crt": []byte("crt"),
+		"pgbackrest-client.key": []byte("key"),
+		"repo2-cipher-pass":     []byte("secret"),
+	})
+}
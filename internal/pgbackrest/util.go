@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/rand"
 
+	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
@@ -47,7 +48,8 @@ func CloudRepoDefined(postgresCluster *v1beta1.PostgresCluster) bool {
 }
 
 // CalculateConfigHashes calculates hashes for any external pgBackRest repository configuration
-// present in the PostgresCluster spec (e.g. configuration for Azure, GCR and/or S3 repositories).
+// present in the PostgresCluster spec (e.g. configuration for Azure, GCR, S3, SFTP and/or POSIX
+// repositories).
 // Additionally it returns a hash of the hashes for each external repository.
 func CalculateConfigHashes(
 	postgresCluster *v1beta1.PostgresCluster) (map[string]string, string, error) {
@@ -80,9 +82,24 @@ func CalculateConfigHashes(
 		case repo.S3 != nil:
 			hash, err = hashFunc([]string{repo.S3.Bucket, repo.S3.Endpoint, repo.S3.Region})
 			name = repo.Name
+		case repo.SFTP != nil:
+			hash, err = hashFunc([]string{repo.SFTP.Host,
+				fmt.Sprint(initialize.FromPointer(repo.SFTP.Port)), repo.SFTP.User, repo.SFTP.Path})
+			name = repo.Name
+		case repo.POSIX != nil:
+			nfs := initialize.FromPointer(repo.POSIX.NFS)
+			hash, err = hashFunc([]string{repo.POSIX.ClaimName, nfs.Server, nfs.Path})
+			name = repo.Name
 		default:
 			return map[string]string{}, "", errors.New("found unexpected repo type")
 		}
+
+		// A different passphrase needs a different stanza. The hash of an
+		// unencrypted repository stays the same.
+		if encryption := RepoEncryption(postgresCluster, repo); err == nil && encryption != nil {
+			hash, err = hashFunc([]string{hash,
+				encryption.Cipher, encryption.SecretName, encryption.Key})
+		}
 		if err != nil {
 			return map[string]string{}, "", errors.WithStack(err)
 		}
@@ -13,6 +13,7 @@ import (
 	"gotest.tools/v3/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
@@ -120,4 +121,55 @@ func TestCalculateConfigHashes(t *testing.T) {
 		repo := "repo" + strconv.Itoa(i+1)
 		assert.Assert(t, hashMap[repo] != configHashMap[repo])
 	}
+
+	t.Run("SFTPAndPOSIX", func(t *testing.T) {
+		cluster := postgresCluster.DeepCopy()
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+			Name: "repo1",
+			SFTP: &v1beta1.RepoSFTP{Host: "sftp.example.com", User: "backups"},
+		}, {
+			Name:  "repo2",
+			POSIX: &v1beta1.RepoPOSIX{ClaimName: "shared"},
+		}}
+
+		hashMap, hash, err := CalculateConfigHashes(cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, hashMap["repo1"] != "")
+		assert.Assert(t, hashMap["repo2"] != "")
+
+		cluster.Spec.Backups.PGBackRest.Repos[0].SFTP.Port = initialize.Int32(2222)
+		cluster.Spec.Backups.PGBackRest.Repos[1].POSIX = &v1beta1.RepoPOSIX{
+			NFS: &v1beta1.RepoNFS{Server: "nfs.example.com", Path: "/exports"},
+		}
+
+		modMap, modHash, err := CalculateConfigHashes(cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, hash != modHash)
+		assert.Assert(t, hashMap["repo1"] != modMap["repo1"])
+		assert.Assert(t, hashMap["repo2"] != modMap["repo2"])
+	})
+
+	t.Run("Encryption", func(t *testing.T) {
+		cluster := postgresCluster.DeepCopy()
+		cluster.Name = "hippo"
+		cluster.Spec.Backups.PGBackRest.Repos = []v1beta1.PGBackRestRepo{{
+			Name: "repo1",
+			GCS:  &v1beta1.RepoGCS{Bucket: "g"},
+		}}
+
+		plain, _, err := CalculateConfigHashes(cluster)
+		assert.NilError(t, err)
+
+		// A new passphrase changes the hash.
+		cluster.Spec.Backups.PGBackRest.Repos[0].Encryption = &v1beta1.RepoEncryption{}
+		generated, _, err := CalculateConfigHashes(cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, plain["repo1"] != generated["repo1"])
+
+		cluster.Spec.Backups.PGBackRest.Repos[0].Encryption.PassphraseSecretRef =
+			&v1beta1.SecretKeyRef{Name: "keys", Key: "repo1"}
+		referenced, _, err := CalculateConfigHashes(cluster)
+		assert.NilError(t, err)
+		assert.Assert(t, generated["repo1"] != referenced["repo1"])
+	})
 }
@@ -0,0 +1,167 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbouncer
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Executor provides methods for calling "psql" in a PgBouncer container.
+type Executor func(
+	ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+) error
+
+// adminConsoleScript prepares a Bash array that connects to the PgBouncer
+// admin console on port "$1" as user "$2". The password is read from stdin so
+// that it does not appear in `ps` or `top`.
+// - https://www.pgbouncer.org/usage.html#admin-console
+const adminConsoleScript = `
+PGPASSWORD="$(cat)" && export PGPASSWORD
+console=(psql -Xwq --host=localhost --port="$1" --username="$2" --dbname=pgbouncer)
+`
+
+// resumeTimerScript stops any timer that a previous [Executor.Pause] of the
+// PgBouncer listening on port "$1" started. The timer is a "sleep" process
+// named for that port; the RESUME that follows it does not run when it is
+// stopped. Pods of PgBouncer share a process namespace, so look in "/proc".
+const resumeTimerScript = `
+timer="pgbouncer-resume-timer-$1"
+for cmdline in /proc/[0-9]*/cmdline; do
+  name='' && { read -rd '' name < "${cmdline}" || true; } 2> /dev/null
+  if [[ "${name}" == "${timer}" ]]; then
+    pid="${cmdline%/cmdline}" && kill "${pid#/proc/}" 2> /dev/null || true
+  fi
+done
+`
+
+// Pause uses the admin console of the PgBouncer listening on port to pause
+// client connections. PgBouncer waits for server connections to be released;
+// when that takes longer than timeout, Pause returns an error and PgBouncer
+// may still be pausing. PgBouncer resumes on its own after limit regardless,
+// unless Pause or [Executor.Resume] is called again before then.
+// - https://www.pgbouncer.org/usage.html#pause-db
+func (exec Executor) Pause(
+	ctx context.Context, password string, port int32, timeout, limit time.Duration,
+) error {
+	// Replace any earlier timer with a background process that resumes
+	// PgBouncer after the limit. This keeps clients from waiting forever
+	// should the caller fail to resume, without an old timer resuming
+	// PgBouncer during a later pause. Then wait for PgBouncer to pause,
+	// but only until the timeout.
+	const script = adminConsoleScript + resumeTimerScript + `
+( (exec -a "${timer}" sleep "$4") && "${console[@]}" --command=RESUME ) < /dev/null > /dev/null 2>&1 &
+timeout "$3" "${console[@]}" --command=PAUSE
+`
+	var stdout, stderr bytes.Buffer
+	err := exec(ctx, strings.NewReader(password), &stdout, &stderr,
+		"bash", "-ceu", "--", script, "-",
+		fmt.Sprint(port), PostgresqlUser, seconds(timeout), seconds(limit))
+
+	if err != nil {
+		err = errors.WithStack(fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String())))
+	}
+	return err
+}
+
+// Resume uses the admin console of the PgBouncer listening on port to resume
+// client connections. It also stops the timer of any earlier [Executor.Pause].
+// It returns no error when PgBouncer is not paused.
+// - https://www.pgbouncer.org/usage.html#resume-db
+func (exec Executor) Resume(ctx context.Context, password string, port int32) error {
+	const script = adminConsoleScript + resumeTimerScript + `
+"${console[@]}" --command=RESUME
+`
+	var stdout, stderr bytes.Buffer
+	err := exec(ctx, strings.NewReader(password), &stdout, &stderr,
+		"bash", "-ceu", "--", script, "-",
+		fmt.Sprint(port), PostgresqlUser)
+
+	// PgBouncer reports an error when it has already resumed.
+	if err != nil && strings.Contains(stderr.String(), "not paused") {
+		err = nil
+	}
+	if err != nil {
+		err = errors.WithStack(fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String())))
+	}
+	return err
+}
+
+// Clients are counts of client connections in PgBouncer.
+type Clients struct {
+	// Active clients are linked to a server connection or idle.
+	Active int
+
+	// Waiting clients are waiting for a server connection.
+	Waiting int
+}
+
+// Clients uses the admin console of the PgBouncer listening on port to count
+// its client connections in every pool except the admin console itself.
+// - https://www.pgbouncer.org/usage.html#show-pools
+func (exec Executor) Clients(ctx context.Context, password string, port int32) (Clients, error) {
+	const script = adminConsoleScript + `
+"${console[@]}" --csv --command="SHOW POOLS"
+`
+	var stdout, stderr bytes.Buffer
+	err := exec(ctx, strings.NewReader(password), &stdout, &stderr,
+		"bash", "-ceu", "--", script, "-",
+		fmt.Sprint(port), PostgresqlUser)
+
+	if err != nil {
+		return Clients{}, errors.WithStack(
+			fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String())))
+	}
+	return parseClients(stdout.String())
+}
+
+// parseClients sums the client columns of "SHOW POOLS" output in CSV format.
+// Columns are found by name because they vary between versions of PgBouncer.
+func parseClients(output string) (Clients, error) {
+	var clients Clients
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil || len(records) == 0 {
+		return clients, errors.Errorf("unexpected output of SHOW POOLS: %q", output)
+	}
+
+	header := records[0]
+	database, active, waiting :=
+		slices.Index(header, "database"),
+		slices.Index(header, "cl_active"),
+		slices.Index(header, "cl_waiting")
+	if database < 0 || active < 0 || waiting < 0 {
+		return clients, errors.Errorf("unexpected columns of SHOW POOLS: %q", header)
+	}
+
+	for _, record := range records[1:] {
+		if record[database] == "pgbouncer" {
+			continue
+		}
+		a, aerr := strconv.Atoi(record[active])
+		w, werr := strconv.Atoi(record[waiting])
+		if aerr != nil || werr != nil {
+			return clients, errors.Errorf("unexpected values in SHOW POOLS: %q", record)
+		}
+		clients.Active += a
+		clients.Waiting += w
+	}
+	return clients, nil
+}
+
+// seconds returns d as a whole number of seconds, rounded up.
+func seconds(d time.Duration) string {
+	return fmt.Sprint(int64(math.Ceil(d.Seconds())))
+}
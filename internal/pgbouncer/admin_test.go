@@ -0,0 +1,224 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbouncer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+)
+
+func TestExecutorPause(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Arguments", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, _, stderr io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Equal(t, string(b), "secret")
+
+			assert.DeepEqual(t, command[:3], []string{"bash", "-ceu", "--"})
+			assert.DeepEqual(t, command[4:], []string{"-", "6432", "_crunchypgbouncer", "3", "60"})
+
+			_, _ = stderr.Write([]byte("timed out\n"))
+			return expected
+		}
+
+		err := Executor(exec).Pause(ctx, "secret", 6432, 2500*time.Millisecond, time.Minute)
+		assert.Assert(t, errors.Is(err, expected))
+		assert.ErrorContains(t, err, "pass-through: timed out")
+	})
+
+	t.Run("Script", func(t *testing.T) {
+		shellcheck := require.ShellCheck(t)
+
+		var script string
+		_ = Executor(func(
+			_ context.Context, _ io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			script = command[3]
+			return nil
+		}).Pause(ctx, "", 5432, time.Second, time.Second)
+
+		// Expect shellcheck to be happy.
+		file := filepath.Join(t.TempDir(), "script.bash")
+		assert.NilError(t, os.WriteFile(file, []byte(script), 0o600))
+
+		cmd := exec.CommandContext(t.Context(), shellcheck, "--enable=all", "--shell=bash", file)
+		output, err := cmd.CombinedOutput()
+		assert.NilError(t, err, "%q\n%s", cmd.Args, output)
+	})
+}
+
+func TestExecutorPauseTimer(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip(`requires "bash" executable`)
+	}
+
+	// Run scripts locally with a "psql" that records its commands.
+	dir := t.TempDir()
+	log := filepath.Join(dir, "commands.log")
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "psql"), []byte(
+		"#!/bin/sh\nfor arg; do case \"$arg\" in --command=*) echo \"${arg#--command=}\" >> '"+log+"';; esac; done\n",
+	), 0o700)) // #nosec G306 -- the fake "psql" must be executable
+
+	local := Executor(func(
+		ctx context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+	) error {
+		cmd := exec.CommandContext(ctx, command[0], command[1:]...) // #nosec G204 -- test scripts
+		cmd.Env = append(os.Environ(), "PATH="+dir+":"+os.Getenv("PATH"))
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = stdin, stdout, stderr
+		return cmd.Run()
+	})
+	commands := func() string {
+		b, _ := os.ReadFile(log)
+		return string(b)
+	}
+
+	ctx := context.Background()
+	port := int32(os.Getpid()%20000 + 20000)
+
+	t.Run("Limit", func(t *testing.T) {
+		assert.NilError(t, os.WriteFile(log, nil, 0o600))
+		assert.NilError(t, local.Pause(ctx, "", port, time.Minute, time.Second))
+
+		time.Sleep(2 * time.Second)
+		assert.Equal(t, commands(), "PAUSE\nRESUME\n", "expected the timer to resume")
+	})
+
+	t.Run("PauseAgain", func(t *testing.T) {
+		assert.NilError(t, os.WriteFile(log, nil, 0o600))
+		assert.NilError(t, local.Pause(ctx, "", port, time.Minute, time.Second))
+		assert.NilError(t, local.Pause(ctx, "", port, time.Minute, 3*time.Second))
+
+		time.Sleep(2 * time.Second)
+		assert.Equal(t, commands(), "PAUSE\nPAUSE\n", "expected the first timer to stop")
+
+		assert.NilError(t, local.Resume(ctx, "", port))
+
+		time.Sleep(2 * time.Second)
+		assert.Equal(t, commands(), "PAUSE\nPAUSE\nRESUME\n", "expected the second timer to stop")
+	})
+}
+
+func TestExecutorResume(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Arguments", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Equal(t, string(b), "secret")
+
+			assert.DeepEqual(t, command[:3], []string{"bash", "-ceu", "--"})
+			assert.DeepEqual(t, command[4:], []string{"-", "6432", "_crunchypgbouncer"})
+			return nil
+		}
+
+		assert.NilError(t, Executor(exec).Resume(ctx, "secret", 6432))
+	})
+
+	t.Run("NotPaused", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, _, stderr io.Writer, _ ...string,
+		) error {
+			_, _ = stderr.Write([]byte("ERROR:  pooler is not paused/suspended\n"))
+			return errors.New("exit status 1")
+		}
+
+		assert.NilError(t, Executor(exec).Resume(ctx, "", 6432))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, _, stderr io.Writer, _ ...string,
+		) error {
+			_, _ = stderr.Write([]byte("connection refused\n"))
+			return errors.New("exit status 2")
+		}
+
+		assert.ErrorContains(t, Executor(exec).Resume(ctx, "", 6432),
+			"exit status 2: connection refused")
+	})
+}
+
+func TestExecutorClients(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Arguments", func(t *testing.T) {
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, _ io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Equal(t, string(b), "secret")
+
+			assert.DeepEqual(t, command[:3], []string{"bash", "-ceu", "--"})
+			assert.Assert(t, cmp.Contains(command[3], "SHOW POOLS"))
+			assert.DeepEqual(t, command[4:], []string{"-", "6432", "_crunchypgbouncer"})
+
+			_, _ = stdout.Write([]byte("" +
+				"database,user,cl_active,cl_waiting,sv_active,pool_mode\n" +
+				"pgbouncer,pgbouncer,1,0,0,statement\n" +
+				"app,alice,7,2,3,transaction\n" +
+				"app,bob,5,0,5,transaction\n"))
+			return nil
+		}
+
+		clients, err := Executor(exec).Clients(ctx, "secret", 6432)
+		assert.NilError(t, err)
+		assert.Equal(t, clients, Clients{Active: 12, Waiting: 2})
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, _, stderr io.Writer, _ ...string,
+		) error {
+			_, _ = stderr.Write([]byte("connection refused\n"))
+			return errors.New("exit status 2")
+		}
+
+		_, err := Executor(exec).Clients(ctx, "", 6432)
+		assert.ErrorContains(t, err, "exit status 2: connection refused")
+	})
+}
+
+func TestParseClients(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		output, err string
+		expected    Clients
+	}{
+		{output: "database,cl_active,cl_waiting\n", expected: Clients{}},
+		{output: "cl_waiting,database,cl_active\n1,db,2\n", expected: Clients{Active: 2, Waiting: 1}},
+		{output: "", err: "unexpected output"},
+		{output: "database,cl_active\n", err: "unexpected columns"},
+		{output: "database,cl_active,cl_waiting\ndb,x,1\n", err: "unexpected values"},
+	} {
+		clients, err := parseClients(tt.output)
+		if tt.err != "" {
+			assert.ErrorContains(t, err, tt.err, "%q", tt.output)
+		} else {
+			assert.NilError(t, err, "%q", tt.output)
+			assert.Equal(t, clients, tt.expected, "%q", tt.output)
+		}
+	}
+}
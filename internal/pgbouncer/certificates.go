@@ -6,6 +6,9 @@ package pgbouncer
 
 import (
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 const (
@@ -54,6 +57,23 @@ func backendAuthority(postgres *corev1.SecretProjection) corev1.VolumeProjection
 	return corev1.VolumeProjection{Secret: result}
 }
 
+// frontendCustomCertificate returns a projection of the PgBouncer certificate
+// when the operator does not generate it: either a custom TLS secret or the
+// secret of a cert-manager Certificate. It returns nil otherwise.
+func frontendCustomCertificate(cluster *v1beta1.PostgresCluster) *corev1.SecretProjection {
+	if custom := cluster.Spec.Proxy.PGBouncer.CustomTLSSecret; custom != nil {
+		return custom
+	}
+	if cluster.Spec.TLS != nil && cluster.Spec.TLS.IssuerRef != nil {
+		return &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: naming.PGBouncerIssuedCertificate(cluster).Name,
+			},
+		}
+	}
+	return nil
+}
+
 // frontendCertificate creates a volume projection of the PgBouncer certificate.
 func frontendCertificate(
 	custom *corev1.SecretProjection, secret *corev1.Secret,
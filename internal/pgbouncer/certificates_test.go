@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
 func TestBackendAuthority(t *testing.T) {
@@ -95,3 +96,35 @@ secret:
 		`))
 	})
 }
+
+func TestFrontendCustomCertificate(t *testing.T) {
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Name = "hippo"
+	cluster.Spec.Proxy = &v1beta1.PostgresProxySpec{PGBouncer: &v1beta1.PGBouncerPodSpec{}}
+
+	// The operator generates the certificate.
+	assert.Assert(t, frontendCustomCertificate(cluster) == nil)
+
+	// The issuer signs the certificate.
+	cluster.Spec.TLS = &v1beta1.PostgresClusterTLSSpec{
+		IssuerRef: &v1beta1.CertManagerIssuerReference{Name: "some-issuer"},
+	}
+	assert.Assert(t, cmp.MarshalMatches(frontendCertificate(frontendCustomCertificate(cluster), nil), `
+secret:
+  items:
+  - key: ca.crt
+    path: ~postgres-operator/frontend-ca.crt
+  - key: tls.key
+    path: ~postgres-operator/frontend-tls.key
+  - key: tls.crt
+    path: ~postgres-operator/frontend-tls.crt
+  name: hippo-pgbouncer-tls
+	`))
+
+	// A custom secret takes precedence.
+	custom := &corev1.SecretProjection{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "some-name"},
+	}
+	cluster.Spec.Proxy.PGBouncer.CustomTLSSecret = custom
+	assert.Equal(t, frontendCustomCertificate(cluster), custom)
+}
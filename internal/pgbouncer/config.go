@@ -25,15 +25,21 @@ const (
 	emptyFileAbsolutePath = configDirectory + "/" + emptyFileProjectionPath
 	iniFileAbsolutePath   = configDirectory + "/" + iniFileProjectionPath
 
+	readOnlyINIFileAbsolutePath = configDirectory + "/" + readOnlyINIFileProjectionPath
+
 	authFileProjectionPath  = "~postgres-operator/users.txt"
 	emptyFileProjectionPath = "pgbouncer.ini"
 	iniFileProjectionPath   = "~postgres-operator.ini"
 
+	readOnlyINIFileProjectionPath = "~postgres-operator-ro.ini"
+
 	authFileSecretKey   = "pgbouncer-users.txt" // #nosec G101 this is a name, not a credential
 	passwordSecretKey   = "pgbouncer-password"  // #nosec G101 this is a name, not a credential
 	verifierSecretKey   = "pgbouncer-verifier"  // #nosec G101 this is a name, not a credential
 	emptyConfigMapKey   = "pgbouncer-empty"
 	iniFileConfigMapKey = "pgbouncer.ini"
+
+	readOnlyINIFileConfigMapKey = "pgbouncer-ro.ini"
 )
 
 const (
@@ -77,12 +83,11 @@ func authFileContents(password string) []byte {
 	return []byte(user1)
 }
 
-func clusterINI(ctx context.Context, cluster *v1beta1.PostgresCluster) string {
-	var (
-		pgBouncerPort = *cluster.Spec.Proxy.PGBouncer.Port
-		postgresPort  = *cluster.Spec.Port
-	)
-
+// globalINI returns the settings that apply to every PgBouncer process of
+// cluster, listening on pgBouncerPort.
+func globalINI(
+	ctx context.Context, cluster *v1beta1.PostgresCluster, pgBouncerPort int32,
+) iniValueSet {
 	global := iniValueSet{
 		// Prior to PostgreSQL v12, the default setting for "extra_float_digits"
 		// does not return precise float values. Applications that want
@@ -132,15 +137,34 @@ func clusterINI(ctx context.Context, cluster *v1beta1.PostgresCluster) string {
 		global["logfile"] = naming.PGBouncerLogPath + "/pgbouncer.log"
 	}
 
-	// When OTel metrics are enabled, allow pgBouncer's postgres user
-	// to run read-only console queries on pgBouncer's virtual db
-	if collector.OpenTelemetryMetricsEnabled(ctx, cluster) {
+	// When OTel metrics or autoscaling are enabled, allow pgBouncer's postgres
+	// user to run read-only console queries on pgBouncer's virtual db
+	if collector.OpenTelemetryMetricsEnabled(ctx, cluster) ||
+		cluster.Spec.Proxy.PGBouncer.Autoscaling != nil {
 		global["stats_users"] = PostgresqlUser
 	}
 
+	// Allow the operator to pause and resume client connections through the
+	// admin console.
+	// - https://www.pgbouncer.org/usage.html#admin-console
+	if cluster.Spec.Proxy.PGBouncer.PauseDuringSwitchover != nil {
+		global["admin_users"] = PostgresqlUser
+	}
+
 	// Override the above with any specified settings.
 	maps.Copy(global, cluster.Spec.Proxy.PGBouncer.Config.Global)
 
+	return global
+}
+
+func clusterINI(ctx context.Context, cluster *v1beta1.PostgresCluster) string {
+	var (
+		pgBouncerPort = *cluster.Spec.Proxy.PGBouncer.Port
+		postgresPort  = *cluster.Spec.Port
+	)
+
+	global := globalINI(ctx, cluster, pgBouncerPort)
+
 	// Prevent the user from bypassing the main configuration file.
 	global["conffile"] = iniFileAbsolutePath
 
@@ -162,10 +186,15 @@ func clusterINI(ctx context.Context, cluster *v1beta1.PostgresCluster) string {
 
 	// Replace the above with any specified databases.
 	if len(cluster.Spec.Proxy.PGBouncer.Config.Databases) > 0 {
-		databases = iniValueSet(cluster.Spec.Proxy.PGBouncer.Config.Databases)
+		databases = iniValueSet{}
 	}
 
-	users := iniValueSet(cluster.Spec.Proxy.PGBouncer.Config.Users)
+	// Add pools and user settings from spec.users then override those with
+	// any specified settings.
+	users, userDatabases, _ := userSettings(cluster)
+	maps.Copy(databases, userDatabases)
+	maps.Copy(databases, cluster.Spec.Proxy.PGBouncer.Config.Databases)
+	maps.Copy(users, cluster.Spec.Proxy.PGBouncer.Config.Users)
 
 	// Include any custom configuration file, then apply global settings, then
 	// pool definitions.
@@ -182,6 +211,64 @@ func clusterINI(ctx context.Context, cluster *v1beta1.PostgresCluster) string {
 	return result
 }
 
+// readOnlyINI returns the configuration of a PgBouncer process that listens on
+// the read-only port of cluster and connects to its replicas.
+func readOnlyINI(ctx context.Context, cluster *v1beta1.PostgresCluster) string {
+	var (
+		readOnly      = cluster.Spec.Proxy.PGBouncer.ReadOnly
+		pgBouncerPort = *readOnly.Port
+		postgresPort  = *cluster.Spec.Port
+	)
+
+	global := globalINI(ctx, cluster, pgBouncerPort)
+
+	// Listen on the read-only port regardless of the specified settings.
+	global["listen_port"] = fmt.Sprint(pgBouncerPort)
+
+	if readOnly.PoolMode != "" {
+		global["pool_mode"] = readOnly.PoolMode
+	}
+
+	// Write to a separate file so both processes can log at the same time.
+	if global["logfile"] == naming.PGBouncerFullLogPath {
+		global["logfile"] = naming.PGBouncerReadOnlyFullLogPath
+	}
+
+	// Prevent the user from bypassing the read-only configuration file.
+	global["conffile"] = readOnlyINIFileAbsolutePath
+
+	// Use a wildcard to create connection pools that connect to replicas. When
+	// an instance set with a Service is named, connect to the replicas of that
+	// instance set. These service names are RFC 1123 DNS labels so they do not
+	// need to be quoted nor escaped.
+	service := naming.ClusterReplicaService(cluster)
+	for i := range cluster.Spec.InstanceSets {
+		if set := &cluster.Spec.InstanceSets[i]; set.Name == readOnly.InstanceSet && set.Service != nil {
+			service = naming.InstanceSetReplicaService(cluster, set)
+		}
+	}
+
+	databases := iniValueSet{
+		"*": fmt.Sprintf("host=%s port=%d", service.Name, postgresPort),
+	}
+
+	// Apply user settings from spec.users then any specified user settings.
+	users, _, _ := userSettings(cluster)
+	maps.Copy(users, cluster.Spec.Proxy.PGBouncer.Config.Users)
+
+	result := iniGeneratedWarning +
+		"\n[pgbouncer]" +
+		"\n%include " + emptyFileAbsolutePath +
+		"\n\n[pgbouncer]\n" + global.String() +
+		"\n[databases]\n" + databases.String()
+
+	if len(users) > 0 {
+		result += "\n[users]\n" + users.String()
+	}
+
+	return result
+}
+
 // podConfigFiles returns projections of PgBouncer's configuration files to
 // include in the configuration volume.
 func podConfigFiles(
@@ -222,6 +309,18 @@ func podConfigFiles(
 				}},
 			},
 		},
+	}...)
+
+	// Add the read-only configuration when it exists.
+	if _, ok := configmap.Data[readOnlyINIFileConfigMapKey]; ok {
+		projections[len(projections)-1].ConfigMap.Items = append(
+			projections[len(projections)-1].ConfigMap.Items, corev1.KeyToPath{
+				Key:  readOnlyINIFileConfigMapKey,
+				Path: readOnlyINIFileProjectionPath,
+			})
+	}
+
+	projections = append(projections, []corev1.VolumeProjection{
 		{
 			Secret: &corev1.SecretProjection{
 				LocalObjectReference: corev1.LocalObjectReference{
@@ -17,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
+	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
@@ -131,6 +132,176 @@ app = mode=rad
 		cluster.Spec.Proxy.PGBouncer.Config.Global["conffile"] = "too-far"
 		assert.Assert(t, !strings.Contains(clusterINI(ctx, cluster), "too-far"))
 	})
+
+	t.Run("Users", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Proxy.PGBouncer.Config = v1beta1.PGBouncerConfiguration{}
+		require.UnmarshalInto(t, &cluster.Spec.Users, `[
+			{ name: loader, databases: [graph], pgBouncer: {
+				poolMode: session,
+				databases: [{ name: graph, poolSize: 5 }],
+			} },
+			{ name: web, databases: [graph], pgBouncer: { poolMode: transaction } },
+		]`)
+
+		// Pools for spec.users are in addition to the wildcard.
+		ini := clusterINI(ctx, cluster)
+		assert.Assert(t, strings.HasSuffix(ini, `
+[databases]
+* = host=foo-baz-primary port=9999
+graph = host=foo-baz-primary port=9999 pool_size=5
+
+[users]
+loader = pool_mode=session
+web = pool_mode=transaction
+`), "got:\n%s", ini)
+
+		// Specified settings take precedence.
+		cluster.Spec.Proxy.PGBouncer.Config.Databases = map[string]string{"graph": "conn=str"}
+		cluster.Spec.Proxy.PGBouncer.Config.Users = map[string]string{"web": "mode=rad"}
+
+		ini = clusterINI(ctx, cluster)
+		assert.Assert(t, strings.HasSuffix(ini, `
+[databases]
+graph = conn=str
+
+[users]
+loader = pool_mode=session
+web = mode=rad
+`), "got:\n%s", ini)
+	})
+
+	t.Run("PauseDuringSwitchover", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Proxy.PGBouncer.Config.Global = nil
+		assert.Assert(t, !strings.Contains(clusterINI(ctx, cluster), "admin_users"))
+
+		// The operator user can use the admin console.
+		cluster.Spec.Proxy.PGBouncer.PauseDuringSwitchover = new(v1beta1.PGBouncerPauseSpec)
+		assert.Assert(t, strings.Contains(clusterINI(ctx, cluster),
+			"\nadmin_users = _crunchypgbouncer\n"))
+	})
+
+	t.Run("Autoscaling", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Proxy.PGBouncer.Config.Global = nil
+		assert.Assert(t, !strings.Contains(clusterINI(ctx, cluster), "stats_users"))
+
+		// The operator user can count client connections.
+		cluster.Spec.Proxy.PGBouncer.Autoscaling = new(v1beta1.PGBouncerAutoscalingSpec)
+		assert.Assert(t, strings.Contains(clusterINI(ctx, cluster),
+			"\nstats_users = _crunchypgbouncer\n"))
+	})
+}
+
+func TestReadOnlyINI(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Default()
+
+	cluster.Name = "foo-baz"
+	*cluster.Spec.Port = 9999
+
+	cluster.Spec.Proxy = new(v1beta1.PostgresProxySpec)
+	cluster.Spec.Proxy.PGBouncer = new(v1beta1.PGBouncerPodSpec)
+	cluster.Spec.Proxy.PGBouncer.Port = initialize.Int32(8888)
+	cluster.Spec.Proxy.PGBouncer.ReadOnly = new(v1beta1.PGBouncerReadOnlySpec)
+	cluster.Spec.Proxy.PGBouncer.ReadOnly.Port = initialize.Int32(7777)
+
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, readOnlyINI(ctx, cluster), strings.Trim(`
+# Generated by postgres-operator. DO NOT EDIT.
+# Your changes will not be saved.
+
+[pgbouncer]
+%include /etc/pgbouncer/pgbouncer.ini
+
+[pgbouncer]
+auth_file = /etc/pgbouncer/~postgres-operator/users.txt
+auth_query = SELECT username, password from pgbouncer.get_auth($1)
+auth_user = _crunchypgbouncer
+client_tls_ca_file = /etc/pgbouncer/~postgres-operator/frontend-ca.crt
+client_tls_cert_file = /etc/pgbouncer/~postgres-operator/frontend-tls.crt
+client_tls_key_file = /etc/pgbouncer/~postgres-operator/frontend-tls.key
+client_tls_sslmode = require
+conffile = /etc/pgbouncer/~postgres-operator-ro.ini
+ignore_startup_parameters = extra_float_digits
+listen_addr = *
+listen_port = 7777
+server_tls_ca_file = /etc/pgbouncer/~postgres-operator/backend-ca.crt
+server_tls_sslmode = verify-full
+unix_socket_dir =
+
+[databases]
+* = host=foo-baz-replicas port=9999
+		`, "\t\n")+"\n")
+	})
+
+	t.Run("InstanceSet", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.InstanceSets = []v1beta1.PostgresInstanceSetSpec{
+			{Name: "one"}, {Name: "two", Service: new(v1beta1.ServiceSpec)},
+		}
+
+		// The instance set must have a Service.
+		cluster.Spec.Proxy.PGBouncer.ReadOnly.InstanceSet = "one"
+		assert.Assert(t, strings.Contains(readOnlyINI(ctx, cluster),
+			"\n* = host=foo-baz-replicas port=9999\n"))
+
+		cluster.Spec.Proxy.PGBouncer.ReadOnly.InstanceSet = "two"
+		assert.Assert(t, strings.Contains(readOnlyINI(ctx, cluster),
+			"\n* = host=foo-baz-two-replicas port=9999\n"))
+	})
+
+	t.Run("CustomSettings", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Proxy.PGBouncer.Config.Global = map[string]string{
+			"listen_port": "1234",
+			"pool_mode":   "session",
+			"verbose":     "whomp",
+		}
+		cluster.Spec.Proxy.PGBouncer.Config.Databases = map[string]string{
+			"appdb": "conn=str",
+		}
+		cluster.Spec.Proxy.PGBouncer.Config.Users = map[string]string{
+			"app": "mode=rad",
+		}
+		cluster.Spec.Proxy.PGBouncer.ReadOnly.PoolMode = "transaction"
+
+		assert.Equal(t, readOnlyINI(ctx, cluster), strings.Trim(`
+# Generated by postgres-operator. DO NOT EDIT.
+# Your changes will not be saved.
+
+[pgbouncer]
+%include /etc/pgbouncer/pgbouncer.ini
+
+[pgbouncer]
+auth_file = /etc/pgbouncer/~postgres-operator/users.txt
+auth_query = SELECT username, password from pgbouncer.get_auth($1)
+auth_user = _crunchypgbouncer
+client_tls_ca_file = /etc/pgbouncer/~postgres-operator/frontend-ca.crt
+client_tls_cert_file = /etc/pgbouncer/~postgres-operator/frontend-tls.crt
+client_tls_key_file = /etc/pgbouncer/~postgres-operator/frontend-tls.key
+client_tls_sslmode = require
+conffile = /etc/pgbouncer/~postgres-operator-ro.ini
+ignore_startup_parameters = extra_float_digits
+listen_addr = *
+listen_port = 7777
+pool_mode = transaction
+server_tls_ca_file = /etc/pgbouncer/~postgres-operator/backend-ca.crt
+server_tls_sslmode = verify-full
+unix_socket_dir =
+verbose = whomp
+
+[databases]
+* = host=foo-baz-replicas port=9999
+
+[users]
+app = mode=rad
+		`, "\t\n")+"\n")
+	})
 }
 
 func TestPodConfigFiles(t *testing.T) {
@@ -202,6 +373,36 @@ func TestPodConfigFiles(t *testing.T) {
 	})
 }
 
+func TestPodConfigFilesReadOnly(t *testing.T) {
+	t.Parallel()
+
+	config := v1beta1.PGBouncerConfiguration{}
+	configmap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "some-cm"}}
+	configmap.Data = map[string]string{"pgbouncer-ro.ini": ""}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "some-shh"}}
+
+	projections := podConfigFiles(config, configmap, secret)
+	assert.Assert(t, cmp.MarshalMatches(projections, `
+- configMap:
+    items:
+    - key: pgbouncer-empty
+      path: pgbouncer.ini
+    name: some-cm
+- configMap:
+    items:
+    - key: pgbouncer.ini
+      path: ~postgres-operator.ini
+    - key: pgbouncer-ro.ini
+      path: ~postgres-operator-ro.ini
+    name: some-cm
+- secret:
+    items:
+    - key: pgbouncer-users.txt
+      path: ~postgres-operator/users.txt
+    name: some-shh
+	`))
+}
+
 func TestReloadCommand(t *testing.T) {
 	shellcheck := require.ShellCheck(t)
 	command := reloadCommand("some-name")
@@ -38,6 +38,10 @@ func ConfigMap(
 
 	outConfigMap.Data[emptyConfigMapKey] = ""
 	outConfigMap.Data[iniFileConfigMapKey] = clusterINI(ctx, inCluster)
+
+	if inCluster.Spec.Proxy.PGBouncer.ReadOnly != nil {
+		outConfigMap.Data[readOnlyINIFileConfigMapKey] = readOnlyINI(ctx, inCluster)
+	}
 }
 
 // Secret populates the PgBouncer Secret.
@@ -90,11 +94,18 @@ func Secret(ctx context.Context,
 		outSecret.Data[verifierSecretKey] = []byte(verifier)
 	}
 
-	if inCluster.Spec.Proxy.PGBouncer.CustomTLSSecret == nil {
+	if frontendCustomCertificate(inCluster) == nil {
 		leaf := &pki.LeafCertificate{}
 		dnsNames := naming.ServiceDNSNames(ctx, inService)
 		dnsFQDN := dnsNames[0]
 
+		// Clients of read-only pools connect through another Service.
+		if inCluster.Spec.Proxy.PGBouncer.ReadOnly != nil {
+			dnsNames = append(dnsNames, naming.ServiceDNSNames(ctx, &corev1.Service{
+				ObjectMeta: naming.ClusterPGBouncerReadOnly(inCluster),
+			})...)
+		}
+
 		if err == nil {
 			// Unmarshal and validate the stored leaf. These first errors can
 			// be ignored because they result in an invalid leaf which is then
@@ -107,7 +118,7 @@ func Secret(ctx context.Context,
 		}
 
 		if err == nil {
-			outSecret.Data[certFrontendAuthoritySecretKey], err = inRoot.Certificate.MarshalText()
+			outSecret.Data[certFrontendAuthoritySecretKey], err = inRoot.Bundle().MarshalText()
 		}
 		if err == nil {
 			outSecret.Data[certFrontendPrivateKeySecretKey], err = leaf.PrivateKey.MarshalText()
@@ -141,7 +152,7 @@ func Pod(
 	configVolume.Projected = &corev1.ProjectedVolumeSource{
 		Sources: append(append([]corev1.VolumeProjection{},
 			podConfigFiles(inCluster.Spec.Proxy.PGBouncer.Config, inConfigMap, inSecret)...),
-			frontendCertificate(inCluster.Spec.Proxy.PGBouncer.CustomTLSSecret, inSecret),
+			frontendCertificate(frontendCustomCertificate(inCluster), inSecret),
 			backendAuthority(inPostgreSQLCertificate),
 		),
 	}
@@ -198,6 +209,26 @@ func Pod(
 
 	template.Spec.Containers = []corev1.Container{container, reloader}
 
+	// Run another PgBouncer process for read-only pools. It shares the
+	// configuration volume, so the reloader signals it, too. It has the same
+	// resources as the read-write process unless they are set separately.
+	if readOnly := inCluster.Spec.Proxy.PGBouncer.ReadOnly; readOnly != nil {
+		replicas := container
+		replicas.Name = naming.ContainerPGBouncerReadOnly
+		if readOnly.Resources != nil {
+			replicas.Resources = *readOnly.Resources
+		}
+		replicas.Command = []string{"pgbouncer", readOnlyINIFileAbsolutePath}
+		replicas.Ports = []corev1.ContainerPort{{
+			Name:          naming.PortPGBouncerReadOnly,
+			ContainerPort: *readOnly.Port,
+			Protocol:      corev1.ProtocolTCP,
+		}}
+		replicas.VolumeMounts = []corev1.VolumeMount{configVolumeMount}
+
+		template.Spec.Containers = []corev1.Container{container, replicas, reloader}
+	}
+
 	// If the PGBouncerSidecars feature gate is enabled and custom pgBouncer
 	// sidecars are defined, add the defined container to the Pod.
 	if feature.Enabled(ctx, feature.PGBouncerSidecars) &&
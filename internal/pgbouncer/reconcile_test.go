@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/crunchydata/postgres-operator/internal/feature"
+	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/pki"
 	"github.com/crunchydata/postgres-operator/internal/postgres"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
@@ -48,6 +49,22 @@ func TestConfigMap(t *testing.T) {
 	before := config.DeepCopy()
 	ConfigMap(ctx, cluster, config)
 	assert.DeepEqual(t, before, config)
+
+	// There is no read-only configuration by default.
+	_, ok := config.Data["pgbouncer-ro.ini"]
+	assert.Assert(t, !ok)
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Proxy.PGBouncer.ReadOnly = new(v1beta1.PGBouncerReadOnlySpec)
+		cluster.Default()
+
+		config := new(corev1.ConfigMap)
+		ConfigMap(ctx, cluster, config)
+
+		// The output of readOnlyINI should go into config.
+		assert.DeepEqual(t, config.Data["pgbouncer-ro.ini"], readOnlyINI(ctx, cluster))
+	})
 }
 
 func TestSecret(t *testing.T) {
@@ -89,6 +106,21 @@ func TestSecret(t *testing.T) {
 	before := intent.DeepCopy()
 	assert.NilError(t, Secret(ctx, cluster, root, existing, service, intent))
 	assert.DeepEqual(t, before, intent)
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Name = "hippo"
+		cluster.Namespace = "ns1"
+		cluster.Spec.Proxy.PGBouncer.ReadOnly = new(v1beta1.PGBouncerReadOnlySpec)
+
+		intent := new(corev1.Secret)
+		assert.NilError(t, Secret(ctx, cluster, root, existing, service, intent))
+
+		// The certificate is valid for the read-only Service, too.
+		var leaf pki.Certificate
+		assert.NilError(t, leaf.UnmarshalText(intent.Data["pgbouncer-frontend.crt"]))
+		assert.Assert(t, cmp.Contains(leaf.DNSNames(), "hippo-pgbouncer-ro.ns1.svc"))
+	})
 }
 
 func TestSCRAMVerifier(t *testing.T) {
@@ -512,6 +544,45 @@ volumes:
 			assert.Assert(t, found, "expected custom sidecar 'customsidecar1', but container not found")
 		})
 	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		cluster.Spec.Proxy.PGBouncer.Containers = nil
+		cluster.Spec.Proxy.PGBouncer.ReadOnly = &v1beta1.PGBouncerReadOnlySpec{
+			Port: initialize.Int32(7777),
+		}
+		call()
+
+		assert.Equal(t, len(template.Spec.Containers), 3)
+		assert.Equal(t, template.Spec.Containers[0].Name, "pgbouncer")
+		assert.Equal(t, template.Spec.Containers[2].Name, "pgbouncer-config")
+
+		container := template.Spec.Containers[1]
+		assert.Equal(t, container.Name, "pgbouncer-ro")
+		assert.Equal(t, container.Image, template.Spec.Containers[0].Image)
+		assert.DeepEqual(t, container.Command,
+			[]string{"pgbouncer", "/etc/pgbouncer/~postgres-operator-ro.ini"})
+		assert.Assert(t, cmp.MarshalMatches(container.Ports, `
+- containerPort: 7777
+  name: pgbouncer-ro
+  protocol: TCP
+		`))
+		assert.DeepEqual(t, container.VolumeMounts, template.Spec.Containers[0].VolumeMounts)
+		assert.DeepEqual(t, container.Resources, template.Spec.Containers[0].Resources)
+
+		t.Run("Resources", func(t *testing.T) {
+			cluster.Spec.Proxy.PGBouncer.ReadOnly.Resources = &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			}
+			call()
+
+			assert.Assert(t, cmp.MarshalMatches(template.Spec.Containers[1].Resources, `
+requests:
+  cpu: 100m
+			`))
+			assert.DeepEqual(t, template.Spec.Containers[0].Resources,
+				cluster.Spec.Proxy.PGBouncer.Resources)
+		})
+	})
 }
 
 func TestPostgreSQL(t *testing.T) {
@@ -0,0 +1,123 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbouncer
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/crunchydata/postgres-operator/internal/naming"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// iniBareName matches names that need no quotes in the [databases] and [users]
+// sections of a PgBouncer configuration file.
+var iniBareName = regexp.MustCompile(`^[0-9A-Za-z_]+$`)
+
+// quoteININame returns name quoted for the [databases] or [users] section of a
+// PgBouncer configuration file, when necessary.
+// - https://www.pgbouncer.org/config.html#section-databases
+func quoteININame(name string) string {
+	if iniBareName.MatchString(name) {
+		return name
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// UserSettingsProblems returns a description of every PgBouncer setting in
+// the users of cluster that is not applied.
+func UserSettingsProblems(cluster *v1beta1.PostgresCluster) []string {
+	_, _, problems := userSettings(cluster)
+	return problems
+}
+
+// userSettings returns the [users] and [databases] entries for the PgBouncer
+// settings in the users of cluster, and a description of any that are skipped.
+func userSettings(cluster *v1beta1.PostgresCluster) (
+	users, databases iniValueSet, problems []string,
+) {
+	users, databases = iniValueSet{}, iniValueSet{}
+	owners := map[string]*v1beta1.PostgresUserSpec{}
+	settings := map[string]v1beta1.PGBouncerDatabaseSpec{}
+
+	for i := range cluster.Spec.Users {
+		user := &cluster.Spec.Users[i]
+		if user.PGBouncer == nil {
+			continue
+		}
+
+		// - https://www.pgbouncer.org/config.html#section-users
+		var values []string
+		if user.PGBouncer.PoolMode != "" {
+			values = append(values, "pool_mode="+user.PGBouncer.PoolMode)
+		}
+		if user.PGBouncer.MaxUserConnections != nil {
+			values = append(values, fmt.Sprintf("max_user_connections=%d",
+				*user.PGBouncer.MaxUserConnections))
+		}
+		if len(values) > 0 {
+			users[quoteININame(user.Name)] = strings.Join(values, " ")
+		}
+
+		for _, database := range user.PGBouncer.Databases {
+			if !slices.Contains(user.Databases, database.Name) {
+				problems = append(problems, fmt.Sprintf(
+					"user %q: database %q is not one of its databases", user.Name, database.Name))
+				continue
+			}
+
+			// A database appears in PgBouncer only once, so every user must
+			// agree on its settings.
+			if owner, ok := owners[database.Name]; ok {
+				if !equality.Semantic.DeepEqual(settings[database.Name], database) {
+					problems = append(problems, fmt.Sprintf(
+						"user %q: database %q has different settings in user %q",
+						user.Name, database.Name, owner.Name))
+				}
+				continue
+			}
+
+			// Connect to the primary or to the replicas of an instance set.
+			host := naming.ClusterPrimaryService(cluster).Name
+			if database.InstanceSet != "" {
+				host = ""
+				for j := range cluster.Spec.InstanceSets {
+					set := &cluster.Spec.InstanceSets[j]
+					if set.Name == database.InstanceSet && set.Service != nil {
+						host = naming.InstanceSetReplicaService(cluster, set).Name
+					}
+				}
+				if host == "" {
+					problems = append(problems, fmt.Sprintf(
+						"user %q: database %q: instance set %q does not exist or has no service",
+						user.Name, database.Name, database.InstanceSet))
+					continue
+				}
+			}
+
+			// - https://www.pgbouncer.org/config.html#section-databases
+			values := []string{
+				"host=" + host,
+				fmt.Sprintf("port=%d", *cluster.Spec.Port),
+			}
+			if database.PoolSize != nil {
+				values = append(values, fmt.Sprintf("pool_size=%d", *database.PoolSize))
+			}
+			if database.ReservePool != nil {
+				values = append(values, fmt.Sprintf("reserve_pool=%d", *database.ReservePool))
+			}
+
+			owners[database.Name] = user
+			settings[database.Name] = database
+			databases[quoteININame(database.Name)] = strings.Join(values, " ")
+		}
+	}
+
+	return users, databases, problems
+}
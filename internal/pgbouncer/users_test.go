@@ -0,0 +1,107 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pgbouncer
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/crunchydata/postgres-operator/internal/initialize"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestQuoteININame(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, quoteININame("app_db1"), `app_db1`)
+	assert.Equal(t, quoteININame("app-db"), `"app-db"`)
+	assert.Equal(t, quoteININame(`some "db"`), `"some ""db"""`)
+}
+
+func TestUserSettings(t *testing.T) {
+	t.Parallel()
+
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Default()
+	cluster.Name = "hippo"
+	cluster.Spec.Port = initialize.Int32(5432)
+
+	t.Run("Empty", func(t *testing.T) {
+		users, databases, problems := userSettings(cluster)
+		assert.Equal(t, len(users), 0)
+		assert.Equal(t, len(databases), 0)
+		assert.Equal(t, len(problems), 0)
+	})
+
+	t.Run("Settings", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			instances: [
+				{ name: one },
+				{ name: two, service: { type: ClusterIP } },
+			],
+			users: [
+				{ name: loader, databases: [graph, "age-data"], pgBouncer: {
+					poolMode: session,
+					databases: [
+						{ name: graph, poolSize: 5, reservePool: 2 },
+						{ name: "age-data", instanceSet: two },
+					],
+				} },
+				{ name: web, databases: [graph], pgBouncer: {
+					poolMode: transaction, maxUserConnections: 50,
+					databases: [
+						{ name: graph, poolSize: 5, reservePool: 2 },
+					],
+				} },
+				{ name: other },
+			],
+		}`)
+
+		users, databases, problems := userSettings(cluster)
+		assert.Equal(t, len(problems), 0, "%v", problems)
+		assert.DeepEqual(t, users, iniValueSet{
+			"loader": "pool_mode=session",
+			"web":    "pool_mode=transaction max_user_connections=50",
+		})
+		assert.DeepEqual(t, databases, iniValueSet{
+			"graph":      "host=hippo-primary port=5432 pool_size=5 reserve_pool=2",
+			`"age-data"`: "host=hippo-two-replicas port=5432",
+		})
+	})
+
+	t.Run("Problems", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			instances: [{ name: one }],
+			users: [
+				{ name: first, databases: [graph, other], pgBouncer: {
+					databases: [
+						{ name: graph, poolSize: 5 },
+						{ name: missing },
+						{ name: other, instanceSet: one },
+					],
+				} },
+				{ name: second, databases: [graph], pgBouncer: {
+					databases: [{ name: graph, poolSize: 10 }],
+				} },
+			],
+		}`)
+
+		users, databases, problems := userSettings(cluster)
+		assert.Equal(t, len(users), 0)
+		assert.DeepEqual(t, databases, iniValueSet{
+			"graph": "host=hippo-primary port=5432 pool_size=5",
+		})
+		assert.DeepEqual(t, problems, []string{
+			`user "first": database "missing" is not one of its databases`,
+			`user "first": database "other": instance set "one" does not exist or has no service`,
+			`user "second": database "graph" has different settings in user "first"`,
+		})
+		assert.DeepEqual(t, UserSettingsProblems(cluster), problems)
+	})
+}
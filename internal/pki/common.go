@@ -5,29 +5,88 @@
 package pki
 
 import (
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"fmt"
 	"math/big"
 	"time"
 )
 
-// certificateSignatureAlgorithm is ECDSA with SHA-384, the recommended
-// signature algorithm with the P-256 curve.
-const certificateSignatureAlgorithm = x509.ECDSAWithSHA384
+// Key algorithms of generated certificates.
+const (
+	AlgorithmECDSAP256 = "ECDSA-P256"
+	AlgorithmECDSAP384 = "ECDSA-P384"
+	AlgorithmRSA2048   = "RSA-2048"
+	AlgorithmRSA4096   = "RSA-4096"
+)
+
+const (
+	defaultLeafLifetime = time.Hour * 24 * 365
+	defaultRootLifetime = time.Hour * 24 * 365 * 10
+
+	// Certificates are valid a little before they are generated to allow for
+	// clock skew.
+	leafStartValid = time.Hour * -1
+	rootStartValid = time.Hour * -1
+)
 
 // currentTime returns the current local time. It is a variable so it can be
 // replaced during testing.
 var currentTime = time.Now
 
-// generateKey returns a random ECDSA key using a P-256 curve. This curve is
-// roughly equivalent to an RSA 3072-bit key but requires less bits to achieve
-// the equivalent cryptographic strength. Additionally, ECDSA is FIPS 140-2
-// compliant.
-func generateKey() (*ecdsa.PrivateKey, error) {
-	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+// generateKey returns a random key for algorithm. The default is ECDSA using
+// a P-256 curve. This curve is roughly equivalent to an RSA 3072-bit key but
+// requires less bits to achieve the equivalent cryptographic strength.
+// Additionally, ECDSA is FIPS 140-2 compliant.
+func generateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case "", AlgorithmECDSAP256:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgorithmECDSAP384:
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case AlgorithmRSA2048:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case AlgorithmRSA4096:
+		return rsa.GenerateKey(rand.Reader, 4096)
+	}
+	return nil, fmt.Errorf("unknown key algorithm %q", algorithm)
+}
+
+// keyAlgorithm returns the algorithm of key, or an empty string when it is
+// not one that this package generates.
+func keyAlgorithm(key crypto.Signer) string {
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return AlgorithmECDSAP256
+		case elliptic.P384():
+			return AlgorithmECDSAP384
+		}
+	case *rsa.PrivateKey:
+		switch key.N.BitLen() {
+		case 2048:
+			return AlgorithmRSA2048
+		case 4096:
+			return AlgorithmRSA4096
+		}
+	}
+	return ""
+}
+
+// signatureAlgorithm returns the algorithm that signer uses to sign other
+// certificates. ECDSA with SHA-384 is the recommended signature algorithm with
+// the P-256 curve.
+func signatureAlgorithm(signer crypto.Signer) x509.SignatureAlgorithm {
+	if _, ok := signer.(*rsa.PrivateKey); ok {
+		return x509.SHA256WithRSA
+	}
+	return x509.ECDSAWithSHA384
 }
 
 // generateSerialNumber returns a random 128-bit integer.
@@ -36,22 +95,19 @@ func generateSerialNumber() (*big.Int, error) {
 }
 
 func generateLeafCertificate(
-	signer *x509.Certificate, signerPrivate *ecdsa.PrivateKey,
-	signeePublic *ecdsa.PublicKey, serialNumber *big.Int,
-	commonName string, dnsNames []string,
+	signer *x509.Certificate, signerPrivate crypto.Signer,
+	signeePublic crypto.PublicKey, serialNumber *big.Int,
+	commonName string, dnsNames []string, lifetime time.Duration,
 ) (*x509.Certificate, error) {
-	const leafExpiration = time.Hour * 24 * 365
-	const leafStartValid = time.Hour * -1
-
 	now := currentTime()
 	template := &x509.Certificate{
 		BasicConstraintsValid: true,
 		DNSNames:              dnsNames,
 		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
 		NotBefore:             now.Add(leafStartValid),
-		NotAfter:              now.Add(leafExpiration),
+		NotAfter:              now.Add(lifetime),
 		SerialNumber:          serialNumber,
-		SignatureAlgorithm:    certificateSignatureAlgorithm,
+		SignatureAlgorithm:    signatureAlgorithm(signerPrivate),
 		Subject: pkix.Name{
 			CommonName: commonName,
 		},
@@ -65,11 +121,9 @@ func generateLeafCertificate(
 }
 
 func generateRootCertificate(
-	privateKey *ecdsa.PrivateKey, serialNumber *big.Int,
+	privateKey crypto.Signer, serialNumber *big.Int, lifetime time.Duration,
 ) (*x509.Certificate, error) {
 	const rootCommonName = "postgres-operator-ca"
-	const rootExpiration = time.Hour * 24 * 365 * 10
-	const rootStartValid = time.Hour * -1
 
 	now := currentTime()
 	template := &x509.Certificate{
@@ -78,9 +132,9 @@ func generateRootCertificate(
 		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
 		MaxPathLenZero:        true, // there are no intermediate certificates
 		NotBefore:             now.Add(rootStartValid),
-		NotAfter:              now.Add(rootExpiration),
+		NotAfter:              now.Add(lifetime),
 		SerialNumber:          serialNumber,
-		SignatureAlgorithm:    certificateSignatureAlgorithm,
+		SignatureAlgorithm:    signatureAlgorithm(privateKey),
 		Subject: pkix.Name{
 			CommonName: rootCommonName,
 		},
@@ -8,6 +8,8 @@
 //
 // NewRootCertificateAuthority() creates a new root CA.
 // GenerateLeafCertificate() creates a new leaf certificate.
+// A Policy determines the keys and lifetimes of both, and RootRotation
+// replaces a root CA in phases. See "rotation.md" for the settings and steps.
 //
 // Certificate and PrivateKey are primitives that can be marshaled.
 package pki
@@ -6,6 +6,7 @@ package pki
 
 import (
 	"crypto/ecdsa"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding"
 	"encoding/pem"
@@ -20,6 +21,10 @@ const (
 	// pemLabelECDSAKey is the textual encoding label for an elliptic curve private key
 	// according to RFC 5915. See https://tools.ietf.org/html/rfc5915.
 	pemLabelECDSAKey = "EC PRIVATE KEY"
+
+	// pemLabelRSAKey is the textual encoding label for an RSA private key
+	// according to RFC 8017. See https://tools.ietf.org/html/rfc8017.
+	pemLabelRSAKey = "RSA PRIVATE KEY"
 )
 
 var (
@@ -56,6 +61,56 @@ func (c *Certificate) UnmarshalText(data []byte) error {
 	return err
 }
 
+// CertificateBundle is a sequence of certificates, such as those trusted as
+// authorities.
+type CertificateBundle []Certificate
+
+var (
+	_ encoding.TextMarshaler   = CertificateBundle{}
+	_ encoding.TextUnmarshaler = (*CertificateBundle)(nil)
+)
+
+// MarshalText returns a PEM encoding of every certificate in b.
+func (b CertificateBundle) MarshalText() ([]byte, error) {
+	if len(b) == 0 {
+		return Certificate{}.MarshalText()
+	}
+
+	var out []byte
+	for i := range b {
+		text, err := b[i].MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, text...)
+	}
+	return out, nil
+}
+
+// UnmarshalText populates b from the PEM encoding of one or more certificates.
+func (b *CertificateBundle) UnmarshalText(data []byte) error {
+	var bundle CertificateBundle
+
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != pemLabelCertificate {
+			continue
+		}
+
+		parsed, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return err
+		}
+		bundle = append(bundle, Certificate{x509: parsed})
+	}
+
+	if len(bundle) == 0 {
+		return fmt.Errorf("not a PEM-encoded certificate")
+	}
+
+	*b = bundle
+	return nil
+}
+
 var (
 	_ encoding.TextMarshaler   = PrivateKey{}
 	_ encoding.TextMarshaler   = (*PrivateKey)(nil)
@@ -64,11 +119,19 @@ var (
 
 // MarshalText returns a PEM encoding of k that OpenSSL understands.
 func (k PrivateKey) MarshalText() ([]byte, error) {
-	if k.ecdsa == nil {
-		k.ecdsa = new(ecdsa.PrivateKey)
+	if key, ok := k.signer.(*rsa.PrivateKey); ok {
+		return pem.EncodeToMemory(&pem.Block{
+			Type:  pemLabelRSAKey,
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}), nil
+	}
+
+	key, ok := k.signer.(*ecdsa.PrivateKey)
+	if !ok {
+		key = new(ecdsa.PrivateKey)
 	}
 
-	der, err := x509.MarshalECPrivateKey(k.ecdsa)
+	der, err := x509.MarshalECPrivateKey(key)
 	if err != nil {
 		return nil, err
 	}
@@ -83,13 +146,25 @@ func (k PrivateKey) MarshalText() ([]byte, error) {
 func (k *PrivateKey) UnmarshalText(data []byte) error {
 	block, _ := pem.Decode(data)
 
-	if block == nil || block.Type != pemLabelECDSAKey {
+	if block == nil {
 		return fmt.Errorf("not a PEM-encoded private key")
 	}
 
-	key, err := x509.ParseECPrivateKey(block.Bytes)
-	if err == nil {
-		k.ecdsa = key
+	switch block.Type {
+	case pemLabelECDSAKey:
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err == nil {
+			k.signer = key
+		}
+		return err
+
+	case pemLabelRSAKey:
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err == nil {
+			k.signer = key
+		}
+		return err
 	}
-	return err
+
+	return fmt.Errorf("not a PEM-encoded private key")
 }
@@ -5,7 +5,7 @@
 package pki
 
 import (
-	"crypto/ecdsa"
+	"crypto"
 	"crypto/x509"
 	"math/big"
 	"time"
@@ -18,7 +18,58 @@ const renewalRatio = 3
 type Certificate struct{ x509 *x509.Certificate }
 
 // PrivateKey represents the private key of a Certificate.
-type PrivateKey struct{ ecdsa *ecdsa.PrivateKey }
+type PrivateKey struct{ signer crypto.Signer }
+
+// Policy determines the keys and lifetimes of generated certificates. The zero
+// value is the default policy.
+type Policy struct {
+	// Algorithm of generated keys. Defaults to [AlgorithmECDSAP256].
+	Algorithm string
+
+	// LeafLifetime is how long leaf certificates are valid. Defaults to one year.
+	LeafLifetime time.Duration
+
+	// LeafRenewBefore is how long before they expire that leaf certificates
+	// are replaced. Defaults to one third of their lifetime.
+	LeafRenewBefore time.Duration
+
+	// RootLifetime is how long root certificates are valid. Root certificates
+	// are rotated when one third of their lifetime remains. Defaults to ten years.
+	RootLifetime time.Duration
+
+	// RootRotationPhase is the shortest time between the steps of rotating a
+	// root certificate. Defaults to one hour.
+	RootRotationPhase time.Duration
+}
+
+func (p Policy) leafLifetime() time.Duration {
+	if p.LeafLifetime > 0 {
+		return p.LeafLifetime
+	}
+	return defaultLeafLifetime
+}
+
+func (p Policy) rootLifetime() time.Duration {
+	if p.RootLifetime > 0 {
+		return p.RootLifetime
+	}
+	return defaultRootLifetime
+}
+
+func (p Policy) rootRotationPhase() time.Duration {
+	if p.RootRotationPhase > 0 {
+		return p.RootRotationPhase
+	}
+	return time.Hour
+}
+
+// algorithm returns the key algorithm of p with its default applied.
+func (p Policy) algorithm() string {
+	if p.Algorithm != "" {
+		return p.Algorithm
+	}
+	return AlgorithmECDSAP256
+}
 
 // Equal reports whether c and other have the same value.
 func (c Certificate) Equal(other Certificate) bool {
@@ -42,6 +93,13 @@ func (c Certificate) DNSNames() []string {
 	return append([]string{}, c.x509.DNSNames...)
 }
 
+// SignedBy reports whether c is signed by the key of root. A certificate is
+// not signed by itself here, so this is false for root.
+func (c Certificate) SignedBy(root Certificate) bool {
+	return c.x509 != nil && root.x509 != nil && !c.x509.Equal(root.x509) &&
+		c.x509.CheckSignatureFrom(root.x509) == nil
+}
+
 // hasSubject checks that c has these values in its subject.
 func (c Certificate) hasSubject(commonName string, dnsNames []string) bool {
 	ok := c.x509 != nil &&
@@ -57,10 +115,20 @@ func (c Certificate) hasSubject(commonName string, dnsNames []string) bool {
 
 // Equal reports whether k and other have the same value.
 func (k PrivateKey) Equal(other PrivateKey) bool {
-	if k.ecdsa == nil || other.ecdsa == nil {
-		return k.ecdsa == other.ecdsa
+	if k.signer == nil || other.signer == nil {
+		return k.signer == other.signer
 	}
-	return k.ecdsa.Equal(other.ecdsa)
+	equal, ok := k.signer.(interface{ Equal(crypto.PrivateKey) bool })
+	return ok && equal.Equal(other.signer)
+}
+
+// signs reports whether k is the private key of c.
+func (k PrivateKey) signs(c *x509.Certificate) bool {
+	if k.signer == nil || c == nil {
+		return false
+	}
+	equal, ok := k.signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && equal.Equal(c.PublicKey)
 }
 
 // LeafCertificate is a certificate and private key pair that can be validated
@@ -75,26 +143,46 @@ type LeafCertificate struct {
 type RootCertificateAuthority struct {
 	Certificate Certificate
 	PrivateKey  PrivateKey
+
+	// Policy determines the keys and lifetimes of leaf certificates generated
+	// by this authority.
+	Policy Policy
+
+	// Trusted are other authorities to trust alongside this one, such as
+	// during a rotation. They do not sign certificates.
+	Trusted []Certificate
 }
 
 // NewRootCertificateAuthority generates a new key and self-signed certificate
 // for issuing other certificates.
 func NewRootCertificateAuthority() (*RootCertificateAuthority, error) {
-	var root RootCertificateAuthority
+	return NewRootCertificateAuthorityWithPolicy(Policy{})
+}
+
+// NewRootCertificateAuthorityWithPolicy generates a new key and self-signed
+// certificate according to policy. Leaf certificates generated by the
+// authority follow policy as well.
+func NewRootCertificateAuthorityWithPolicy(policy Policy) (*RootCertificateAuthority, error) {
+	root := RootCertificateAuthority{Policy: policy}
 	var serial *big.Int
 
-	key, err := generateKey()
+	key, err := generateKey(policy.Algorithm)
 	if err == nil {
 		serial, err = generateSerialNumber()
 	}
 	if err == nil {
-		root.PrivateKey.ecdsa = key
-		root.Certificate.x509, err = generateRootCertificate(key, serial)
+		root.PrivateKey.signer = key
+		root.Certificate.x509, err = generateRootCertificate(key, serial, policy.rootLifetime())
 	}
 
 	return &root, err
 }
 
+// Bundle returns the certificate of root followed by those it trusts.
+func (root *RootCertificateAuthority) Bundle() CertificateBundle {
+	return append(CertificateBundle{root.Certificate}, root.Trusted...)
+}
+
 // RootIsValid checks if root is valid according to this package's policies.
 func RootIsValid(root *RootCertificateAuthority) bool {
 	if root == nil || root.Certificate.x509 == nil {
@@ -124,13 +212,21 @@ func RootIsValid(root *RootCertificateAuthority) bool {
 		len(root.Certificate.x509.SubjectKeyId) > 0
 
 	// It is signed by this private key.
-	ok = ok &&
-		root.PrivateKey.ecdsa != nil &&
-		root.PrivateKey.ecdsa.PublicKey.Equal(root.Certificate.x509.PublicKey)
+	ok = ok && root.PrivateKey.signs(root.Certificate.x509)
 
 	return ok
 }
 
+// rootIsDue checks if root should be replaced according to policy: one third
+// of its lifetime remains, it has a different key algorithm, or its lifetime
+// is longer than policy allows.
+func rootIsDue(root *RootCertificateAuthority, policy Policy) bool {
+	cert := root.Certificate.x509
+	return !isBeforeRenewalTime(cert.NotBefore, cert.NotAfter) ||
+		keyAlgorithm(root.PrivateKey.signer) != policy.algorithm() ||
+		cert.NotAfter.Sub(cert.NotBefore) > policy.rootLifetime()-rootStartValid
+}
+
 // GenerateLeafCertificate generates a new key and certificate signed by root.
 func (root *RootCertificateAuthority) GenerateLeafCertificate(
 	commonName string, dnsNames []string,
@@ -138,15 +234,15 @@ func (root *RootCertificateAuthority) GenerateLeafCertificate(
 	var leaf LeafCertificate
 	var serial *big.Int
 
-	key, err := generateKey()
+	key, err := generateKey(root.Policy.Algorithm)
 	if err == nil {
 		serial, err = generateSerialNumber()
 	}
 	if err == nil {
-		leaf.PrivateKey.ecdsa = key
+		leaf.PrivateKey.signer = key
 		leaf.Certificate.x509, err = generateLeafCertificate(
-			root.Certificate.x509, root.PrivateKey.ecdsa, &key.PublicKey, serial,
-			commonName, dnsNames)
+			root.Certificate.x509, root.PrivateKey.signer, key.Public(), serial,
+			commonName, dnsNames, root.Policy.leafLifetime())
 	}
 
 	return &leaf, err
@@ -182,15 +278,23 @@ func (root *RootCertificateAuthority) leafIsValid(leaf *LeafCertificate) bool {
 		!leaf.Certificate.x509.IsCA
 
 	// It is signed by this private key.
+	ok = ok && leaf.PrivateKey.signs(leaf.Certificate.x509)
+
+	// It has the key algorithm and at most the lifetime of the policy.
 	ok = ok &&
-		leaf.PrivateKey.ecdsa != nil &&
-		leaf.PrivateKey.ecdsa.PublicKey.Equal(leaf.Certificate.x509.PublicKey)
-
-	// It is not yet past the "renewal by" time,
-	// as defined by the before and after times of the certificate's expiration
-	// and the default ratio
-	ok = ok && isBeforeRenewalTime(leaf.Certificate.x509.NotBefore,
-		leaf.Certificate.x509.NotAfter)
+		keyAlgorithm(leaf.PrivateKey.signer) == root.Policy.algorithm() &&
+		leaf.Certificate.x509.NotAfter.Sub(leaf.Certificate.x509.NotBefore) <=
+			root.Policy.leafLifetime()-leafStartValid
+
+	// It is not yet past the "renewal by" time, as defined by the policy or
+	// by the before and after times of the certificate's expiration and the
+	// default ratio
+	if renew := root.Policy.LeafRenewBefore; renew > 0 {
+		ok = ok && currentTime().Before(leaf.Certificate.x509.NotAfter.Add(-renew))
+	} else {
+		ok = ok && isBeforeRenewalTime(leaf.Certificate.x509.NotBefore,
+			leaf.Certificate.x509.NotAfter)
+	}
 
 	return ok
 }
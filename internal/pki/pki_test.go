@@ -6,6 +6,7 @@ package pki
 
 import (
 	"crypto/ecdsa"
+	"crypto/rsa"
 	"crypto/x509"
 	"os"
 	"os/exec"
@@ -65,6 +66,26 @@ func TestCertificateEqual(t *testing.T) {
 	assert.DeepEqual(t, root.Certificate, root.Certificate)
 }
 
+func TestCertificateSignedBy(t *testing.T) {
+	zero := Certificate{}
+
+	root, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+	other, err := NewRootCertificateAuthority()
+	assert.NilError(t, err)
+
+	leaf, err := root.GenerateLeafCertificate("leaf", nil)
+	assert.NilError(t, err)
+
+	assert.Assert(t, leaf.Certificate.SignedBy(root.Certificate))
+	assert.Assert(t, !leaf.Certificate.SignedBy(other.Certificate))
+	assert.Assert(t, !leaf.Certificate.SignedBy(zero))
+	assert.Assert(t, !zero.SignedBy(root.Certificate))
+
+	// A root is not signed by itself.
+	assert.Assert(t, !root.Certificate.SignedBy(root.Certificate))
+}
+
 func TestPrivateKeyEqual(t *testing.T) {
 	zero := PrivateKey{}
 	assert.Assert(t, zero.Equal(zero))
@@ -172,7 +193,9 @@ func TestRootIsInvalid(t *testing.T) {
 		leaf, err := root.GenerateLeafCertificate("", nil)
 		assert.NilError(t, err)
 
-		assert.Assert(t, !RootIsValid((*RootCertificateAuthority)(leaf)))
+		assert.Assert(t, !RootIsValid(&RootCertificateAuthority{
+			Certificate: leaf.Certificate, PrivateKey: leaf.PrivateKey,
+		}))
 	})
 
 	t.Run("TooEarly", func(t *testing.T) {
@@ -356,7 +379,9 @@ func TestLeafIsInvalid(t *testing.T) {
 	})
 
 	t.Run("IsAuthority", func(t *testing.T) {
-		assert.Assert(t, !root.leafIsValid((*LeafCertificate)(root)))
+		assert.Assert(t, !root.leafIsValid(&LeafCertificate{
+			Certificate: root.Certificate, PrivateKey: root.PrivateKey,
+		}))
 	})
 
 	t.Run("TooEarly", func(t *testing.T) {
@@ -435,6 +460,110 @@ func TestRegenerateLeaf(t *testing.T) {
 	assert.Assert(t, !after.Certificate.Equal(before.Certificate))
 }
 
+func TestPolicy(t *testing.T) {
+	for _, tt := range []struct {
+		algorithm  string
+		publicKey  x509.PublicKeyAlgorithm
+		signature  x509.SignatureAlgorithm
+		keyBitSize int
+	}{
+		{algorithm: "", publicKey: x509.ECDSA, signature: x509.ECDSAWithSHA384, keyBitSize: 256},
+		{algorithm: AlgorithmECDSAP256, publicKey: x509.ECDSA, signature: x509.ECDSAWithSHA384, keyBitSize: 256},
+		{algorithm: AlgorithmECDSAP384, publicKey: x509.ECDSA, signature: x509.ECDSAWithSHA384, keyBitSize: 384},
+		{algorithm: AlgorithmRSA2048, publicKey: x509.RSA, signature: x509.SHA256WithRSA, keyBitSize: 2048},
+	} {
+		t.Run("Algorithm"+tt.algorithm, func(t *testing.T) {
+			policy := Policy{Algorithm: tt.algorithm}
+
+			root, err := NewRootCertificateAuthorityWithPolicy(policy)
+			assert.NilError(t, err)
+			assert.Assert(t, RootIsValid(root))
+			assert.Equal(t, root.Certificate.x509.PublicKeyAlgorithm, tt.publicKey)
+			assert.Equal(t, root.Certificate.x509.SignatureAlgorithm, tt.signature)
+			assert.Assert(t, !rootIsDue(root, policy))
+
+			leaf, err := root.GenerateLeafCertificate("leaf", nil)
+			assert.NilError(t, err)
+			assert.Assert(t, root.leafIsValid(leaf))
+			assert.Equal(t, leaf.Certificate.x509.PublicKeyAlgorithm, tt.publicKey)
+
+			switch key := leaf.Certificate.x509.PublicKey.(type) {
+			case *ecdsa.PublicKey:
+				assert.Equal(t, key.Curve.Params().BitSize, tt.keyBitSize)
+			case *rsa.PublicKey:
+				assert.Equal(t, key.N.BitLen(), tt.keyBitSize)
+			}
+		})
+	}
+
+	t.Run("UnknownAlgorithm", func(t *testing.T) {
+		_, err := NewRootCertificateAuthorityWithPolicy(Policy{Algorithm: "DSA"})
+		assert.ErrorContains(t, err, "DSA")
+	})
+
+	t.Run("AlgorithmChange", func(t *testing.T) {
+		root, err := NewRootCertificateAuthority()
+		assert.NilError(t, err)
+
+		leaf, err := root.GenerateLeafCertificate("leaf", nil)
+		assert.NilError(t, err)
+		assert.Assert(t, root.leafIsValid(leaf))
+
+		// Leaves and roots with a different algorithm are replaced.
+		root.Policy.Algorithm = AlgorithmECDSAP384
+		assert.Assert(t, !root.leafIsValid(leaf))
+		assert.Assert(t, rootIsDue(root, root.Policy))
+	})
+
+	t.Run("LeafLifetime", func(t *testing.T) {
+		root, err := NewRootCertificateAuthorityWithPolicy(Policy{
+			LeafLifetime: 90 * 24 * time.Hour,
+		})
+		assert.NilError(t, err)
+
+		leaf, err := root.GenerateLeafCertificate("leaf", nil)
+		assert.NilError(t, err)
+		assert.Assert(t, root.leafIsValid(leaf))
+
+		cert := leaf.Certificate.x509
+		assert.Equal(t, cert.NotAfter.Sub(cert.NotBefore), 90*24*time.Hour+time.Hour)
+
+		// Leaves that are valid longer than the policy allows are replaced.
+		root.Policy.LeafLifetime = 30 * 24 * time.Hour
+		assert.Assert(t, !root.leafIsValid(leaf))
+	})
+
+	t.Run("LeafRenewBefore", func(t *testing.T) {
+		root, err := NewRootCertificateAuthorityWithPolicy(Policy{
+			LeafLifetime:    90 * 24 * time.Hour,
+			LeafRenewBefore: 7 * 24 * time.Hour,
+		})
+		assert.NilError(t, err)
+
+		leaf, err := root.GenerateLeafCertificate("leaf", nil)
+		assert.NilError(t, err)
+
+		original := currentTime
+		t.Cleanup(func() { currentTime = original })
+
+		// Past the default renewal time but before the policy.
+		currentTime = func() time.Time { return time.Now().Add(70 * 24 * time.Hour) }
+		assert.Assert(t, root.leafIsValid(leaf))
+
+		currentTime = func() time.Time { return time.Now().Add(84 * 24 * time.Hour) }
+		assert.Assert(t, !root.leafIsValid(leaf))
+	})
+
+	t.Run("RootLifetime", func(t *testing.T) {
+		root, err := NewRootCertificateAuthority()
+		assert.NilError(t, err)
+		assert.Assert(t, !rootIsDue(root, Policy{}))
+
+		// Roots that are valid longer than the policy allows are due.
+		assert.Assert(t, rootIsDue(root, Policy{RootLifetime: 5 * 365 * 24 * time.Hour}))
+	})
+}
+
 func basicOpenSSLVerify(t *testing.T, openssl string, root, leaf Certificate) {
 	verify := func(t testing.TB, args ...string) {
 		t.Helper()
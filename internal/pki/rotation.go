@@ -0,0 +1,121 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pki
+
+import "time"
+
+// Phases of a root certificate rotation.
+const (
+	RotationPublish = "Publish"
+	RotationReissue = "Reissue"
+)
+
+// RootRotation replaces one root certificate authority with another in phases
+// so that no certificate in use becomes untrusted:
+//
+//  1. Publish: a new root is trusted alongside the current one. The current
+//     root continues to sign leaf certificates.
+//  2. Reissue: the new root signs leaf certificates, and those signed by the
+//     previous root are replaced. The previous root is still trusted.
+//  3. The previous root is no longer trusted.
+//
+// Each phase lasts at least the RootRotationPhase of a Policy. The reissue
+// phase also lasts until no leaf certificate is signed by the previous root.
+type RootRotation struct {
+	// Current signs leaf certificates.
+	Current *RootCertificateAuthority
+
+	// Next replaces Current after the publish phase.
+	Next *RootCertificateAuthority
+
+	// Previous was replaced by Current and is trusted during the reissue phase.
+	Previous *Certificate
+
+	// PreviousInUse indicates that some leaf certificate is still signed by
+	// Previous. The reissue phase does not end until this is false.
+	PreviousInUse bool
+
+	// PhaseStart is when the current phase began.
+	PhaseStart time.Time
+}
+
+// Phase returns the phase of rotation, or an empty string when no rotation
+// is in progress.
+func (rotation *RootRotation) Phase() string {
+	switch {
+	case rotation.Next != nil:
+		return RotationPublish
+	case rotation.Previous != nil:
+		return RotationReissue
+	}
+	return ""
+}
+
+// Advance moves rotation to its next phase when the current phase has lasted
+// long enough according to policy. It starts a rotation when requested or
+// when the current root is due to be replaced. A current root that is not
+// valid is replaced immediately. It returns true when a rotation started.
+func (rotation *RootRotation) Advance(policy Policy, requested bool) (bool, error) {
+	now := currentTime()
+	phase := policy.rootRotationPhase()
+
+	// Restart a phase that has no start time.
+	if rotation.Phase() != "" && rotation.PhaseStart.IsZero() {
+		rotation.PhaseStart = now
+	}
+
+	var err error
+	var started bool
+
+	switch {
+	case !RootIsValid(rotation.Current):
+		// An invalid root cannot sign anything. Use the next root, if any,
+		// or a new one.
+		if rotation.Next != nil && RootIsValid(rotation.Next) {
+			rotation.Current = rotation.Next
+		} else {
+			rotation.Current, err = NewRootCertificateAuthorityWithPolicy(policy)
+		}
+		rotation.Next, rotation.Previous, rotation.PhaseStart = nil, nil, time.Time{}
+
+	case rotation.Next != nil && !RootIsValid(rotation.Next):
+		// Discard a next root that is not valid; it is generated again later.
+		rotation.Next, rotation.PhaseStart = nil, time.Time{}
+
+	case rotation.Next != nil:
+		if now.Sub(rotation.PhaseStart) >= phase {
+			previous := rotation.Current.Certificate
+			rotation.Current, rotation.Next, rotation.Previous = rotation.Next, nil, &previous
+			rotation.PhaseStart = now
+		}
+
+	case rotation.Previous != nil:
+		if now.Sub(rotation.PhaseStart) >= phase && !rotation.PreviousInUse {
+			rotation.Previous, rotation.PhaseStart = nil, time.Time{}
+		}
+
+	case requested || rootIsDue(rotation.Current, policy):
+		rotation.Next, err = NewRootCertificateAuthorityWithPolicy(policy)
+		rotation.PhaseStart = now
+		started = err == nil
+	}
+
+	return started, err
+}
+
+// Root returns the authority that signs leaf certificates. It trusts the other
+// roots of rotation.
+func (rotation *RootRotation) Root() *RootCertificateAuthority {
+	root := *rotation.Current
+	root.Trusted = nil
+
+	if rotation.Next != nil {
+		root.Trusted = append(root.Trusted, rotation.Next.Certificate)
+	}
+	if rotation.Previous != nil {
+		root.Trusted = append(root.Trusted, *rotation.Previous)
+	}
+	return &root
+}
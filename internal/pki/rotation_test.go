@@ -0,0 +1,153 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pki
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRootRotation(t *testing.T) {
+	now := time.Now()
+	original := currentTime
+	t.Cleanup(func() { currentTime = original })
+	currentTime = func() time.Time { return now }
+
+	policy := Policy{RootRotationPhase: time.Hour}
+
+	t.Run("Invalid", func(t *testing.T) {
+		rotation := RootRotation{Current: &RootCertificateAuthority{}}
+
+		started, err := rotation.Advance(policy, false)
+		assert.NilError(t, err)
+		assert.Assert(t, !started)
+		assert.Assert(t, RootIsValid(rotation.Current))
+		assert.Equal(t, rotation.Phase(), "")
+		assert.Equal(t, len(rotation.Root().Trusted), 0)
+	})
+
+	t.Run("InvalidWithNext", func(t *testing.T) {
+		next, err := NewRootCertificateAuthority()
+		assert.NilError(t, err)
+
+		// An invalid current root is replaced by the next one immediately.
+		rotation := RootRotation{
+			Current: &RootCertificateAuthority{}, Next: next, PhaseStart: now,
+		}
+		_, err = rotation.Advance(policy, false)
+		assert.NilError(t, err)
+		assert.Equal(t, rotation.Current, next)
+		assert.Equal(t, rotation.Phase(), "")
+		assert.Assert(t, rotation.PhaseStart.IsZero())
+	})
+
+	t.Run("Phases", func(t *testing.T) {
+		t.Cleanup(func() { currentTime = func() time.Time { return now } })
+
+		current, err := NewRootCertificateAuthority()
+		assert.NilError(t, err)
+
+		leaf, err := current.GenerateLeafCertificate("leaf", nil)
+		assert.NilError(t, err)
+
+		rotation := RootRotation{Current: current}
+
+		// Nothing happens until requested or due.
+		started, err := rotation.Advance(policy, false)
+		assert.NilError(t, err)
+		assert.Assert(t, !started)
+		assert.Equal(t, rotation.Phase(), "")
+
+		// Publish the next root alongside the current one.
+		started, err = rotation.Advance(policy, true)
+		assert.NilError(t, err)
+		assert.Assert(t, started)
+		assert.Equal(t, rotation.Phase(), RotationPublish)
+		assert.Equal(t, rotation.PhaseStart, now)
+		assert.Assert(t, RootIsValid(rotation.Next))
+
+		next := rotation.Next
+		root := rotation.Root()
+		assert.Assert(t, root.Certificate.Equal(current.Certificate))
+		assert.DeepEqual(t, root.Trusted, []Certificate{next.Certificate})
+
+		// The current root continues to sign; existing leaves are fine.
+		assert.Assert(t, root.leafIsValid(leaf))
+
+		// Another request does not start another rotation.
+		started, err = rotation.Advance(policy, true)
+		assert.NilError(t, err)
+		assert.Assert(t, !started)
+		assert.Equal(t, rotation.Next, next)
+
+		// Reissue leaves with the next root after the phase.
+		currentTime = func() time.Time { return now.Add(time.Hour) }
+		started, err = rotation.Advance(policy, false)
+		assert.NilError(t, err)
+		assert.Assert(t, !started)
+		assert.Equal(t, rotation.Phase(), RotationReissue)
+		assert.Equal(t, rotation.Current, next)
+		assert.Assert(t, rotation.Previous.Equal(current.Certificate))
+
+		root = rotation.Root()
+		assert.Assert(t, root.Certificate.Equal(next.Certificate))
+		assert.DeepEqual(t, root.Trusted, []Certificate{current.Certificate})
+
+		// Leaves of the previous root are replaced.
+		assert.Assert(t, !root.leafIsValid(leaf))
+		reissued, err := root.RegenerateLeafWhenNecessary(leaf, "leaf", nil)
+		assert.NilError(t, err)
+		assert.Assert(t, root.leafIsValid(reissued))
+
+		// The phase has not passed.
+		currentTime = func() time.Time { return now.Add(90 * time.Minute) }
+		_, err = rotation.Advance(policy, false)
+		assert.NilError(t, err)
+		assert.Equal(t, rotation.Phase(), RotationReissue)
+
+		// Keep trusting the previous root while a leaf is signed by it.
+		currentTime = func() time.Time { return now.Add(2 * time.Hour) }
+		rotation.PreviousInUse = true
+		_, err = rotation.Advance(policy, false)
+		assert.NilError(t, err)
+		assert.Equal(t, rotation.Phase(), RotationReissue)
+
+		// Stop trusting the previous root after the phase.
+		rotation.PreviousInUse = false
+		_, err = rotation.Advance(policy, false)
+		assert.NilError(t, err)
+		assert.Equal(t, rotation.Phase(), "")
+		assert.Assert(t, rotation.PhaseStart.IsZero())
+		assert.Equal(t, len(rotation.Root().Trusted), 0)
+	})
+
+	t.Run("Due", func(t *testing.T) {
+		current, err := NewRootCertificateAuthority()
+		assert.NilError(t, err)
+
+		// A change of algorithm starts a rotation with the new algorithm.
+		rotation := RootRotation{Current: current}
+		started, err := rotation.Advance(Policy{Algorithm: AlgorithmECDSAP384}, false)
+		assert.NilError(t, err)
+		assert.Assert(t, started)
+		assert.Equal(t, keyAlgorithm(rotation.Next.PrivateKey.signer), AlgorithmECDSAP384)
+	})
+
+	t.Run("MissingPhaseStart", func(t *testing.T) {
+		current, err := NewRootCertificateAuthority()
+		assert.NilError(t, err)
+		next, err := NewRootCertificateAuthority()
+		assert.NilError(t, err)
+
+		// A phase without a start time begins again.
+		rotation := RootRotation{Current: current, Next: next}
+		_, err = rotation.Advance(policy, false)
+		assert.NilError(t, err)
+		assert.Equal(t, rotation.Phase(), RotationPublish)
+		assert.Assert(t, !rotation.PhaseStart.IsZero())
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ArchiverStatus is the state of WAL archiving reported by "pg_stat_archiver".
+// - https://www.postgresql.org/docs/current/monitoring-stats.html#MONITORING-PG-STAT-ARCHIVER-VIEW
+type ArchiverStatus struct {
+	ArchivedCount    int64      `json:"archived_count"`
+	LastArchivedWAL  string     `json:"last_archived_wal"`
+	LastArchivedTime *time.Time `json:"last_archived_time"`
+	FailedCount      int64      `json:"failed_count"`
+	LastFailedWAL    string     `json:"last_failed_wal"`
+	LastFailedTime   *time.Time `json:"last_failed_time"`
+	StatsReset       *time.Time `json:"stats_reset"`
+}
+
+// ArchiverStatus uses "psql" to read "pg_stat_archiver".
+func (exec Executor) ArchiverStatus(ctx context.Context) (*ArchiverStatus, error) {
+	stdout, stderr, err := exec.Exec(ctx, strings.NewReader(`
+		\pset format unaligned
+		\pset tuples_only on
+		SELECT pg_catalog.row_to_json(a) FROM pg_catalog.pg_stat_archiver a;`),
+		map[string]string{
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	if err != nil {
+		return nil, errors.Wrap(err, stderr)
+	}
+
+	status := new(ArchiverStatus)
+	err = json.Unmarshal([]byte(strings.TrimSpace(stdout)), status)
+	return status, errors.WithStack(err)
+}
+
+// WALDirectoryStatus is the size of the WAL directory and the number of WAL
+// files in it that are waiting to be archived.
+type WALDirectoryStatus struct {
+	Size         int64 `json:"size"`
+	PendingFiles int64 `json:"pending_files"`
+}
+
+// WALDirectoryStatus uses "psql" to read the WAL directory. PostgreSQL marks
+// each WAL file that is waiting to be archived with a ".ready" file.
+// - https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-ADMIN-GENFILE
+func (exec Executor) WALDirectoryStatus(ctx context.Context) (*WALDirectoryStatus, error) {
+	stdout, stderr, err := exec.Exec(ctx, strings.NewReader(`
+		\pset format unaligned
+		\pset tuples_only on
+		SELECT pg_catalog.json_build_object(
+		  'size', (SELECT COALESCE(pg_catalog.sum(size), 0) FROM pg_catalog.pg_ls_waldir()),
+		  'pending_files', (SELECT pg_catalog.count(*)
+		    FROM pg_catalog.pg_ls_dir('pg_wal/archive_status') AS name
+		    WHERE name LIKE '%.ready'));`),
+		map[string]string{
+			"ON_ERROR_STOP": "on", // Abort when any one statement fails.
+			"QUIET":         "on", // Do not print successful statements to stdout.
+		})
+
+	if err != nil {
+		return nil, errors.Wrap(err, stderr)
+	}
+
+	status := new(WALDirectoryStatus)
+	err = json.Unmarshal([]byte(strings.TrimSpace(stdout)), status)
+	return status, errors.WithStack(err)
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestArchiverStatus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Arguments", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, strings.Contains(string(b), "pg_stat_archiver"))
+			assert.Assert(t, stdout != nil, "should capture stdout")
+			assert.Assert(t, stderr != nil, "should capture stderr")
+			return expected
+		}
+
+		_, err := Executor(exec).ArchiverStatus(ctx)
+		assert.Assert(t, errors.Is(err, expected))
+	})
+
+	t.Run("Result", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, stdout, _ io.Writer, _ ...string,
+		) error {
+			_, err := stdout.Write([]byte(`{"archived_count":12,"last_archived_wal":"00000001000000000000000C","last_archived_time":"2025-01-02T03:04:05.123456+00:00","failed_count":0,"last_failed_wal":null,"last_failed_time":null,"stats_reset":"2025-01-01T00:00:00+00:00"}` + "\n"))
+			return err
+		}
+
+		status, err := Executor(exec).ArchiverStatus(ctx)
+		assert.NilError(t, err)
+		assert.Equal(t, status.ArchivedCount, int64(12))
+		assert.Equal(t, status.LastArchivedWAL, "00000001000000000000000C")
+		assert.Assert(t, status.LastArchivedTime.Equal(
+			time.Date(2025, 1, 2, 3, 4, 5, 123456000, time.UTC)))
+		assert.Equal(t, status.FailedCount, int64(0))
+		assert.Equal(t, status.LastFailedWAL, "")
+		assert.Assert(t, status.LastFailedTime == nil)
+	})
+}
+
+func TestWALDirectoryStatus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Arguments", func(t *testing.T) {
+		expected := errors.New("pass-through")
+		exec := func(
+			_ context.Context, stdin io.Reader, stdout, stderr io.Writer, command ...string,
+		) error {
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, strings.Contains(string(b), "pg_ls_waldir()"))
+			assert.Assert(t, strings.Contains(string(b), "archive_status"))
+			assert.Assert(t, stdout != nil, "should capture stdout")
+			assert.Assert(t, stderr != nil, "should capture stderr")
+			return expected
+		}
+
+		_, err := Executor(exec).WALDirectoryStatus(ctx)
+		assert.Assert(t, errors.Is(err, expected))
+	})
+
+	t.Run("Result", func(t *testing.T) {
+		exec := func(
+			_ context.Context, _ io.Reader, stdout, _ io.Writer, _ ...string,
+		) error {
+			_, err := stdout.Write([]byte(`{"size" : 1073741824, "pending_files" : 48}` + "\n"))
+			return err
+		}
+
+		status, err := Executor(exec).WALDirectoryStatus(ctx)
+		assert.NilError(t, err)
+		assert.Equal(t, status.Size, int64(1073741824))
+		assert.Equal(t, status.PendingFiles, int64(48))
+	})
+}
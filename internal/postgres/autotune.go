@@ -0,0 +1,151 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+// SetAutotune adds to pgParameters the default values derived from the
+// resources of cluster that are the same for every instance set. Values that
+// differ between instance sets are returned by [AutotuneInstance].
+func SetAutotune(cluster *v1beta1.PostgresCluster, pgParameters *Parameters) {
+	for k, v := range autotuneCluster(cluster).AsMap() {
+		pgParameters.Default.Add(k, v)
+	}
+}
+
+// AutotuneInstance returns the values derived from the resources of instance
+// that differ from those of the entire cluster; see [SetAutotune].
+func AutotuneInstance(
+	cluster *v1beta1.PostgresCluster, instance *v1beta1.PostgresInstanceSetSpec,
+) *ParameterSet {
+	result := NewParameterSet()
+	shared := autotuneCluster(cluster)
+
+	// The "max_connections" parameter is never set per instance; see [autotuneCluster].
+	for k, v := range autotune(cluster, instance).AsMap() {
+		if !shared.Has(k) && k != "max_connections" {
+			result.Add(k, v)
+		}
+	}
+	return result
+}
+
+// autotuneCluster returns the values derived from every instance set of
+// cluster that are the same for all of them.
+func autotuneCluster(cluster *v1beta1.PostgresCluster) *ParameterSet {
+	result := NewParameterSet()
+	if cluster.Spec.Config == nil || cluster.Spec.Config.Autotune == nil ||
+		len(cluster.Spec.InstanceSets) == 0 {
+		return result
+	}
+
+	sets := make([]*ParameterSet, len(cluster.Spec.InstanceSets))
+	for i := range cluster.Spec.InstanceSets {
+		sets[i] = autotune(cluster, &cluster.Spec.InstanceSets[i])
+	}
+
+	for k, v := range sets[0].AsMap() {
+		same := true
+		for _, set := range sets[1:] {
+			same = same && set.Has(k) && set.Value(k) == v
+		}
+		if same {
+			result.Add(k, v)
+		}
+	}
+
+	// Patroni requires that "max_connections" is the same on every instance,
+	// so use the largest value of any instance set. When an instance set has
+	// no memory to go by, use the smallest value so no instance is given more
+	// connections than its memory allows.
+	// - https://patroni.readthedocs.io/en/latest/patroni_configuration.html
+	var largest, smallest int64
+	var unknown bool
+	for _, set := range sets {
+		value, err := strconv.ParseInt(set.Value("max_connections"), 10, 64)
+		if err != nil {
+			unknown = true
+			continue
+		}
+		largest = max(largest, value)
+		if smallest == 0 || value < smallest {
+			smallest = value
+		}
+	}
+	if unknown && smallest > 0 {
+		result.Add("max_connections", strconv.FormatInt(smallest, 10))
+	} else if largest > 0 {
+		result.Add("max_connections", strconv.FormatInt(largest, 10))
+	}
+
+	return result
+}
+
+// autotune returns parameters derived from the memory and CPU of instance and
+// the storage of cluster. Memory parameters are omitted when instance has no
+// memory limit or request.
+// - https://www.postgresql.org/docs/current/runtime-config-resource.html
+// - https://www.postgresql.org/docs/current/runtime-config-query.html
+func autotune(
+	cluster *v1beta1.PostgresCluster, instance *v1beta1.PostgresInstanceSetSpec,
+) *ParameterSet {
+	result := NewParameterSet()
+	if cluster.Spec.Config == nil || cluster.Spec.Config.Autotune == nil {
+		return result
+	}
+
+	// Prefer limits over requests; Postgres can use everything it is allowed.
+	quantity := func(name corev1.ResourceName) int64 {
+		if q, ok := instance.Resources.Limits[name]; ok && !q.IsZero() {
+			return q.MilliValue()
+		}
+		if q, ok := instance.Resources.Requests[name]; ok && !q.IsZero() {
+			return q.MilliValue()
+		}
+		return 0
+	}
+
+	// Random reads from rotating disks are much slower than sequential reads,
+	// and they cannot serve many requests at once.
+	if cluster.Spec.Config.Autotune.StorageType == "HDD" {
+		result.Add("effective_io_concurrency", "2")
+		result.Add("random_page_cost", "4")
+	} else {
+		result.Add("effective_io_concurrency", "200")
+		result.Add("random_page_cost", "1.1")
+	}
+
+	// Allow a parallel worker for every CPU, rounded up.
+	if cpus := (quantity(corev1.ResourceCPU) + 999) / 1000; cpus > 0 {
+		result.Add("max_parallel_workers", strconv.FormatInt(cpus, 10))
+	}
+
+	if mebibytes := quantity(corev1.ResourceMemory) / 1000 / (1 << 20); mebibytes > 0 {
+		// Allow about one connection for every 40MiB of memory.
+		connections := min(max(mebibytes/40, 20), 500)
+		result.Add("max_connections", strconv.FormatInt(connections, 10))
+
+		// Give a quarter of memory to shared buffers and expect the kernel to
+		// cache most of the rest.
+		result.Add("shared_buffers", fmt.Sprintf("%dMB", max(mebibytes/4, 16)))
+		result.Add("effective_cache_size", fmt.Sprintf("%dMB", max(mebibytes*3/4, 16)))
+
+		// Maintenance operations run one at a time per table.
+		result.Add("maintenance_work_mem", fmt.Sprintf("%dMB", min(max(mebibytes/16, 64), 2048)))
+
+		// Every connection may use a few sorts or hashes at once.
+		kibibytes := (mebibytes * 3 / 4) * 1024 / (connections * 3)
+		result.Add("work_mem", fmt.Sprintf("%dkB", max(kibibytes, 64)))
+	}
+
+	return result
+}
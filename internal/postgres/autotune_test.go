@@ -0,0 +1,137 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestAutotune(t *testing.T) {
+	cluster := new(v1beta1.PostgresCluster)
+	cluster.Spec.InstanceSets = []v1beta1.PostgresInstanceSetSpec{{
+		Name: "small",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("4Gi"),
+			},
+		},
+	}, {
+		Name: "large",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}}
+
+	t.Run("Disabled", func(t *testing.T) {
+		parameters := NewParameters()
+		SetAutotune(cluster, &parameters)
+
+		assert.DeepEqual(t, parameters.Default.AsMap(), NewParameters().Default.AsMap())
+		assert.Equal(t, AutotuneInstance(cluster, &cluster.Spec.InstanceSets[0]).String(), "")
+	})
+
+	cluster.Spec.Config = &v1beta1.PostgresConfigSpec{
+		Autotune: &v1beta1.PostgresAutotuneSpec{},
+	}
+
+	t.Run("InstanceSet", func(t *testing.T) {
+		assert.DeepEqual(t, autotune(cluster, &cluster.Spec.InstanceSets[0]).AsMap(), map[string]string{
+			"effective_cache_size":     "3072MB",
+			"effective_io_concurrency": "200",
+			"maintenance_work_mem":     "256MB",
+			"max_connections":          "102",
+			"max_parallel_workers":     "2",
+			"random_page_cost":         "1.1",
+			"shared_buffers":           "1024MB",
+			"work_mem":                 "10280kB",
+		})
+
+		// Limits take precedence over requests.
+		assert.DeepEqual(t, autotune(cluster, &cluster.Spec.InstanceSets[1]).AsMap(), map[string]string{
+			"effective_cache_size":     "6144MB",
+			"effective_io_concurrency": "200",
+			"maintenance_work_mem":     "512MB",
+			"max_connections":          "204",
+			"max_parallel_workers":     "1",
+			"random_page_cost":         "1.1",
+			"shared_buffers":           "2048MB",
+			"work_mem":                 "10280kB",
+		})
+	})
+
+	t.Run("Cluster", func(t *testing.T) {
+		parameters := NewParameters()
+		SetAutotune(cluster, &parameters)
+
+		assert.Equal(t, parameters.Default.Value("effective_io_concurrency"), "200")
+		assert.Equal(t, parameters.Default.Value("random_page_cost"), "1.1")
+		assert.Equal(t, parameters.Default.Value("work_mem"), "10280kB")
+
+		// The largest number of connections is used everywhere.
+		assert.Equal(t, parameters.Default.Value("max_connections"), "204")
+
+		// Values that differ are left to each instance set.
+		assert.Assert(t, !parameters.Default.Has("shared_buffers"))
+		assert.Assert(t, !parameters.Default.Has("max_parallel_workers"))
+
+		assert.DeepEqual(t, AutotuneInstance(cluster, &cluster.Spec.InstanceSets[0]).AsMap(), map[string]string{
+			"effective_cache_size": "3072MB",
+			"maintenance_work_mem": "256MB",
+			"max_parallel_workers": "2",
+			"shared_buffers":       "1024MB",
+		})
+	})
+
+	t.Run("MixedLimits", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.InstanceSets = append(cluster.Spec.InstanceSets,
+			v1beta1.PostgresInstanceSetSpec{Name: "unknown"})
+
+		parameters := NewParameters()
+		SetAutotune(cluster, &parameters)
+
+		// The smallest number of connections is used everywhere.
+		assert.Equal(t, parameters.Default.Value("max_connections"), "102")
+
+		for i := range cluster.Spec.InstanceSets {
+			instance := AutotuneInstance(cluster, &cluster.Spec.InstanceSets[i])
+			assert.Assert(t, !instance.Has("max_connections"),
+				"expected no max_connections for %q", cluster.Spec.InstanceSets[i].Name)
+		}
+
+		// Without any memory, nothing is set.
+		cluster.Spec.InstanceSets = cluster.Spec.InstanceSets[2:]
+		parameters = NewParameters()
+		SetAutotune(cluster, &parameters)
+		assert.Assert(t, !parameters.Default.Has("max_connections"))
+	})
+
+	t.Run("HDD", func(t *testing.T) {
+		cluster := cluster.DeepCopy()
+		cluster.Spec.Config.Autotune.StorageType = "HDD"
+		cluster.Spec.InstanceSets = cluster.Spec.InstanceSets[:1]
+
+		parameters := NewParameters()
+		SetAutotune(cluster, &parameters)
+
+		assert.Equal(t, parameters.Default.Value("effective_io_concurrency"), "2")
+		assert.Equal(t, parameters.Default.Value("random_page_cost"), "4")
+		assert.Equal(t, parameters.Default.Value("shared_buffers"), "1024MB")
+		assert.Equal(t, AutotuneInstance(cluster, &cluster.Spec.InstanceSets[0]).String(), "")
+	})
+}
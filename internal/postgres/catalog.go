@@ -0,0 +1,208 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnrecognizedParameter is returned by [ValidateParameter] for parameters
+// that are not in the catalog.
+var ErrUnrecognizedParameter = errors.New("unrecognized configuration parameter")
+
+// Parameter contexts determine when a change to a parameter takes effect.
+// - https://www.postgresql.org/docs/current/view-pg-settings.html
+const (
+	ContextPostmaster       = "postmaster"
+	ContextSighup           = "sighup"
+	ContextSuperuserBackend = "superuser-backend"
+	ContextSuperuser        = "superuser"
+	ContextUser             = "user"
+)
+
+// ParameterDefinition describes a PostgreSQL parameter in a range of major
+// versions, similar to a row of "pg_settings".
+// - https://www.postgresql.org/docs/current/view-pg-settings.html
+type ParameterDefinition struct {
+	Name    string
+	Type    string // bool, enum, integer, real, or string
+	Unit    string // the base unit of numeric values, if any
+	Context string
+
+	// Min and Max are the limits of numeric values in Unit.
+	Min, Max float64
+
+	// Values are the allowed values of enum parameters.
+	Values []string
+
+	// Since is the first major version that has this definition.
+	// Until is the first major version that does not, or zero.
+	Since, Until int
+}
+
+// LookupParameter returns the definition of the parameter name in the major
+// PostgreSQL version, if it is in the catalog.
+func LookupParameter(version int, name string) (ParameterDefinition, bool) {
+	name = strings.ToLower(name)
+	for _, definition := range parameterCatalog {
+		if definition.Name == name &&
+			(definition.Since == 0 || version >= definition.Since) &&
+			(definition.Until == 0 || version < definition.Until) {
+			return definition, true
+		}
+	}
+	return ParameterDefinition{}, false
+}
+
+// ValidateParameter returns an error when value is not valid for the parameter
+// name in the major PostgreSQL version. Parameters with a dot in their name
+// belong to extensions and are not validated. Other parameters that are not in
+// the catalog return [ErrUnrecognizedParameter].
+func ValidateParameter(version int, name, value string) error {
+	if strings.Contains(name, ".") {
+		return nil
+	}
+
+	definition, ok := LookupParameter(version, name)
+	if !ok {
+		for _, other := range parameterCatalog {
+			if other.Name == strings.ToLower(name) {
+				return fmt.Errorf("%s is not available in PostgreSQL %d", name, version)
+			}
+		}
+		return errors.WithStack(ErrUnrecognizedParameter)
+	}
+
+	switch definition.Type {
+	case "bool":
+		if _, ok := parseBool(value); !ok {
+			return fmt.Errorf("%s requires a Boolean value", name)
+		}
+
+	case "enum":
+		if !slices.ContainsFunc(definition.Values, func(v string) bool {
+			return strings.EqualFold(v, strings.TrimSpace(value))
+		}) {
+			return fmt.Errorf("%s must be one of: %s", name, strings.Join(definition.Values, ", "))
+		}
+
+	case "integer", "real":
+		number, err := parseNumeric(definition, value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if number < definition.Min || number > definition.Max {
+			return fmt.Errorf("%s must be between %s and %s",
+				name, formatNumeric(definition, definition.Min), formatNumeric(definition, definition.Max))
+		}
+
+	case "string":
+		// An empty list loads no libraries; otherwise every item needs a name.
+		// Names without a path must be known so that typos are not loaded.
+		if (name == "shared_preload_libraries" || name == "session_preload_libraries" ||
+			name == "local_preload_libraries") && strings.TrimSpace(value) != "" {
+			for _, library := range strings.Split(value, ",") {
+				library = strings.TrimSpace(library)
+				if !preloadLibrary.MatchString(library) {
+					return fmt.Errorf("%s has an invalid library name: %q", name, library)
+				}
+				if bare := strings.Trim(library, `"`); !strings.Contains(bare, "/") &&
+					!slices.Contains(preloadLibraries, bare) {
+					return fmt.Errorf("%s has an unknown library: %q; give other libraries"+
+						" by path, such as \"$libdir/%s\"", name, library, bare)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ParameterRequiresRestart returns true when PostgreSQL must restart before a
+// change to the parameter name takes effect.
+func ParameterRequiresRestart(version int, name string) bool {
+	definition, ok := LookupParameter(version, name)
+	return ok && definition.Context == ContextPostmaster
+}
+
+var (
+	numericValue   = regexp.MustCompile(`^\s*([-+]?(?:[0-9]+\.?[0-9]*|\.[0-9]+)(?:[eE][-+]?[0-9]+)?)\s*([a-zA-Z]*)\s*$`)
+	preloadLibrary = regexp.MustCompile(`^(?:[$A-Za-z0-9_./-]+|"[^"]+")$`)
+
+	memoryUnits = map[string]float64{
+		"B": 1, "kB": 1 << 10, "8kB": 8 << 10, "MB": 1 << 20, "GB": 1 << 30, "TB": 1 << 40,
+	}
+	timeUnits = map[string]float64{
+		"us": 0.001, "ms": 1, "s": 1000, "min": 60000, "h": 3600000, "d": 86400000,
+	}
+)
+
+// parseBool interprets value the same way PostgreSQL does: any unambiguous
+// prefix of "true", "false", "yes", "no", or "on", "off", "1", and "0".
+// - https://www.postgresql.org/docs/current/config-setting.html
+func parseBool(value string) (bool, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case value == "":
+		return false, false
+	case value == "1", value == "on", strings.HasPrefix("true", value), strings.HasPrefix("yes", value):
+		return true, true
+	case value == "0", value == "of", value == "off",
+		strings.HasPrefix("false", value), strings.HasPrefix("no", value):
+		return false, true
+	}
+	return false, false
+}
+
+// parseNumeric returns value in the base unit of definition.
+func parseNumeric(definition ParameterDefinition, value string) (float64, error) {
+	match := numericValue.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+
+	number, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if definition.Type == "integer" && match[2] == "" && number != math.Trunc(number) {
+		return 0, fmt.Errorf("invalid value %q; an integer is required", value)
+	}
+
+	unit := match[2]
+	switch {
+	case unit == "":
+		return number, nil
+
+	case memoryUnits[definition.Unit] > 0:
+		if factor := memoryUnits[unit]; factor > 0 {
+			return number * factor / memoryUnits[definition.Unit], nil
+		}
+		return 0, fmt.Errorf("invalid value %q; valid units are B, kB, MB, GB, and TB", value)
+
+	case timeUnits[definition.Unit] > 0:
+		if factor := timeUnits[unit]; factor > 0 {
+			return number * factor / timeUnits[definition.Unit], nil
+		}
+		return 0, fmt.Errorf("invalid value %q; valid units are us, ms, s, min, h, and d", value)
+	}
+
+	return 0, fmt.Errorf("invalid value %q; units are not allowed", value)
+}
+
+// formatNumeric returns number with the base unit of definition.
+func formatNumeric(definition ParameterDefinition, number float64) string {
+	if math.Abs(number) < 1e15 {
+		return strconv.FormatFloat(number, 'f', -1, 64) + definition.Unit
+	}
+	return strconv.FormatFloat(number, 'g', 6, 64) + definition.Unit
+}
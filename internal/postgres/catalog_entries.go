@@ -0,0 +1,451 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import "math"
+
+// These constructors keep the catalog below readable.
+func boolParameter(name, context string) ParameterDefinition {
+	return ParameterDefinition{Name: name, Type: "bool", Context: context}
+}
+func enumParameter(name, context string, values ...string) ParameterDefinition {
+	return ParameterDefinition{Name: name, Type: "enum", Context: context, Values: values}
+}
+func intParameter(name, unit string, low, high float64, context string) ParameterDefinition {
+	return ParameterDefinition{Name: name, Type: "integer", Unit: unit, Min: low, Max: high, Context: context}
+}
+func realParameter(name, unit string, low, high float64, context string) ParameterDefinition {
+	return ParameterDefinition{Name: name, Type: "real", Unit: unit, Min: low, Max: high, Context: context}
+}
+func stringParameter(name, context string) ParameterDefinition {
+	return ParameterDefinition{Name: name, Type: "string", Context: context}
+}
+
+// versions returns a copy of p that applies to major versions since (inclusive)
+// until (exclusive). Zero means no bound.
+func (p ParameterDefinition) versions(since, until int) ParameterDefinition {
+	p.Since, p.Until = since, until
+	return p
+}
+
+const (
+	intMax      = math.MaxInt32
+	maxBackends = 262143
+)
+
+var (
+	booleanValues  = []string{"on", "off", "true", "false", "yes", "no", "1", "0"}
+	messageLevels  = []string{"debug5", "debug4", "debug3", "debug2", "debug1", "info", "notice", "warning", "error", "log", "fatal", "panic"}
+	clientMessages = []string{"debug5", "debug4", "debug3", "debug2", "debug1", "log", "notice", "warning", "error"}
+)
+
+// parameterCatalog describes the PostgreSQL parameters that can be set in
+// PostgresCluster specs. Those that are commonly set have their values checked;
+// the others, at the end, are here so their names are recognized.
+//
+// - https://www.postgresql.org/docs/current/runtime-config.html
+var parameterCatalog = []ParameterDefinition{
+	// # Connections and Authentication
+	// - https://www.postgresql.org/docs/current/runtime-config-connection.html
+	intParameter("max_connections", "", 1, maxBackends, ContextPostmaster),
+	intParameter("superuser_reserved_connections", "", 0, maxBackends, ContextPostmaster),
+	intParameter("reserved_connections", "", 0, maxBackends, ContextPostmaster).versions(16, 0),
+	intParameter("tcp_keepalives_idle", "s", 0, intMax, ContextUser),
+	intParameter("tcp_keepalives_interval", "s", 0, intMax, ContextUser),
+	intParameter("tcp_keepalives_count", "", 0, intMax, ContextUser),
+	intParameter("tcp_user_timeout", "ms", 0, intMax, ContextUser).versions(12, 0),
+	intParameter("client_connection_check_interval", "ms", 0, intMax, ContextUser).versions(14, 0),
+	intParameter("authentication_timeout", "s", 1, 600, ContextSighup),
+	enumParameter("password_encryption", ContextUser, "md5", "scram-sha-256", "on", "off").versions(0, 14),
+	enumParameter("password_encryption", ContextUser, "md5", "scram-sha-256").versions(14, 0),
+
+	// # Resource Consumption
+	// - https://www.postgresql.org/docs/current/runtime-config-resource.html
+	intParameter("shared_buffers", "8kB", 16, intMax/2, ContextPostmaster),
+	enumParameter("huge_pages", ContextPostmaster, "off", "on", "try"),
+	intParameter("huge_page_size", "kB", 0, intMax, ContextPostmaster).versions(14, 0),
+	intParameter("temp_buffers", "8kB", 100, intMax/2, ContextUser),
+	intParameter("max_prepared_transactions", "", 0, maxBackends, ContextPostmaster),
+	intParameter("work_mem", "kB", 64, intMax, ContextUser),
+	realParameter("hash_mem_multiplier", "", 1, 1000, ContextUser).versions(13, 0),
+	intParameter("maintenance_work_mem", "kB", 1024, intMax, ContextUser),
+	intParameter("autovacuum_work_mem", "kB", -1, intMax, ContextSighup),
+	intParameter("logical_decoding_work_mem", "kB", 64, intMax, ContextUser).versions(13, 0),
+	intParameter("max_stack_depth", "kB", 100, intMax, ContextSuperuser),
+	enumParameter("shared_memory_type", ContextPostmaster, "mmap", "sysv").versions(12, 0),
+	enumParameter("dynamic_shared_memory_type", ContextPostmaster, "posix", "sysv", "mmap"),
+	intParameter("min_dynamic_shared_memory", "MB", 0, intMax, ContextPostmaster).versions(14, 0),
+	intParameter("temp_file_limit", "kB", -1, intMax, ContextSuperuser),
+	intParameter("max_files_per_process", "", 25, intMax, ContextPostmaster),
+	realParameter("vacuum_cost_delay", "ms", 0, 100, ContextUser),
+	intParameter("vacuum_cost_page_hit", "", 0, 10000, ContextUser),
+	intParameter("vacuum_cost_page_miss", "", 0, 10000, ContextUser),
+	intParameter("vacuum_cost_page_dirty", "", 0, 10000, ContextUser),
+	intParameter("vacuum_cost_limit", "", 1, 10000, ContextUser),
+	intParameter("bgwriter_delay", "ms", 10, 10000, ContextSighup),
+	intParameter("bgwriter_lru_maxpages", "", 0, intMax/2, ContextSighup),
+	realParameter("bgwriter_lru_multiplier", "", 0, 10, ContextSighup),
+	intParameter("bgwriter_flush_after", "8kB", 0, 256, ContextSighup),
+	intParameter("backend_flush_after", "8kB", 0, 256, ContextUser),
+	intParameter("effective_io_concurrency", "", 0, 1000, ContextUser),
+	intParameter("maintenance_io_concurrency", "", 0, 1000, ContextUser).versions(13, 0),
+	intParameter("max_worker_processes", "", 0, maxBackends, ContextPostmaster),
+	intParameter("max_parallel_workers_per_gather", "", 0, 1024, ContextUser),
+	intParameter("max_parallel_maintenance_workers", "", 0, 1024, ContextUser),
+	intParameter("max_parallel_workers", "", 0, 1024, ContextUser),
+	boolParameter("parallel_leader_participation", ContextUser),
+	intParameter("old_snapshot_threshold", "min", -1, 86400, ContextPostmaster).versions(0, 17),
+
+	// # Write Ahead Log
+	// - https://www.postgresql.org/docs/current/runtime-config-wal.html
+	boolParameter("fsync", ContextSighup),
+	enumParameter("synchronous_commit", ContextUser,
+		append([]string{"local", "remote_write", "remote_apply"}, booleanValues...)...),
+	enumParameter("wal_sync_method", ContextSighup, "fsync", "fdatasync", "open_sync", "open_datasync"),
+	boolParameter("full_page_writes", ContextSighup),
+	boolParameter("wal_compression", ContextSuperuser).versions(0, 15),
+	enumParameter("wal_compression", ContextSuperuser,
+		append([]string{"pglz", "lz4", "zstd"}, booleanValues...)...).versions(15, 0),
+	boolParameter("wal_init_zero", ContextSuperuser).versions(12, 0),
+	boolParameter("wal_recycle", ContextSuperuser).versions(12, 0),
+	intParameter("wal_buffers", "8kB", -1, 262143, ContextPostmaster),
+	intParameter("wal_writer_delay", "ms", 1, 10000, ContextSighup),
+	intParameter("wal_writer_flush_after", "8kB", 0, intMax, ContextSighup),
+	intParameter("commit_delay", "", 0, 100000, ContextSuperuser),
+	intParameter("commit_siblings", "", 0, 1000, ContextUser),
+	intParameter("checkpoint_timeout", "s", 30, 86400, ContextSighup),
+	realParameter("checkpoint_completion_target", "", 0, 1, ContextSighup),
+	intParameter("checkpoint_flush_after", "8kB", 0, 256, ContextSighup),
+	intParameter("checkpoint_warning", "s", 0, intMax, ContextSighup),
+	intParameter("max_wal_size", "MB", 2, intMax, ContextSighup),
+	intParameter("min_wal_size", "MB", 2, intMax, ContextSighup),
+	intParameter("archive_timeout", "s", 0, intMax/2, ContextSighup),
+	boolParameter("summarize_wal", ContextSighup).versions(17, 0),
+
+	// # Replication
+	// - https://www.postgresql.org/docs/current/runtime-config-replication.html
+	intParameter("max_wal_senders", "", 0, maxBackends, ContextPostmaster),
+	intParameter("max_replication_slots", "", 0, maxBackends, ContextPostmaster),
+	intParameter("wal_keep_segments", "", 0, intMax, ContextSighup).versions(0, 13),
+	intParameter("wal_keep_size", "MB", 0, intMax, ContextSighup).versions(13, 0),
+	intParameter("max_slot_wal_keep_size", "MB", -1, intMax, ContextSighup).versions(13, 0),
+	intParameter("wal_sender_timeout", "ms", 0, intMax, ContextUser),
+	boolParameter("track_commit_timestamp", ContextPostmaster),
+	boolParameter("hot_standby_feedback", ContextSighup),
+	intParameter("max_standby_archive_delay", "ms", -1, intMax, ContextSighup),
+	intParameter("max_standby_streaming_delay", "ms", -1, intMax, ContextSighup),
+	intParameter("wal_receiver_status_interval", "s", 0, intMax/1000, ContextSighup),
+	intParameter("wal_receiver_timeout", "ms", 0, intMax, ContextSighup),
+	intParameter("wal_retrieve_retry_interval", "ms", 1, intMax, ContextSighup),
+	intParameter("max_logical_replication_workers", "", 0, maxBackends, ContextPostmaster),
+	intParameter("max_sync_workers_per_subscription", "", 0, maxBackends, ContextSighup),
+	intParameter("max_parallel_apply_workers_per_subscription", "", 0, 1024, ContextSighup).versions(16, 0),
+
+	// # Query Planning
+	// - https://www.postgresql.org/docs/current/runtime-config-query.html
+	boolParameter("enable_bitmapscan", ContextUser),
+	boolParameter("enable_hashagg", ContextUser),
+	boolParameter("enable_hashjoin", ContextUser),
+	boolParameter("enable_incremental_sort", ContextUser).versions(13, 0),
+	boolParameter("enable_indexscan", ContextUser),
+	boolParameter("enable_indexonlyscan", ContextUser),
+	boolParameter("enable_material", ContextUser),
+	boolParameter("enable_memoize", ContextUser).versions(14, 0),
+	boolParameter("enable_mergejoin", ContextUser),
+	boolParameter("enable_nestloop", ContextUser),
+	boolParameter("enable_parallel_append", ContextUser),
+	boolParameter("enable_parallel_hash", ContextUser),
+	boolParameter("enable_partition_pruning", ContextUser),
+	boolParameter("enable_partitionwise_aggregate", ContextUser),
+	boolParameter("enable_partitionwise_join", ContextUser),
+	boolParameter("enable_seqscan", ContextUser),
+	boolParameter("enable_sort", ContextUser),
+	boolParameter("enable_tidscan", ContextUser),
+	realParameter("seq_page_cost", "", 0, math.MaxFloat64, ContextUser),
+	realParameter("random_page_cost", "", 0, math.MaxFloat64, ContextUser),
+	realParameter("cpu_tuple_cost", "", 0, math.MaxFloat64, ContextUser),
+	realParameter("cpu_index_tuple_cost", "", 0, math.MaxFloat64, ContextUser),
+	realParameter("cpu_operator_cost", "", 0, math.MaxFloat64, ContextUser),
+	realParameter("parallel_setup_cost", "", 0, math.MaxFloat64, ContextUser),
+	realParameter("parallel_tuple_cost", "", 0, math.MaxFloat64, ContextUser),
+	intParameter("effective_cache_size", "8kB", 1, intMax, ContextUser),
+	boolParameter("jit", ContextUser),
+	realParameter("jit_above_cost", "", -1, math.MaxFloat64, ContextUser),
+	realParameter("jit_inline_above_cost", "", -1, math.MaxFloat64, ContextUser),
+	realParameter("jit_optimize_above_cost", "", -1, math.MaxFloat64, ContextUser),
+	intParameter("default_statistics_target", "", 1, 10000, ContextUser),
+	enumParameter("constraint_exclusion", ContextUser, "partition", "on", "off", "true", "false", "yes", "no", "1", "0"),
+	realParameter("cursor_tuple_fraction", "", 0, 1, ContextUser),
+	intParameter("from_collapse_limit", "", 1, intMax, ContextUser),
+	intParameter("join_collapse_limit", "", 1, intMax, ContextUser),
+	boolParameter("geqo", ContextUser),
+	enumParameter("plan_cache_mode", ContextUser, "auto", "force_generic_plan", "force_custom_plan").versions(12, 0),
+
+	// # Error Reporting and Logging
+	// - https://www.postgresql.org/docs/current/runtime-config-logging.html
+	stringParameter("log_destination", ContextSighup),
+	stringParameter("log_filename", ContextSighup),
+	intParameter("log_rotation_age", "min", 0, intMax/60, ContextSighup),
+	intParameter("log_rotation_size", "kB", 0, intMax/1024, ContextSighup),
+	boolParameter("log_truncate_on_rotation", ContextSighup),
+	enumParameter("log_min_messages", ContextSuperuser, messageLevels...),
+	enumParameter("log_min_error_statement", ContextSuperuser, messageLevels...),
+	intParameter("log_min_duration_statement", "ms", -1, intMax, ContextSuperuser),
+	intParameter("log_min_duration_sample", "ms", -1, intMax, ContextSuperuser).versions(13, 0),
+	realParameter("log_statement_sample_rate", "", 0, 1, ContextSuperuser).versions(13, 0),
+	realParameter("log_transaction_sample_rate", "", 0, 1, ContextSuperuser).versions(12, 0),
+	intParameter("log_startup_progress_interval", "ms", 0, intMax, ContextSighup).versions(15, 0),
+	intParameter("log_autovacuum_min_duration", "ms", -1, intMax, ContextSighup),
+	boolParameter("log_checkpoints", ContextSighup),
+	boolParameter("log_connections", ContextSuperuserBackend),
+	boolParameter("log_disconnections", ContextSuperuserBackend),
+	boolParameter("log_duration", ContextSuperuser),
+	enumParameter("log_error_verbosity", ContextSuperuser, "terse", "default", "verbose"),
+	boolParameter("log_hostname", ContextSighup),
+	stringParameter("log_line_prefix", ContextSighup),
+	boolParameter("log_lock_waits", ContextSuperuser),
+	boolParameter("log_recovery_conflict_waits", ContextSighup).versions(14, 0),
+	intParameter("log_parameter_max_length", "B", -1, intMax/2, ContextSuperuser).versions(13, 0),
+	intParameter("log_parameter_max_length_on_error", "B", -1, intMax/2, ContextUser).versions(13, 0),
+	enumParameter("log_statement", ContextSuperuser, "none", "ddl", "mod", "all"),
+	boolParameter("log_replication_commands", ContextSuperuser),
+	intParameter("log_temp_files", "kB", -1, intMax, ContextSuperuser),
+	stringParameter("log_timezone", ContextSighup),
+
+	// # Run-time Statistics
+	// - https://www.postgresql.org/docs/current/runtime-config-statistics.html
+	boolParameter("track_activities", ContextSuperuser),
+	intParameter("track_activity_query_size", "B", 100, 1048576, ContextPostmaster),
+	boolParameter("track_counts", ContextSuperuser),
+	boolParameter("track_io_timing", ContextSuperuser),
+	boolParameter("track_wal_io_timing", ContextSuperuser).versions(14, 0),
+	enumParameter("track_functions", ContextSuperuser, "none", "pl", "all"),
+	enumParameter("compute_query_id", ContextSuperuser,
+		append([]string{"auto", "regress"}, booleanValues...)...).versions(14, 0),
+	stringParameter("stats_temp_directory", ContextSighup).versions(0, 15),
+
+	// # Automatic Vacuuming
+	// - https://www.postgresql.org/docs/current/runtime-config-autovacuum.html
+	boolParameter("autovacuum", ContextSighup),
+	intParameter("autovacuum_max_workers", "", 1, maxBackends, ContextPostmaster),
+	intParameter("autovacuum_naptime", "s", 1, intMax/1000, ContextSighup),
+	intParameter("autovacuum_vacuum_threshold", "", 0, intMax, ContextSighup),
+	intParameter("autovacuum_vacuum_insert_threshold", "", -1, intMax, ContextSighup).versions(13, 0),
+	intParameter("autovacuum_analyze_threshold", "", 0, intMax, ContextSighup),
+	realParameter("autovacuum_vacuum_scale_factor", "", 0, 100, ContextSighup),
+	realParameter("autovacuum_vacuum_insert_scale_factor", "", 0, 100, ContextSighup).versions(13, 0),
+	realParameter("autovacuum_analyze_scale_factor", "", 0, 100, ContextSighup),
+	intParameter("autovacuum_freeze_max_age", "", 100000, 2000000000, ContextPostmaster),
+	intParameter("autovacuum_multixact_freeze_max_age", "", 10000, 2000000000, ContextPostmaster),
+	realParameter("autovacuum_vacuum_cost_delay", "ms", -1, 100, ContextSighup),
+	intParameter("autovacuum_vacuum_cost_limit", "", -1, 10000, ContextSighup),
+
+	// # Client Connection Defaults
+	// - https://www.postgresql.org/docs/current/runtime-config-client.html
+	enumParameter("client_min_messages", ContextUser, clientMessages...),
+	stringParameter("search_path", ContextUser),
+	boolParameter("row_security", ContextUser),
+	stringParameter("default_tablespace", ContextUser),
+	stringParameter("temp_tablespaces", ContextUser),
+	enumParameter("default_toast_compression", ContextUser, "pglz", "lz4").versions(14, 0),
+	enumParameter("default_transaction_isolation", ContextUser,
+		"serializable", "repeatable read", "read committed", "read uncommitted"),
+	boolParameter("default_transaction_read_only", ContextUser),
+	intParameter("statement_timeout", "ms", 0, intMax, ContextUser),
+	intParameter("lock_timeout", "ms", 0, intMax, ContextUser),
+	intParameter("idle_in_transaction_session_timeout", "ms", 0, intMax, ContextUser),
+	intParameter("idle_session_timeout", "ms", 0, intMax, ContextUser).versions(14, 0),
+	intParameter("transaction_timeout", "ms", 0, intMax, ContextUser).versions(17, 0),
+	intParameter("vacuum_freeze_min_age", "", 0, 1000000000, ContextUser),
+	intParameter("vacuum_freeze_table_age", "", 0, 2000000000, ContextUser),
+	enumParameter("bytea_output", ContextUser, "escape", "hex"),
+	intParameter("gin_pending_list_limit", "kB", 64, intMax, ContextUser),
+	stringParameter("datestyle", ContextUser),
+	stringParameter("timezone", ContextUser),
+	stringParameter("lc_messages", ContextSuperuser),
+	stringParameter("lc_monetary", ContextUser),
+	stringParameter("lc_numeric", ContextUser),
+	stringParameter("lc_time", ContextUser),
+	stringParameter("default_text_search_config", ContextUser),
+	stringParameter("shared_preload_libraries", ContextPostmaster),
+	stringParameter("session_preload_libraries", ContextSuperuser),
+	stringParameter("local_preload_libraries", ContextUser),
+
+	// # Lock Management
+	// - https://www.postgresql.org/docs/current/runtime-config-locks.html
+	intParameter("deadlock_timeout", "ms", 1, intMax, ContextSuperuser),
+	intParameter("max_locks_per_transaction", "", 10, intMax, ContextPostmaster),
+	intParameter("max_pred_locks_per_transaction", "", 10, intMax, ContextPostmaster),
+
+	// # Version and Platform Compatibility
+	// - https://www.postgresql.org/docs/current/runtime-config-compatible.html
+	boolParameter("standard_conforming_strings", ContextUser),
+
+	// # Other Parameters
+	// These are recognized, but only their Boolean values are checked.
+	// - https://www.postgresql.org/docs/current/runtime-config-connection.html
+	boolParameter("bonjour", ContextPostmaster),
+	stringParameter("bonjour_name", ContextPostmaster),
+	stringParameter("krb_server_keyfile", ContextSighup),
+	boolParameter("krb_caseins_users", ContextSighup),
+	boolParameter("gss_accept_delegation", ContextSighup).versions(16, 0),
+	boolParameter("db_user_namespace", ContextSighup).versions(0, 17),
+	stringParameter("scram_iterations", ContextUser).versions(16, 0),
+
+	// - https://www.postgresql.org/docs/current/runtime-config-resource.html
+	stringParameter("vacuum_buffer_usage_limit", ContextUser).versions(16, 0),
+	stringParameter("io_combine_limit", ContextUser).versions(17, 0),
+	stringParameter("commit_timestamp_buffers", ContextPostmaster).versions(17, 0),
+	stringParameter("multixact_member_buffers", ContextPostmaster).versions(17, 0),
+	stringParameter("multixact_offset_buffers", ContextPostmaster).versions(17, 0),
+	stringParameter("notify_buffers", ContextPostmaster).versions(17, 0),
+	stringParameter("serializable_buffers", ContextPostmaster).versions(17, 0),
+	stringParameter("subtransaction_buffers", ContextPostmaster).versions(17, 0),
+	stringParameter("transaction_buffers", ContextPostmaster).versions(17, 0),
+	stringParameter("max_notify_queue_pages", ContextPostmaster).versions(17, 0),
+
+	// - https://www.postgresql.org/docs/current/runtime-config-wal.html
+	enumParameter("wal_level", ContextPostmaster, "minimal", "replica", "logical"),
+	stringParameter("wal_skip_threshold", ContextUser).versions(13, 0),
+	stringParameter("wal_consistency_checking", ContextSuperuser),
+	stringParameter("archive_library", ContextSighup).versions(15, 0),
+	stringParameter("archive_cleanup_command", ContextSighup),
+	stringParameter("recovery_end_command", ContextSighup),
+	enumParameter("recovery_prefetch", ContextSighup,
+		append([]string{"try"}, booleanValues...)...).versions(15, 0),
+	stringParameter("wal_decode_buffer_size", ContextPostmaster).versions(15, 0),
+	enumParameter("recovery_init_sync_method", ContextSighup, "fsync", "syncfs").versions(14, 0),
+	stringParameter("wal_summary_keep_time", ContextSighup).versions(17, 0),
+	boolParameter("data_sync_retry", ContextPostmaster),
+
+	// - https://www.postgresql.org/docs/current/runtime-config-replication.html
+	stringParameter("vacuum_defer_cleanup_age", ContextSighup).versions(0, 16),
+	stringParameter("promote_trigger_file", ContextSighup).versions(12, 16),
+	boolParameter("wal_receiver_create_temp_slot", ContextSighup).versions(13, 0),
+	boolParameter("sync_replication_slots", ContextSighup).versions(17, 0),
+	stringParameter("synchronized_standby_slots", ContextSighup).versions(17, 0),
+
+	// - https://www.postgresql.org/docs/current/runtime-config-query.html
+	boolParameter("enable_async_append", ContextUser).versions(14, 0),
+	boolParameter("enable_gathermerge", ContextUser),
+	boolParameter("enable_group_by_reordering", ContextUser).versions(17, 0),
+	boolParameter("enable_presorted_aggregate", ContextUser).versions(16, 0),
+	stringParameter("min_parallel_table_scan_size", ContextUser),
+	stringParameter("min_parallel_index_scan_size", ContextUser),
+	stringParameter("geqo_threshold", ContextUser),
+	stringParameter("geqo_effort", ContextUser),
+	stringParameter("geqo_pool_size", ContextUser),
+	stringParameter("geqo_generations", ContextUser),
+	stringParameter("geqo_selection_bias", ContextUser),
+	stringParameter("geqo_seed", ContextUser),
+	stringParameter("recursive_worktable_factor", ContextUser).versions(15, 0),
+	stringParameter("force_parallel_mode", ContextUser).versions(0, 16),
+	stringParameter("debug_parallel_query", ContextUser).versions(16, 0),
+
+	// - https://www.postgresql.org/docs/current/runtime-config-logging.html
+	stringParameter("log_directory", ContextSighup),
+	stringParameter("syslog_facility", ContextSighup),
+	stringParameter("syslog_ident", ContextSighup),
+	boolParameter("syslog_sequence_numbers", ContextSighup),
+	boolParameter("syslog_split_messages", ContextSighup),
+	stringParameter("event_source", ContextPostmaster),
+	boolParameter("debug_print_parse", ContextUser),
+	boolParameter("debug_print_rewritten", ContextUser),
+	boolParameter("debug_print_plan", ContextUser),
+	boolParameter("debug_pretty_print", ContextUser),
+	stringParameter("application_name", ContextUser),
+	boolParameter("update_process_title", ContextSuperuser),
+
+	// - https://www.postgresql.org/docs/current/runtime-config-statistics.html
+	boolParameter("log_executor_stats", ContextSuperuser),
+	boolParameter("log_parser_stats", ContextSuperuser),
+	boolParameter("log_planner_stats", ContextSuperuser),
+	boolParameter("log_statement_stats", ContextSuperuser),
+	enumParameter("stats_fetch_consistency", ContextUser, "none", "cache", "snapshot").versions(15, 0),
+
+	// - https://www.postgresql.org/docs/current/runtime-config-client.html
+	stringParameter("session_replication_role", ContextSuperuser),
+	boolParameter("check_function_bodies", ContextUser),
+	stringParameter("default_table_access_method", ContextUser).versions(12, 0),
+	boolParameter("default_transaction_deferrable", ContextUser),
+	stringParameter("vacuum_multixact_freeze_min_age", ContextUser),
+	stringParameter("vacuum_multixact_freeze_table_age", ContextUser),
+	stringParameter("vacuum_failsafe_age", ContextUser).versions(14, 0),
+	stringParameter("vacuum_multixact_failsafe_age", ContextUser).versions(14, 0),
+	stringParameter("vacuum_cleanup_index_scale_factor", ContextUser).versions(0, 14),
+	stringParameter("xmlbinary", ContextUser),
+	stringParameter("xmloption", ContextUser),
+	stringParameter("createrole_self_grant", ContextUser).versions(16, 0),
+	boolParameter("event_triggers", ContextSuperuser).versions(17, 0),
+	stringParameter("restrict_nonsystem_relation_kind", ContextUser).versions(12, 0),
+	stringParameter("intervalstyle", ContextUser),
+	stringParameter("timezone_abbreviations", ContextUser),
+	stringParameter("extra_float_digits", ContextUser),
+	stringParameter("client_encoding", ContextUser),
+	stringParameter("icu_validation_level", ContextUser).versions(16, 0),
+	stringParameter("dynamic_library_path", ContextSuperuser),
+	stringParameter("gin_fuzzy_search_limit", ContextUser),
+
+	// - https://www.postgresql.org/docs/current/runtime-config-locks.html
+	stringParameter("max_pred_locks_per_relation", ContextSighup),
+	stringParameter("max_pred_locks_per_page", ContextSighup),
+
+	// - https://www.postgresql.org/docs/current/runtime-config-compatible.html
+	boolParameter("array_nulls", ContextUser),
+	stringParameter("backslash_quote", ContextUser),
+	boolParameter("escape_string_warning", ContextUser),
+	boolParameter("lo_compat_privileges", ContextSuperuser),
+	boolParameter("operator_precedence_warning", ContextUser).versions(0, 14),
+	boolParameter("quote_all_identifiers", ContextUser),
+	boolParameter("synchronize_seqscans", ContextUser),
+	boolParameter("transform_null_equals", ContextUser),
+	boolParameter("default_with_oids", ContextUser).versions(0, 12),
+
+	// - https://www.postgresql.org/docs/current/runtime-config-error-handling.html
+	boolParameter("exit_on_error", ContextUser),
+	boolParameter("restart_after_crash", ContextSighup),
+	boolParameter("remove_temp_files_after_crash", ContextSighup).versions(14, 0),
+	boolParameter("send_abort_for_crash", ContextSighup).versions(16, 0),
+	boolParameter("send_abort_for_kill", ContextSighup).versions(16, 0),
+	boolParameter("allow_alter_system", ContextSighup).versions(17, 0),
+
+	// - https://www.postgresql.org/docs/current/runtime-config-developer.html
+	boolParameter("allow_system_table_mods", ContextPostmaster).versions(0, 12),
+	boolParameter("allow_system_table_mods", ContextSuperuser).versions(12, 0),
+	stringParameter("backtrace_functions", ContextSuperuser).versions(13, 0),
+	stringParameter("debug_discard_caches", ContextSuperuser).versions(14, 0),
+	stringParameter("debug_io_direct", ContextPostmaster).versions(16, 0),
+	stringParameter("debug_logical_replication_streaming", ContextUser).versions(16, 0),
+	boolParameter("ignore_checksum_failure", ContextSuperuser),
+	boolParameter("ignore_invalid_pages", ContextPostmaster).versions(13, 0),
+	boolParameter("ignore_system_indexes", ContextSuperuserBackend),
+	stringParameter("jit_provider", ContextPostmaster),
+	boolParameter("jit_debugging_support", ContextSuperuserBackend),
+	boolParameter("jit_dump_bitcode", ContextSuperuser),
+	boolParameter("jit_expressions", ContextUser),
+	boolParameter("jit_profiling_support", ContextSuperuserBackend),
+	boolParameter("jit_tuple_deforming", ContextUser),
+	stringParameter("post_auth_delay", ContextSuperuserBackend),
+	stringParameter("pre_auth_delay", ContextSighup),
+	boolParameter("trace_notify", ContextUser),
+	stringParameter("trace_recovery_messages", ContextSighup).versions(0, 17),
+	boolParameter("trace_sort", ContextUser),
+	boolParameter("zero_damaged_pages", ContextSuperuser),
+}
+
+// preloadLibraries are the names of libraries that can be loaded by the
+// "*_preload_libraries" parameters. Other libraries must be given by path.
+var preloadLibraries = []string{
+	// Modules distributed with PostgreSQL
+	// - https://www.postgresql.org/docs/current/contrib.html
+	"auth_delay", "auto_explain", "passwordcheck", "pg_prewarm",
+	"pg_stat_statements", "plpgsql", "sepgsql",
+
+	// Extensions that are commonly installed
+	"age", "citus", "pg_cron", "pg_failover_slots", "pg_hint_plan",
+	"pg_partman_bgw", "pg_squeeze", "pg_stat_kcache", "pg_stat_monitor",
+	"pg_qualstats", "pg_wait_sampling", "pgaudit", "pglogical", "pgnodemx",
+	"pgsodium", "plugin_debugger", "set_user", "timescaledb",
+}
@@ -0,0 +1,129 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParameterCatalog(t *testing.T) {
+	for _, definition := range parameterCatalog {
+		assert.Assert(t, definition.Name != "")
+		assert.Assert(t, definition.Until == 0 || definition.Since < definition.Until, definition.Name)
+		assert.Assert(t, definition.Min <= definition.Max, definition.Name)
+
+		switch definition.Type {
+		case "enum":
+			assert.Assert(t, len(definition.Values) > 0, definition.Name)
+		case "bool", "integer", "real", "string":
+		default:
+			t.Errorf("unexpected type for %s: %q", definition.Name, definition.Type)
+		}
+
+		// Each version has at most one definition.
+		for version := 11; version <= 17; version++ {
+			count := 0
+			for _, other := range parameterCatalog {
+				if other.Name == definition.Name &&
+					(other.Since == 0 || version >= other.Since) &&
+					(other.Until == 0 || version < other.Until) {
+					count++
+				}
+			}
+			assert.Assert(t, count <= 1, "%s in PostgreSQL %d", definition.Name, version)
+		}
+	}
+}
+
+func TestValidateParameter(t *testing.T) {
+	for _, tt := range []struct {
+		version     int
+		name, value string
+		expected    string
+	}{
+		// Booleans
+		{version: 16, name: "hot_standby_feedback", value: "on"},
+		{version: 16, name: "hot_standby_feedback", value: "FALSE"},
+		{version: 16, name: "hot_standby_feedback", value: "y"},
+		{version: 16, name: "hot_standby_feedback", value: "o", expected: "requires a Boolean value"},
+		{version: 16, name: "hot_standby_feedback", value: "enabled", expected: "requires a Boolean value"},
+
+		// Enums
+		{version: 16, name: "log_statement", value: "DDL"},
+		{version: 16, name: "log_statement", value: "some", expected: "must be one of: none, ddl, mod, all"},
+		{version: 13, name: "password_encryption", value: "on"},
+		{version: 14, name: "password_encryption", value: "on", expected: "must be one of"},
+
+		// Integers with units
+		{version: 16, name: "work_mem", value: "64MB"},
+		{version: 16, name: "work_mem", value: "4096"},
+		{version: 16, name: "work_mem", value: "10GBB", expected: "valid units are B, kB, MB, GB, and TB"},
+		{version: 16, name: "work_mem", value: "10s", expected: "valid units are B, kB, MB, GB, and TB"},
+		{version: 16, name: "work_mem", value: "32kB", expected: "must be between 64kB and 2147483647kB"},
+		{version: 16, name: "shared_buffers", value: "1.5GB"},
+		{version: 16, name: "shared_buffers", value: "100", expected: ""},
+		{version: 16, name: "shared_buffers", value: "100.5", expected: "an integer is required"},
+		{version: 16, name: "statement_timeout", value: "5min"},
+		{version: 16, name: "statement_timeout", value: "-1", expected: "must be between 0ms and"},
+		{version: 16, name: "max_connections", value: "100"},
+		{version: 16, name: "max_connections", value: "100MB", expected: "units are not allowed"},
+		{version: 16, name: "max_connections", value: "lots", expected: `invalid value "lots"`},
+
+		// Reals
+		{version: 16, name: "random_page_cost", value: "1.1"},
+		{version: 16, name: "checkpoint_completion_target", value: "1.5", expected: "must be between 0 and 1"},
+
+		// Versions
+		{version: 12, name: "wal_keep_segments", value: "64"},
+		{version: 13, name: "wal_keep_segments", value: "64", expected: "not available in PostgreSQL 13"},
+		{version: 12, name: "wal_keep_size", value: "1GB", expected: "not available in PostgreSQL 12"},
+		{version: 15, name: "wal_compression", value: "zstd"},
+		{version: 14, name: "wal_compression", value: "zstd", expected: "requires a Boolean value"},
+
+		// Strings
+		{version: 16, name: "shared_preload_libraries", value: "pg_stat_statements, auto_explain"},
+		{version: 16, name: "shared_preload_libraries", value: ""},
+		{version: 16, name: "session_preload_libraries", value: " "},
+		{version: 16, name: "shared_preload_libraries", value: "pg_stat_statements,,auto_explain", expected: `invalid library name: ""`},
+		{version: 16, name: "shared_preload_libraries", value: "pg stat", expected: `invalid library name: "pg stat"`},
+		{version: 16, name: "shared_preload_libraries", value: "pg_stat_statments", expected: `unknown library: "pg_stat_statments"`},
+		{version: 16, name: "shared_preload_libraries", value: "$libdir/custom, \"/opt/lib/other\""},
+		{version: 16, name: "session_preload_libraries", value: "auto_explain,custom", expected: `unknown library: "custom"`},
+		{version: 16, name: "search_path", value: `"$user", public`},
+
+		// Extensions
+		{version: 16, name: "pgaudit.log", value: "anything"},
+	} {
+		err := ValidateParameter(tt.version, tt.name, tt.value)
+		if tt.expected == "" {
+			assert.NilError(t, err, "%s = %q", tt.name, tt.value)
+		} else {
+			assert.ErrorContains(t, err, tt.expected, "%s = %q", tt.name, tt.value)
+		}
+	}
+
+	t.Run("Unrecognized", func(t *testing.T) {
+		err := ValidateParameter(16, "shared_bufers", "1GB")
+		assert.Assert(t, errors.Is(err, ErrUnrecognizedParameter))
+
+		// Parameters that are rarely set are recognized by name.
+		assert.NilError(t, ValidateParameter(16, "application_name", "anything"))
+		assert.NilError(t, ValidateParameter(17, "sync_replication_slots", "on"))
+		assert.ErrorContains(t, ValidateParameter(17, "sync_replication_slots", "sometimes"), "Boolean")
+		assert.ErrorContains(t, ValidateParameter(16, "sync_replication_slots", "on"), "not available")
+	})
+}
+
+func TestParameterRequiresRestart(t *testing.T) {
+	assert.Assert(t, ParameterRequiresRestart(16, "shared_buffers"))
+	assert.Assert(t, ParameterRequiresRestart(16, "Max_Connections"))
+	assert.Assert(t, !ParameterRequiresRestart(16, "work_mem"))
+	assert.Assert(t, !ParameterRequiresRestart(16, "unknown"))
+	assert.Assert(t, ParameterRequiresRestart(16, "old_snapshot_threshold"))
+	assert.Assert(t, !ParameterRequiresRestart(17, "old_snapshot_threshold"))
+}
@@ -9,9 +9,11 @@ import (
 	"fmt"
 	"math"
 	"path"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/crunchydata/postgres-operator/internal/config"
@@ -263,6 +265,50 @@ func Environment(cluster *v1beta1.PostgresCluster) []corev1.EnvVar {
 	}
 }
 
+// AutoGrowPolicy returns policy in the form read by the manageAutogrowAnnotation
+// shell function: the percent of a volume that must be used before it grows,
+// followed by the percent and the mebibytes by which it grows.
+func AutoGrowPolicy(policy *v1beta1.VolumeAutoGrowPolicy) string {
+	threshold, percent, mebibytes := int64(75), int64(50), int64(0)
+
+	if policy != nil && policy.ThresholdPercent != nil {
+		threshold = int64(*policy.ThresholdPercent)
+	}
+	if policy != nil && policy.Step != "" {
+		if value, ok := strings.CutSuffix(policy.Step, "%"); ok {
+			percent, _ = strconv.ParseInt(value, 10, 64)
+		} else if quantity, err := resource.ParseQuantity(policy.Step); err == nil {
+			// Round up to the next mebibyte.
+			percent, mebibytes = 0, (quantity.Value()+(1<<20)-1)>>20
+		}
+	}
+
+	return fmt.Sprintf("%d %d %d", threshold, percent, mebibytes)
+}
+
+// autogrowEnvironment returns the environment variables that configure how
+// the volumes of an instance grow; see [reloadCommand].
+func autogrowEnvironment(cluster *v1beta1.PostgresCluster) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	if spec := cluster.Spec.AutoGrow; spec != nil {
+		for _, policy := range []struct {
+			name   string
+			policy *v1beta1.VolumeAutoGrowPolicy
+		}{
+			{"AUTOGROW_PGDATA", spec.PGData},
+			{"AUTOGROW_PGWAL", spec.PGWAL},
+			{"AUTOGROW_TABLESPACES", spec.Tablespaces},
+		} {
+			if policy.policy != nil {
+				env = append(env, corev1.EnvVar{
+					Name: policy.name, Value: AutoGrowPolicy(policy.policy),
+				})
+			}
+		}
+	}
+	return env
+}
+
 // reloadCommand returns an entrypoint that convinces PostgreSQL to reload
 // certificate files when they change. The process will appear as name in `ps`
 // and `top`.
@@ -300,16 +346,17 @@ CACERT=${SERVICEACCOUNT}/ca.crt
 # Manage autogrow annotation.
 # Return size in Mebibytes.
 manageAutogrowAnnotation() {
-  local volume=$1
+  local volume=$1 directory=$2 threshold percent mebibytes
+  read -r threshold percent mebibytes <<< "$3"
 
-  size=$(df --human-readable --block-size=M /"${volume}" | awk 'FNR == 2 {print $2}')
-  use=$(df --human-readable /"${volume}" | awk 'FNR == 2 {print $5}')
+  size=$(df --human-readable --block-size=M "${directory}" | awk 'FNR == 2 {print $2}')
+  use=$(df --human-readable "${directory}" | awk 'FNR == 2 {print $5}')
   sizeInt="${size//M/}"
   # Use the sed punctuation class, because the shell will not accept the percent sign in an expansion.
   useInt=$(echo $use | sed 's/[[:punct:]]//g')
-  triggerExpansion="$((useInt > 75))"
+  triggerExpansion="$((useInt > threshold))"
   if [ $triggerExpansion -eq 1 ]; then
-    newSize="$(((sizeInt / 2)+sizeInt))"
+    newSize="$((sizeInt + (sizeInt * percent / 100) + mebibytes))"
     newSizeMi="${newSize}Mi"
     d='[{"op": "add", "path": "/metadata/annotations/suggested-'"${volume}"'-pvc-size", "value": "'"$newSizeMi"'"}]'
     curl --cacert ${CACERT} --header "Authorization: Bearer ${TOKEN}" -XPATCH "${APISERVER}/api/v1/namespaces/${NAMESPACE}/pods/${HOSTNAME}?fieldManager=kubectl-annotate" -H "Content-Type: application/json-patch+json" --data "$d"
@@ -329,7 +376,19 @@ while read -r -t 5 -u "${fd}" ||:; do
   fi
 
   # manage autogrow annotation for the pgData volume
-  manageAutogrowAnnotation "pgdata"
+  manageAutogrowAnnotation "pgdata" "/pgdata" "${AUTOGROW_PGDATA:-75 50 0}"
+
+  # manage autogrow annotation for the pgWAL volume, if it exists
+  if [[ -d /pgwal ]]; then
+    manageAutogrowAnnotation "pgwal" "/pgwal" "${AUTOGROW_PGWAL:-75 50 0}"
+  fi
+
+  # manage autogrow annotation for each tablespace volume
+  for tablespace in /tablespaces/*; do
+    if [[ -d "${tablespace}" ]]; then
+      manageAutogrowAnnotation "tablespace-${tablespace##*/}" "${tablespace}" "${AUTOGROW_TABLESPACES:-75 50 0}"
+    fi
+  done
 done
 `,
 		naming.CertMountPath,
@@ -21,11 +21,42 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 
+	"github.com/crunchydata/postgres-operator/internal/initialize"
 	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
 	"github.com/crunchydata/postgres-operator/internal/testing/require"
 	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
 )
 
+func TestAutoGrowPolicy(t *testing.T) {
+	assert.Equal(t, AutoGrowPolicy(nil), "75 50 0")
+	assert.Equal(t, AutoGrowPolicy(&v1beta1.VolumeAutoGrowPolicy{}), "75 50 0")
+
+	assert.Equal(t, AutoGrowPolicy(&v1beta1.VolumeAutoGrowPolicy{
+		ThresholdPercent: initialize.Int32(90),
+		Step:             "25%",
+	}), "90 25 0")
+
+	assert.Equal(t, AutoGrowPolicy(&v1beta1.VolumeAutoGrowPolicy{
+		Step: "2Gi",
+	}), "75 0 2048")
+}
+
+func TestAutogrowEnvironment(t *testing.T) {
+	cluster := new(v1beta1.PostgresCluster)
+	assert.Assert(t, autogrowEnvironment(cluster) == nil)
+
+	cluster.Spec.AutoGrow = &v1beta1.VolumeAutoGrowSpec{
+		PGWAL:       &v1beta1.VolumeAutoGrowPolicy{Step: "100Mi"},
+		Tablespaces: &v1beta1.VolumeAutoGrowPolicy{ThresholdPercent: initialize.Int32(80)},
+	}
+	assert.Assert(t, cmp.MarshalMatches(autogrowEnvironment(cluster), `
+- name: AUTOGROW_PGWAL
+  value: 75 0 100
+- name: AUTOGROW_TABLESPACES
+  value: 80 50 0
+	`))
+}
+
 func TestConfigDirectory(t *testing.T) {
 	cluster := new(v1beta1.PostgresCluster)
 	cluster.Spec.PostgresVersion = 11
@@ -246,3 +246,22 @@ func (o *OrderedHBAs) AsStrings() []string {
 func (o *OrderedHBAs) Length() int {
 	return len(o.records)
 }
+
+// hbaMapOption matches the "map" option of a pg_hba.conf line. The first
+// submatch is its value, which may be quoted.
+var hbaMapOption = regexp.MustCompile(`(?:^|\s)(?:map|"map")=("(?:[^"]|"")*"|[^\s"]+)`)
+
+// IdentMapNames returns the names of the user name maps that records in o
+// refer to in their "map" option. Names are unquoted and in the order they
+// first appear.
+func (o *OrderedHBAs) IdentMapNames() []string {
+	var names []string
+	for _, record := range o.records {
+		for _, match := range hbaMapOption.FindAllStringSubmatch(record, -1) {
+			if name := unquoteIdentMapName(match[1]); !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
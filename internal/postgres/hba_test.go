@@ -159,4 +159,23 @@ func TestOrderedHBAs(t *testing.T) {
 			"multiple \\\n lines okay",
 		})
 	})
+
+	t.Run("IdentMapNames", func(t *testing.T) {
+		rules := new(OrderedHBAs)
+		assert.Assert(t, rules.IdentMapNames() == nil)
+
+		rules.Append(
+			NewHBA().TLS().Method("cert").Options(map[string]string{"map": "one"}),
+			NewHBA().TLS().Method("cert").Options(map[string]string{"map": `with "quotes"`}),
+		)
+		rules.AppendUnstructured(
+			`hostssl all all all cert map=two clientcert=verify-full`,
+			`hostgssenc all all all gss include_realm=0 map="three"`,
+			`hostssl all all all cert map=one`,
+			`hostssl all all all cert remap=other`,
+		)
+		assert.DeepEqual(t, rules.IdentMapNames(), []string{
+			"one", `with "quotes"`, "two", "three",
+		})
+	})
 }
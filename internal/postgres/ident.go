@@ -0,0 +1,106 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// IdentMap represents a single record for pg_ident.conf.
+// - https://www.postgresql.org/docs/current/auth-username-maps.html
+type IdentMap struct {
+	name, system, database string
+}
+
+// NewIdentMap returns a record of the map called name that allows systemUser
+// to connect as databaseUser. When systemUser starts with slash U+002F, it is
+// a regular expression and databaseUser may refer to its first capture group.
+func NewIdentMap(name, systemUser, databaseUser string) *IdentMap {
+	ident := new(IdentMap)
+	ident.name = ident.quote(name)
+	ident.system = ident.quote(systemUser)
+	ident.database = ident.quoteDatabaseUser(databaseUser)
+	return ident
+}
+
+func (*IdentMap) quote(value string) string {
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+}
+
+func (*IdentMap) quoteDatabaseUser(name string) string {
+	// Since PostgreSQL 16, the database user can be a keyword, group, or
+	// regular expression. Quote it the same as an HBA user so it is none of
+	// those. A quoted name can still refer to a capture group.
+	return new(HostBasedAuthentication).quoteUser(name)
+}
+
+// String returns ident formatted for the pg_ident.conf file without a newline.
+func (ident *IdentMap) String() string {
+	return fmt.Sprintf("%s %s %s", ident.name, ident.system, ident.database)
+}
+
+// OrderedIdentMaps is an append-only sequence of pg_ident.conf lines.
+type OrderedIdentMaps struct {
+	records []string
+}
+
+// Append renders and adds pg_ident.conf lines to o. Nil pointers are ignored.
+func (o *OrderedIdentMaps) Append(idents ...*IdentMap) {
+	for _, ident := range idents {
+		if ident != nil {
+			o.records = append(o.records, ident.String())
+		}
+	}
+}
+
+// AppendUnstructured trims and adds unvalidated pg_ident.conf lines to o.
+// Empty lines and lines that are entirely control characters are omitted.
+func (o *OrderedIdentMaps) AppendUnstructured(idents ...string) {
+	for _, ident := range idents {
+		ident = strings.TrimFunc(ident, func(r rune) bool {
+			// control characters, space, and backslash
+			return r > '~' || r < '!' || r == '\\'
+		})
+
+		// NOTE: Skipping "include" directives here is a security measure.
+		if len(ident) > 0 && !strings.HasPrefix(ident, "include") {
+			o.records = append(o.records, ident)
+		}
+	}
+}
+
+// AsStrings returns a copy of o as a slice.
+func (o *OrderedIdentMaps) AsStrings() []string {
+	return slices.Clone(o.records)
+}
+
+// identMapName matches the first field of a pg_ident.conf line, which may be
+// quoted.
+var identMapName = regexp.MustCompile(`^("(?:[^"]|"")*"|[^\s"]+)`)
+
+// Names returns the unquoted names of the maps in o in the order they first
+// appear.
+func (o *OrderedIdentMaps) Names() []string {
+	var names []string
+	for _, record := range o.records {
+		if match := identMapName.FindStringSubmatch(record); match != nil {
+			if name := unquoteIdentMapName(match[1]); !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// unquoteIdentMapName reverses [IdentMap.quote] when value is quoted.
+func unquoteIdentMapName(value string) string {
+	if len(value) > 1 && value[0] == '"' && value[len(value)-1] == '"' {
+		return strings.ReplaceAll(value[1:len(value)-1], `""`, `"`)
+	}
+	return value
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestIdentMap(t *testing.T) {
+	assert.Equal(t, `"certs" "app.example.com" "app"`,
+		NewIdentMap("certs", "app.example.com", "app").String())
+
+	t.Run("RegularExpression", func(t *testing.T) {
+		assert.Equal(t, `"krb" "/^(.*)@EXAMPLE\.COM$" "\1"`,
+			NewIdentMap("krb", `/^(.*)@EXAMPLE\.COM$`, `\1`).String())
+	})
+
+	t.Run("SpecialCharactersEscaped", func(t *testing.T) {
+		assert.Equal(t, `"m" "some ""quoted"" name" "all"`,
+			NewIdentMap("m", `some "quoted" name`, "all").String())
+
+		// Database users; slash U+002F triggers regex escaping
+		assert.Equal(t, `"m" "x" "/^[/]asdf_[+]$"`,
+			NewIdentMap("m", "x", "/asdf_+").String())
+	})
+}
+
+func TestOrderedIdentMaps(t *testing.T) {
+	ordered := new(OrderedIdentMaps)
+	assert.Assert(t, ordered.AsStrings() == nil)
+
+	ordered.Append(NewIdentMap("one", "a", "b"), nil)
+	ordered.AppendUnstructured(" two  c d ", "\t", "include other", "three e f")
+
+	assert.DeepEqual(t, ordered.AsStrings(), []string{
+		`"one" "a" "b"`,
+		`two  c d`,
+		`three e f`,
+	})
+
+	t.Run("Names", func(t *testing.T) {
+		assert.Assert(t, new(OrderedIdentMaps).Names() == nil)
+
+		ordered := new(OrderedIdentMaps)
+		ordered.Append(NewIdentMap(`with "quotes"`, "a", "b"), NewIdentMap("one", "c", "d"))
+		ordered.AppendUnstructured(`one e f`, `"two" g h`, `three`)
+
+		assert.DeepEqual(t, ordered.Names(), []string{
+			`with "quotes"`, "one", "two", "three",
+		})
+	})
+}
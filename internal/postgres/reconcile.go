@@ -175,6 +175,7 @@ func InstancePod(ctx context.Context,
 		Name: naming.ContainerClientCertCopy,
 
 		Command: reloadCommand(naming.ContainerClientCertCopy),
+		Env:     autogrowEnvironment(inCluster),
 
 		Image:           container.Image,
 		ImagePullPolicy: container.ImagePullPolicy,
@@ -226,6 +227,7 @@ func InstancePod(ctx context.Context,
 		outInstancePod.Spec.Volumes = append(outInstancePod.Spec.Volumes, tablespaceVolume)
 		container.VolumeMounts = append(container.VolumeMounts, tablespaceVolumeMount)
 		startup.VolumeMounts = append(startup.VolumeMounts, tablespaceVolumeMount)
+		reloader.VolumeMounts = append(reloader.VolumeMounts, tablespaceVolumeMount)
 	}
 
 	if inCluster.Spec.Config != nil && len(inCluster.Spec.Config.Files) != 0 {
@@ -254,6 +256,7 @@ func InstancePod(ctx context.Context,
 
 		container.VolumeMounts = append(container.VolumeMounts, walVolumeMount)
 		startup.VolumeMounts = append(startup.VolumeMounts, walVolumeMount)
+		reloader.VolumeMounts = append(reloader.VolumeMounts, walVolumeMount)
 		outInstancePod.Spec.Volumes = append(outInstancePod.Spec.Volumes, walVolume)
 	}
 
@@ -180,16 +180,17 @@ containers:
     # Manage autogrow annotation.
     # Return size in Mebibytes.
     manageAutogrowAnnotation() {
-      local volume=$1
+      local volume=$1 directory=$2 threshold percent mebibytes
+      read -r threshold percent mebibytes <<< "$3"
 
-      size=$(df --human-readable --block-size=M /"${volume}" | awk 'FNR == 2 {print $2}')
-      use=$(df --human-readable /"${volume}" | awk 'FNR == 2 {print $5}')
+      size=$(df --human-readable --block-size=M "${directory}" | awk 'FNR == 2 {print $2}')
+      use=$(df --human-readable "${directory}" | awk 'FNR == 2 {print $5}')
       sizeInt="${size//M/}"
       # Use the sed punctuation class, because the shell will not accept the percent sign in an expansion.
       useInt=$(echo $use | sed 's/[[:punct:]]//g')
-      triggerExpansion="$((useInt > 75))"
+      triggerExpansion="$((useInt > threshold))"
       if [ $triggerExpansion -eq 1 ]; then
-        newSize="$(((sizeInt / 2)+sizeInt))"
+        newSize="$((sizeInt + (sizeInt * percent / 100) + mebibytes))"
         newSizeMi="${newSize}Mi"
         d='[{"op": "add", "path": "/metadata/annotations/suggested-'"${volume}"'-pvc-size", "value": "'"$newSizeMi"'"}]'
         curl --cacert ${CACERT} --header "Authorization: Bearer ${TOKEN}" -XPATCH "${APISERVER}/api/v1/namespaces/${NAMESPACE}/pods/${HOSTNAME}?fieldManager=kubectl-annotate" -H "Content-Type: application/json-patch+json" --data "$d"
@@ -209,7 +210,19 @@ containers:
       fi
 
       # manage autogrow annotation for the pgData volume
-      manageAutogrowAnnotation "pgdata"
+      manageAutogrowAnnotation "pgdata" "/pgdata" "${AUTOGROW_PGDATA:-75 50 0}"
+
+      # manage autogrow annotation for the pgWAL volume, if it exists
+      if [[ -d /pgwal ]]; then
+        manageAutogrowAnnotation "pgwal" "/pgwal" "${AUTOGROW_PGWAL:-75 50 0}"
+      fi
+
+      # manage autogrow annotation for each tablespace volume
+      for tablespace in /tablespaces/*; do
+        if [[ -d "${tablespace}" ]]; then
+          manageAutogrowAnnotation "tablespace-${tablespace##*/}" "${tablespace}" "${AUTOGROW_TABLESPACES:-75 50 0}"
+        fi
+      done
     done
     }; export -f monitor; exec -a "$0" bash -ceu monitor
   - replication-cert-copy
@@ -417,6 +430,16 @@ volumes:
 - mountPath: /pgwal
   name: postgres-wal`), "expected WAL mount, no downwardAPI mount in %q container", pod.Spec.InitContainers[0].Name)
 
+		// The reloader needs the WAL volume to report its usage
+		assert.Assert(t, cmp.MarshalMatches(pod.Spec.Containers[1].VolumeMounts, `
+- mountPath: /pgconf/tls
+  name: cert-volume
+  readOnly: true
+- mountPath: /pgdata
+  name: postgres-data
+- mountPath: /pgwal
+  name: postgres-wal`), "expected WAL mount in %q container", pod.Spec.Containers[1].Name)
+
 		assert.Assert(t, cmp.MarshalMatches(pod.Spec.Volumes, `
 - name: cert-volume
   projected:
@@ -604,6 +627,18 @@ volumes:
   name: tablespace-castle
 - mountPath: /tablespaces/trial
   name: tablespace-trial`), "expected tablespace mount(s) in %q container", pod.Spec.InitContainers[0].Name)
+
+		// The reloader needs tablespace volumes to report their usage
+		assert.Assert(t, cmp.MarshalMatches(pod.Spec.Containers[1].VolumeMounts, `
+- mountPath: /pgconf/tls
+  name: cert-volume
+  readOnly: true
+- mountPath: /pgdata
+  name: postgres-data
+- mountPath: /tablespaces/castle
+  name: tablespace-castle
+- mountPath: /tablespaces/trial
+  name: tablespace-trial`), "expected tablespace mount(s) in %q container", pod.Spec.Containers[1].Name)
 	})
 
 	t.Run("WithWALVolumeWithWALVolumeSpec", func(t *testing.T) {
@@ -56,10 +56,26 @@ func sanitizeAlterRoleOptions(options string) string {
 	return strings.TrimPrefix(sql, AlterRolePrefix)
 }
 
+// DualPasswordRotation reports whether the password of spec alternates between
+// two roles. See [NextUserName].
+func DualPasswordRotation(spec *v1beta1.PostgresUserSpec) bool {
+	return spec.Name != "postgres" && spec.PasswordRotation != nil &&
+		spec.PasswordRotation.GraceMode == v1beta1.PostgresPasswordGraceModeDualPassword
+}
+
+// NextUserName returns the name of the role that alternates with user when
+// its password rotates in DualPassword mode.
+func NextUserName(user string) string { return user + "_next" }
+
 // WriteUsersInPostgreSQL calls exec to create users that do not exist in
 // PostgreSQL. Once they exist, it updates their options and passwords and
 // grants them access to their specified databases. The databases must already
 // exist.
+//
+// Users with [DualPasswordRotation] have a second role that switches to the
+// user after connecting. The password of either role is left unchanged when
+// verifiers has no value for it. When a user leaves DualPassword mode, its
+// second role can no longer login and loses its password and membership.
 func WriteUsersInPostgreSQL(
 	ctx context.Context, cluster *v1beta1.PostgresCluster, exec Executor,
 	users []v1beta1.PostgresUserSpec, verifiers map[string]string,
@@ -101,13 +117,30 @@ CREATE TEMPORARY TABLE input (id serial, data json);
 			options = `LOGIN SUPERUSER`
 		}
 
-		if err == nil {
-			err = encoder.Encode(map[string]any{
-				"databases": databases,
-				"options":   options,
-				"username":  spec.Name,
-				"verifier":  verifiers[spec.Name],
-			})
+		if !DualPasswordRotation(&spec) {
+			if err == nil {
+				err = encoder.Encode(map[string]any{
+					"databases": databases,
+					"options":   options,
+					"username":  spec.Name,
+					"verifier":  verifiers[spec.Name],
+				})
+			}
+			continue
+		}
+
+		// The second role needs only to login. It gets everything else from
+		// the user; see below.
+		for _, role := range []map[string]any{
+			{"databases": databases, "options": options, "username": spec.Name},
+			{"options": "LOGIN INHERIT", "username": NextUserName(spec.Name), "member_of": spec.Name},
+		} {
+			if verifier, ok := verifiers[role["username"].(string)]; ok {
+				role["verifier"] = verifier
+			}
+			if err == nil {
+				err = encoder.Encode(role)
+			}
 		}
 	}
 	_, _ = sql.WriteString(`\.` + "\n")
@@ -139,7 +172,61 @@ SELECT pg_catalog.format('ALTER ROLE %I WITH %s PASSWORD %L',
        pg_catalog.json_extract_path_text(input.data, 'username'),
        pg_catalog.json_extract_path_text(input.data, 'options'),
        pg_catalog.json_extract_path_text(input.data, 'verifier'))
-  FROM input ORDER BY input.id
+  FROM input
+ WHERE pg_catalog.json_extract_path(input.data, 'verifier') IS NOT NULL
+ ORDER BY input.id
+\gexec
+`)
+
+	// Set options without changing passwords that have no verifier.
+	_, _ = sql.WriteString(`
+SELECT pg_catalog.format('ALTER ROLE %I WITH %s',
+       pg_catalog.json_extract_path_text(input.data, 'username'),
+       pg_catalog.json_extract_path_text(input.data, 'options'))
+  FROM input
+ WHERE pg_catalog.json_extract_path(input.data, 'verifier') IS NULL
+ ORDER BY input.id
+\gexec
+`)
+
+	// Make second roles members of their users. After connecting, they switch
+	// to their user so that the objects they create belong to it.
+	// - https://www.postgresql.org/docs/current/role-membership.html
+	// - https://www.postgresql.org/docs/current/sql-set-role.html
+	_, _ = sql.WriteString(`
+SELECT pg_catalog.format('GRANT %I TO %I',
+       pg_catalog.json_extract_path_text(input.data, 'member_of'),
+       pg_catalog.json_extract_path_text(input.data, 'username')),
+       pg_catalog.format('ALTER ROLE %I SET role = %L',
+       pg_catalog.json_extract_path_text(input.data, 'username'),
+       pg_catalog.json_extract_path_text(input.data, 'member_of'))
+  FROM input
+ WHERE pg_catalog.json_extract_path_text(input.data, 'member_of') IS NOT NULL
+   AND NOT pg_catalog.pg_has_role(
+       pg_catalog.json_extract_path_text(input.data, 'username'),
+       pg_catalog.json_extract_path_text(input.data, 'member_of'), 'MEMBER')
+ ORDER BY input.id
+\gexec
+`)
+
+	// Disable the second role of users that are no longer in DualPassword
+	// mode. It cannot login, has no password, and is no longer a member of
+	// its user. The suffix matches [NextUserName].
+	_, _ = sql.WriteString(`
+SELECT pg_catalog.format('ALTER ROLE %I WITH NOLOGIN PASSWORD NULL', second.rolname),
+       pg_catalog.format('ALTER ROLE %I RESET role', second.rolname),
+       pg_catalog.format('REVOKE %I FROM %I', users.rolname, second.rolname)
+  FROM input
+  JOIN pg_catalog.pg_roles AS users
+    ON users.rolname = pg_catalog.json_extract_path_text(input.data, 'username')
+  JOIN pg_catalog.pg_auth_members AS members ON members.roleid = users.oid
+  JOIN pg_catalog.pg_roles AS second ON second.oid = members.member
+ WHERE second.rolname = users.rolname || '_next'
+   AND pg_catalog.json_extract_path(input.data, 'member_of') IS NULL
+   AND NOT EXISTS (
+       SELECT 1 FROM input AS other
+       WHERE pg_catalog.json_extract_path_text(other.data, 'username') = second.rolname)
+ ORDER BY input.id
 \gexec
 `)
 
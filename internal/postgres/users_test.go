@@ -81,7 +81,47 @@ SELECT pg_catalog.format('ALTER ROLE %I WITH %s PASSWORD %L',
        pg_catalog.json_extract_path_text(input.data, 'username'),
        pg_catalog.json_extract_path_text(input.data, 'options'),
        pg_catalog.json_extract_path_text(input.data, 'verifier'))
-  FROM input ORDER BY input.id
+  FROM input
+ WHERE pg_catalog.json_extract_path(input.data, 'verifier') IS NOT NULL
+ ORDER BY input.id
+\gexec
+
+SELECT pg_catalog.format('ALTER ROLE %I WITH %s',
+       pg_catalog.json_extract_path_text(input.data, 'username'),
+       pg_catalog.json_extract_path_text(input.data, 'options'))
+  FROM input
+ WHERE pg_catalog.json_extract_path(input.data, 'verifier') IS NULL
+ ORDER BY input.id
+\gexec
+
+SELECT pg_catalog.format('GRANT %I TO %I',
+       pg_catalog.json_extract_path_text(input.data, 'member_of'),
+       pg_catalog.json_extract_path_text(input.data, 'username')),
+       pg_catalog.format('ALTER ROLE %I SET role = %L',
+       pg_catalog.json_extract_path_text(input.data, 'username'),
+       pg_catalog.json_extract_path_text(input.data, 'member_of'))
+  FROM input
+ WHERE pg_catalog.json_extract_path_text(input.data, 'member_of') IS NOT NULL
+   AND NOT pg_catalog.pg_has_role(
+       pg_catalog.json_extract_path_text(input.data, 'username'),
+       pg_catalog.json_extract_path_text(input.data, 'member_of'), 'MEMBER')
+ ORDER BY input.id
+\gexec
+
+SELECT pg_catalog.format('ALTER ROLE %I WITH NOLOGIN PASSWORD NULL', second.rolname),
+       pg_catalog.format('ALTER ROLE %I RESET role', second.rolname),
+       pg_catalog.format('REVOKE %I FROM %I', users.rolname, second.rolname)
+  FROM input
+  JOIN pg_catalog.pg_roles AS users
+    ON users.rolname = pg_catalog.json_extract_path_text(input.data, 'username')
+  JOIN pg_catalog.pg_auth_members AS members ON members.roleid = users.oid
+  JOIN pg_catalog.pg_roles AS second ON second.oid = members.member
+ WHERE second.rolname = users.rolname || '_next'
+   AND pg_catalog.json_extract_path(input.data, 'member_of') IS NULL
+   AND NOT EXISTS (
+       SELECT 1 FROM input AS other
+       WHERE pg_catalog.json_extract_path_text(other.data, 'username') = second.rolname)
+ ORDER BY input.id
 \gexec
 
 SELECT pg_catalog.format('GRANT ALL PRIVILEGES ON DATABASE %I TO %I',
@@ -185,6 +225,90 @@ COMMIT;`))
 		))
 		assert.Equal(t, calls, 1)
 	})
+
+	t.Run("DualPassword", func(t *testing.T) {
+		calls := 0
+		cluster := new(v1beta1.PostgresCluster)
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+			assert.Assert(t, cmp.Contains(string(b), `
+\copy input (data) from stdin with (format text)
+{"databases":["db1"],"options":"CREATEDB","username":"app","verifier":"current"}
+{"member_of":"app","options":"LOGIN INHERIT","username":"app_next"}
+{"databases":null,"options":"","username":"other"}
+{"member_of":"other","options":"LOGIN INHERIT","username":"other_next","verifier":""}
+{"databases":["postgres"],"options":"LOGIN SUPERUSER","username":"postgres","verifier":""}
+\.
+`))
+			return nil
+		}
+
+		rotation := &v1beta1.PostgresPasswordRotationSpec{
+			GraceMode: v1beta1.PostgresPasswordGraceModeDualPassword,
+		}
+
+		// The passwords of "app_next" and "other" are unchanged, and the
+		// password of "other_next" is removed.
+		assert.NilError(t, WriteUsersInPostgreSQL(ctx, cluster, exec,
+			[]v1beta1.PostgresUserSpec{
+				{
+					Name:             "app",
+					Databases:        []string{"db1"},
+					Options:          "createdb",
+					PasswordRotation: rotation,
+				},
+				{Name: "other", PasswordRotation: rotation},
+				{Name: "postgres", PasswordRotation: rotation},
+			},
+			map[string]string{
+				"app":        "current",
+				"other_next": "",
+			},
+		))
+		assert.Equal(t, calls, 1)
+	})
+
+	t.Run("DualPasswordDisabled", func(t *testing.T) {
+		calls := 0
+		cluster := new(v1beta1.PostgresCluster)
+		exec := func(
+			_ context.Context, stdin io.Reader, _, _ io.Writer, command ...string,
+		) error {
+			calls++
+
+			b, err := io.ReadAll(stdin)
+			assert.NilError(t, err)
+
+			// The second role is not in the input, and any that exists
+			// is disabled.
+			assert.Assert(t, cmp.Contains(string(b), `
+\copy input (data) from stdin with (format text)
+{"databases":null,"options":"","username":"app","verifier":"current"}
+\.
+`))
+			assert.Assert(t, cmp.Contains(string(b),
+				`'ALTER ROLE %I WITH NOLOGIN PASSWORD NULL', second.rolname`))
+			assert.Assert(t, cmp.Contains(string(b),
+				`'REVOKE %I FROM %I', users.rolname, second.rolname`))
+			return nil
+		}
+
+		assert.NilError(t, WriteUsersInPostgreSQL(ctx, cluster, exec,
+			[]v1beta1.PostgresUserSpec{{
+				Name: "app",
+				PasswordRotation: &v1beta1.PostgresPasswordRotationSpec{
+					GraceMode: v1beta1.PostgresPasswordGraceModeNone,
+				},
+			}},
+			map[string]string{"app": "current"},
+		))
+		assert.Equal(t, calls, 1)
+	})
 }
 
 func TestWriteUsersSchemasInPostgreSQL(t *testing.T) {
@@ -0,0 +1,170 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestPGBackRestRepos(t *testing.T) {
+	ctx := t.Context()
+	cc := require.Kubernetes(t)
+	t.Parallel()
+
+	namespace := require.Namespace(t, cc)
+	base := v1beta1.NewPostgresCluster()
+
+	// required fields
+	require.UnmarshalInto(t, &base.Spec, `{
+		postgresVersion: 16,
+		instances: [{
+			dataVolumeClaimSpec: {
+				accessModes: [ReadWriteOnce],
+				resources: { requests: { storage: 1Mi } },
+			},
+		}],
+	}`)
+
+	base.Namespace = namespace.Name
+	base.Name = "pgbackrest-repos"
+
+	assert.NilError(t, cc.Create(ctx, base.DeepCopy(), client.DryRunAll),
+		"expected this base cluster to be valid")
+
+	t.Run("SFTP", func(t *testing.T) {
+		cluster := base.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec.Backups.PGBackRest, `{
+			repos: [{
+				name: repo1,
+				sftp: {
+					host: sftp.example.com, user: backups, path: /srv/pgbackrest,
+					knownHosts: ["sftp.example.com ssh-ed25519 AAAA"],
+					privateKeySecretRef: { name: sftp-keys, key: id_ed25519 },
+				},
+			}],
+		}`)
+
+		assert.NilError(t, cc.Create(ctx, cluster.DeepCopy(), client.DryRunAll))
+
+		t.Run("RelativePath", func(t *testing.T) {
+			cluster := cluster.DeepCopy()
+			cluster.Spec.Backups.PGBackRest.Repos[0].SFTP.Path = "srv/pgbackrest"
+
+			err := cc.Create(ctx, cluster, client.DryRunAll)
+			assert.Assert(t, apierrors.IsInvalid(err))
+			assert.ErrorContains(t, err, "absolute path")
+		})
+
+		t.Run("NoKnownHosts", func(t *testing.T) {
+			cluster := cluster.DeepCopy()
+			cluster.Spec.Backups.PGBackRest.Repos[0].SFTP.KnownHosts = nil
+
+			err := cc.Create(ctx, cluster, client.DryRunAll)
+			assert.Assert(t, apierrors.IsInvalid(err))
+			assert.ErrorContains(t, err, "knownHosts")
+		})
+
+		t.Run("AnotherType", func(t *testing.T) {
+			cluster := cluster.DeepCopy()
+			cluster.Spec.Backups.PGBackRest.Repos[0].GCS = &v1beta1.RepoGCS{Bucket: "some"}
+
+			err := cc.Create(ctx, cluster, client.DryRunAll)
+			assert.Assert(t, apierrors.IsInvalid(err))
+			assert.ErrorContains(t, err, "sftp cannot be combined")
+		})
+	})
+
+	t.Run("POSIX", func(t *testing.T) {
+		cluster := base.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec.Backups.PGBackRest, `{
+			repos: [
+				{ name: repo1, posix: { claimName: shared-backups } },
+				{ name: repo2, posix: { nfs: { server: nfs.example.com, path: /exports/pg } } },
+			],
+		}`)
+
+		assert.NilError(t, cc.Create(ctx, cluster.DeepCopy(), client.DryRunAll))
+
+		t.Run("ClaimAndNFS", func(t *testing.T) {
+			cluster := cluster.DeepCopy()
+			cluster.Spec.Backups.PGBackRest.Repos[0].POSIX.NFS =
+				cluster.Spec.Backups.PGBackRest.Repos[1].POSIX.NFS
+
+			err := cc.Create(ctx, cluster, client.DryRunAll)
+			assert.Assert(t, apierrors.IsInvalid(err))
+			assert.ErrorContains(t, err, "exactly one of claimName or nfs")
+		})
+
+		t.Run("Neither", func(t *testing.T) {
+			cluster := cluster.DeepCopy()
+			cluster.Spec.Backups.PGBackRest.Repos[0].POSIX.ClaimName = ""
+
+			err := cc.Create(ctx, cluster, client.DryRunAll)
+			assert.Assert(t, apierrors.IsInvalid(err))
+			assert.ErrorContains(t, err, "exactly one of claimName or nfs")
+		})
+
+		t.Run("AnotherType", func(t *testing.T) {
+			cluster := cluster.DeepCopy()
+			cluster.Spec.Backups.PGBackRest.Repos[1].Volume = &v1beta1.RepoPVC{}
+
+			err := cc.Create(ctx, cluster, client.DryRunAll)
+			assert.Assert(t, apierrors.IsInvalid(err))
+			assert.ErrorContains(t, err, "posix cannot be combined")
+		})
+	})
+
+	t.Run("Encryption", func(t *testing.T) {
+		cluster := base.DeepCopy()
+		cluster.Name = "pgbackrest-encryption"
+		require.UnmarshalInto(t, &cluster.Spec.Backups.PGBackRest, `{
+			repos: [{
+				name: repo1,
+				gcs: { bucket: some },
+				encryption: { passphraseSecretRef: { name: passphrases, key: repo1 } },
+			}],
+		}`)
+
+		assert.NilError(t, cc.Create(ctx, cluster))
+		t.Cleanup(func() { assert.Check(t, cc.Delete(ctx, cluster)) })
+
+		t.Run("Change", func(t *testing.T) {
+			cluster := cluster.DeepCopy()
+			cluster.Spec.Backups.PGBackRest.Repos[0].Encryption.PassphraseSecretRef.Key = "other"
+
+			err := cc.Update(ctx, cluster, client.DryRunAll)
+			assert.Assert(t, apierrors.IsInvalid(err))
+			assert.ErrorContains(t, err, "encryption cannot change")
+		})
+
+		t.Run("Remove", func(t *testing.T) {
+			cluster := cluster.DeepCopy()
+			cluster.Spec.Backups.PGBackRest.Repos[0].Encryption = nil
+
+			err := cc.Update(ctx, cluster, client.DryRunAll)
+			assert.Assert(t, apierrors.IsInvalid(err))
+			assert.ErrorContains(t, err, "encryption cannot change")
+		})
+
+		t.Run("AnotherRepository", func(t *testing.T) {
+			cluster := cluster.DeepCopy()
+			cluster.Spec.Backups.PGBackRest.Repos = append(cluster.Spec.Backups.PGBackRest.Repos,
+				v1beta1.PGBackRestRepo{
+					Name:       "repo2",
+					GCS:        &v1beta1.RepoGCS{Bucket: "some"},
+					Encryption: &v1beta1.RepoEncryption{},
+				})
+
+			assert.NilError(t, cc.Update(ctx, cluster, client.DryRunAll))
+		})
+	})
+}
@@ -0,0 +1,70 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestPGBouncerPause(t *testing.T) {
+	ctx := t.Context()
+	cc := require.Kubernetes(t)
+	t.Parallel()
+
+	namespace := require.Namespace(t, cc)
+	base := v1beta1.NewPostgresCluster()
+
+	// required fields
+	require.UnmarshalInto(t, &base.Spec, `{
+		postgresVersion: 16,
+		instances: [{
+			dataVolumeClaimSpec: {
+				accessModes: [ReadWriteOnce],
+				resources: { requests: { storage: 1Mi } },
+			},
+		}],
+		proxy: { pgBouncer: {} },
+	}`)
+
+	base.Namespace = namespace.Name
+	base.Name = "pgbouncer-pause"
+
+	assert.NilError(t, cc.Create(ctx, base.DeepCopy(), client.DryRunAll),
+		"expected this base cluster to be valid")
+
+	for _, tt := range []struct {
+		name  string
+		pause string
+		valid bool
+	}{
+		{name: "Defaults", pause: `{}`, valid: true},
+		{name: "Both", pause: `{ timeout: 30s, maximumDuration: 2m }`, valid: true},
+		{name: "ShortTimeout", pause: `{ timeout: 5s }`, valid: true},
+		{name: "LongTimeout", pause: `{ timeout: 5m }`, valid: false},
+		{name: "ShortMaximum", pause: `{ maximumDuration: 10s }`, valid: false},
+		{name: "Equal", pause: `{ timeout: 30s, maximumDuration: 30s }`, valid: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cluster := base.DeepCopy()
+			require.UnmarshalInto(t,
+				&cluster.Spec.Proxy.PGBouncer.PauseDuringSwitchover, tt.pause)
+
+			err := cc.Create(ctx, cluster, client.DryRunAll)
+			if tt.valid {
+				assert.NilError(t, err)
+			} else {
+				assert.Assert(t, apierrors.IsInvalid(err))
+				assert.ErrorContains(t, err, "longer than timeout")
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crunchydata/postgres-operator/internal/testing/cmp"
+	"github.com/crunchydata/postgres-operator/internal/testing/require"
+	"github.com/crunchydata/postgres-operator/pkg/apis/postgres-operator.crunchydata.com/v1beta1"
+)
+
+func TestPostgresAudit(t *testing.T) {
+	ctx := t.Context()
+	cc := require.Kubernetes(t)
+	t.Parallel()
+
+	namespace := require.Namespace(t, cc)
+	base := v1beta1.NewPostgresCluster()
+
+	// required fields
+	require.UnmarshalInto(t, &base.Spec, `{
+		postgresVersion: 16,
+		instances: [{
+			dataVolumeClaimSpec: {
+				accessModes: [ReadWriteOnce],
+				resources: { requests: { storage: 1Mi } },
+			},
+		}],
+	}`)
+
+	base.Namespace = namespace.Name
+	base.Name = "postgres-audit"
+
+	assert.NilError(t, cc.Create(ctx, base.DeepCopy(), client.DryRunAll),
+		"expected this base cluster to be valid")
+
+	t.Run("Valid", func(t *testing.T) {
+		cluster := base.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			audit: {
+				log: [write, ddl, -misc],
+				logParameter: true,
+				databases: [{ name: app, log: [read, write] }],
+				roles: [{ name: alice, log: [all], logParameter: false }],
+				objects: [{ database: app, role: auditor, tables: [
+					{ privileges: [SELECT, UPDATE], table: accounts },
+					{ privileges: [INSERT], schema: sales, table: orders },
+					{ privileges: [SELECT], graph: people, label: Person },
+				] }],
+				exporters: [siem],
+			},
+		}`)
+
+		assert.NilError(t, cc.Create(ctx, cluster, client.DryRunAll))
+	})
+
+	t.Run("Classes", func(t *testing.T) {
+		cluster := base.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			audit: { log: [read, everything, -ddl] },
+		}`)
+
+		err := cc.Create(ctx, cluster, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+
+		details := require.StatusErrorDetails(t, err)
+		assert.Assert(t, cmp.Len(details.Causes, 1))
+		assert.Equal(t, details.Causes[0].Field, "spec.audit.log[1]")
+	})
+
+	t.Run("Tables", func(t *testing.T) {
+		cluster := base.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			audit: { objects: [{ database: app, role: auditor, tables: [
+				{ privileges: [SELECT] },
+				{ privileges: [SELECT], table: t, graph: g, label: l },
+				{ privileges: [SELECT], label: l },
+				{ privileges: [SELECT], schema: s, graph: g, label: l },
+			] }] },
+		}`)
+
+		err := cc.Create(ctx, cluster, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+
+		details := require.StatusErrorDetails(t, err)
+		assert.Assert(t, cmp.Len(details.Causes, 4))
+
+		for i, cause := range details.Causes {
+			assert.Equal(t, cause.Field, fmt.Sprintf("spec.audit.objects[0].tables[%d]", i))
+		}
+		assert.Assert(t, cmp.Contains(details.Causes[0].Message, `exactly one of "table" or "label"`))
+		assert.Assert(t, cmp.Contains(details.Causes[1].Message, `exactly one of "table" or "label"`))
+		assert.Assert(t, cmp.Contains(details.Causes[2].Message, `"graph" and "label" must be set together`))
+		assert.Assert(t, cmp.Contains(details.Causes[3].Message, `"schema" requires "table"`))
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		cluster := base.DeepCopy()
+		require.UnmarshalInto(t, &cluster.Spec, `{
+			audit: { objects: [{ database: app, role: auditor, tables: [
+				{ privileges: [SELECT], table: t },
+			] }] },
+			users: [{ name: app }],
+		}`)
+
+		assert.NilError(t, cc.Create(ctx, cluster.DeepCopy(), client.DryRunAll))
+
+		cluster.Spec.Users[0].Name = "auditor"
+
+		err := cc.Create(ctx, cluster, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+		assert.ErrorContains(t, err, "audit roles cannot be users")
+	})
+}
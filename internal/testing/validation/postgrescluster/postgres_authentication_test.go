@@ -263,4 +263,27 @@ func testPostgresAuthenticationCommon(t *testing.T, cc client.Client, base unstr
 			assert.NilError(t, cc.Create(ctx, cluster, client.DryRunAll))
 		})
 	})
+
+	t.Run("IdentMaps", func(t *testing.T) {
+		// Rules can refer to maps that are defined in Patroni, so the API
+		// does not check the "map" option.
+		// See [internal/controller/postgrescluster.TestReconcileIdentMapsValid]
+		cluster := base.DeepCopy()
+		require.UnmarshalIntoField(t, cluster, `{
+			identMaps: [
+				{ map: certs, systemUser: app.example.com, databaseUser: app },
+				{ map: krb, systemUser: '/^(.*)@EXAMPLE\.COM$', databaseUser: '\1' },
+			],
+			rules: [
+				{ connection: hostssl, method: cert, options: { map: certs } },
+				{ connection: hostgssenc, method: gss, options: { map: krb, include_realm: 1 } },
+				{ connection: hostssl, method: cert, options: { map: elsewhere } },
+				{ hba: 'hostssl all all all cert map=elsewhere' },
+			],
+		}`, "spec", "authentication")
+		require.UnmarshalIntoField(t, cluster, `{
+			postgresql: { pg_ident: [ 'elsewhere postgres postgres' ] },
+		}`, "spec", "patroni", "dynamicConfiguration")
+		assert.NilError(t, cc.Create(ctx, cluster, client.DryRunAll))
+	})
 }
@@ -151,6 +151,66 @@ func testPostgresUserOptionsCommon(t *testing.T, cc client.Client, base unstruct
 		assert.Equal(t, details.Causes[0].Field, "spec.users[0].options")
 	})
 
+	t.Run("PasswordRotation", func(t *testing.T) {
+		cluster := base.DeepCopy()
+		require.UnmarshalIntoField(t, cluster, `[
+			{ name: short, passwordRotation: { interval: 0h } },
+			{ name: grace, passwordRotation: { interval: 1d, gracePeriod: 2d } },
+			{ name: postgres, passwordRotation: { interval: 1d, graceMode: DualPassword } },
+			{ name: some-really-long-user-name-that-leaves-no-room-for-a-suffix,
+			  passwordRotation: { interval: 1d, graceMode: DualPassword } },
+		]`, "spec", "users")
+
+		err := cc.Create(ctx, cluster, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+
+		details := require.StatusErrorDetails(t, err)
+		assert.Assert(t, cmp.Len(details.Causes, 4))
+
+		assert.Equal(t, details.Causes[0].Field, "spec.users[0].passwordRotation.interval")
+		assert.Assert(t, cmp.Contains(details.Causes[0].Message, "at least one hour"))
+		assert.Equal(t, details.Causes[1].Field, "spec.users[1].passwordRotation")
+		assert.Assert(t, cmp.Contains(details.Causes[1].Message, "shorter than interval"))
+
+		for i, cause := range details.Causes[2:] {
+			assert.Equal(t, cause.Field, fmt.Sprintf("spec.users[%d]", i+2))
+			assert.Assert(t, cmp.Contains(cause.Message, "DualPassword requires"))
+		}
+
+		// These are valid.
+
+		unstructured.RemoveNestedField(cluster.Object, "spec", "users")
+		require.UnmarshalIntoField(t, cluster, `[
+			{ name: postgres, passwordRotation: { interval: 90d } },
+			{ name: app, passwordRotation: { interval: 2w, graceMode: DualPassword, gracePeriod: 1d } },
+		]`, "spec", "users")
+		assert.NilError(t, cc.Create(ctx, cluster, client.DryRunAll))
+	})
+
+	t.Run("ClientCertificate", func(t *testing.T) {
+		cluster := base.DeepCopy()
+		require.UnmarshalIntoField(t, cluster, `[
+			{ name: app, clientCertificate: true },
+		]`, "spec", "users")
+		require.UnmarshalIntoField(t, cluster,
+			`{ name: custom-tls }`, "spec", "customTLSSecret")
+
+		err := cc.Create(ctx, cluster, client.DryRunAll)
+		assert.Assert(t, apierrors.IsInvalid(err))
+		assert.ErrorContains(t, err, "cannot have a clientCertificate")
+
+		details := require.StatusErrorDetails(t, err)
+		assert.Assert(t, cmp.Len(details.Causes, 1))
+		assert.Equal(t, details.Causes[0].Field, "spec")
+
+		// These are valid.
+
+		require.UnmarshalIntoField(t, cluster, `[
+			{ name: app, clientCertificate: false },
+		]`, "spec", "users")
+		assert.NilError(t, cc.Create(ctx, cluster, client.DryRunAll))
+	})
+
 	t.Run("Valid", func(t *testing.T) {
 		cluster := base.DeepCopy()
 		require.UnmarshalIntoField(t, cluster,
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -15,6 +16,13 @@ import (
 )
 
 // PostgresClusterSpec defines the desired state of PostgresCluster
+// ---
+// Client certificates are signed by the cluster certificate authority, which
+// PostgreSQL does not trust when the server uses a custom TLS secret.
+// +kubebuilder:validation:XValidation:rule=`!has(self.customTLSSecret) || !has(self.users) || self.users.all(u, !has(u.clientCertificate) || !u.clientCertificate)`,message="users cannot have a clientCertificate when customTLSSecret is set"
+//
+// Audit roles cannot login, but users in spec.users can.
+// +kubebuilder:validation:XValidation:rule=`!has(self.audit) || !has(self.audit.objects) || !has(self.users) || self.audit.objects.all(o, !self.users.exists(u, u.name == o.role))`,message="audit roles cannot be users in spec.users"
 type PostgresClusterSpec struct {
 	// +optional
 	Metadata *v1beta1.Metadata `json:"metadata,omitempty"`
@@ -27,10 +35,25 @@ type PostgresClusterSpec struct {
 	// +optional
 	Authentication *v1beta1.PostgresAuthenticationSpec `json:"authentication,omitempty"`
 
+	// pgAudit policies for roles, databases, and tables.
+	// +optional
+	Audit *v1beta1.PostgresAuditSpec `json:"audit,omitempty"`
+
+	// How volumes grow when the AutoGrowVolumes feature gate is enabled and
+	// a volume has a storage limit.
+	// +optional
+	AutoGrow *v1beta1.VolumeAutoGrowSpec `json:"autoGrow,omitempty"`
+
 	// PostgreSQL backup configuration
 	// +optional
 	Backups Backups `json:"backups,omitzero"`
 
+	// Settings for the certificates that the operator generates for this
+	// cluster. These do not apply to the root certificate authority, which is
+	// shared by clusters in a namespace and follows the settings of the operator.
+	// +optional
+	Certificates *v1beta1.CertificatesSpec `json:"certificates,omitempty"`
+
 	// General configuration of the PostgreSQL server
 	// +optional
 	Config *v1beta1.PostgresConfigSpec `json:"config,omitempty"`
@@ -47,6 +70,10 @@ type PostgresClusterSpec struct {
 	// +optional
 	CustomTLSSecret *corev1.SecretProjection `json:"customTLSSecret,omitempty"`
 
+	// Settings for the TLS certificates of this cluster.
+	// +optional
+	TLS *v1beta1.PostgresClusterTLSSpec `json:"tls,omitempty"`
+
 	// The secret containing the replication client certificates and keys for
 	// secure connections to the PostgreSQL server. It will need to contain the
 	// client TLS certificate, TLS key and the Certificate Authority certificate
@@ -202,6 +229,8 @@ type PostgresClusterSpec struct {
 }
 
 // DataSource defines data sources for a new PostgresCluster.
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.volumeSnapshot) || !(has(self.pgbackrest) || has(self.postgresCluster))`,message="volumeSnapshot cannot be combined with pgbackrest or postgresCluster"
 type DataSource struct {
 	// Defines a pgBackRest cloud-based data source that can be used to pre-populate the
 	// PostgreSQL data directory for a new PostgreSQL cluster using a pgBackRest restore.
@@ -218,6 +247,12 @@ type DataSource struct {
 	// +optional
 	PostgresCluster *PostgresClusterDataSource `json:"postgresCluster,omitempty"`
 
+	// Defines a VolumeSnapshot that is used to pre-populate the PostgreSQL data
+	// directory for a new PostgreSQL cluster. A pgBackRest restore then brings
+	// that data forward using the WAL in a repository of the source cluster.
+	// +optional
+	VolumeSnapshot *v1beta1.VolumeSnapshotDataSource `json:"volumeSnapshot,omitempty"`
+
 	// Defines any existing volumes to reuse for this PostgresCluster.
 	// +optional
 	Volumes *DataSourceVolumes `json:"volumes,omitempty"`
@@ -404,6 +439,16 @@ type PostgresClusterStatus struct {
 	// Identifies the users that have been installed into PostgreSQL.
 	UsersRevision string `json:"usersRevision,omitempty"`
 
+	// Password rotation of PostgreSQL users.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	UserPasswords []v1beta1.PostgresUserPasswordStatus `json:"userPasswords,omitempty"`
+
+	// Identifies the pgAudit policies that have been applied in PostgreSQL.
+	// +optional
+	AuditRevision string `json:"auditRevision,omitempty"`
+
 	// Current state of PostgreSQL cluster monitoring tool configuration
 	// +optional
 	Monitoring MonitoringStatus `json:"monitoring,omitzero"`
@@ -412,6 +457,28 @@ type PostgresClusterStatus struct {
 	// +optional
 	DatabaseInitSQL *string `json:"databaseInitSQL,omitempty"`
 
+	// The VolumeSnapshots of the cluster, oldest first.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	VolumeSnapshots []v1beta1.VolumeSnapshotStatus `json:"volumeSnapshots,omitempty"`
+
+	// The last time autogrow requested a larger size for each kind of volume,
+	// such as "pgData/instance1" or "repo1/repo-host".
+	// +optional
+	VolumeAutoGrowTimes map[string]metav1.Time `json:"volumeAutoGrowTimes,omitempty"`
+
+	// The values of parameters in spec.config.parameters that take effect only
+	// when PostgreSQL starts, as of the last reconcile.
+	// +optional
+	PostmasterParameters map[string]string `json:"postmasterParameters,omitempty"`
+
+	// Parameters in spec.config.parameters that changed and take effect after
+	// PostgreSQL restarts.
+	// +listType=set
+	// +optional
+	PendingRestartParameters []string `json:"pendingRestartParameters,omitempty"`
+
 	// observedGeneration represents the .metadata.generation on which the status was based.
 	// +optional
 	// +kubebuilder:validation:Minimum=0
@@ -436,6 +503,8 @@ const (
 	Registered                  = "Registered"
 )
 
+// ---
+// +kubebuilder:validation:XValidation:rule=`has(self.applyDelay) == (has(self.role) && self.role == "delayed")`,message="applyDelay is required for delayed instance sets and not allowed otherwise"
 type PostgresInstanceSetSpec struct {
 	// +optional
 	Metadata *v1beta1.Metadata `json:"metadata,omitempty"`
@@ -497,6 +566,52 @@ type PostgresInstanceSetSpec struct {
 	// +optional
 	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
 
+	// The role of PostgreSQL pods in this set. Pods in a "delayed" set apply
+	// changes from the primary only after applyDelay, never become primary,
+	// and are not exposed by the replica Service. At least one instance set
+	// must not be delayed. Changing this value causes PostgreSQL to restart.
+	// ---
+	// Kubernetes assumes the evaluation cost of an enum value is very large.
+	// TODO(k8s-1.29): Drop MaxLength after Kubernetes 1.29; https://issue.k8s.io/119511
+	// +kubebuilder:validation:MaxLength=10
+	// +kubebuilder:validation:Type=string
+	//
+	// +kubebuilder:validation:Enum={default,delayed}
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// How long pods in a "delayed" set wait before applying changes from the
+	// primary. This is the PostgreSQL "recovery_min_apply_delay" parameter.
+	// Changing this value causes PostgreSQL to restart.
+	// More info: https://www.postgresql.org/docs/current/runtime-config-replication.html#GUC-RECOVERY-MIN-APPLY-DELAY
+	// ---
+	// Kubernetes ensures the value is in the "duration" format, but go ahead
+	// and loosely validate the format to show some acceptable units.
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	//
+	// `controller-gen` needs to know "Type=string" to allow a "Pattern".
+	// +kubebuilder:validation:Type=string
+	//
+	// PostgreSQL stores this value in milliseconds as a 32-bit integer.
+	// Set a max length to keep rule costs low.
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1s") <= self && self <= duration("576h")`,message="must be between one second and 24 days"
+	//
+	// +optional
+	ApplyDelay *v1beta1.Duration `json:"applyDelay,omitempty"`
+
+	// Replicas in this set are removed from read Services while their
+	// replication lag, as reported by Patroni, exceeds this amount of WAL.
+	// When this is not set, replicas are exposed regardless of their lag.
+	// +optional
+	MaximumLag *resource.Quantity `json:"maximumLag,omitempty"`
+
+	// Specification of a Service that exposes the replicas in this set.
+	// The Service is named "{cluster}-{set}-replicas".
+	// +optional
+	Service *v1beta1.ServiceSpec `json:"service,omitempty"`
+
 	// Compute resources of a PostgreSQL container.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitzero"`
@@ -592,6 +707,20 @@ type PostgresInstanceSetStatus struct {
 	// Desired Size of the pgData volume
 	// +optional
 	DesiredPGDataVolume map[string]string `json:"desiredPGDataVolume,omitempty"`
+
+	// Desired Size of the pgWAL volume
+	// +optional
+	DesiredPGWALVolume map[string]string `json:"desiredPGWALVolume,omitempty"`
+
+	// Desired Size of each tablespace volume, by tablespace name
+	// +optional
+	DesiredTablespaceVolumes map[string]string `json:"desiredTablespaceVolumes,omitempty"`
+
+	// The smallest PostgreSQL "recovery_min_apply_delay" that running pods in
+	// this set apply. This can differ from spec during a rolling update. It is
+	// the minimum; the observed replay lag can be longer.
+	// +optional
+	ConfiguredApplyDelay string `json:"configuredApplyDelay,omitempty"`
 }
 
 // PostgresProxySpec is a union of the supported PostgreSQL proxies.
@@ -618,6 +747,8 @@ type PostgresProxyStatus struct {
 }
 
 // PostgresStandbySpec defines if/how the cluster should be a hot standby.
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.clusterName) || !has(self.host)`,message="clusterName and host cannot both be set"
 type PostgresStandbySpec struct {
 	// Whether or not the PostgreSQL cluster should be read-only. When this is
 	// true, WAL files are applied from a pgBackRest repository or another
@@ -639,6 +770,18 @@ type PostgresStandbySpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=1024
 	Port *int32 `json:"port,omitempty"`
+
+	// The name of a PostgresCluster in this namespace to follow via streaming
+	// replication. That cluster can itself be a standby, making this a cascading
+	// standby. The host and port of its primary Service are used, and so is its
+	// customReplicationTLSSecret when this cluster does not have one. While
+	// that cluster does not exist, the StandbySourceFound condition is False
+	// and Patroni keeps its current standby settings.
+	// ---
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	ClusterName string `json:"clusterName,omitempty"`
 }
 
 // UserInterfaceSpec is a union of the supported PostgreSQL user interfaces.
@@ -666,6 +809,7 @@ type PostgresUserInterfaceStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.proxy.pgBouncer.replicas,statuspath=.status.proxy.pgBouncer.replicas,selectorpath=.status.proxy.pgBouncer.selector
 // +operator-sdk:csv:customresourcedefinitions:resources={{ConfigMap,v1},{Secret,v1},{Service,v1},{CronJob,v1beta1},{Deployment,v1},{Job,v1},{StatefulSet,v1},{PersistentVolumeClaim,v1}}
 
 // PostgresCluster is the Schema for the postgresclusters API
@@ -738,4 +882,23 @@ type VolumeSnapshots struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	VolumeSnapshotClassName string `json:"volumeSnapshotClassName"`
+
+	// How often to take a snapshot of the most recent backup in addition to
+	// the snapshot taken after each backup, such as "24h". Another snapshot is
+	// taken whenever the most recent one is older than this, even when there
+	// has been no backup since. When omitted, snapshots are taken only after
+	// backups.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1h") <= self`,message="must be at least one hour"
+	//
+	// +optional
+	Interval *v1beta1.Duration `json:"interval,omitempty"`
+
+	// Which snapshots to keep. When omitted, only the snapshot of the most
+	// recent backup is kept.
+	// +optional
+	Retention *v1beta1.VolumeSnapshotRetention `json:"retention,omitempty"`
 }
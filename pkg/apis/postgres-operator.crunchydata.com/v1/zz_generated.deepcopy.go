@@ -23,7 +23,7 @@ func (in *Backups) DeepCopyInto(out *Backups) {
 	if in.Snapshots != nil {
 		in, out := &in.Snapshots, &out.Snapshots
 		*out = new(VolumeSnapshots)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -50,6 +50,11 @@ func (in *DataSource) DeepCopyInto(out *DataSource) {
 		*out = new(PostgresClusterDataSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VolumeSnapshot != nil {
+		in, out := &in.VolumeSnapshot, &out.VolumeSnapshot
+		*out = new(v1beta1.VolumeSnapshotDataSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = new(DataSourceVolumes)
@@ -302,7 +307,22 @@ func (in *PostgresClusterSpec) DeepCopyInto(out *PostgresClusterSpec) {
 		*out = new(v1beta1.PostgresAuthenticationSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(v1beta1.PostgresAuditSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoGrow != nil {
+		in, out := &in.AutoGrow, &out.AutoGrow
+		*out = new(v1beta1.VolumeAutoGrowSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Backups.DeepCopyInto(&out.Backups)
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = new(v1beta1.CertificatesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(v1beta1.PostgresConfigSpec)
@@ -313,6 +333,11 @@ func (in *PostgresClusterSpec) DeepCopyInto(out *PostgresClusterSpec) {
 		*out = new(corev1.SecretProjection)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(v1beta1.PostgresClusterTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CustomReplicationClientTLSSecret != nil {
 		in, out := &in.CustomReplicationClientTLSSecret, &out.CustomReplicationClientTLSSecret
 		*out = new(corev1.SecretProjection)
@@ -445,18 +470,51 @@ func (in *PostgresClusterStatus) DeepCopyInto(out *PostgresClusterStatus) {
 		*out = new(RegistrationRequirementStatus)
 		**out = **in
 	}
-	out.Proxy = in.Proxy
+	in.Proxy.DeepCopyInto(&out.Proxy)
 	if in.UserInterface != nil {
 		in, out := &in.UserInterface, &out.UserInterface
 		*out = new(PostgresUserInterfaceStatus)
 		**out = **in
 	}
+	if in.UserPasswords != nil {
+		in, out := &in.UserPasswords, &out.UserPasswords
+		*out = make([]v1beta1.PostgresUserPasswordStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Monitoring = in.Monitoring
 	if in.DatabaseInitSQL != nil {
 		in, out := &in.DatabaseInitSQL, &out.DatabaseInitSQL
 		*out = new(string)
 		**out = **in
 	}
+	if in.VolumeSnapshots != nil {
+		in, out := &in.VolumeSnapshots, &out.VolumeSnapshots
+		*out = make([]v1beta1.VolumeSnapshotStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeAutoGrowTimes != nil {
+		in, out := &in.VolumeAutoGrowTimes, &out.VolumeAutoGrowTimes
+		*out = make(map[string]metav1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.PostmasterParameters != nil {
+		in, out := &in.PostmasterParameters, &out.PostmasterParameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PendingRestartParameters != nil {
+		in, out := &in.PendingRestartParameters, &out.PendingRestartParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -512,6 +570,21 @@ func (in *PostgresInstanceSetSpec) DeepCopyInto(out *PostgresInstanceSetSpec) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.ApplyDelay != nil {
+		in, out := &in.ApplyDelay, &out.ApplyDelay
+		*out = new(v1beta1.Duration)
+		**out = **in
+	}
+	if in.MaximumLag != nil {
+		in, out := &in.MaximumLag, &out.MaximumLag
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(v1beta1.ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	if in.Sidecars != nil {
 		in, out := &in.Sidecars, &out.Sidecars
@@ -570,6 +643,20 @@ func (in *PostgresInstanceSetStatus) DeepCopyInto(out *PostgresInstanceSetStatus
 			(*out)[key] = val
 		}
 	}
+	if in.DesiredPGWALVolume != nil {
+		in, out := &in.DesiredPGWALVolume, &out.DesiredPGWALVolume
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DesiredTablespaceVolumes != nil {
+		in, out := &in.DesiredTablespaceVolumes, &out.DesiredTablespaceVolumes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresInstanceSetStatus.
@@ -605,7 +692,7 @@ func (in *PostgresProxySpec) DeepCopy() *PostgresProxySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresProxyStatus) DeepCopyInto(out *PostgresProxyStatus) {
 	*out = *in
-	out.PGBouncer = in.PGBouncer
+	in.PGBouncer.DeepCopyInto(&out.PGBouncer)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresProxyStatus.
@@ -708,6 +795,16 @@ func (in *UserInterfaceSpec) DeepCopy() *UserInterfaceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshots) DeepCopyInto(out *VolumeSnapshots) {
 	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1beta1.Duration)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(v1beta1.VolumeSnapshotRetention)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshots.
@@ -4,7 +4,10 @@
 
 package v1beta1
 
-import "k8s.io/apimachinery/pkg/api/resource"
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 type PatroniSpec struct {
 	// Patroni dynamic configuration settings. Changes to this value will be
@@ -149,4 +152,38 @@ type PatroniStatus struct {
 	// Tracks the current timeline during switchovers
 	// +optional
 	SwitchoverTimeline *int64 `json:"switchoverTimeline,omitempty"`
+
+	// How client connections through PgBouncer were handled during the most
+	// recent change of primary.
+	// +optional
+	SwitchoverProxy *PatroniSwitchoverProxyStatus `json:"switchoverProxy,omitempty"`
 }
+
+// PatroniSwitchoverProxyStatus describes how client connections through the
+// proxy were handled during a change of primary.
+type PatroniSwitchoverProxyStatus struct {
+	// Whether client connections were paused while the primary changed.
+	// Clients stay connected when PgBouncer is "Paused"; otherwise, the
+	// primary changed while clients were connected.
+	// +kubebuilder:validation:Enum={Paused,NotPaused}
+	// +required
+	Result string `json:"result"`
+
+	// A human-readable description of the result.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// The number of PgBouncer pods that paused.
+	// +optional
+	PausedPods int32 `json:"pausedPods,omitempty"`
+
+	// The time at which PgBouncer resumed.
+	// +optional
+	ResumeTime *metav1.Time `json:"resumeTime,omitempty"`
+}
+
+// PatroniSwitchoverProxyStatus results.
+const (
+	PatroniSwitchoverProxyPaused    = "Paused"
+	PatroniSwitchoverProxyNotPaused = "NotPaused"
+)
@@ -0,0 +1,156 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+// PostgresAuditSpec defines the pgAudit policies of a PostgresCluster.
+// More info: https://github.com/pgaudit/pgaudit#settings
+type PostgresAuditSpec struct {
+	// Classes of statements to log in every session. This sets the
+	// "pgaudit.log" parameter and takes precedence over spec.config.parameters.
+	// ---
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=set
+	// +optional
+	Log []PGAuditClass `json:"log,omitempty"`
+
+	// Whether or not to log the parameters of audited statements. This sets
+	// the "pgaudit.log_parameter" parameter.
+	// ---
+	// +optional
+	LogParameter *bool `json:"logParameter,omitempty"`
+
+	// Settings for sessions connected to specific databases. These take
+	// precedence over the settings above. The operator resets pgAudit
+	// settings of databases that are not in this list or the objects below,
+	// including settings made by "ALTER DATABASE" outside the operator.
+	// ---
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Databases []PGAuditPolicySpec `json:"databases,omitempty"`
+
+	// Settings for sessions of specific roles. These take precedence over the
+	// settings above and those of databases. The operator resets pgAudit
+	// settings of roles that are not in this list, including settings made
+	// by "ALTER ROLE" outside the operator.
+	// ---
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Roles []PGAuditPolicySpec `json:"roles,omitempty"`
+
+	// Statements that access specific tables in a database. A statement is
+	// logged when the audit role of its database has a privilege it uses.
+	// When a database is removed from this list, or its audit role changes,
+	// privileges of the former audit role in that database are revoked. The
+	// role itself is not dropped.
+	// ---
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=database
+	// +optional
+	Objects []PGAuditObjectsSpec `json:"objects,omitempty"`
+
+	// The names of exporters that should send audit logs. When this is set,
+	// audit records are sent only to these exporters and are removed from
+	// other PostgreSQL logs. This requires OpenTelemetry logs.
+	// ---
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	// +optional
+	Exporters []string `json:"exporters,omitempty"`
+}
+
+// ---
+// A class of statements that pgAudit logs. A class prefixed with minus U+002D
+// is excluded.
+// - https://github.com/pgaudit/pgaudit#pgauditlog
+// +kubebuilder:validation:MaxLength=10
+// +kubebuilder:validation:Pattern=`^-?(read|write|function|role|ddl|misc|misc_set|all|none)$`
+type PGAuditClass = string
+
+type PGAuditPolicySpec struct {
+	// The name of the database or role.
+	// ---
+	// +required
+	Name PostgresIdentifier `json:"name"`
+
+	// Classes of statements to log. This sets "pgaudit.log".
+	// ---
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=set
+	// +required
+	Log []PGAuditClass `json:"log"`
+
+	// Whether or not to log the parameters of audited statements. This sets
+	// "pgaudit.log_parameter".
+	// ---
+	// +optional
+	LogParameter *bool `json:"logParameter,omitempty"`
+}
+
+type PGAuditObjectsSpec struct {
+	// The database that contains the tables.
+	// ---
+	// +required
+	Database PostgresIdentifier `json:"database"`
+
+	// The audit role of the database. This sets "pgaudit.role" for the
+	// database. The role is created without LOGIN when it does not exist.
+	// Privileges of this role on other tables in the database are revoked,
+	// so it should not be used for anything else. A role that can login is
+	// not used; it cannot be a user in spec.users.
+	// ---
+	// +required
+	Role PostgresIdentifier `json:"role"`
+
+	// Privileges of the audit role.
+	// ---
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=atomic
+	// +required
+	Tables []PGAuditTableSpec `json:"tables"`
+}
+
+// ---
+// +kubebuilder:validation:XValidation:rule=`has(self.table) != has(self.label)`,message=`exactly one of "table" or "label" is required`
+// +kubebuilder:validation:XValidation:rule=`has(self.graph) == has(self.label)`,message=`"graph" and "label" must be set together`
+// +kubebuilder:validation:XValidation:rule=`!has(self.schema) || has(self.table)`,message=`"schema" requires "table"`
+// +structType=atomic
+type PGAuditTableSpec struct {
+	// The privileges used by statements that should be logged.
+	// ---
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=4
+	// +kubebuilder:validation:items:Enum={SELECT,INSERT,UPDATE,DELETE}
+	// +listType=set
+	// +required
+	Privileges []string `json:"privileges"`
+
+	// The schema of the table. Defaults to "public".
+	// ---
+	// +optional
+	Schema PostgresIdentifier `json:"schema,omitempty"`
+
+	// The name of a table.
+	// ---
+	// +optional
+	Table PostgresIdentifier `json:"table,omitempty"`
+
+	// The name of an Apache AGE graph. Each label of a graph is stored in a
+	// table with the same name in the schema of the graph.
+	// ---
+	// +optional
+	Graph PostgresIdentifier `json:"graph,omitempty"`
+
+	// The name of a vertex or edge label in graph.
+	// ---
+	// +optional
+	Label PostgresIdentifier `json:"label,omitempty"`
+}
@@ -6,6 +6,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -136,6 +137,138 @@ type PGBackRestArchive struct {
 	// Configuration for pgBackRest sidecar containers
 	// +optional
 	Sidecars *PGBackRestSidecars `json:"sidecars,omitempty"`
+
+	// Defines how the backups in each repository are reported in status.
+	// When this is set, the operator periodically runs "pgbackrest info".
+	// +optional
+	Catalog *PGBackRestCatalog `json:"catalog,omitempty"`
+
+	// Defines a schedule to verify backups by restoring the latest one into
+	// a temporary volume and running SQL against the result.
+	// +optional
+	Verification *PGBackRestVerification `json:"verification,omitempty"`
+
+	// Defines when WAL archiving is unhealthy, as reported by the
+	// ArchivingHealthy condition. When this is set, the operator periodically
+	// reads the archiving status of the primary.
+	// +optional
+	ArchiveHealth *PGBackRestArchiveHealth `json:"archiveHealth,omitempty"`
+}
+
+// PGBackRestArchiveHealth defines how WAL archiving is checked on the primary
+type PGBackRestArchiveHealth struct {
+
+	// How often to check WAL archiving on the primary. Defaults to one minute.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("10s") <= self`,message="must be at least ten seconds"
+	//
+	// +optional
+	CheckInterval *Duration `json:"checkInterval,omitempty"`
+
+	// How long WAL archiving can fail before it is unhealthy. Defaults to five minutes.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	//
+	// +optional
+	FailureThreshold *Duration `json:"failureThreshold,omitempty"`
+
+	// The number of WAL files waiting to be archived at which archiving is
+	// unhealthy. Defaults to 256.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PendingFilesThreshold *int32 `json:"pendingFilesThreshold,omitempty"`
+
+	// The size of the WAL directory at which archiving is unhealthy. There is
+	// no limit by default.
+	// +optional
+	WALSizeThreshold *resource.Quantity `json:"walSizeThreshold,omitempty"`
+
+	// Whether or not to grow the WAL volume of the primary while archiving is
+	// unhealthy. This requires the AutoGrowVolumes feature gate and a storage
+	// limit on the WAL volume. Defaults to false.
+	// +optional
+	AutoGrowWALVolume *bool `json:"autoGrowWALVolume,omitempty"`
+}
+
+// PGBackRestCatalog defines how the backups in each repository are reported in status.
+type PGBackRestCatalog struct {
+	// How often to read the backups in each repository. Backups are also read
+	// after a backup Job finishes. Defaults to 5 minutes.
+	// ---
+	// Kubernetes ensures the value is in the "duration" format, but go ahead
+	// and loosely validate the format to show some acceptable units.
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	//
+	// `controller-gen` needs to know "Type=string" to allow a "Pattern".
+	// +kubebuilder:validation:Type=string
+	//
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1m") <= self`,message="must be at least one minute"
+	//
+	// +optional
+	RefreshInterval *Duration `json:"refreshInterval,omitempty"`
+
+	// The "PGBackRestFullBackupCurrent" condition is False when the newest
+	// full backup in every repository is older than this.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1h") <= self`,message="must be at least one hour"
+	//
+	// +optional
+	MaximumFullBackupAge *Duration `json:"maximumFullBackupAge,omitempty"`
+}
+
+// PGBackRestVerification defines how backups are verified. On its schedule, a Job runs
+// "pgbackrest verify", restores the latest backup into a volume that exists only as long
+// as the Job, starts PostgreSQL there, and runs any check SQL.
+type PGBackRestVerification struct {
+	// Defines the Cron schedule for verifying backups.
+	// Follows the standard Cron schedule syntax:
+	// https://k8s.io/docs/concepts/workloads/controllers/cron-jobs/#cron-schedule-syntax
+	// +required
+	// +kubebuilder:validation:MinLength=6
+	Schedule string `json:"schedule"`
+
+	// The name of the pgBackRest repo to verify and restore from. Defaults to
+	// the first repository in "repos".
+	// +optional
+	// +kubebuilder:validation:Pattern=^repo[1-4]
+	RepoName string `json:"repoName,omitempty"`
+
+	// A ConfigMap key containing SQL to run against the restored database.
+	// Verification fails when any statement fails.
+	// +optional
+	CheckSQL *ConfigMapKeyRef `json:"checkSQL,omitempty"`
+
+	// The database in which to run the check SQL. Defaults to "postgres".
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Database string `json:"database,omitempty"`
+
+	// Defines a PersistentVolumeClaim spec for the restored data. The volume
+	// is created for each verification Job and deleted with its Pod.
+	// +required
+	VolumeClaimSpec VolumeClaimSpec `json:"volumeClaimSpec"`
+
+	// Resource requirements for the verification container.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitzero"`
+
+	// How long a verification Job may run before it is stopped and counted
+	// as failed. Defaults to 6 hours.
+	// More info: https://kubernetes.io/docs/concepts/workloads/controllers/job#job-termination-and-cleanup
+	// +optional
+	// +kubebuilder:validation:Minimum=60
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
 }
 
 // PGBackRestSidecars defines the configuration for pgBackRest sidecar containers
@@ -308,12 +441,98 @@ type PGBackRestStatus struct {
 	// +listMapKey=name
 	Repos []RepoStatus `json:"repos,omitempty"`
 
+	// The last time the backups in each repository were read into status.
+	// +optional
+	CatalogTime *metav1.Time `json:"catalogTime,omitempty"`
+
+	// Status information for the most recent backup verification
+	// +optional
+	Verification *PGBackRestVerificationStatus `json:"verification,omitempty"`
+
+	// Status information for WAL archiving on the primary
+	// +optional
+	Archiving *PGBackRestArchivingStatus `json:"archiving,omitempty"`
+
 	// Status information for in-place restores
 	// +optional
 	Restore *PGBackRestJobStatus `json:"restore,omitempty"`
 }
 
+// PGBackRestArchivingStatus is the state of WAL archiving on the primary as
+// reported by "pg_stat_archiver" and the WAL directory
+type PGBackRestArchivingStatus struct {
+
+	// When WAL archiving was last checked
+	// +optional
+	CheckTime *metav1.Time `json:"checkTime,omitempty"`
+
+	// When WAL archiving was first seen failing, if it is failing
+	// +optional
+	FailingSince *metav1.Time `json:"failingSince,omitempty"`
+
+	// The WAL file that was last archived successfully
+	// +optional
+	LastArchivedWAL string `json:"lastArchivedWAL,omitempty"`
+
+	// When the last WAL file was archived successfully
+	// +optional
+	LastArchivedTime *metav1.Time `json:"lastArchivedTime,omitempty"`
+
+	// The WAL file of the last failed archive attempt
+	// +optional
+	LastFailedWAL string `json:"lastFailedWAL,omitempty"`
+
+	// When the last archive attempt failed
+	// +optional
+	LastFailedTime *metav1.Time `json:"lastFailedTime,omitempty"`
+
+	// The number of WAL files waiting to be archived
+	// +optional
+	PendingFiles int64 `json:"pendingFiles,omitempty"`
+
+	// The size of the WAL directory
+	// +optional
+	WALSize *resource.Quantity `json:"walSize,omitempty"`
+}
+
+// PGBackRestVerificationStatus describes the most recent backup verification that finished.
+type PGBackRestVerificationStatus struct {
+	// The name of the verification Job.
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// The name of the pgBackRest repository that was verified.
+	// +optional
+	RepoName string `json:"repo,omitempty"`
+
+	// Whether or not the backup was verified and all check SQL succeeded.
+	// +required
+	Succeeded bool `json:"succeeded"`
+
+	// Represents the time the verification Job was acknowledged by the Job controller.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Represents the time the verification Job succeeded or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// How long the verification Job ran.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// The last time a verification succeeded.
+	// +optional
+	LastSuccessTime *metav1.Time `json:"lastSuccessTime,omitempty"`
+}
+
 // PGBackRestRepo represents a pgBackRest repository.  Only one of its members may be specified.
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.sftp) || !(has(self.azure) || has(self.gcs) || has(self.s3) || has(self.volume) || has(self.posix))`,message="sftp cannot be combined with another repository type"
+// +kubebuilder:validation:XValidation:rule=`!has(self.posix) || !(has(self.azure) || has(self.gcs) || has(self.s3) || has(self.volume) || has(self.sftp))`,message="posix cannot be combined with another repository type"
+//
+// pgBackRest cannot re-encrypt a repository; the passphrase is fixed when its stanza is created.
+// +kubebuilder:validation:XValidation:rule=`has(self.encryption) == has(oldSelf.encryption) && (!has(self.encryption) || self.encryption == oldSelf.encryption)`,message="encryption cannot change; add a repository with another name instead"
 type PGBackRestRepo struct {
 	// Please note that as a Union type that follows OpenAPI 3.0 'oneOf' semantics, the following KEP
 	// will be applicable once implemented:
@@ -346,6 +565,37 @@ type PGBackRestRepo struct {
 	// Represents a pgBackRest repository that is created using a PersistentVolumeClaim
 	// +optional
 	Volume *RepoPVC `json:"volume,omitempty"`
+
+	// Represents a pgBackRest repository on an SFTP server
+	// +optional
+	SFTP *RepoSFTP `json:"sftp,omitempty"`
+
+	// Represents a pgBackRest repository on a filesystem that is shared by
+	// PostgreSQL instances and backup Jobs, such as NFS
+	// +optional
+	POSIX *RepoPOSIX `json:"posix,omitempty"`
+
+	// Encrypts the backups and WAL in this repository. This cannot change
+	// after the repository is added. The operator does not rotate passphrases.
+	// ---
+	// +optional
+	Encryption *RepoEncryption `json:"encryption,omitempty"`
+}
+
+// RepoEncryption defines how a pgBackRest repository is encrypted
+type RepoEncryption struct {
+
+	// The cipher that encrypts the repository. Defaults to "aes-256-cbc".
+	// More info: https://pgbackrest.org/configuration.html#section-repository/option-repo-cipher-type
+	// +optional
+	// +kubebuilder:validation:Enum={aes-256-cbc}
+	Cipher string `json:"cipher,omitempty"`
+
+	// A Secret key containing the passphrase that encrypts the repository.
+	// When omitted, the operator generates a passphrase and stores it in the
+	// pgBackRest Secret of the cluster.
+	// +optional
+	PassphraseSecretRef *SecretKeyRef `json:"passphraseSecretRef,omitempty"`
 }
 
 // RepoHostStatus defines the status of a pgBackRest repository host
@@ -399,6 +649,83 @@ type RepoS3 struct {
 	Region string `json:"region"`
 }
 
+// RepoSFTP represents a pgBackRest repository on an SFTP server
+type RepoSFTP struct {
+
+	// The host name or IP address of the SFTP server
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Host string `json:"host"`
+
+	// The port of the SFTP server. Defaults to 22.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+
+	// The user with which to log into the SFTP server
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	User string `json:"user"`
+
+	// The absolute path of the directory on the SFTP server in which to store
+	// the repository. Defaults to "/pgbackrest/" followed by the repository name.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:XValidation:rule=`self.startsWith("/")`,message="must be an absolute path"
+	Path string `json:"path,omitempty"`
+
+	// Public keys of the SFTP server in OpenSSH "known_hosts" format. The
+	// server must present one of these keys.
+	// +required
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=16384
+	KnownHosts []string `json:"knownHosts"`
+
+	// A Secret key containing the private key with which to log into the
+	// SFTP server
+	// +required
+	PrivateKeySecretRef SecretKeyRef `json:"privateKeySecretRef"`
+}
+
+// RepoPOSIX represents a pgBackRest repository on a filesystem that is mounted
+// by every PostgreSQL instance and backup Job. The filesystem must allow many
+// Pods to read and write at once.
+// ---
+// +kubebuilder:validation:XValidation:rule=`has(self.claimName) != has(self.nfs)`,message="exactly one of claimName or nfs is required"
+type RepoPOSIX struct {
+
+	// The name of an existing PersistentVolumeClaim with the ReadWriteMany access mode
+	// +optional
+	ClaimName DNS1123Subdomain `json:"claimName,omitempty"`
+
+	// An NFS export
+	// +optional
+	NFS *RepoNFS `json:"nfs,omitempty"`
+}
+
+// RepoNFS represents an NFS export that contains a pgBackRest repository
+type RepoNFS struct {
+
+	// The host name or IP address of the NFS server
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Server string `json:"server"`
+
+	// The absolute path of the export on the NFS server
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:XValidation:rule=`self.startsWith("/")`,message="must be an absolute path"
+	Path string `json:"path"`
+}
+
 // RepoStatus the status of a pgBackRest repository
 type RepoStatus struct {
 
@@ -422,8 +749,8 @@ type RepoStatus struct {
 	// to bootstrap replicas.
 	ReplicaCreateBackupComplete bool `json:"replicaCreateBackupComplete,omitempty"`
 
-	// A hash of the required fields in the spec for defining an Azure, GCS or S3 repository,
-	// Utilized to detect changes to these fields and then execute pgBackRest stanza-create
+	// A hash of the required fields in the spec for defining an Azure, GCS, S3, SFTP or POSIX
+	// repository, Utilized to detect changes to these fields and then execute pgBackRest stanza-create
 	// commands accordingly.
 	// +optional
 	RepoOptionsHash string `json:"repoOptionsHash,omitempty"`
@@ -431,6 +758,113 @@ type RepoStatus struct {
 	// Desired Size of the repo volume
 	// +optional
 	DesiredRepoVolume string `json:"desiredRepoVolume,omitempty"`
+
+	// The most recent backups in the repository, oldest first, as reported by
+	// "pgbackrest info". At most 20 are listed, and the newest full backup
+	// is always among them.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=20
+	Backups []PGBackRestBackupInfo `json:"backups,omitempty"`
+
+	// The range of WAL in the repository, as reported by "pgbackrest info".
+	// +optional
+	WAL *PGBackRestWALRange `json:"wal,omitempty"`
+
+	// The range of times that a point-in-time restore from the repository
+	// can target.
+	// +optional
+	RecoveryWindow *PGBackRestRecoveryWindow `json:"recoveryWindow,omitempty"`
+
+	// The passphrase that protects every backup set in the repository. This
+	// is recorded when the stanza is created.
+	// +optional
+	Encryption *RepoEncryptionStatus `json:"encryption,omitempty"`
+}
+
+// RepoEncryptionStatus identifies the passphrase of an encrypted pgBackRest repository
+type RepoEncryptionStatus struct {
+
+	// The cipher that encrypts the repository
+	// +required
+	Cipher string `json:"cipher"`
+
+	// The name of the Secret containing the passphrase
+	// +required
+	SecretName string `json:"secretName"`
+
+	// The key in the Secret that contains the passphrase
+	// +required
+	Key string `json:"key"`
+}
+
+// PGBackRestBackupInfo describes one backup in a pgBackRest repository.
+type PGBackRestBackupInfo struct {
+	// The pgBackRest label of the backup, e.g. "20250102-030405F"
+	// +required
+	Label string `json:"label"`
+
+	// The type of the backup: "full", "diff", or "incr"
+	// +required
+	Type string `json:"type"`
+
+	// When the backup started
+	// +required
+	StartTime metav1.Time `json:"startTime"`
+
+	// When the backup stopped
+	// +required
+	StopTime metav1.Time `json:"stopTime"`
+
+	// The size of the database when it was backed up
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// The space this backup added to the repository
+	// +optional
+	RepositorySize *resource.Quantity `json:"repositorySize,omitempty"`
+
+	// The first WAL segment needed to make the backup consistent
+	// +optional
+	WALStart string `json:"walStart,omitempty"`
+
+	// The last WAL segment needed to make the backup consistent
+	// +optional
+	WALStop string `json:"walStop,omitempty"`
+
+	// Whether or not pgBackRest found errors, such as page checksum
+	// failures, while taking the backup
+	// +optional
+	Error bool `json:"error,omitempty"`
+
+	// The passphrase that protects the backup when pgBackRest reports that
+	// its repository is encrypted
+	// +optional
+	Encryption *RepoEncryptionStatus `json:"encryption,omitempty"`
+}
+
+// PGBackRestWALRange is a range of WAL segments.
+type PGBackRestWALRange struct {
+	// The first WAL segment in the range
+	// +optional
+	Start string `json:"start,omitempty"`
+
+	// The last WAL segment in the range
+	// +optional
+	Stop string `json:"stop,omitempty"`
+}
+
+// PGBackRestRecoveryWindow is a range of times that a point-in-time restore can target.
+type PGBackRestRecoveryWindow struct {
+	// The earliest time a restore can target. This is when the oldest backup
+	// in the repository stopped.
+	// +required
+	Start metav1.Time `json:"start"`
+
+	// The latest time a restore can target, when known. This is the last time
+	// PostgreSQL archived WAL that is also in the repository.
+	// +optional
+	End *metav1.Time `json:"end,omitempty"`
 }
 
 // PGBackRestDataSource defines a pgBackRest configuration specifically for restoring from cloud-based data source
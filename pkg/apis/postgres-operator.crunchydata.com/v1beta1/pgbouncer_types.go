@@ -6,6 +6,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -45,10 +46,17 @@ type PGBouncerConfiguration struct {
 }
 
 // PGBouncerPodSpec defines the desired state of a PgBouncer connection pooler.
+// +kubebuilder:validation:XValidation:rule=`!has(self.readOnly) || !has(self.readOnly.port) || !has(self.port) || self.readOnly.port != self.port`,message="readOnly.port must differ from port"
 type PGBouncerPodSpec struct {
 	// +optional
 	Metadata *Metadata `json:"metadata,omitempty"`
 
+	// Scale the number of PgBouncer pods according to their client
+	// connections. When this field is empty, the number of pods is the
+	// replicas field.
+	// +optional
+	Autoscaling *PGBouncerAutoscalingSpec `json:"autoscaling,omitempty"`
+
 	// Scheduling constraints of a PgBouncer pod. Changing this value causes
 	// PgBouncer to restart.
 	// More info: https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node
@@ -89,25 +97,42 @@ type PGBouncerPodSpec struct {
 	// +kubebuilder:validation:Minimum=1024
 	Port *int32 `json:"port,omitempty"`
 
+	// Pause client connections while the PostgreSQL primary changes during a
+	// switchover or rolling restart. Clients wait rather than fail to connect.
+	// When this field is empty, client connections are not paused.
+	// More info: https://www.pgbouncer.org/usage.html#pause-db
+	// +optional
+	PauseDuringSwitchover *PGBouncerPauseSpec `json:"pauseDuringSwitchover,omitempty"`
+
 	// Priority class name for the pgBouncer pod. Changing this value causes
 	// PostgreSQL to restart.
 	// More info: https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/
 	// +optional
 	PriorityClassName *string `json:"priorityClassName,omitempty"`
 
-	// Number of desired PgBouncer pods.
+	// Number of desired PgBouncer pods. This is the field changed through
+	// the scale subresource of the cluster. When autoscaling is enabled, this
+	// is the number of pods until autoscaling first observes them, within its
+	// limits.
 	// +optional
 	// +kubebuilder:default=1
 	// +kubebuilder:validation:Minimum=0
 	Replicas *int32 `json:"replicas,omitempty"`
 
+	// Connection pools that route to PostgreSQL replicas rather than the
+	// primary. These pools listen on their own port and are exposed by their
+	// own Service. Changing this value causes PgBouncer to restart.
+	// +optional
+	ReadOnly *PGBouncerReadOnlySpec `json:"readOnly,omitempty"`
+
 	// Minimum number of pods that should be available at a time.
 	// Defaults to one when the replicas field is greater than one.
 	// +optional
 	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
 
-	// Compute resources of a PgBouncer container. Changing this value causes
-	// PgBouncer to restart.
+	// Compute resources of a PgBouncer container. These also apply to the
+	// container for read-only pools unless "readOnly.resources" is set.
+	// Changing this value causes PgBouncer to restart.
 	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitzero"`
@@ -135,6 +160,195 @@ type PGBouncerPodSpec struct {
 	Volumes *PGBouncerVolumesSpec `json:"volumes,omitempty"`
 }
 
+// PGBouncerReadOnlySpec defines connection pools that route to replicas.
+type PGBouncerReadOnlySpec struct {
+	// The name of an instance set to which read-only pools connect. That
+	// instance set must define a "service". Otherwise, or when this field is
+	// empty, pools connect to the replicas of every instance set.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	InstanceSet string `json:"instanceSet,omitempty"`
+
+	// The pool mode of read-only pools. When this field is empty, read-only
+	// pools use the global "pool_mode" setting.
+	// More info: https://www.pgbouncer.org/config.html#pool_mode
+	// +optional
+	// +kubebuilder:validation:Enum={session,transaction,statement}
+	PoolMode string `json:"poolMode,omitempty"`
+
+	// Port on which PgBouncer should listen for read-only client connections.
+	// This must differ from the port of read-write connections.
+	// +optional
+	// +kubebuilder:default=5433
+	// +kubebuilder:validation:Minimum=1024
+	Port *int32 `json:"port,omitempty"`
+
+	// Compute resources of the PgBouncer container for read-only pools.
+	// When this field is empty, that container has the same resources as
+	// the PgBouncer container for read-write pools. Changing this value
+	// causes PgBouncer to restart.
+	// More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Specification of the service that exposes read-only pools.
+	// +optional
+	Service *ServiceSpec `json:"service,omitempty"`
+}
+
+// PGBouncerPauseSpec defines how PgBouncer pauses client connections.
+// +kubebuilder:validation:XValidation:rule=`(has(self.maximumDuration) ? self.maximumDuration : duration("1m")) > (has(self.timeout) ? self.timeout : duration("10s"))`,message="maximumDuration must be longer than timeout"
+type PGBouncerPauseSpec struct {
+	// How long to wait for PgBouncer to pause. PAUSE waits for server
+	// connections to be released, which can take a while in session pooling.
+	// When this time passes, PgBouncer resumes and the primary changes while
+	// clients are connected. Must be shorter than maximumDuration.
+	// Defaults to ten seconds.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	//
+	// +optional
+	Timeout *Duration `json:"timeout,omitempty"`
+
+	// The longest time client connections can be paused. PgBouncer resumes
+	// on its own after this time, even when the primary has not changed.
+	// Must be longer than timeout. Defaults to one minute.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("5s") <= self`,message="must be at least five seconds"
+	//
+	// +optional
+	MaximumDuration *Duration `json:"maximumDuration,omitempty"`
+}
+
+// PGBouncerAutoscalingSpec defines how the number of PgBouncer pods changes
+// with client connections. The operator reads "SHOW POOLS" from the admin
+// console of each pod and runs enough pods to keep the metric at or below the
+// target in each.
+// More info: https://www.pgbouncer.org/usage.html#show-pools
+// +kubebuilder:validation:XValidation:rule=`!has(self.minReplicas) || self.minReplicas <= self.maxReplicas`,message="minReplicas must be less than or equal to maxReplicas"
+type PGBouncerAutoscalingSpec struct {
+	// The fewest PgBouncer pods to run.
+	// ---
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// The most PgBouncer pods to run.
+	// ---
+	// +kubebuilder:validation:Minimum=1
+	// +required
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// The client connections to count: "ClientConnections" counts active and
+	// waiting clients; "WaitingClients" counts only clients that are waiting
+	// for a server connection. Defaults to "ClientConnections".
+	// ---
+	// +kubebuilder:validation:Enum={ClientConnections,WaitingClients}
+	// +optional
+	Metric string `json:"metric,omitempty"`
+
+	// The number of counted client connections that each pod should have.
+	// ---
+	// +kubebuilder:validation:Minimum=1
+	// +required
+	Target int32 `json:"target"`
+
+	// How long to wait after a change in the number of pods before adding
+	// more pods. Defaults to one minute.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	//
+	// +optional
+	ScaleUpCooldown *Duration `json:"scaleUpCooldown,omitempty"`
+
+	// How long to wait after a change in the number of pods before removing
+	// pods. Defaults to five minutes.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	//
+	// +optional
+	ScaleDownCooldown *Duration `json:"scaleDownCooldown,omitempty"`
+}
+
+const (
+	PGBouncerAutoscalingClientConnections = "ClientConnections"
+	PGBouncerAutoscalingWaitingClients    = "WaitingClients"
+)
+
+// PGBouncerUserSpec defines PgBouncer settings for a PostgreSQL user.
+// More info: https://www.pgbouncer.org/config.html#section-users
+type PGBouncerUserSpec struct {
+	// The pool mode of connections by this user. When this field is empty,
+	// connections use the pool mode of their database or the global setting.
+	// More info: https://www.pgbouncer.org/config.html#pool_mode
+	// ---
+	// +kubebuilder:validation:Enum={session,transaction,statement}
+	// +optional
+	PoolMode string `json:"poolMode,omitempty"`
+
+	// The most connections to PostgreSQL by this user from each PgBouncer
+	// pod. Zero means no limit.
+	// More info: https://www.pgbouncer.org/config.html#max_user_connections
+	// ---
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxUserConnections *int32 `json:"maxUserConnections,omitempty"`
+
+	// Pool settings for databases of this user. Each name must also be in the
+	// databases of this user. A database cannot have different settings in
+	// different users.
+	// More info: https://www.pgbouncer.org/config.html#section-databases
+	// ---
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Databases []PGBouncerDatabaseSpec `json:"databases,omitempty"`
+}
+
+// PGBouncerDatabaseSpec defines PgBouncer pool settings for a database.
+type PGBouncerDatabaseSpec struct {
+	// The name of the database.
+	// ---
+	// +required
+	Name PostgresIdentifier `json:"name"`
+
+	// The number of server connections in each pool of this database. When
+	// this field is empty, pools use the global "default_pool_size" setting.
+	// More info: https://www.pgbouncer.org/config.html#pool_size
+	// ---
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	PoolSize *int32 `json:"poolSize,omitempty"`
+
+	// The number of additional server connections that pools of this database
+	// can use when clients wait too long. When this field is empty, pools use
+	// the global "reserve_pool_size" setting.
+	// More info: https://www.pgbouncer.org/config.html#reserve_pool
+	// ---
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ReservePool *int32 `json:"reservePool,omitempty"`
+
+	// The name of an instance set whose replicas serve this database. That
+	// instance set must define a "service". When this field is empty, pools
+	// connect to the primary.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	InstanceSet string `json:"instanceSet,omitempty"`
+}
+
 // PGBouncerVolumesSpec defines the configuration for pgBouncer additional volumes
 type PGBouncerVolumesSpec struct {
 	// Additional pre-existing volumes to add to the pod.
@@ -165,6 +379,16 @@ func (s *PGBouncerPodSpec) Default() {
 		s.Replicas = new(int32)
 		*s.Replicas = 1
 	}
+
+	if s.Autoscaling != nil && s.Autoscaling.MinReplicas == nil {
+		s.Autoscaling.MinReplicas = new(int32)
+		*s.Autoscaling.MinReplicas = 1
+	}
+
+	if s.ReadOnly != nil && s.ReadOnly.Port == nil {
+		s.ReadOnly.Port = new(int32)
+		*s.ReadOnly.Port = 5433
+	}
 }
 
 type PGBouncerPodStatus struct {
@@ -178,4 +402,36 @@ type PGBouncerPodStatus struct {
 
 	// Total number of non-terminated pods.
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// The label selector of PgBouncer pods in string form. The scale
+	// subresource of the cluster reports this selector.
+	// More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors
+	// +optional
+	Selector string `json:"selector,omitempty"`
+
+	// The most recent observations of autoscaling.
+	// +optional
+	Autoscaling *PGBouncerAutoscalingStatus `json:"autoscaling,omitempty"`
+}
+
+type PGBouncerAutoscalingStatus struct {
+	// The number of pods chosen by autoscaling.
+	// +optional
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
+
+	// The counted client connections across all pods, when last observed.
+	// +optional
+	CurrentValue int32 `json:"currentValue,omitempty"`
+
+	// When the metric was last observed.
+	// +optional
+	LastObservedTime *metav1.Time `json:"lastObservedTime,omitempty"`
+
+	// When autoscaling last changed the number of pods.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+
+	// A description of the last observation or why it failed.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
@@ -0,0 +1,192 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PGCloneSpec defines the desired state of PGClone
+// ---
+// +kubebuilder:validation:XValidation:rule=`self == oldSelf`,message="spec is immutable"
+type PGCloneSpec struct {
+
+	// The name of the PostgresCluster to clone. It must be in the same
+	// namespace as the PGClone.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	SourceClusterName string `json:"sourceClusterName"`
+
+	// The name of the pgBackRest repository of the source cluster that
+	// contains the backups and WAL to restore.
+	// ---
+	// +kubebuilder:validation:Pattern=^repo[1-4]
+	// +required
+	RepoName string `json:"repoName"`
+
+	// The name of the PostgresCluster to create. Defaults to the name of
+	// the PGClone.
+	// ---
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// The point to which the clone is recovered. When omitted, the clone
+	// replays all the WAL in the repository.
+	// +optional
+	Target *PGCloneTarget `json:"target,omitempty"`
+
+	// Changes to the spec of the source cluster, in the form of a JSON merge
+	// patch, that are applied before the clone is created. Lists, such as
+	// "instances", are replaced entirely.
+	// More info: https://www.rfc-editor.org/rfc/rfc7386
+	// ---
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	Overrides SchemalessObject `json:"overrides,omitempty"`
+
+	// Statements to run in the clone after it is restored and before it is
+	// Ready, such as those that mask sensitive data. Until they complete, the
+	// clone has no users, proxy, or user interface, and it rejects network
+	// connections.
+	// +optional
+	Sanitization *PGCloneSanitization `json:"sanitization,omitempty"`
+}
+
+// PGCloneTarget identifies the point to which a clone is recovered.
+// ---
+// +kubebuilder:validation:XValidation:rule=`[has(self.time), has(self.lsn), has(self.backupLabel)].filter(x, x).size() == 1`,message="exactly one of time, lsn, or backupLabel is required"
+type PGCloneTarget struct {
+
+	// Recover to this time, in RFC 3339 format.
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// Recover to this WAL location, such as "0/3000060".
+	// ---
+	// +kubebuilder:validation:Pattern=`^[0-9A-Fa-f]{1,8}/[0-9A-Fa-f]{1,8}$`
+	// +optional
+	LSN string `json:"lsn,omitempty"`
+
+	// Recover to the end of this backup, such as "20250102-030405F". The
+	// labels of backups are in the status of the source cluster.
+	// ---
+	// +kubebuilder:validation:Pattern=`^[0-9]{8}-[0-9]{6}F(_[0-9]{8}-[0-9]{6}[DI])?$`
+	// +optional
+	BackupLabel string `json:"backupLabel,omitempty"`
+}
+
+// PGCloneSanitization defines statements that run in a single transaction
+// in the clone. The SQL runs first, then the Cypher queries in order. When
+// any statement fails, nothing is changed and the whole transaction is tried
+// again later.
+// ---
+// +kubebuilder:validation:XValidation:rule=`has(self.sql) || has(self.cypher)`,message="at least one of sql or cypher is required"
+type PGCloneSanitization struct {
+
+	// The database in which to run the statements. Defaults to "postgres".
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Database string `json:"database,omitempty"`
+
+	// A ConfigMap key containing SQL statements.
+	// +optional
+	SQL *ConfigMapKeyRef `json:"sql,omitempty"`
+
+	// Cypher queries to run against Apache AGE graphs.
+	// ---
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=atomic
+	// +optional
+	Cypher []PGCloneCypherQuery `json:"cypher,omitempty"`
+}
+
+// PGCloneCypherQuery is a Cypher query for one Apache AGE graph.
+// ---
+// +kubebuilder:validation:XValidation:rule=`!self.query.contains('$cypher$')`,message="query cannot contain $cypher$"
+type PGCloneCypherQuery struct {
+
+	// The name of the graph.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +required
+	Graph string `json:"graph"`
+
+	// A single Cypher query, such as "MATCH (p:Person) SET p.email = null".
+	// It may return at most one column.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	// +required
+	Query string `json:"query"`
+}
+
+// PGCloneStatus defines the observed state of PGClone
+type PGCloneStatus struct {
+	// conditions represent the observations of PGClone's current state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The name of the PostgresCluster created for this clone.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// The time the pgBackRest restore Job of the clone started.
+	// +optional
+	RestoreStartTime *metav1.Time `json:"restoreStartTime,omitempty"`
+
+	// The time the pgBackRest restore Job of the clone completed.
+	// +optional
+	RestoreCompletionTime *metav1.Time `json:"restoreCompletionTime,omitempty"`
+
+	// The time sanitization statements completed in the clone.
+	// +optional
+	SanitizationCompletionTime *metav1.Time `json:"sanitizationCompletionTime,omitempty"`
+
+	// observedGeneration represents the .metadata.generation on which the status was based.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+versionName=v1beta1
+
+// PGClone is the Schema for the pgclones API. It creates a PostgresCluster
+// from the backups of another PostgresCluster in its namespace.
+type PGClone struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// +optional
+	Spec PGCloneSpec `json:"spec,omitzero"`
+	// +optional
+	Status PGCloneStatus `json:"status,omitzero"`
+}
+
+//+kubebuilder:object:root=true
+
+// PGCloneList contains a list of PGClone
+type PGCloneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []PGClone `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PGClone{}, &PGCloneList{})
+}
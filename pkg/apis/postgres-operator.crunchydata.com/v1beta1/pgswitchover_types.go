@@ -0,0 +1,103 @@
+// Copyright 2017 - 2025 Crunchy Data Solutions, Inc.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PGSwitchoverSpec defines the desired state of PGSwitchover
+// ---
+// +kubebuilder:validation:XValidation:rule=`self.primaryClusterName != self.standbyClusterName`,message="primaryClusterName and standbyClusterName must be different"
+// +kubebuilder:validation:XValidation:rule=`self == oldSelf`,message="spec is immutable"
+type PGSwitchoverSpec struct {
+
+	// The name of the PostgresCluster that is currently primary. It is demoted
+	// and becomes a standby of the other cluster.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	PrimaryClusterName string `json:"primaryClusterName"`
+
+	// The name of the PostgresCluster that is currently a standby. It is
+	// promoted once it has received all the WAL of the demoted cluster.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	StandbyClusterName string `json:"standbyClusterName"`
+
+	// How long to wait for the standby cluster to replay the WAL of the
+	// demoted cluster. When this time passes, the switchover fails and the
+	// demoted cluster is promoted again. Defaults to ten minutes.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	//
+	// +optional
+	Timeout *Duration `json:"timeout,omitempty"`
+}
+
+// PGSwitchoverStatus defines the observed state of PGSwitchover
+type PGSwitchoverStatus struct {
+	// conditions represent the observations of PGSwitchover's current state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// When the primary cluster was demoted.
+	// +optional
+	DemotedTime *metav1.Time `json:"demotedTime,omitempty"`
+
+	// The timeline of the primary cluster when it was demoted.
+	// +optional
+	DemotedTimeline int64 `json:"demotedTimeline,omitempty"`
+
+	// The WAL location that the standby cluster must replay before it is
+	// promoted. This is where the primary cluster stopped writing WAL.
+	// +optional
+	DemotedLSN string `json:"demotedLSN,omitempty"`
+
+	// The timeline of the standby cluster after it was promoted.
+	// +optional
+	PromotedTimeline int64 `json:"promotedTimeline,omitempty"`
+
+	// observedGeneration represents the .metadata.generation on which the status was based.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+versionName=v1beta1
+
+// PGSwitchover is the Schema for the pgswitchovers API. It exchanges the roles
+// of a primary PostgresCluster and a standby PostgresCluster in its namespace.
+type PGSwitchover struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// +optional
+	Spec PGSwitchoverSpec `json:"spec,omitzero"`
+	// +optional
+	Status PGSwitchoverStatus `json:"status,omitzero"`
+}
+
+//+kubebuilder:object:root=true
+
+// PGSwitchoverList contains a list of PGSwitchover
+type PGSwitchoverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []PGSwitchover `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PGSwitchover{}, &PGSwitchoverList{})
+}
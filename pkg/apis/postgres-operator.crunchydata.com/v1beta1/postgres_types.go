@@ -6,10 +6,25 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type PostgresAuthenticationSpec struct {
+	// Maps from the user names of external authentication systems to
+	// PostgreSQL user names. Rules that use the "cert", "gss", "ident", "peer",
+	// or "sspi" method refer to these maps by name in their "map" option.
+	// Changes to these maps are reloaded automatically. Rules that refer to a
+	// map that is not defined here or in Patroni are reported in the
+	// "IdentMapsValid" condition.
+	//
+	// More info: https://www.postgresql.org/docs/current/auth-username-maps.html
+	// ---
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=atomic
+	// +optional
+	IdentMaps []PostgresIdentMapSpec `json:"identMaps,omitempty"`
+
 	// Postgres compares every new connection to these rules in the order they are
 	// defined. The first rule that matches determines if and how the connection
 	// must then authenticate. Connections that match no rules are disconnected.
@@ -27,6 +42,12 @@ type PostgresAuthenticationSpec struct {
 }
 
 type PostgresConfigSpec struct {
+	// Derive memory, parallelism, and connection parameters from the resources
+	// of each instance set. Parameters specified elsewhere always take precedence.
+	// ---
+	// +optional
+	Autotune *PostgresAutotuneSpec `json:"autotune,omitempty"`
+
 	// Files to mount under "/etc/postgres".
 	// ---
 	// +optional
@@ -34,7 +55,10 @@ type PostgresConfigSpec struct {
 
 	// Configuration parameters for the PostgreSQL server. Some values will
 	// be reloaded without validation and some cause PostgreSQL to restart.
-	// Some values cannot be changed at all.
+	// Some values cannot be changed at all. Names that PostgreSQL does not
+	// have and preload libraries that are not known are not applied; give
+	// other libraries by path, such as "$libdir/name". These are reported in
+	// the "ParametersValid" condition.
 	// More info: https://www.postgresql.org/docs/current/runtime-config.html
 	// ---
 	//
@@ -85,6 +109,16 @@ type PostgresConfigSpec struct {
 	Parameters map[string]intstr.IntOrString `json:"parameters,omitempty"`
 }
 
+type PostgresAutotuneSpec struct {
+	// The kind of storage behind the storage class of pgData volumes. This
+	// affects how the query planner estimates the cost of reading from disk.
+	// ---
+	// +kubebuilder:validation:Enum={SSD,HDD}
+	// +default="SSD"
+	// +optional
+	StorageType string `json:"storageType,omitempty"`
+}
+
 // ---
 type PostgresHBARule struct {
 	// The connection transport this rule matches. Typical values are:
@@ -162,6 +196,34 @@ type PostgresHBARuleSpec struct {
 	PostgresHBARule `json:",inline"`
 }
 
+// +structType=atomic
+type PostgresIdentMapSpec struct {
+	// The name of the map that contains this entry. Entries with the same name
+	// are compared in the order they are defined, and the first match is used.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[-_a-z0-9]+$`
+	// +required
+	Map string `json:"map"`
+
+	// The user name from the external authentication system, such as the
+	// common name of a client certificate or a Kerberos principal. When this
+	// starts with a slash (/), the rest is a regular expression.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=200
+	// +kubebuilder:validation:Pattern=`^[[:print:]]+$`
+	// +required
+	SystemUser string `json:"systemUser"`
+
+	// The PostgreSQL user that the system user may connect as. When systemUser
+	// is a regular expression, "\1" is replaced by its first capture group.
+	// ---
+	// +required
+	DatabaseUser PostgresIdentifier `json:"databaseUser"`
+}
+
 // ---
 // PostgreSQL identifiers are limited in length but may contain any character.
 // - https://www.postgresql.org/docs/current/sql-syntax-lexical.html#SQL-SYNTAX-IDENTIFIERS
@@ -191,6 +253,61 @@ const (
 	PostgresPasswordTypeASCII        = "ASCII"
 )
 
+// PostgresPasswordRotationSpec defines how often the password of a user is
+// replaced and how clients move to the new one.
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.gracePeriod) || self.gracePeriod < self.interval`,message=`gracePeriod must be shorter than interval`
+type PostgresPasswordRotationSpec struct {
+	// How often to generate a new password. The first password is replaced
+	// this long after rotation is enabled.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(h|hr|d|w|wk)|(hour|day|week)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1h") <= self`,message="must be at least one hour"
+	//
+	// +required
+	Interval Duration `json:"interval"`
+
+	// How clients move to a new password. Defaults to None.
+	// "None" replaces the password of the user. Sessions that are connected
+	// stay connected, but new connections must use the new password.
+	// "DualPassword" alternates the user Secret between the user and a second
+	// role named with the suffix "_next". That role inherits the privileges of
+	// the user and switches to it after connecting. The previous role keeps
+	// its password until gracePeriod passes. Leaving DualPassword mode
+	// disables the second role.
+	// ---
+	// Kubernetes assumes the evaluation cost of an enum value is very large.
+	// TODO(k8s-1.29): Drop MaxLength after Kubernetes 1.29; https://issue.k8s.io/119511
+	// +kubebuilder:validation:MaxLength=15
+	//
+	// +kubebuilder:default=None
+	// +kubebuilder:validation:Enum={None,DualPassword}
+	// +optional
+	GraceMode string `json:"graceMode,omitempty"`
+
+	// How long the previous password remains valid in DualPassword mode.
+	// Defaults to one hour.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(m|h|hr|d)|(min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	//
+	// +optional
+	GracePeriod *Duration `json:"gracePeriod,omitempty"`
+}
+
+// PostgresPasswordRotationSpec grace modes.
+const (
+	PostgresPasswordGraceModeDualPassword = "DualPassword"
+	PostgresPasswordGraceModeNone         = "None"
+)
+
+// ---
+// The "_next" role of DualPassword must also fit within the 63 characters of
+// a PostgreSQL identifier. The "postgres" user must remain a single role.
+// +kubebuilder:validation:XValidation:rule=`!has(self.passwordRotation) || !has(self.passwordRotation.graceMode) || self.passwordRotation.graceMode != "DualPassword" || (self.name != "postgres" && size(self.name) <= 58)`,message=`DualPassword requires a name other than "postgres" of at most 58 characters`
 type PostgresUserSpec struct {
 	// The name of this PostgreSQL user. The value may contain only lowercase
 	// letters, numbers, and hyphen so that it fits into Kubernetes metadata.
@@ -229,4 +346,43 @@ type PostgresUserSpec struct {
 	// ---
 	// +optional
 	Password *PostgresPasswordSpec `json:"password,omitempty"`
+
+	// Replace the password of this user periodically. The user Secret is
+	// updated with each new password, and an event is recorded so clients can
+	// be restarted.
+	// ---
+	// +optional
+	PasswordRotation *PostgresPasswordRotationSpec `json:"passwordRotation,omitempty"`
+
+	// Whether or not to issue a TLS client certificate for this user from the
+	// cluster certificate authority. The certificate, its private key, and the
+	// authority are stored in the user Secret as "tls.crt", "tls.key", and
+	// "ca.crt", and the certificate is renewed before it expires. When true,
+	// TLS connections by this user to its databases must present this
+	// certificate rather than a password, so this user cannot connect through
+	// PgBouncer. When the cluster has a cert-manager issuer, the certificate
+	// is copied to the user Secret after the issuer signs it. This cannot be
+	// set when the cluster has a custom TLS secret.
+	// More info: https://www.postgresql.org/docs/current/auth-cert.html
+	// ---
+	// +optional
+	ClientCertificate *bool `json:"clientCertificate,omitempty"`
+
+	// PgBouncer settings for this user and its databases. These are ignored
+	// when PgBouncer is not enabled.
+	// ---
+	// +optional
+	PGBouncer *PGBouncerUserSpec `json:"pgBouncer,omitempty"`
+}
+
+// PostgresUserPasswordStatus describes the password rotation of a user.
+type PostgresUserPasswordStatus struct {
+	// The name of the PostgreSQL user.
+	// +required
+	Name string `json:"name"`
+
+	// When the password was last replaced or, before that, when rotation was
+	// enabled.
+	// +required
+	LastRotationTime metav1.Time `json:"lastRotationTime"`
 }
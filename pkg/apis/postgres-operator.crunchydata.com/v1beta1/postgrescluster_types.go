@@ -8,11 +8,19 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // PostgresClusterSpec defines the desired state of PostgresCluster
+// ---
+// Client certificates are signed by the cluster certificate authority, which
+// PostgreSQL does not trust when the server uses a custom TLS secret.
+// +kubebuilder:validation:XValidation:rule=`!has(self.customTLSSecret) || !has(self.users) || self.users.all(u, !has(u.clientCertificate) || !u.clientCertificate)`,message="users cannot have a clientCertificate when customTLSSecret is set"
+//
+// Audit roles cannot login, but users in spec.users can.
+// +kubebuilder:validation:XValidation:rule=`!has(self.audit) || !has(self.audit.objects) || !has(self.users) || self.audit.objects.all(o, !self.users.exists(u, u.name == o.role))`,message="audit roles cannot be users in spec.users"
 type PostgresClusterSpec struct {
 	// +optional
 	Metadata *Metadata `json:"metadata,omitempty"`
@@ -25,10 +33,25 @@ type PostgresClusterSpec struct {
 	// +optional
 	Authentication *PostgresAuthenticationSpec `json:"authentication,omitempty"`
 
+	// pgAudit policies for roles, databases, and tables.
+	// +optional
+	Audit *PostgresAuditSpec `json:"audit,omitempty"`
+
+	// How volumes grow when the AutoGrowVolumes feature gate is enabled and
+	// a volume has a storage limit.
+	// +optional
+	AutoGrow *VolumeAutoGrowSpec `json:"autoGrow,omitempty"`
+
 	// PostgreSQL backup configuration
 	// +optional
 	Backups Backups `json:"backups,omitzero"`
 
+	// Settings for the certificates that the operator generates for this
+	// cluster. These do not apply to the root certificate authority, which is
+	// shared by clusters in a namespace and follows the settings of the operator.
+	// +optional
+	Certificates *CertificatesSpec `json:"certificates,omitempty"`
+
 	// General configuration of the PostgreSQL server
 	// +optional
 	Config *PostgresConfigSpec `json:"config,omitempty"`
@@ -45,6 +68,10 @@ type PostgresClusterSpec struct {
 	// +optional
 	CustomTLSSecret *corev1.SecretProjection `json:"customTLSSecret,omitempty"`
 
+	// Settings for the TLS certificates of this cluster.
+	// +optional
+	TLS *PostgresClusterTLSSpec `json:"tls,omitempty"`
+
 	// The secret containing the replication client certificates and keys for
 	// secure connections to the PostgreSQL server. It will need to contain the
 	// client TLS certificate, TLS key and the Certificate Authority certificate
@@ -199,6 +226,8 @@ type PostgresClusterSpec struct {
 }
 
 // DataSource defines data sources for a new PostgresCluster.
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.volumeSnapshot) || !(has(self.pgbackrest) || has(self.postgresCluster))`,message="volumeSnapshot cannot be combined with pgbackrest or postgresCluster"
 type DataSource struct {
 	// Defines a pgBackRest cloud-based data source that can be used to pre-populate the
 	// PostgreSQL data directory for a new PostgreSQL cluster using a pgBackRest restore.
@@ -215,11 +244,49 @@ type DataSource struct {
 	// +optional
 	PostgresCluster *PostgresClusterDataSource `json:"postgresCluster,omitempty"`
 
+	// Defines a VolumeSnapshot that is used to pre-populate the PostgreSQL data
+	// directory for a new PostgreSQL cluster. A pgBackRest restore then brings
+	// that data forward using the WAL in a repository of the source cluster.
+	// +optional
+	VolumeSnapshot *VolumeSnapshotDataSource `json:"volumeSnapshot,omitempty"`
+
 	// Defines any existing volumes to reuse for this PostgresCluster.
 	// +optional
 	Volumes *DataSourceVolumes `json:"volumes,omitempty"`
 }
 
+// VolumeSnapshotDataSource defines a VolumeSnapshot of a PostgreSQL data
+// volume and the PostgresCluster whose backups complete it.
+type VolumeSnapshotDataSource struct {
+	// The name of a VolumeSnapshot of a PostgreSQL data volume in the namespace
+	// of the new PostgresCluster. Defaults to the latest VolumeSnapshot of
+	// clusterName that is ready to use.
+	// ---
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// The name of an existing PostgresCluster in the namespace of the new
+	// PostgresCluster. Its backups and WAL are used to bring the data in the
+	// VolumeSnapshot forward.
+	// ---
+	// +kubebuilder:validation:MinLength=1
+	// +required
+	ClusterName string `json:"clusterName"`
+
+	// The name of the pgBackRest repo within the source PostgresCluster that
+	// contains the WAL to replay.
+	// ---
+	// +kubebuilder:validation:Pattern=^repo[1-4]
+	// +required
+	RepoName string `json:"repoName"`
+
+	// Command line options to include when running the pgBackRest restore command.
+	// https://pgbackrest.org/command.html#command-restore
+	// +optional
+	Options []string `json:"options,omitempty"`
+}
+
 // DataSourceVolumes defines any existing volumes to reuse for this PostgresCluster.
 type DataSourceVolumes struct {
 	// Defines the existing pgData volume and directory to use in the current
@@ -351,6 +418,80 @@ type Backups struct {
 	Snapshots *VolumeSnapshots `json:"snapshots,omitempty"`
 }
 
+// CertificatesSpec defines the keys and lifetimes of generated certificates.
+// Fields that are not set follow the settings of the operator.
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.lifetime) || !has(self.renewBefore) || self.renewBefore < self.lifetime`,message="renewBefore must be less than lifetime"
+type CertificatesSpec struct {
+	// The algorithm of generated private keys. Defaults to ECDSA-P256.
+	// +kubebuilder:validation:Enum={ECDSA-P256,ECDSA-P384,RSA-2048,RSA-4096}
+	// +optional
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// How long generated certificates are valid. Defaults to one year.
+	// ---
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d|w|wk)|(sec|min|hour|day|week)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("24h") <= self`,message="must be at least one day"
+	// +optional
+	Lifetime *Duration `json:"lifetime,omitempty"`
+
+	// How long before they expire that generated certificates are replaced.
+	// Defaults to one third of their lifetime.
+	// ---
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d|w|wk)|(sec|min|hour|day|week)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1h") <= self`,message="must be at least one hour"
+	// +optional
+	RenewBefore *Duration `json:"renewBefore,omitempty"`
+}
+
+// PostgresClusterTLSSpec defines how the TLS certificates of a cluster are issued.
+type PostgresClusterTLSSpec struct {
+	// A cert-manager Issuer or ClusterIssuer that signs the certificates of
+	// PostgreSQL, replication, PgBouncer, the Patroni API, and the client
+	// certificates of users. The operator creates cert-manager Certificates and
+	// waits for their Secrets. Custom TLS secrets take precedence. Backups
+	// continue to use the root certificate authority of the operator.
+	// PostgreSQL trusts any certificate from this issuer with the name of
+	// the replication user or of a user with a client certificate, so use a
+	// dedicated Issuer in this namespace rather than one that is shared.
+	// The issuer must provide its authority in "ca.crt", which ACME issuers
+	// do not. When this field is removed, the operator deletes the
+	// Certificates and their Secrets.
+	// More info: https://cert-manager.io/docs/usage/certificate/
+	// +optional
+	IssuerRef *CertManagerIssuerReference `json:"issuerRef,omitempty"`
+}
+
+// CertManagerIssuerReference refers to a cert-manager Issuer, ClusterIssuer,
+// or external issuer.
+// More info: https://cert-manager.io/docs/reference/api-docs/#meta.cert-manager.io/v1.IssuerReference
+// +structType=atomic
+type CertManagerIssuerReference struct {
+	// Name of the issuer.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +required
+	Name string `json:"name"`
+
+	// Kind of the issuer. Defaults to Issuer.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:default=Issuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// API group of the issuer. Defaults to cert-manager.io.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:default=cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
 // PostgresClusterStatus defines the observed state of PostgresCluster
 type PostgresClusterStatus struct {
 
@@ -401,6 +542,16 @@ type PostgresClusterStatus struct {
 	// Identifies the users that have been installed into PostgreSQL.
 	UsersRevision string `json:"usersRevision,omitempty"`
 
+	// Password rotation of PostgreSQL users.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	UserPasswords []PostgresUserPasswordStatus `json:"userPasswords,omitempty"`
+
+	// Identifies the pgAudit policies that have been applied in PostgreSQL.
+	// +optional
+	AuditRevision string `json:"auditRevision,omitempty"`
+
 	// Current state of PostgreSQL cluster monitoring tool configuration
 	// +optional
 	Monitoring MonitoringStatus `json:"monitoring,omitzero"`
@@ -409,6 +560,28 @@ type PostgresClusterStatus struct {
 	// +optional
 	DatabaseInitSQL *string `json:"databaseInitSQL,omitempty"`
 
+	// The VolumeSnapshots of the cluster, oldest first.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	VolumeSnapshots []VolumeSnapshotStatus `json:"volumeSnapshots,omitempty"`
+
+	// The last time autogrow requested a larger size for each kind of volume,
+	// such as "pgData/instance1" or "repo1/repo-host".
+	// +optional
+	VolumeAutoGrowTimes map[string]metav1.Time `json:"volumeAutoGrowTimes,omitempty"`
+
+	// The values of parameters in spec.config.parameters that take effect only
+	// when PostgreSQL starts, as of the last reconcile.
+	// +optional
+	PostmasterParameters map[string]string `json:"postmasterParameters,omitempty"`
+
+	// Parameters in spec.config.parameters that changed and take effect after
+	// PostgreSQL restarts.
+	// +listType=set
+	// +optional
+	PendingRestartParameters []string `json:"pendingRestartParameters,omitempty"`
+
 	// observedGeneration represents the .metadata.generation on which the status was based.
 	// +optional
 	// +kubebuilder:validation:Minimum=0
@@ -433,6 +606,8 @@ const (
 	Registered                  = "Registered"
 )
 
+// ---
+// +kubebuilder:validation:XValidation:rule=`has(self.applyDelay) == (has(self.role) && self.role == "delayed")`,message="applyDelay is required for delayed instance sets and not allowed otherwise"
 type PostgresInstanceSetSpec struct {
 	// +optional
 	Metadata *Metadata `json:"metadata,omitempty"`
@@ -494,6 +669,52 @@ type PostgresInstanceSetSpec struct {
 	// +optional
 	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
 
+	// The role of PostgreSQL pods in this set. Pods in a "delayed" set apply
+	// changes from the primary only after applyDelay, never become primary,
+	// and are not exposed by the replica Service. At least one instance set
+	// must not be delayed. Changing this value causes PostgreSQL to restart.
+	// ---
+	// Kubernetes assumes the evaluation cost of an enum value is very large.
+	// TODO(k8s-1.29): Drop MaxLength after Kubernetes 1.29; https://issue.k8s.io/119511
+	// +kubebuilder:validation:MaxLength=10
+	// +kubebuilder:validation:Type=string
+	//
+	// +kubebuilder:validation:Enum={default,delayed}
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// How long pods in a "delayed" set wait before applying changes from the
+	// primary. This is the PostgreSQL "recovery_min_apply_delay" parameter.
+	// Changing this value causes PostgreSQL to restart.
+	// More info: https://www.postgresql.org/docs/current/runtime-config-replication.html#GUC-RECOVERY-MIN-APPLY-DELAY
+	// ---
+	// Kubernetes ensures the value is in the "duration" format, but go ahead
+	// and loosely validate the format to show some acceptable units.
+	// NOTE: This rejects fractional numbers: https://github.com/kubernetes/kube-openapi/issues/523
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	//
+	// `controller-gen` needs to know "Type=string" to allow a "Pattern".
+	// +kubebuilder:validation:Type=string
+	//
+	// PostgreSQL stores this value in milliseconds as a 32-bit integer.
+	// Set a max length to keep rule costs low.
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1s") <= self && self <= duration("576h")`,message="must be between one second and 24 days"
+	//
+	// +optional
+	ApplyDelay *Duration `json:"applyDelay,omitempty"`
+
+	// Replicas in this set are removed from read Services while their
+	// replication lag, as reported by Patroni, exceeds this amount of WAL.
+	// When this is not set, replicas are exposed regardless of their lag.
+	// +optional
+	MaximumLag *resource.Quantity `json:"maximumLag,omitempty"`
+
+	// Specification of a Service that exposes the replicas in this set.
+	// The Service is named "{cluster}-{set}-replicas".
+	// +optional
+	Service *ServiceSpec `json:"service,omitempty"`
+
 	// Compute resources of a PostgreSQL container.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitzero"`
@@ -587,6 +808,12 @@ func (s *PostgresInstanceSetSpec) Default(i int) {
 	}
 }
 
+// PostgresInstanceSetSpec role values.
+const (
+	InstanceSetRoleDefault = "default"
+	InstanceSetRoleDelayed = "delayed"
+)
+
 type PostgresInstanceSetStatus struct {
 	Name string `json:"name"`
 
@@ -605,6 +832,20 @@ type PostgresInstanceSetStatus struct {
 	// Desired Size of the pgData volume
 	// +optional
 	DesiredPGDataVolume map[string]string `json:"desiredPGDataVolume,omitempty"`
+
+	// Desired Size of the pgWAL volume
+	// +optional
+	DesiredPGWALVolume map[string]string `json:"desiredPGWALVolume,omitempty"`
+
+	// Desired Size of each tablespace volume, by tablespace name
+	// +optional
+	DesiredTablespaceVolumes map[string]string `json:"desiredTablespaceVolumes,omitempty"`
+
+	// The smallest PostgreSQL "recovery_min_apply_delay" that running pods in
+	// this set apply. This can differ from spec during a rolling update. It is
+	// the minimum; the observed replay lag can be longer.
+	// +optional
+	ConfiguredApplyDelay string `json:"configuredApplyDelay,omitempty"`
 }
 
 // PostgresProxySpec is a union of the supported PostgreSQL proxies.
@@ -631,6 +872,8 @@ type PostgresProxyStatus struct {
 }
 
 // PostgresStandbySpec defines if/how the cluster should be a hot standby.
+// ---
+// +kubebuilder:validation:XValidation:rule=`!has(self.clusterName) || !has(self.host)`,message="clusterName and host cannot both be set"
 type PostgresStandbySpec struct {
 	// Whether or not the PostgreSQL cluster should be read-only. When this is
 	// true, WAL files are applied from a pgBackRest repository or another
@@ -652,6 +895,18 @@ type PostgresStandbySpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=1024
 	Port *int32 `json:"port,omitempty"`
+
+	// The name of a PostgresCluster in this namespace to follow via streaming
+	// replication. That cluster can itself be a standby, making this a cascading
+	// standby. The host and port of its primary Service are used, and so is its
+	// customReplicationTLSSecret when this cluster does not have one. While
+	// that cluster does not exist, the StandbySourceFound condition is False
+	// and Patroni keeps its current standby settings.
+	// ---
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	ClusterName string `json:"clusterName,omitempty"`
 }
 
 // UserInterfaceSpec is a union of the supported PostgreSQL user interfaces.
@@ -679,6 +934,7 @@ type PostgresUserInterfaceStatus struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:subresource:scale:specpath=.spec.proxy.pgBouncer.replicas,statuspath=.status.proxy.pgBouncer.replicas,selectorpath=.status.proxy.pgBouncer.selector
 //+kubebuilder:storageversion
 //+versionName=v1beta1
 // +operator-sdk:csv:customresourcedefinitions:resources={{ConfigMap,v1},{Secret,v1},{Service,v1},{CronJob,v1beta1},{Deployment,v1},{Job,v1},{StatefulSet,v1},{PersistentVolumeClaim,v1}}
@@ -753,4 +1009,130 @@ type VolumeSnapshots struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	VolumeSnapshotClassName string `json:"volumeSnapshotClassName"`
+
+	// How often to take a snapshot of the most recent backup in addition to
+	// the snapshot taken after each backup, such as "24h". Another snapshot is
+	// taken whenever the most recent one is older than this, even when there
+	// has been no backup since. When omitted, snapshots are taken only after
+	// backups.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:XValidation:rule=`duration("1h") <= self`,message="must be at least one hour"
+	//
+	// +optional
+	Interval *Duration `json:"interval,omitempty"`
+
+	// Which snapshots to keep. When omitted, only the snapshot of the most
+	// recent backup is kept.
+	// +optional
+	Retention *VolumeSnapshotRetention `json:"retention,omitempty"`
+}
+
+// VolumeAutoGrowSpec defines how volumes grow. Volumes grow only when the
+// AutoGrowVolumes feature gate is enabled and their storage limit is set.
+type VolumeAutoGrowSpec struct {
+
+	// Report the size to which each volume would grow, in status and events,
+	// without changing any volume.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// How PostgreSQL data volumes grow.
+	// +optional
+	PGData *VolumeAutoGrowPolicy `json:"pgData,omitempty"`
+
+	// How PostgreSQL WAL volumes grow.
+	// +optional
+	PGWAL *VolumeAutoGrowPolicy `json:"pgWAL,omitempty"`
+
+	// How PostgreSQL tablespace volumes grow.
+	// +optional
+	Tablespaces *VolumeAutoGrowPolicy `json:"tablespaces,omitempty"`
+
+	// How pgBackRest repository volumes grow.
+	// +optional
+	Repos *VolumeAutoGrowPolicy `json:"repos,omitempty"`
+}
+
+// VolumeAutoGrowPolicy defines when and by how much a volume grows.
+type VolumeAutoGrowPolicy struct {
+
+	// Grow a volume when more than this percent of it is used. Defaults to 75.
+	// ---
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=99
+	// +optional
+	ThresholdPercent *int32 `json:"thresholdPercent,omitempty"`
+
+	// How much to grow a volume each time, as a percent of its current size
+	// such as "50%" or as an amount such as "10Gi". Defaults to "50%".
+	// ---
+	// +kubebuilder:validation:MaxLength=20
+	// +kubebuilder:validation:Pattern=`^([1-9][0-9]{0,2}%|[1-9][0-9]*(Mi|Gi|Ti))$`
+	// +optional
+	Step string `json:"step,omitempty"`
+
+	// The least amount of time between requests to grow a volume. Defaults
+	// to no delay.
+	// ---
+	// +kubebuilder:validation:Pattern=`^(PT)?( *[0-9]+ *(?i:(s|m|h|hr|d)|(sec|min|hour|day)s?))+$`
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:MaxLength=20
+	//
+	// +optional
+	Cooldown *Duration `json:"cooldown,omitempty"`
+}
+
+// VolumeSnapshotRetention defines which VolumeSnapshots of a PostgresCluster
+// are kept. A snapshot is kept when any rule keeps it; others that are ready
+// to use are deleted. Days and weeks begin at midnight UTC, and weeks begin
+// on Monday.
+type VolumeSnapshotRetention struct {
+
+	// Keep this many of the most recent snapshots. Defaults to one.
+	// ---
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// Keep the most recent snapshot of each day for this many days.
+	// ---
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=366
+	// +optional
+	KeepDaily *int32 `json:"keepDaily,omitempty"`
+
+	// Keep the most recent snapshot of each week for this many weeks.
+	// ---
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=260
+	// +optional
+	KeepWeekly *int32 `json:"keepWeekly,omitempty"`
+}
+
+// VolumeSnapshotStatus describes a VolumeSnapshot of a PostgresCluster.
+type VolumeSnapshotStatus struct {
+
+	// The name of the VolumeSnapshot.
+	// +required
+	Name string `json:"name"`
+
+	// The time the snapshot was taken.
+	// +optional
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// The completion time of the backup in the snapshot.
+	// +optional
+	BackupCompletionTime *metav1.Time `json:"backupCompletionTime,omitempty"`
+
+	// Whether or not the snapshot can be used to provision a volume.
+	// +optional
+	ReadyToUse bool `json:"readyToUse"`
+
+	// The minimum size of a volume provisioned from the snapshot.
+	// +optional
+	RestoreSize *resource.Quantity `json:"restoreSize,omitempty"`
 }
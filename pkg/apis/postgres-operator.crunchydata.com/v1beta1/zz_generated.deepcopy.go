@@ -103,7 +103,7 @@ func (in *Backups) DeepCopyInto(out *Backups) {
 	if in.Snapshots != nil {
 		in, out := &in.Snapshots, &out.Snapshots
 		*out = new(VolumeSnapshots)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -117,6 +117,46 @@ func (in *Backups) DeepCopy() *Backups {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesSpec) DeepCopyInto(out *CertificatesSpec) {
+	*out = *in
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesSpec.
+func (in *CertificatesSpec) DeepCopy() *CertificatesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterUpgrade) DeepCopyInto(out *ClusterUpgrade) {
 	*out = *in
@@ -326,6 +366,11 @@ func (in *DataSource) DeepCopyInto(out *DataSource) {
 		*out = new(PostgresClusterDataSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.VolumeSnapshot != nil {
+		in, out := &in.VolumeSnapshot, &out.VolumeSnapshot
+		*out = new(VolumeSnapshotDataSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = new(DataSourceVolumes)
@@ -1119,6 +1164,73 @@ func (in *PGAdminVolumesSpec) DeepCopy() *PGAdminVolumesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGAuditObjectsSpec) DeepCopyInto(out *PGAuditObjectsSpec) {
+	*out = *in
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]PGAuditTableSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGAuditObjectsSpec.
+func (in *PGAuditObjectsSpec) DeepCopy() *PGAuditObjectsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGAuditObjectsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGAuditPolicySpec) DeepCopyInto(out *PGAuditPolicySpec) {
+	*out = *in
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = make([]PGAuditClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogParameter != nil {
+		in, out := &in.LogParameter, &out.LogParameter
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGAuditPolicySpec.
+func (in *PGAuditPolicySpec) DeepCopy() *PGAuditPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGAuditPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGAuditTableSpec) DeepCopyInto(out *PGAuditTableSpec) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGAuditTableSpec.
+func (in *PGAuditTableSpec) DeepCopy() *PGAuditTableSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGAuditTableSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestArchive) DeepCopyInto(out *PGBackRestArchive) {
 	*out = *in
@@ -1173,6 +1285,21 @@ func (in *PGBackRestArchive) DeepCopyInto(out *PGBackRestArchive) {
 		*out = new(PGBackRestSidecars)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Catalog != nil {
+		in, out := &in.Catalog, &out.Catalog
+		*out = new(PGBackRestCatalog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(PGBackRestVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ArchiveHealth != nil {
+		in, out := &in.ArchiveHealth, &out.ArchiveHealth
+		*out = new(PGBackRestArchiveHealth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestArchive.
@@ -1185,6 +1312,114 @@ func (in *PGBackRestArchive) DeepCopy() *PGBackRestArchive {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestArchiveHealth) DeepCopyInto(out *PGBackRestArchiveHealth) {
+	*out = *in
+	if in.CheckInterval != nil {
+		in, out := &in.CheckInterval, &out.CheckInterval
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.PendingFilesThreshold != nil {
+		in, out := &in.PendingFilesThreshold, &out.PendingFilesThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WALSizeThreshold != nil {
+		in, out := &in.WALSizeThreshold, &out.WALSizeThreshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AutoGrowWALVolume != nil {
+		in, out := &in.AutoGrowWALVolume, &out.AutoGrowWALVolume
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestArchiveHealth.
+func (in *PGBackRestArchiveHealth) DeepCopy() *PGBackRestArchiveHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestArchiveHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestArchivingStatus) DeepCopyInto(out *PGBackRestArchivingStatus) {
+	*out = *in
+	if in.CheckTime != nil {
+		in, out := &in.CheckTime, &out.CheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FailingSince != nil {
+		in, out := &in.FailingSince, &out.FailingSince
+		*out = (*in).DeepCopy()
+	}
+	if in.LastArchivedTime != nil {
+		in, out := &in.LastArchivedTime, &out.LastArchivedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailedTime != nil {
+		in, out := &in.LastFailedTime, &out.LastFailedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.WALSize != nil {
+		in, out := &in.WALSize, &out.WALSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestArchivingStatus.
+func (in *PGBackRestArchivingStatus) DeepCopy() *PGBackRestArchivingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestArchivingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestBackupInfo) DeepCopyInto(out *PGBackRestBackupInfo) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.StopTime.DeepCopyInto(&out.StopTime)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.RepositorySize != nil {
+		in, out := &in.RepositorySize, &out.RepositorySize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(RepoEncryptionStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestBackupInfo.
+func (in *PGBackRestBackupInfo) DeepCopy() *PGBackRestBackupInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestBackupInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestBackupSchedules) DeepCopyInto(out *PGBackRestBackupSchedules) {
 	*out = *in
@@ -1215,6 +1450,31 @@ func (in *PGBackRestBackupSchedules) DeepCopy() *PGBackRestBackupSchedules {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestCatalog) DeepCopyInto(out *PGBackRestCatalog) {
+	*out = *in
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.MaximumFullBackupAge != nil {
+		in, out := &in.MaximumFullBackupAge, &out.MaximumFullBackupAge
+		*out = new(Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestCatalog.
+func (in *PGBackRestCatalog) DeepCopy() *PGBackRestCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestDataSource) DeepCopyInto(out *PGBackRestDataSource) {
 	*out = *in
@@ -1316,6 +1576,26 @@ func (in *PGBackRestManualBackup) DeepCopy() *PGBackRestManualBackup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestRecoveryWindow) DeepCopyInto(out *PGBackRestRecoveryWindow) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestRecoveryWindow.
+func (in *PGBackRestRecoveryWindow) DeepCopy() *PGBackRestRecoveryWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestRecoveryWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBackRestRepo) DeepCopyInto(out *PGBackRestRepo) {
 	*out = *in
@@ -1344,6 +1624,21 @@ func (in *PGBackRestRepo) DeepCopyInto(out *PGBackRestRepo) {
 		*out = new(RepoPVC)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SFTP != nil {
+		in, out := &in.SFTP, &out.SFTP
+		*out = new(RepoSFTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.POSIX != nil {
+		in, out := &in.POSIX, &out.POSIX
+		*out = new(RepoPOSIX)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(RepoEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestRepo.
@@ -1507,7 +1802,23 @@ func (in *PGBackRestStatus) DeepCopyInto(out *PGBackRestStatus) {
 	if in.Repos != nil {
 		in, out := &in.Repos, &out.Repos
 		*out = make([]RepoStatus, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CatalogTime != nil {
+		in, out := &in.CatalogTime, &out.CatalogTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(PGBackRestVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Archiving != nil {
+		in, out := &in.Archiving, &out.Archiving
+		*out = new(PGBackRestArchivingStatus)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Restore != nil {
 		in, out := &in.Restore, &out.Restore
@@ -1527,79 +1838,261 @@ func (in *PGBackRestStatus) DeepCopy() *PGBackRestStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PGBackRestVolumesSpec) DeepCopyInto(out *PGBackRestVolumesSpec) {
+func (in *PGBackRestVerification) DeepCopyInto(out *PGBackRestVerification) {
 	*out = *in
-	if in.Additional != nil {
-		in, out := &in.Additional, &out.Additional
-		*out = make([]AdditionalVolume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.CheckSQL != nil {
+		in, out := &in.CheckSQL, &out.CheckSQL
+		*out = new(ConfigMapKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	in.VolumeClaimSpec.DeepCopyInto(&out.VolumeClaimSpec)
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestVolumesSpec.
-func (in *PGBackRestVolumesSpec) DeepCopy() *PGBackRestVolumesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestVerification.
+func (in *PGBackRestVerification) DeepCopy() *PGBackRestVerification {
 	if in == nil {
 		return nil
 	}
-	out := new(PGBackRestVolumesSpec)
+	out := new(PGBackRestVerification)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PGBouncerConfiguration) DeepCopyInto(out *PGBouncerConfiguration) {
+func (in *PGBackRestVerificationStatus) DeepCopyInto(out *PGBackRestVerificationStatus) {
 	*out = *in
-	if in.Files != nil {
-		in, out := &in.Files, &out.Files
-		*out = make([]corev1.VolumeProjection, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Global != nil {
-		in, out := &in.Global, &out.Global
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Databases != nil {
-		in, out := &in.Databases, &out.Databases
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
 	}
-	if in.Users != nil {
-		in, out := &in.Users, &out.Users
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.LastSuccessTime != nil {
+		in, out := &in.LastSuccessTime, &out.LastSuccessTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBouncerConfiguration.
-func (in *PGBouncerConfiguration) DeepCopy() *PGBouncerConfiguration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestVerificationStatus.
+func (in *PGBackRestVerificationStatus) DeepCopy() *PGBackRestVerificationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PGBouncerConfiguration)
+	out := new(PGBackRestVerificationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PGBouncerPodSpec) DeepCopyInto(out *PGBouncerPodSpec) {
+func (in *PGBackRestVolumesSpec) DeepCopyInto(out *PGBackRestVolumesSpec) {
 	*out = *in
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
-		*out = new(Metadata)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Affinity != nil {
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]AdditionalVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestVolumesSpec.
+func (in *PGBackRestVolumesSpec) DeepCopy() *PGBackRestVolumesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestVolumesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBackRestWALRange) DeepCopyInto(out *PGBackRestWALRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBackRestWALRange.
+func (in *PGBackRestWALRange) DeepCopy() *PGBackRestWALRange {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBackRestWALRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBouncerAutoscalingSpec) DeepCopyInto(out *PGBouncerAutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleUpCooldown != nil {
+		in, out := &in.ScaleUpCooldown, &out.ScaleUpCooldown
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.ScaleDownCooldown != nil {
+		in, out := &in.ScaleDownCooldown, &out.ScaleDownCooldown
+		*out = new(Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBouncerAutoscalingSpec.
+func (in *PGBouncerAutoscalingSpec) DeepCopy() *PGBouncerAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBouncerAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBouncerAutoscalingStatus) DeepCopyInto(out *PGBouncerAutoscalingStatus) {
+	*out = *in
+	if in.LastObservedTime != nil {
+		in, out := &in.LastObservedTime, &out.LastObservedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBouncerAutoscalingStatus.
+func (in *PGBouncerAutoscalingStatus) DeepCopy() *PGBouncerAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBouncerAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBouncerConfiguration) DeepCopyInto(out *PGBouncerConfiguration) {
+	*out = *in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]corev1.VolumeProjection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Global != nil {
+		in, out := &in.Global, &out.Global
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBouncerConfiguration.
+func (in *PGBouncerConfiguration) DeepCopy() *PGBouncerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBouncerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBouncerDatabaseSpec) DeepCopyInto(out *PGBouncerDatabaseSpec) {
+	*out = *in
+	if in.PoolSize != nil {
+		in, out := &in.PoolSize, &out.PoolSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReservePool != nil {
+		in, out := &in.ReservePool, &out.ReservePool
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBouncerDatabaseSpec.
+func (in *PGBouncerDatabaseSpec) DeepCopy() *PGBouncerDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBouncerDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBouncerPauseSpec) DeepCopyInto(out *PGBouncerPauseSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.MaximumDuration != nil {
+		in, out := &in.MaximumDuration, &out.MaximumDuration
+		*out = new(Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBouncerPauseSpec.
+func (in *PGBouncerPauseSpec) DeepCopy() *PGBouncerPauseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBouncerPauseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBouncerPodSpec) DeepCopyInto(out *PGBouncerPodSpec) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(Metadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(PGBouncerAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
 		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
@@ -1622,6 +2115,11 @@ func (in *PGBouncerPodSpec) DeepCopyInto(out *PGBouncerPodSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.PauseDuringSwitchover != nil {
+		in, out := &in.PauseDuringSwitchover, &out.PauseDuringSwitchover
+		*out = new(PGBouncerPauseSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PriorityClassName != nil {
 		in, out := &in.PriorityClassName, &out.PriorityClassName
 		*out = new(string)
@@ -1632,6 +2130,11 @@ func (in *PGBouncerPodSpec) DeepCopyInto(out *PGBouncerPodSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = new(PGBouncerReadOnlySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MinAvailable != nil {
 		in, out := &in.MinAvailable, &out.MinAvailable
 		*out = new(intstr.IntOrString)
@@ -1682,6 +2185,11 @@ func (in *PGBouncerPodSpec) DeepCopy() *PGBouncerPodSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBouncerPodStatus) DeepCopyInto(out *PGBouncerPodStatus) {
 	*out = *in
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(PGBouncerAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBouncerPodStatus.
@@ -1694,6 +2202,36 @@ func (in *PGBouncerPodStatus) DeepCopy() *PGBouncerPodStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBouncerReadOnlySpec) DeepCopyInto(out *PGBouncerReadOnlySpec) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBouncerReadOnlySpec.
+func (in *PGBouncerReadOnlySpec) DeepCopy() *PGBouncerReadOnlySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBouncerReadOnlySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBouncerSidecars) DeepCopyInto(out *PGBouncerSidecars) {
 	*out = *in
@@ -1714,6 +2252,33 @@ func (in *PGBouncerSidecars) DeepCopy() *PGBouncerSidecars {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGBouncerUserSpec) DeepCopyInto(out *PGBouncerUserSpec) {
+	*out = *in
+	if in.MaxUserConnections != nil {
+		in, out := &in.MaxUserConnections, &out.MaxUserConnections
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]PGBouncerDatabaseSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGBouncerUserSpec.
+func (in *PGBouncerUserSpec) DeepCopy() *PGBouncerUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGBouncerUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGBouncerVolumesSpec) DeepCopyInto(out *PGBouncerVolumesSpec) {
 	*out = *in
@@ -1736,6 +2301,184 @@ func (in *PGBouncerVolumesSpec) DeepCopy() *PGBouncerVolumesSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGClone) DeepCopyInto(out *PGClone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGClone.
+func (in *PGClone) DeepCopy() *PGClone {
+	if in == nil {
+		return nil
+	}
+	out := new(PGClone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PGClone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGCloneCypherQuery) DeepCopyInto(out *PGCloneCypherQuery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGCloneCypherQuery.
+func (in *PGCloneCypherQuery) DeepCopy() *PGCloneCypherQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(PGCloneCypherQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGCloneList) DeepCopyInto(out *PGCloneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PGClone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGCloneList.
+func (in *PGCloneList) DeepCopy() *PGCloneList {
+	if in == nil {
+		return nil
+	}
+	out := new(PGCloneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PGCloneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGCloneSanitization) DeepCopyInto(out *PGCloneSanitization) {
+	*out = *in
+	if in.SQL != nil {
+		in, out := &in.SQL, &out.SQL
+		*out = new(ConfigMapKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cypher != nil {
+		in, out := &in.Cypher, &out.Cypher
+		*out = make([]PGCloneCypherQuery, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGCloneSanitization.
+func (in *PGCloneSanitization) DeepCopy() *PGCloneSanitization {
+	if in == nil {
+		return nil
+	}
+	out := new(PGCloneSanitization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGCloneSpec) DeepCopyInto(out *PGCloneSpec) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(PGCloneTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Overrides = in.Overrides.DeepCopy()
+	if in.Sanitization != nil {
+		in, out := &in.Sanitization, &out.Sanitization
+		*out = new(PGCloneSanitization)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGCloneSpec.
+func (in *PGCloneSpec) DeepCopy() *PGCloneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGCloneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGCloneStatus) DeepCopyInto(out *PGCloneStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RestoreStartTime != nil {
+		in, out := &in.RestoreStartTime, &out.RestoreStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestoreCompletionTime != nil {
+		in, out := &in.RestoreCompletionTime, &out.RestoreCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.SanitizationCompletionTime != nil {
+		in, out := &in.SanitizationCompletionTime, &out.SanitizationCompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGCloneStatus.
+func (in *PGCloneStatus) DeepCopy() *PGCloneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PGCloneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGCloneTarget) DeepCopyInto(out *PGCloneTarget) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGCloneTarget.
+func (in *PGCloneTarget) DeepCopy() *PGCloneTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PGCloneTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGMonitorSpec) DeepCopyInto(out *PGMonitorSpec) {
 	*out = *in
@@ -1756,6 +2499,111 @@ func (in *PGMonitorSpec) DeepCopy() *PGMonitorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGSwitchover) DeepCopyInto(out *PGSwitchover) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGSwitchover.
+func (in *PGSwitchover) DeepCopy() *PGSwitchover {
+	if in == nil {
+		return nil
+	}
+	out := new(PGSwitchover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PGSwitchover) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGSwitchoverList) DeepCopyInto(out *PGSwitchoverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PGSwitchover, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGSwitchoverList.
+func (in *PGSwitchoverList) DeepCopy() *PGSwitchoverList {
+	if in == nil {
+		return nil
+	}
+	out := new(PGSwitchoverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PGSwitchoverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGSwitchoverSpec) DeepCopyInto(out *PGSwitchoverSpec) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGSwitchoverSpec.
+func (in *PGSwitchoverSpec) DeepCopy() *PGSwitchoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PGSwitchoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PGSwitchoverStatus) DeepCopyInto(out *PGSwitchoverStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DemotedTime != nil {
+		in, out := &in.DemotedTime, &out.DemotedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PGSwitchoverStatus.
+func (in *PGSwitchoverStatus) DeepCopy() *PGSwitchoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PGSwitchoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PGUpgrade) DeepCopyInto(out *PGUpgrade) {
 	*out = *in
@@ -1980,6 +2828,11 @@ func (in *PatroniStatus) DeepCopyInto(out *PatroniStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.SwitchoverProxy != nil {
+		in, out := &in.SwitchoverProxy, &out.SwitchoverProxy
+		*out = new(PatroniSwitchoverProxyStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatroniStatus.
@@ -2012,9 +2865,86 @@ func (in *PatroniSwitchover) DeepCopy() *PatroniSwitchover {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PatroniSwitchoverProxyStatus) DeepCopyInto(out *PatroniSwitchoverProxyStatus) {
+	*out = *in
+	if in.ResumeTime != nil {
+		in, out := &in.ResumeTime, &out.ResumeTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PatroniSwitchoverProxyStatus.
+func (in *PatroniSwitchoverProxyStatus) DeepCopy() *PatroniSwitchoverProxyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PatroniSwitchoverProxyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresAuditSpec) DeepCopyInto(out *PostgresAuditSpec) {
+	*out = *in
+	if in.Log != nil {
+		in, out := &in.Log, &out.Log
+		*out = make([]PGAuditClass, len(*in))
+		copy(*out, *in)
+	}
+	if in.LogParameter != nil {
+		in, out := &in.LogParameter, &out.LogParameter
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]PGAuditPolicySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]PGAuditPolicySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]PGAuditObjectsSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Exporters != nil {
+		in, out := &in.Exporters, &out.Exporters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresAuditSpec.
+func (in *PostgresAuditSpec) DeepCopy() *PostgresAuditSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresAuditSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresAuthenticationSpec) DeepCopyInto(out *PostgresAuthenticationSpec) {
 	*out = *in
+	if in.IdentMaps != nil {
+		in, out := &in.IdentMaps, &out.IdentMaps
+		*out = make([]PostgresIdentMapSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Rules != nil {
 		in, out := &in.Rules, &out.Rules
 		*out = make([]PostgresHBARuleSpec, len(*in))
@@ -2034,6 +2964,21 @@ func (in *PostgresAuthenticationSpec) DeepCopy() *PostgresAuthenticationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresAutotuneSpec) DeepCopyInto(out *PostgresAutotuneSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresAutotuneSpec.
+func (in *PostgresAutotuneSpec) DeepCopy() *PostgresAutotuneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresAutotuneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresCluster) DeepCopyInto(out *PostgresCluster) {
 	*out = *in
@@ -2154,7 +3099,22 @@ func (in *PostgresClusterSpec) DeepCopyInto(out *PostgresClusterSpec) {
 		*out = new(PostgresAuthenticationSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(PostgresAuditSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoGrow != nil {
+		in, out := &in.AutoGrow, &out.AutoGrow
+		*out = new(VolumeAutoGrowSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Backups.DeepCopyInto(&out.Backups)
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = new(CertificatesSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Config != nil {
 		in, out := &in.Config, &out.Config
 		*out = new(PostgresConfigSpec)
@@ -2165,6 +3125,11 @@ func (in *PostgresClusterSpec) DeepCopyInto(out *PostgresClusterSpec) {
 		*out = new(corev1.SecretProjection)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(PostgresClusterTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CustomReplicationClientTLSSecret != nil {
 		in, out := &in.CustomReplicationClientTLSSecret, &out.CustomReplicationClientTLSSecret
 		*out = new(corev1.SecretProjection)
@@ -2297,18 +3262,51 @@ func (in *PostgresClusterStatus) DeepCopyInto(out *PostgresClusterStatus) {
 		*out = new(RegistrationRequirementStatus)
 		**out = **in
 	}
-	out.Proxy = in.Proxy
+	in.Proxy.DeepCopyInto(&out.Proxy)
 	if in.UserInterface != nil {
 		in, out := &in.UserInterface, &out.UserInterface
 		*out = new(PostgresUserInterfaceStatus)
 		**out = **in
 	}
+	if in.UserPasswords != nil {
+		in, out := &in.UserPasswords, &out.UserPasswords
+		*out = make([]PostgresUserPasswordStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Monitoring = in.Monitoring
 	if in.DatabaseInitSQL != nil {
 		in, out := &in.DatabaseInitSQL, &out.DatabaseInitSQL
 		*out = new(string)
 		**out = **in
 	}
+	if in.VolumeSnapshots != nil {
+		in, out := &in.VolumeSnapshots, &out.VolumeSnapshots
+		*out = make([]VolumeSnapshotStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeAutoGrowTimes != nil {
+		in, out := &in.VolumeAutoGrowTimes, &out.VolumeAutoGrowTimes
+		*out = make(map[string]v1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.PostmasterParameters != nil {
+		in, out := &in.PostmasterParameters, &out.PostmasterParameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PendingRestartParameters != nil {
+		in, out := &in.PendingRestartParameters, &out.PendingRestartParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -2328,9 +3326,34 @@ func (in *PostgresClusterStatus) DeepCopy() *PostgresClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresClusterTLSSpec) DeepCopyInto(out *PostgresClusterTLSSpec) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertManagerIssuerReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresClusterTLSSpec.
+func (in *PostgresClusterTLSSpec) DeepCopy() *PostgresClusterTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresClusterTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresConfigSpec) DeepCopyInto(out *PostgresConfigSpec) {
 	*out = *in
+	if in.Autotune != nil {
+		in, out := &in.Autotune, &out.Autotune
+		*out = new(PostgresAutotuneSpec)
+		**out = **in
+	}
 	if in.Files != nil {
 		in, out := &in.Files, &out.Files
 		*out = make([]corev1.VolumeProjection, len(*in))
@@ -2405,6 +3428,21 @@ func (in *PostgresHBARuleSpec) DeepCopy() *PostgresHBARuleSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresIdentMapSpec) DeepCopyInto(out *PostgresIdentMapSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresIdentMapSpec.
+func (in *PostgresIdentMapSpec) DeepCopy() *PostgresIdentMapSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresIdentMapSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresInstanceSetSpec) DeepCopyInto(out *PostgresInstanceSetSpec) {
 	*out = *in
@@ -2441,6 +3479,21 @@ func (in *PostgresInstanceSetSpec) DeepCopyInto(out *PostgresInstanceSetSpec) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.ApplyDelay != nil {
+		in, out := &in.ApplyDelay, &out.ApplyDelay
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.MaximumLag != nil {
+		in, out := &in.MaximumLag, &out.MaximumLag
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Resources.DeepCopyInto(&out.Resources)
 	if in.Sidecars != nil {
 		in, out := &in.Sidecars, &out.Sidecars
@@ -2499,6 +3552,20 @@ func (in *PostgresInstanceSetStatus) DeepCopyInto(out *PostgresInstanceSetStatus
 			(*out)[key] = val
 		}
 	}
+	if in.DesiredPGWALVolume != nil {
+		in, out := &in.DesiredPGWALVolume, &out.DesiredPGWALVolume
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DesiredTablespaceVolumes != nil {
+		in, out := &in.DesiredTablespaceVolumes, &out.DesiredTablespaceVolumes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresInstanceSetStatus.
@@ -2511,6 +3578,27 @@ func (in *PostgresInstanceSetStatus) DeepCopy() *PostgresInstanceSetStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresPasswordRotationSpec) DeepCopyInto(out *PostgresPasswordRotationSpec) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresPasswordRotationSpec.
+func (in *PostgresPasswordRotationSpec) DeepCopy() *PostgresPasswordRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresPasswordRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresPasswordSpec) DeepCopyInto(out *PostgresPasswordSpec) {
 	*out = *in
@@ -2549,7 +3637,7 @@ func (in *PostgresProxySpec) DeepCopy() *PostgresProxySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresProxyStatus) DeepCopyInto(out *PostgresProxyStatus) {
 	*out = *in
-	out.PGBouncer = in.PGBouncer
+	in.PGBouncer.DeepCopyInto(&out.PGBouncer)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresProxyStatus.
@@ -2598,6 +3686,22 @@ func (in *PostgresUserInterfaceStatus) DeepCopy() *PostgresUserInterfaceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PostgresUserPasswordStatus) DeepCopyInto(out *PostgresUserPasswordStatus) {
+	*out = *in
+	in.LastRotationTime.DeepCopyInto(&out.LastRotationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresUserPasswordStatus.
+func (in *PostgresUserPasswordStatus) DeepCopy() *PostgresUserPasswordStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PostgresUserPasswordStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PostgresUserSpec) DeepCopyInto(out *PostgresUserSpec) {
 	*out = *in
@@ -2611,6 +3715,21 @@ func (in *PostgresUserSpec) DeepCopyInto(out *PostgresUserSpec) {
 		*out = new(PostgresPasswordSpec)
 		**out = **in
 	}
+	if in.PasswordRotation != nil {
+		in, out := &in.PasswordRotation, &out.PasswordRotation
+		*out = new(PostgresPasswordRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PGBouncer != nil {
+		in, out := &in.PGBouncer, &out.PGBouncer
+		*out = new(PGBouncerUserSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PostgresUserSpec.
@@ -2679,6 +3798,41 @@ func (in *RepoAzure) DeepCopy() *RepoAzure {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepoEncryption) DeepCopyInto(out *RepoEncryption) {
+	*out = *in
+	if in.PassphraseSecretRef != nil {
+		in, out := &in.PassphraseSecretRef, &out.PassphraseSecretRef
+		*out = new(SecretKeyRef)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoEncryption.
+func (in *RepoEncryption) DeepCopy() *RepoEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(RepoEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepoEncryptionStatus) DeepCopyInto(out *RepoEncryptionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoEncryptionStatus.
+func (in *RepoEncryptionStatus) DeepCopy() *RepoEncryptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RepoEncryptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepoGCS) DeepCopyInto(out *RepoGCS) {
 	*out = *in
@@ -2710,6 +3864,41 @@ func (in *RepoHostStatus) DeepCopy() *RepoHostStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepoNFS) DeepCopyInto(out *RepoNFS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoNFS.
+func (in *RepoNFS) DeepCopy() *RepoNFS {
+	if in == nil {
+		return nil
+	}
+	out := new(RepoNFS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepoPOSIX) DeepCopyInto(out *RepoPOSIX) {
+	*out = *in
+	if in.NFS != nil {
+		in, out := &in.NFS, &out.NFS
+		*out = new(RepoNFS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoPOSIX.
+func (in *RepoPOSIX) DeepCopy() *RepoPOSIX {
+	if in == nil {
+		return nil
+	}
+	out := new(RepoPOSIX)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepoPVC) DeepCopyInto(out *RepoPVC) {
 	*out = *in
@@ -2741,9 +3930,57 @@ func (in *RepoS3) DeepCopy() *RepoS3 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepoSFTP) DeepCopyInto(out *RepoSFTP) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KnownHosts != nil {
+		in, out := &in.KnownHosts, &out.KnownHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.PrivateKeySecretRef.DeepCopyInto(&out.PrivateKeySecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoSFTP.
+func (in *RepoSFTP) DeepCopy() *RepoSFTP {
+	if in == nil {
+		return nil
+	}
+	out := new(RepoSFTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepoStatus) DeepCopyInto(out *RepoStatus) {
 	*out = *in
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = make([]PGBackRestBackupInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WAL != nil {
+		in, out := &in.WAL, &out.WAL
+		*out = new(PGBackRestWALRange)
+		**out = **in
+	}
+	if in.RecoveryWindow != nil {
+		in, out := &in.RecoveryWindow, &out.RecoveryWindow
+		*out = new(PGBackRestRecoveryWindow)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(RepoEncryptionStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepoStatus.
@@ -2952,6 +4189,66 @@ func (in *UserInterfaceSpec) DeepCopy() *UserInterfaceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeAutoGrowPolicy) DeepCopyInto(out *VolumeAutoGrowPolicy) {
+	*out = *in
+	if in.ThresholdPercent != nil {
+		in, out := &in.ThresholdPercent, &out.ThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeAutoGrowPolicy.
+func (in *VolumeAutoGrowPolicy) DeepCopy() *VolumeAutoGrowPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeAutoGrowPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeAutoGrowSpec) DeepCopyInto(out *VolumeAutoGrowSpec) {
+	*out = *in
+	if in.PGData != nil {
+		in, out := &in.PGData, &out.PGData
+		*out = new(VolumeAutoGrowPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PGWAL != nil {
+		in, out := &in.PGWAL, &out.PGWAL
+		*out = new(VolumeAutoGrowPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tablespaces != nil {
+		in, out := &in.Tablespaces, &out.Tablespaces
+		*out = new(VolumeAutoGrowPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Repos != nil {
+		in, out := &in.Repos, &out.Repos
+		*out = new(VolumeAutoGrowPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeAutoGrowSpec.
+func (in *VolumeAutoGrowSpec) DeepCopy() *VolumeAutoGrowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeAutoGrowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeClaimSpec.
 func (in *VolumeClaimSpec) DeepCopy() *VolumeClaimSpec {
 	if in == nil {
@@ -2962,9 +4259,97 @@ func (in *VolumeClaimSpec) DeepCopy() *VolumeClaimSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotDataSource) DeepCopyInto(out *VolumeSnapshotDataSource) {
+	*out = *in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotDataSource.
+func (in *VolumeSnapshotDataSource) DeepCopy() *VolumeSnapshotDataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotDataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotRetention) DeepCopyInto(out *VolumeSnapshotRetention) {
+	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepDaily != nil {
+		in, out := &in.KeepDaily, &out.KeepDaily
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepWeekly != nil {
+		in, out := &in.KeepWeekly, &out.KeepWeekly
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotRetention.
+func (in *VolumeSnapshotRetention) DeepCopy() *VolumeSnapshotRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotStatus) DeepCopyInto(out *VolumeSnapshotStatus) {
+	*out = *in
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BackupCompletionTime != nil {
+		in, out := &in.BackupCompletionTime, &out.BackupCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestoreSize != nil {
+		in, out := &in.RestoreSize, &out.RestoreSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotStatus.
+func (in *VolumeSnapshotStatus) DeepCopy() *VolumeSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeSnapshots) DeepCopyInto(out *VolumeSnapshots) {
 	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(Duration)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(VolumeSnapshotRetention)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshots.